@@ -0,0 +1,34 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adopt
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/adopt/operatorroles"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "adopt",
+	Short: "Adopt existing resources",
+	Long:  "Reuse existing AWS resources for a new cluster instead of recreating them.",
+	Args:  cobra.NoArgs,
+}
+
+func init() {
+	Cmd.AddCommand(operatorroles.NewAdoptOperatorRolesCommand())
+}
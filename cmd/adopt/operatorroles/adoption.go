@@ -0,0 +1,234 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatorroles
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	errors "github.com/zgalor/weberr"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// roleAdoption describes the changes needed for an existing operator role to be used by a cluster.
+type roleAdoption struct {
+	credRequest string
+	roleName    string
+	// trustPolicy is the trust policy expected by the cluster, set when the role has another one
+	trustPolicy string
+	// clusterID is set when the role is tagged with the ID of another cluster, previousClusterID
+	// is then the ID of that cluster
+	clusterID         string
+	previousClusterID string
+}
+
+func (r *roleAdoption) needsUpdate() bool {
+	return r.trustPolicy != "" || r.clusterID != ""
+}
+
+// adoption is the result of comparing the operator roles of a prefix with the credential
+// requests of a cluster. Problems can't be fixed in place and require recreating the roles.
+type adoption struct {
+	roles    []*roleAdoption
+	problems []string
+}
+
+func (a *adoption) problemf(format string, args ...interface{}) {
+	a.problems = append(a.problems, fmt.Sprintf(format, args...))
+}
+
+// updates returns the roles whose trust policy or tags must be updated.
+func (a *adoption) updates() []*roleAdoption {
+	var updates []*roleAdoption
+	for _, role := range a.roles {
+		if role.needsUpdate() {
+			updates = append(updates, role)
+		}
+	}
+	return updates
+}
+
+// previousClusters returns the IDs of the other clusters the roles are tagged with.
+func (a *adoption) previousClusters() []string {
+	var clusterIDs []string
+	for _, role := range a.roles {
+		if role.previousClusterID != "" && !helper.Contains(clusterIDs, role.previousClusterID) {
+			clusterIDs = append(clusterIDs, role.previousClusterID)
+		}
+	}
+	sort.Strings(clusterIDs)
+	return clusterIDs
+}
+
+// clusterGetter is the part of the OCM client used to check that the previous clusters of the
+// roles are gone.
+type clusterGetter interface {
+	GetClusterByID(clusterKey string, creator *aws.Creator) (*cmv1.Cluster, error)
+}
+
+// existingClusters returns the IDs of the given clusters that still exist. Clusters of other
+// organizations can't be found and are considered gone.
+func existingClusters(ocmClient clusterGetter, creator *aws.Creator, clusterIDs []string) ([]string, error) {
+	var existing []string
+	for _, clusterID := range clusterIDs {
+		_, err := ocmClient.GetClusterByID(clusterID, creator)
+		if err != nil {
+			if errors.GetType(err) == errors.NotFound {
+				continue
+			}
+			return nil, fmt.Errorf("Failed to get cluster '%s': %v", clusterID, err)
+		}
+		existing = append(existing, clusterID)
+	}
+	return existing, nil
+}
+
+// planAdoption checks that the operator roles the cluster expects exist, have the permission
+// policy of their credential request attached and computes the trust policies to update.
+func planAdoption(awsClient aws.Client, creator *aws.Creator, cluster *cmv1.Cluster,
+	credRequests map[string]*cmv1.STSOperator, policies map[string]*cmv1.AWSSTSPolicy) (*adoption, error) {
+	managedPolicies := cluster.AWS().STS().ManagedPolicies()
+	hostedCPPolicies := aws.IsHostedCPManagedPolicies(cluster)
+	isSharedVpc := cluster.AWS().PrivateHostedZoneRoleARN() != ""
+	path, err := aws.GetPathFromAccountRole(cluster, aws.AccountRoles[aws.InstallerAccountRole].Name)
+	if err != nil {
+		return nil, err
+	}
+	policyPrefix := ""
+	if !managedPolicies {
+		policyPrefix, err = aws.GetOperatorRolePolicyPrefixFromCluster(cluster, awsClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+	clusterMinor := ""
+	if cluster.Version() != nil {
+		clusterMinor = ocm.GetVersionMinor(cluster.Version().RawID())
+	}
+
+	credRequestNames := make([]string, 0, len(credRequests))
+	for credRequest := range credRequests {
+		credRequestNames = append(credRequestNames, credRequest)
+	}
+	sort.Strings(credRequestNames)
+
+	result := &adoption{}
+	for _, credRequest := range credRequestNames {
+		operator := credRequests[credRequest]
+		if clusterMinor != "" && operator.MinVersion() != "" {
+			isSupported, err := ocm.CheckSupportedVersion(clusterMinor, operator.MinVersion())
+			if err != nil {
+				return nil, fmt.Errorf("Error validating operator role '%s' version: %v", operator.Name(), err)
+			}
+			if !isSupported {
+				continue
+			}
+		}
+		roleName, found := aws.FindOperatorRoleNameBySTSOperator(cluster, operator)
+		if !found {
+			result.problemf("Cluster has no operator role for '%s/%s'", operator.Namespace(), operator.Name())
+			continue
+		}
+		exists, _, err := awsClient.CheckRoleExists(roleName)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get operator role '%s': %v", roleName, err)
+		}
+		if !exists {
+			result.problemf("Operator role '%s' does not exist", roleName)
+			continue
+		}
+		role, err := awsClient.GetRoleByName(roleName)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get operator role '%s': %v", roleName, err)
+		}
+
+		policyKey := aws.GetOperatorPolicyKey(credRequest, hostedCPPolicies, isSharedVpc)
+		var expectedPolicyArn string
+		if managedPolicies {
+			expectedPolicyArn, err = aws.GetManagedPolicyARN(policies, policyKey)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			expectedPolicyArn = aws.GetOperatorPolicyARN(creator.Partition, creator.AccountID, policyPrefix,
+				operator.Namespace(), operator.Name(), path)
+		}
+		attachedPolicies, err := awsClient.GetAttachedPolicy(awssdk.String(roleName))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get policies attached to operator role '%s': %v", roleName, err)
+		}
+		attachedPolicyArn := ""
+		policySuffix := fmt.Sprintf("-%s-%s", operator.Namespace(), operator.Name())
+		for _, attachedPolicy := range attachedPolicies {
+			if attachedPolicy.PolicyType == aws.Inline {
+				continue
+			}
+			if attachedPolicy.PolicyArn == expectedPolicyArn ||
+				(!managedPolicies && strings.HasSuffix(attachedPolicy.PolicyName, policySuffix)) {
+				attachedPolicyArn = attachedPolicy.PolicyArn
+				break
+			}
+		}
+		if attachedPolicyArn == "" {
+			result.problemf("Operator role '%s' does not have policy '%s' attached", roleName, expectedPolicyArn)
+			continue
+		}
+		if !managedPolicies && clusterMinor != "" {
+			isCompatible, err := awsClient.IsPolicyCompatible(attachedPolicyArn, clusterMinor)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to check version of policy '%s': %v", attachedPolicyArn, err)
+			}
+			if !isCompatible {
+				result.problemf("Policy '%s' of operator role '%s' is not compatible with cluster version '%s'",
+					attachedPolicyArn, roleName, clusterMinor)
+				continue
+			}
+		}
+
+		roleAdoption := &roleAdoption{
+			credRequest: credRequest,
+			roleName:    roleName,
+		}
+		trustPolicy, err := aws.GenerateOperatorRolePolicyDoc(creator.Partition, cluster, creator.AccountID,
+			operator, aws.GetPolicyDetails(policies, "operator_iam_role_policy"))
+		if err != nil {
+			return nil, err
+		}
+		matches, err := aws.TrustPolicyMatches(role.AssumeRolePolicyDocument, trustPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to check trust policy of operator role '%s': %v", roleName, err)
+		}
+		if !matches {
+			roleAdoption.trustPolicy = trustPolicy
+		}
+		for _, tag := range role.Tags {
+			if awssdk.ToString(tag.Key) == tags.ClusterID && awssdk.ToString(tag.Value) != cluster.ID() {
+				roleAdoption.clusterID = cluster.ID()
+				roleAdoption.previousClusterID = awssdk.ToString(tag.Value)
+			}
+		}
+		result.roles = append(result.roles, roleAdoption)
+	}
+	return result, nil
+}
@@ -0,0 +1,143 @@
+package operatorroles
+
+import (
+	"net/url"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	errors "github.com/zgalor/weberr"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+const trustPolicyTemplate = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow",` +
+	`"Principal":{"Federated":"%{oidc_provider_arn}"},"Action":"sts:AssumeRoleWithWebIdentity",` +
+	`"Condition":{"StringEquals":{"%{issuer_url}:sub":["%{service_accounts}"]}}}]}`
+
+func trustPolicy(issuer string) *string {
+	policy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow",` +
+		`"Principal":{"Federated":"arn:aws:iam::123456789012:oidc-provider/` + issuer + `"},` +
+		`"Action":"sts:AssumeRoleWithWebIdentity",` +
+		`"Condition":{"StringEquals":{"` + issuer + `:sub":"system:serviceaccount:ns:sa"}}}]}`
+	return awssdk.String(url.QueryEscape(policy))
+}
+
+type fakeClusterGetter map[string]bool
+
+func (f fakeClusterGetter) GetClusterByID(clusterKey string, _ *aws.Creator) (*cmv1.Cluster, error) {
+	if !f[clusterKey] {
+		return nil, errors.NotFound.Errorf("There is no cluster with identifier '%s'", clusterKey)
+	}
+	return cmv1.NewCluster().ID(clusterKey).Build()
+}
+
+var _ = Describe("Operator roles adoption", func() {
+	const (
+		roleName  = "p-ns-op"
+		policyArn = "arn:aws:iam::aws:policy/service-role/ROSAIngressOperatorPolicy"
+	)
+
+	var (
+		mockCtrl     *gomock.Controller
+		mockClient   *aws.MockClient
+		creator      *aws.Creator
+		cluster      *cmv1.Cluster
+		credRequests map[string]*cmv1.STSOperator
+		policies     map[string]*cmv1.AWSSTSPolicy
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockClient = aws.NewMockClient(mockCtrl)
+		creator = &aws.Creator{AccountID: "123456789012", Partition: "aws"}
+
+		var err error
+		cluster, err = cmv1.NewCluster().ID("new").
+			Hypershift(cmv1.NewHypershift().Enabled(true)).
+			AWS(cmv1.NewAWS().STS(cmv1.NewSTS().
+				RoleARN("arn:aws:iam::123456789012:role/p-HCP-ROSA-Installer-Role").
+				ManagedPolicies(true).
+				OIDCEndpointURL("https://oidc.example.com/abc").
+				OperatorRolePrefix("p").
+				OperatorIAMRoles(cmv1.NewOperatorIAMRole().Namespace("ns").Name("op").
+					RoleARN("arn:aws:iam::123456789012:role/" + roleName)))).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		operator, err := cmv1.NewSTSOperator().Namespace("ns").Name("op").ServiceAccounts("sa").Build()
+		Expect(err).NotTo(HaveOccurred())
+		credRequests = map[string]*cmv1.STSOperator{"ingress": operator}
+		managedPolicy, err := cmv1.NewAWSSTSPolicy().ARN(policyArn).Build()
+		Expect(err).NotTo(HaveOccurred())
+		trustPolicy, err := cmv1.NewAWSSTSPolicy().Details(trustPolicyTemplate).Build()
+		Expect(err).NotTo(HaveOccurred())
+		policies = map[string]*cmv1.AWSSTSPolicy{
+			"openshift_hcp_ingress_policy": managedPolicy,
+			"operator_iam_role_policy":     trustPolicy,
+		}
+	})
+
+	It("does nothing when the roles already trust the cluster", func() {
+		mockClient.EXPECT().CheckRoleExists(roleName).Return(true, "", nil)
+		mockClient.EXPECT().GetRoleByName(roleName).Return(iamtypes.Role{
+			AssumeRolePolicyDocument: trustPolicy("oidc.example.com/abc"),
+		}, nil)
+		mockClient.EXPECT().GetAttachedPolicy(gomock.Any()).Return([]aws.PolicyDetail{{PolicyArn: policyArn}}, nil)
+
+		adoption, err := planAdoption(mockClient, creator, cluster, credRequests, policies)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(adoption.problems).To(BeEmpty())
+		Expect(adoption.updates()).To(BeEmpty())
+	})
+
+	It("updates the trust policy and the cluster tag of roles used by another cluster", func() {
+		mockClient.EXPECT().CheckRoleExists(roleName).Return(true, "", nil)
+		mockClient.EXPECT().GetRoleByName(roleName).Return(iamtypes.Role{
+			AssumeRolePolicyDocument: trustPolicy("oidc.example.com/old"),
+			Tags:                     []iamtypes.Tag{{Key: awssdk.String(tags.ClusterID), Value: awssdk.String("old")}},
+		}, nil)
+		mockClient.EXPECT().GetAttachedPolicy(gomock.Any()).Return([]aws.PolicyDetail{{PolicyArn: policyArn}}, nil)
+
+		adoption, err := planAdoption(mockClient, creator, cluster, credRequests, policies)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(adoption.problems).To(BeEmpty())
+		updates := adoption.updates()
+		Expect(updates).To(HaveLen(1))
+		Expect(updates[0].roleName).To(Equal(roleName))
+		Expect(updates[0].trustPolicy).To(ContainSubstring("oidc-provider/oidc.example.com/abc"))
+		Expect(updates[0].clusterID).To(Equal("new"))
+		Expect(adoption.previousClusters()).To(Equal([]string{"old"}))
+	})
+
+	It("only reports the previous clusters that still exist", func() {
+		ocmClient := fakeClusterGetter{"live": true}
+		existing, err := existingClusters(ocmClient, creator, []string{"gone", "live"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existing).To(Equal([]string{"live"}))
+	})
+
+	It("reports missing roles", func() {
+		mockClient.EXPECT().CheckRoleExists(roleName).Return(false, "", nil)
+
+		adoption, err := planAdoption(mockClient, creator, cluster, credRequests, policies)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(adoption.problems).To(ConsistOf("Operator role 'p-ns-op' does not exist"))
+	})
+
+	It("reports roles with other permission policies", func() {
+		mockClient.EXPECT().CheckRoleExists(roleName).Return(true, "", nil)
+		mockClient.EXPECT().GetRoleByName(roleName).Return(iamtypes.Role{}, nil)
+		mockClient.EXPECT().GetAttachedPolicy(gomock.Any()).Return([]aws.PolicyDetail{
+			{PolicyArn: "arn:aws:iam::aws:policy/service-role/ROSAOtherPolicy"},
+		}, nil)
+
+		adoption, err := planAdoption(mockClient, creator, cluster, credRequests, policies)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(adoption.problems).To(HaveLen(1))
+		Expect(adoption.problems[0]).To(ContainSubstring("does not have policy"))
+	})
+})
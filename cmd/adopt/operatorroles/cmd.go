@@ -0,0 +1,246 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatorroles
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "operator-roles"
+	short = "Adopt existing operator roles for a cluster"
+	long  = "Reuse the operator roles of a prefix for a cluster, typically when rebuilding a cluster " +
+		"with the same OIDC configuration. The command verifies that the roles the cluster expects " +
+		"exist and have the permission policies of the cluster's credential requests attached, then " +
+		"updates their trust policies in place to trust the cluster's OIDC provider and service accounts.\n\n" +
+		"The cluster must have been created with '--operator-roles-prefix' set to the prefix of the " +
+		"roles. Roles missing or having other permission policies must be recreated with " +
+		"'rosa create operator-roles'.\n\n" +
+		"Roles tagged with another cluster are only adopted once that cluster no longer exists, unless " +
+		"'--force' is used."
+	example = `  # Adopt the operator roles of prefix 'mycluster' for cluster 'mycluster-rebuilt'
+  rosa adopt operator-roles --prefix mycluster --cluster mycluster-rebuilt
+
+  # Print the AWS commands that update the roles instead of running them
+  rosa adopt operator-roles --prefix mycluster --cluster mycluster-rebuilt --mode manual`
+
+	prefixFlag = "prefix"
+	forceFlag  = "force"
+)
+
+var aliases = []string{"operatorroles", "operator-role"}
+
+type AdoptOperatorRolesOptions struct {
+	prefix string
+	force  bool
+}
+
+func NewAdoptOperatorRolesCommand() *cobra.Command {
+	options := &AdoptOperatorRolesOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), AdoptOperatorRolesRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	ocm.AddClusterFlag(cmd)
+	flags.StringVar(
+		&options.prefix,
+		prefixFlag,
+		"",
+		"Prefix of the operator roles to adopt (required).",
+	)
+	flags.BoolVar(
+		&options.force,
+		forceFlag,
+		false,
+		"Adopt the roles even if they are tagged with another cluster that still exists.",
+	)
+	cmd.MarkFlagRequired(prefixFlag)
+	interactive.AddModeFlag(cmd)
+	confirm.AddFlag(flags)
+	arguments.AddProfileFlag(flags)
+	arguments.AddRegionFlag(flags)
+	return cmd
+}
+
+func AdoptOperatorRolesRunner(options *AdoptOperatorRolesOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, cmd *cobra.Command, _ []string) error {
+		mode, err := interactive.GetMode()
+		if err != nil {
+			return err
+		}
+		if !interactive.Enabled() && !cmd.Flags().Changed("mode") {
+			interactive.Enable()
+		}
+		if interactive.Enabled() {
+			mode, err = interactive.GetOptionMode(cmd, mode, "Operator roles adoption mode")
+			if err != nil {
+				return fmt.Errorf("Expected a valid operator roles adoption mode: %s", err)
+			}
+		}
+
+		clusterKey := r.GetClusterKey()
+		cluster, err := r.OCMClient.GetCluster(clusterKey, r.Creator)
+		if err != nil {
+			return err
+		}
+		if cluster.AWS().STS().RoleARN() == "" {
+			return fmt.Errorf("Cluster '%s' is not an STS cluster", clusterKey)
+		}
+		clusterPrefix := cluster.AWS().STS().OperatorRolePrefix()
+		if clusterPrefix != options.prefix {
+			return fmt.Errorf("Cluster '%s' uses operator roles prefix '%s', not '%s'. Create the cluster "+
+				"with '--operator-roles-prefix %s' to adopt these roles", clusterKey, clusterPrefix,
+				options.prefix, options.prefix)
+		}
+
+		issuerUrl := cluster.AWS().STS().OIDCEndpointURL()
+		hasProvider, err := r.AWSClient.HasOpenIDConnectProvider(issuerUrl, r.Creator.Partition,
+			r.Creator.AccountID)
+		if err != nil {
+			return fmt.Errorf("Failed to check OIDC provider of issuer '%s': %v", issuerUrl, err)
+		}
+		if !hasProvider {
+			r.Reporter.Warnf("There is no OIDC provider for issuer '%s', create it with "+
+				"'rosa create oidc-provider --cluster %s'", issuerUrl, clusterKey)
+		}
+
+		credRequests, err := r.OCMClient.GetCredRequests(cluster.Hypershift().Enabled())
+		if err != nil {
+			return fmt.Errorf("Error getting operator credential request from OCM: %v", err)
+		}
+		policies, err := r.OCMClient.GetPolicies("OperatorRole")
+		if err != nil {
+			return fmt.Errorf("Failed to get operator role policies from OCM: %v", err)
+		}
+
+		r.Reporter.Infof("Verifying operator roles with prefix '%s' for cluster '%s'", options.prefix, clusterKey)
+		adoption, err := planAdoption(r.AWSClient, r.Creator, cluster, credRequests, policies)
+		if err != nil {
+			return err
+		}
+		if len(adoption.problems) > 0 {
+			return fmt.Errorf("Operator roles with prefix '%s' can't be adopted by cluster '%s':\n  - %s\n"+
+				"Delete them with 'rosa delete operator-roles --prefix %s' and create them again with "+
+				"'rosa create operator-roles --cluster %s'", options.prefix, clusterKey,
+				strings.Join(adoption.problems, "\n  - "), options.prefix, clusterKey)
+		}
+		updates := adoption.updates()
+		if len(updates) == 0 {
+			r.Reporter.Infof("Operator roles with prefix '%s' already match cluster '%s'", options.prefix, clusterKey)
+			return nil
+		}
+		existing, err := existingClusters(r.OCMClient, r.Creator, adoption.previousClusters())
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			if !options.force {
+				return fmt.Errorf("Operator roles with prefix '%s' are used by clusters '%s' that still exist, "+
+					"adopting them would break these clusters. Check the prefix, or use '--%s' to adopt them anyway",
+					options.prefix, strings.Join(existing, "', '"), forceFlag)
+			}
+			r.Reporter.Warnf("Operator roles with prefix '%s' are used by clusters '%s' that still exist, "+
+				"they stop working once the roles are adopted", options.prefix, strings.Join(existing, "', '"))
+		}
+
+		switch mode {
+		case interactive.ModeAuto:
+			return adoptAuto(r, updates, options.prefix)
+		case interactive.ModeManual:
+			return adoptManual(r, updates)
+		default:
+			return fmt.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
+		}
+	}
+}
+
+func adoptAuto(r *rosa.Runtime, updates []*roleAdoption, prefix string) error {
+	if !confirm.Confirm("update %d operator roles with prefix '%s'", len(updates), prefix) {
+		return nil
+	}
+	for _, update := range updates {
+		if update.trustPolicy != "" {
+			r.Reporter.Debugf("Updating trust policy of role '%s'", update.roleName)
+			err := r.AWSClient.UpdateAssumeRolePolicy(update.roleName, update.trustPolicy)
+			if err != nil {
+				return fmt.Errorf("Failed to update trust policy of operator role '%s': %v", update.roleName, err)
+			}
+		}
+		if update.clusterID != "" {
+			err := r.AWSClient.AddRoleTag(update.roleName, tags.ClusterID, update.clusterID)
+			if err != nil {
+				return fmt.Errorf("Failed to tag operator role '%s': %v", update.roleName, err)
+			}
+		}
+		r.Reporter.Infof("Updated operator role '%s'", update.roleName)
+	}
+	return nil
+}
+
+func adoptManual(r *rosa.Runtime, updates []*roleAdoption) error {
+	commands := []string{}
+	for _, update := range updates {
+		if update.trustPolicy != "" {
+			filename := aws.GetFormattedFileName(fmt.Sprintf("operator_%s_policy", update.credRequest))
+			r.Reporter.Debugf("Saving '%s' to the current directory", filename)
+			err := helper.SaveDocument(update.trustPolicy, filename)
+			if err != nil {
+				return err
+			}
+			commands = append(commands, awscb.NewIAMCommandBuilder().
+				SetCommand(awscb.UpdateAssumeRolePolicy).
+				AddParam(awscb.RoleName, update.roleName).
+				AddParam(awscb.PolicyDocument, fmt.Sprintf("file://%s", filename)).
+				Build())
+		}
+		if update.clusterID != "" {
+			commands = append(commands, awscb.NewIAMCommandBuilder().
+				SetCommand(awscb.TagRole).
+				AddParam(awscb.RoleName, update.roleName).
+				AddTags(map[string]string{tags.ClusterID: update.clusterID}).
+				Build())
+		}
+	}
+	if r.Reporter.IsTerminal() {
+		r.Reporter.Infof("All policy files saved to the current directory")
+		r.Reporter.Infof("Run the following commands to update the operator roles:\n")
+	}
+	fmt.Println(awscb.JoinCommands(commands))
+	return nil
+}
@@ -0,0 +1,13 @@
+package operatorroles
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAdoptOperatorRoles(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Adopt operator roles suite")
+}
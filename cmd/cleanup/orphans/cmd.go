@@ -0,0 +1,207 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/orphans"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "orphans"
+	short = "Delete IAM and OIDC resources no longer used by any cluster"
+	long  = "Find the unmanaged OIDC configurations created by ROSA in the AWS account that are not used " +
+		"by any cluster, and delete them.\n\n" +
+		"Operator roles, OIDC providers and account roles are only included with '--include-iam'. They are " +
+		"only checked against the clusters of the current organization, make sure that no other " +
+		"organization or environment uses the AWS account before deleting them.\n\n" +
+		"Resources created more recently than the '--older-than' threshold are kept, so that " +
+		"resources created ahead of a cluster are not deleted. Resources carrying the protect tag are " +
+		"never deleted. Unmanaged OIDC configurations whose private key is stored in another region " +
+		"are only deleted when running the command in that region."
+	example = `  # List the resources that would be deleted
+  rosa cleanup orphans --dry-run
+
+  # Delete the resources unused for more than a week
+  rosa cleanup orphans --older-than 168h
+
+  # Keep the resources tagged with 'team=platform'
+  rosa cleanup orphans --protect-tag team=platform
+
+  # Also list the IAM resources not used by any cluster of the current organization
+  rosa cleanup orphans --include-iam --dry-run`
+
+	dryRunFlag     = "dry-run"
+	olderThanFlag  = "older-than"
+	protectTagFlag = "protect-tag"
+	includeIAMFlag = "include-iam"
+
+	defaultOlderThan = 24 * time.Hour
+)
+
+type CleanupOrphansOptions struct {
+	dryRun     bool
+	olderThan  time.Duration
+	protectTag string
+	includeIAM bool
+}
+
+func NewCleanupOrphansCommand() *cobra.Command {
+	options := &CleanupOrphansOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: []string{"orphan"},
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), CleanupOrphansRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	flags.BoolVar(
+		&options.dryRun,
+		dryRunFlag,
+		false,
+		"List the orphaned resources without deleting them.",
+	)
+	flags.DurationVar(
+		&options.olderThan,
+		olderThanFlag,
+		defaultOlderThan,
+		"Only delete resources created longer ago than this duration.",
+	)
+	flags.StringVar(
+		&options.protectTag,
+		protectTagFlag,
+		orphans.DefaultProtectTag,
+		"Tag, as 'key' or 'key=value', that keeps a resource from being deleted.",
+	)
+	flags.BoolVar(
+		&options.includeIAM,
+		includeIAMFlag,
+		false,
+		"Also delete the operator roles, OIDC providers and account roles not used by any cluster of "+
+			"the current organization.",
+	)
+	confirm.AddFlag(flags)
+	arguments.AddProfileFlag(flags)
+	arguments.AddRegionFlag(flags)
+	return cmd
+}
+
+func CleanupOrphansRunner(options *CleanupOrphansOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		if options.olderThan < 0 {
+			return fmt.Errorf("Age threshold must not be negative")
+		}
+		protectTag, err := orphans.ParseProtectTag(options.protectTag)
+		if err != nil {
+			return err
+		}
+
+		collector := &orphans.Collector{
+			AWSClient:  r.AWSClient,
+			OCMClient:  r.OCMClient,
+			Creator:    r.Creator,
+			Region:     r.AWSClient.GetRegion(),
+			ProtectTag: protectTag,
+			IncludeIAM: options.includeIAM,
+		}
+		r.Reporter.Infof("Looking for resources not used by any cluster")
+		if options.includeIAM {
+			r.Reporter.Warnf("Operator roles, OIDC providers and account roles are only checked against the " +
+				"clusters of the current organization, the ones used by other organizations or environments " +
+				"sharing the AWS account are reported as orphaned")
+		}
+		resources, err := collector.Collect()
+		if err != nil {
+			return err
+		}
+		if len(resources) == 0 {
+			r.Reporter.Infof("There are no resources created by ROSA in the AWS account")
+			return nil
+		}
+
+		now := time.Now()
+		var orphaned []*orphans.Resource
+		for _, resource := range resources {
+			resource.Evaluate(options.olderThan, now)
+			if resource.Status == orphans.StatusOrphaned {
+				orphaned = append(orphaned, resource)
+			}
+		}
+		printResources(resources, now)
+
+		if len(orphaned) == 0 {
+			r.Reporter.Infof("There are no orphaned resources to delete")
+			return nil
+		}
+		if options.dryRun {
+			r.Reporter.Infof("Found %d orphaned resources, run the command again without '--%s' to delete them",
+				len(orphaned), dryRunFlag)
+			return nil
+		}
+		if !confirm.Confirm("delete %d orphaned resources", len(orphaned)) {
+			return nil
+		}
+
+		failed := 0
+		for _, resource := range orphaned {
+			r.Reporter.Infof("Deleting %s '%s'", resource.Kind, resource.Name)
+			err := resource.Delete()
+			if err != nil {
+				r.Reporter.Errorf("Failed to delete %s '%s': %v", resource.Kind, resource.Name, err)
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("Failed to delete %d of %d orphaned resources", failed, len(orphaned))
+		}
+		r.Reporter.Infof("Deleted %d orphaned resources", len(orphaned))
+		return nil
+	}
+}
+
+func printResources(resources []*orphans.Resource, now time.Time) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "KIND\tNAME\tDETAILS\tAGE\tSTATUS\n")
+	for _, resource := range resources {
+		age := ""
+		if !resource.CreatedAt.IsZero() {
+			age = now.Sub(resource.CreatedAt).Round(time.Hour).String()
+		}
+		status := resource.Status
+		if resource.Status == orphans.StatusOtherRegion {
+			status = fmt.Sprintf("%s (%s)", status, resource.Region)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			resource.Kind, resource.Name, resource.Details, age, status)
+	}
+	writer.Flush()
+}
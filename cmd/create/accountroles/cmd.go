@@ -26,6 +26,7 @@ import (
 	"github.com/openshift/rosa/cmd/verify/oc"
 	"github.com/openshift/rosa/cmd/verify/quota"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/conventions"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
@@ -165,6 +166,12 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	roleConventions, err := conventions.ForKind(conventions.AccountRoles)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
 	managedPolicies := args.managed
 	if args.forcePolicyCreation && managedPolicies {
 		r.Reporter.Warnf("Forcing creation of policies only works for unmanaged policies")
@@ -225,7 +232,7 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 
 	// Validate AWS quota
-	// Call `verify quota` as part of init
+	// Call `verify quota` as part of init, which only verifies the static minimums
 	quota.Cmd.Run(cmd, argv)
 	// Verify version of `oc`
 	oc.Cmd.Run(cmd, argv)
@@ -250,6 +257,9 @@ func run(cmd *cobra.Command, argv []string) {
 	r.Reporter.Debugf("Creating account roles compatible with OpenShift versions up to %s", policyVersion)
 
 	prefix := args.prefix
+	if !cmd.Flags().Changed("prefix") {
+		prefix = roleConventions.DefaultPrefix(prefix)
+	}
 	if interactive.Enabled() {
 		prefix, err = interactive.GetString(interactive.Input{
 			Question: "Role prefix",
@@ -278,8 +288,13 @@ func run(cmd *cobra.Command, argv []string) {
 		r.Reporter.Errorf("The '-HCP' suffix is reserved for hosted CP managed policies")
 		os.Exit(1)
 	}
+	err = roleConventions.ValidatePrefix(prefix)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 
-	permissionsBoundary := args.permissionsBoundary
+	permissionsBoundary := roleConventions.DefaultPermissionsBoundary(args.permissionsBoundary)
 	if interactive.Enabled() {
 		permissionsBoundary, err = interactive.GetString(interactive.Input{
 			Question: "Permissions boundary ARN",
@@ -302,8 +317,13 @@ func run(cmd *cobra.Command, argv []string) {
 			os.Exit(1)
 		}
 	}
+	err = roleConventions.ValidatePermissionsBoundary(permissionsBoundary)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 
-	path := args.path
+	path := roleConventions.DefaultPath(args.path)
 	if interactive.Enabled() {
 		path, err = interactive.GetString(interactive.Input{
 			Question: "Path",
@@ -324,6 +344,11 @@ func run(cmd *cobra.Command, argv []string) {
 			"It must begin and end with '/' and contain only alphanumeric characters and/or '/' characters.")
 		os.Exit(1)
 	}
+	err = roleConventions.ValidatePath(path)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 
 	if interactive.Enabled() {
 		mode, err = interactive.GetOptionMode(cmd, mode, "Role creation mode")
@@ -384,6 +409,7 @@ func run(cmd *cobra.Command, argv []string) {
 
 	input := buildRolesCreationInput(prefix, permissionsBoundary, r.Creator.AccountID, env, policies,
 		policyVersion, path)
+	input.conventions = roleConventions
 
 	switch mode {
 	case interactive.ModeAuto:
@@ -9,6 +9,7 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
 	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/conventions"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -60,6 +61,7 @@ type accountRolesCreationInput struct {
 	policies             map[string]*cmv1.AWSSTSPolicy
 	defaultPolicyVersion string
 	path                 string
+	conventions          *conventions.Rules
 }
 
 func buildRolesCreationInput(prefix, permissionsBoundary, accountID, env string,
@@ -395,12 +397,12 @@ func (hcp *hcpManagedPoliciesCreator) getAccountRolesMap() map[string]aws.Accoun
 }
 
 func getBaseRoleTags(roleType string, input *accountRolesCreationInput) map[string]string {
-	return map[string]string{
+	return input.conventions.AddTags(map[string]string{
 		common.OpenShiftVersion: input.defaultPolicyVersion,
 		tags.RolePrefix:         input.prefix,
 		tags.RoleType:           roleType,
 		tags.RedHatManaged:      tags.True,
-	}
+	})
 }
 
 func buildCreateRoleCommand(accRoleName string, file string, iamTags map[string]string,
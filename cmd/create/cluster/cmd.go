@@ -49,18 +49,21 @@ import (
 	"github.com/openshift/rosa/pkg/aws/tags"
 	"github.com/openshift/rosa/pkg/clusterautoscaler"
 	"github.com/openshift/rosa/pkg/clusterregistryconfig"
+	"github.com/openshift/rosa/pkg/conventions"
 	"github.com/openshift/rosa/pkg/fedramp"
 	"github.com/openshift/rosa/pkg/helper"
 	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
 	"github.com/openshift/rosa/pkg/helper/roles"
 	"github.com/openshift/rosa/pkg/helper/versions"
 	"github.com/openshift/rosa/pkg/ingress"
+	"github.com/openshift/rosa/pkg/instancetypes"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/interactive/consts"
 	interactiveOidc "github.com/openshift/rosa/pkg/interactive/oidc"
 	"github.com/openshift/rosa/pkg/interactive/securitygroups"
 	interactiveSgs "github.com/openshift/rosa/pkg/interactive/securitygroups"
+	"github.com/openshift/rosa/pkg/network"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/properties"
@@ -131,6 +134,7 @@ var args struct {
 	etcdEncryptionKmsARN     string
 	// Scaling options
 	computeMachineType       string
+	recommendInstanceType    bool
 	computeNodes             int
 	autoscalingEnabled       bool
 	minReplicas              int
@@ -149,6 +153,9 @@ var args struct {
 	// unless using PrivateLink, in which case it should only be one private per availability zone
 	subnetIDs []string
 
+	// Name of a stack created by 'rosa create network' to take the subnets and machine CIDR from
+	networkStack string
+
 	// Selecting availability zones for a non-BYOVPC cluster
 	availabilityZones []string
 
@@ -540,6 +547,15 @@ func initFlags(cmd *cobra.Command) {
 			"Leave empty for installer provisioned subnet IDs.",
 	)
 
+	flags.StringVar(
+		&args.networkStack,
+		"network-stack",
+		"",
+		"Name of a stack created by 'rosa create network' to install the cluster in. "+
+			"The subnets are picked from the stack according to '--private-link' and '--multi-az', "+
+			"and the machine CIDR defaults to the CIDR block of the VPC.",
+	)
+
 	flags.StringSliceVar(
 		&args.availabilityZones,
 		"availability-zones",
@@ -555,6 +571,13 @@ func initFlags(cmd *cobra.Command) {
 		"",
 		"Instance type for the compute nodes. Determines the amount of memory and vCPU allocated to each compute node.",
 	)
+	flags.BoolVar(
+		&args.recommendInstanceType,
+		"recommend-instance-type",
+		false,
+		"Recommend a compute nodes instance type from CPU and memory requirements. "+
+			"Only supported in interactive mode.",
+	)
 
 	flags.IntVar(
 		&args.computeNodes,
@@ -921,7 +944,12 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 	}
 
-	isBYOVPC := cmd.Flags().Changed("subnet-ids")
+	if cmd.Flags().Changed("subnet-ids") && cmd.Flags().Changed("network-stack") {
+		r.Reporter.Errorf("Flags '--subnet-ids' and '--network-stack' are mutually exclusive")
+		os.Exit(1)
+	}
+
+	isBYOVPC := cmd.Flags().Changed("subnet-ids") || cmd.Flags().Changed("network-stack")
 	isAvailabilityZonesSet := cmd.Flags().Changed("availability-zones")
 	// Setting subnet IDs is choosing BYOVPC implicitly,
 	// and selecting availability zones is only allowed for non-BYOVPC clusters
@@ -1707,8 +1735,14 @@ func run(cmd *cobra.Command, _ []string) {
 	operatorIAMRoles := args.operatorIAMRoles
 	computedOperatorIamRoleList := []ocm.OperatorIAMRole{}
 	if isSTS {
+		operatorRolesConventions, err := conventions.ForKind(conventions.OperatorRoles)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
 		if operatorRolesPrefix == "" {
-			operatorRolesPrefix = roles.GeOperatorRolePrefixFromClusterName(clusterName)
+			operatorRolesPrefix = roles.GeOperatorRolePrefixFromClusterName(
+				operatorRolesConventions.DefaultPrefix(clusterName))
 		}
 		if interactive.Enabled() {
 			operatorRolesPrefix, err = interactive.GetString(interactive.Input{
@@ -1738,6 +1772,11 @@ func run(cmd *cobra.Command, _ []string) {
 			r.Reporter.Errorf("Expected valid operator roles prefix matching %s", aws.RoleNameRE.String())
 			os.Exit(1)
 		}
+		err = operatorRolesConventions.ValidatePrefix(operatorRolesPrefix)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
 
 		credRequests, err := r.OCMClient.GetAllCredRequests()
 		if err != nil {
@@ -2039,6 +2078,31 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	// Network stack:
+	if args.networkStack != "" {
+		stack, err := network.GetStack(awsClient, args.networkStack)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		args.subnetIDs, err = network.ClusterSubnets(awsClient, stack, multiAZ, privateLink, isHostedCP)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Using subnets '%s' of network stack '%s'",
+			strings.Join(args.subnetIDs, ","), args.networkStack)
+		if !cmd.Flags().Changed("machine-cidr") && stack.VpcCidr != "" {
+			_, vpcCIDR, err := net.ParseCIDR(stack.VpcCidr)
+			if err != nil {
+				r.Reporter.Errorf("Invalid CIDR block '%s' of the VPC of network stack '%s': %s",
+					stack.VpcCidr, args.networkStack, err)
+				os.Exit(1)
+			}
+			args.machineCIDR = *vpcCIDR
+		}
+	}
+
 	// Machine CIDR:
 	machineCIDR := args.machineCIDR
 	if ocm.IsEmptyCIDR(machineCIDR) {
@@ -2408,7 +2472,19 @@ func run(cmd *cobra.Command, _ []string) {
 	if computeMachineType == "" {
 		computeMachineType = defaultComputeMachineType
 	}
+	if args.recommendInstanceType && !interactive.Enabled() {
+		r.Reporter.Errorf("The '--recommend-instance-type' option is only supported in interactive mode")
+		os.Exit(1)
+	}
 	if interactive.Enabled() {
+		if args.recommendInstanceType {
+			computeMachineType, err = instancetypes.PromptRecommendation(computeMachineTypeList, multiAZ,
+				isHostedCP, computeMachineType)
+			if err != nil {
+				r.Reporter.Errorf("Expected a valid machine type: %s", err)
+				os.Exit(1)
+			}
+		}
 		computeMachineType, err = interactive.GetOption(interactive.Input{
 			Question: "Compute nodes instance type",
 			Help:     cmd.Flags().Lookup("compute-machine-type").Usage,
@@ -30,11 +30,13 @@ import (
 	"github.com/openshift/rosa/cmd/create/idp"
 	"github.com/openshift/rosa/cmd/create/kubeletconfig"
 	"github.com/openshift/rosa/cmd/create/machinepool"
+	"github.com/openshift/rosa/cmd/create/machinepoolschedule"
 	"github.com/openshift/rosa/cmd/create/network"
 	"github.com/openshift/rosa/cmd/create/ocmrole"
 	"github.com/openshift/rosa/cmd/create/oidcconfig"
 	"github.com/openshift/rosa/cmd/create/oidcprovider"
 	"github.com/openshift/rosa/cmd/create/operatorroles"
+	"github.com/openshift/rosa/cmd/create/permissionsboundary"
 	"github.com/openshift/rosa/cmd/create/service"
 	"github.com/openshift/rosa/cmd/create/tuningconfigs"
 	"github.com/openshift/rosa/cmd/create/userrole"
@@ -57,6 +59,8 @@ func init() {
 	Cmd.AddCommand(idp.Cmd)
 	machinepool := machinepool.NewCreateMachinePoolCommand()
 	Cmd.AddCommand(machinepool)
+	machinePoolSchedule := machinepoolschedule.NewCreateMachinePoolScheduleCommand()
+	Cmd.AddCommand(machinePoolSchedule)
 	Cmd.AddCommand(oidcconfig.Cmd)
 	Cmd.AddCommand(oidcprovider.Cmd)
 	Cmd.AddCommand(operatorroles.Cmd)
@@ -74,6 +78,7 @@ func init() {
 	decisionCommand := decision.NewCreateDecisionCommand()
 	Cmd.AddCommand(decisionCommand)
 	Cmd.AddCommand(network.NewNetworkCommand())
+	Cmd.AddCommand(permissionsboundary.NewCreatePermissionsBoundaryCommand())
 
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
@@ -86,7 +91,7 @@ func init() {
 		oidcprovider.Cmd, breakglasscredential.Cmd,
 		admin.Cmd, autoscalerCommand, dnsdomains.Cmd,
 		externalauthprovider.Cmd, idp.Cmd, kubeletConfig, tuningconfigs.Cmd,
-		decisionCommand,
+		decisionCommand, machinePoolSchedule,
 	}
 	arguments.MarkRegionDeprecated(Cmd, globallyAvailableCommands)
 }
@@ -0,0 +1,187 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepoolschedule
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/machinepool"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/schedules"
+)
+
+const (
+	use   = "machinepool-schedule"
+	short = "Schedule the scaling of a machine pool"
+	long  = "Schedule the scaling of a machine pool. The schedule is stored locally and applied by " +
+		"'rosa schedules run-due', which should be run periodically, for example from cron."
+	example = `  # Scale the machine pool 'gpu' of cluster 'mycluster' down to 0 replicas every weekday at 19:00 UTC
+  rosa create machinepool-schedule -c mycluster --machinepool gpu --name gpu-night --cron "0 19 * * 1-5" --replicas 0
+
+  # Set the autoscaling bounds of the machine pool 'gpu' to 2-6 every weekday at 07:00 UTC
+  rosa create machinepool-schedule -c mycluster --machinepool gpu --name gpu-day --cron "0 7 * * 1-5" \
+  --min-replicas 2 --max-replicas 6`
+)
+
+var aliases = []string{"machinepool-schedules", "machine-pool-schedule", "machine-pool-schedules"}
+
+type CreateMachinePoolScheduleOptions struct {
+	name        string
+	machinePool string
+	cron        string
+	replicas    int
+	minReplicas int
+	maxReplicas int
+}
+
+func NewCreateMachinePoolScheduleCommand() *cobra.Command {
+	options := &CreateMachinePoolScheduleOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), CreateMachinePoolScheduleRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(cmd)
+	flags.StringVar(
+		&options.machinePool,
+		"machinepool",
+		"",
+		"Machine pool of the cluster to scale.",
+	)
+	cmd.MarkFlagRequired("machinepool")
+	flags.StringVar(
+		&options.name,
+		"name",
+		"",
+		"Name of the schedule. Defaults to the machine pool name followed by the cron expression.",
+	)
+	flags.StringVar(
+		&options.cron,
+		"cron",
+		"",
+		"Cron expression in UTC of the times the machine pool is scaled.",
+	)
+	cmd.MarkFlagRequired("cron")
+	flags.IntVar(
+		&options.replicas,
+		"replicas",
+		0,
+		"Count of machines the machine pool is scaled to. Disables autoscaling.",
+	)
+	flags.IntVar(
+		&options.minReplicas,
+		"min-replicas",
+		0,
+		"Minimum number of machines set on the autoscaling machine pool.",
+	)
+	flags.IntVar(
+		&options.maxReplicas,
+		"max-replicas",
+		0,
+		"Maximum number of machines set on the autoscaling machine pool.",
+	)
+	return cmd
+}
+
+func CreateMachinePoolScheduleRunner(options *CreateMachinePoolScheduleOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, cmd *cobra.Command, _ []string) error {
+		schedule := &schedules.Schedule{
+			Name:        options.name,
+			MachinePool: options.machinePool,
+			Cron:        options.cron,
+			CreatedAt:   time.Now().UTC(),
+		}
+		if cmd.Flags().Changed("replicas") {
+			schedule.Replicas = &options.replicas
+		}
+		if cmd.Flags().Changed("min-replicas") {
+			schedule.MinReplicas = &options.minReplicas
+		}
+		if cmd.Flags().Changed("max-replicas") {
+			schedule.MaxReplicas = &options.maxReplicas
+		}
+		if schedule.Name == "" {
+			schedule.Name = defaultName(options.machinePool, options.cron)
+		}
+		if err := schedule.Validate(); err != nil {
+			return err
+		}
+
+		clusterKey := r.GetClusterKey()
+		cluster := r.FetchCluster()
+		schedule.ClusterID = cluster.ID()
+		schedule.ClusterName = cluster.Name()
+
+		_, err := machinepool.GetBulkTarget(r, cluster, options.machinePool)
+		if err != nil {
+			return fmt.Errorf("Failed to get machine pool '%s' for cluster '%s': %v",
+				options.machinePool, clusterKey, err)
+		}
+
+		store, err := schedules.Load()
+		if err != nil {
+			return err
+		}
+		if err = store.Add(schedule); err != nil {
+			return err
+		}
+		if err = store.Save(); err != nil {
+			return err
+		}
+
+		next, err := schedule.Next(time.Now())
+		if err != nil {
+			return err
+		}
+		r.Reporter.Infof("Schedule '%s' will set machine pool '%s' on cluster '%s' to %s, next on %s",
+			schedule.Name, schedule.MachinePool, clusterKey, schedule.Size(), next.Format("2006-01-02 15:04 MST"))
+		r.Reporter.Infof("Run 'rosa schedules run-due' periodically to apply the schedules that are due")
+		return nil
+	}
+}
+
+// defaultName builds a schedule name from the machine pool name and the cron expression, keeping
+// only the characters that can be typed on a command line without quoting.
+func defaultName(machinePool string, cron string) string {
+	name := []rune(machinePool + "-")
+	for _, c := range cron {
+		switch {
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+			name = append(name, c)
+		case name[len(name)-1] != '-':
+			name = append(name, '-')
+		}
+	}
+	result := string(name)
+	for len(result) > 0 && result[len(result)-1] == '-' {
+		result = result[:len(result)-1]
+	}
+	return result
+}
@@ -7,11 +7,11 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-
-	"gopkg.in/yaml.v3"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/aws/tags"
 	"github.com/openshift/rosa/pkg/interactive"
 	helper "github.com/openshift/rosa/pkg/network"
 	"github.com/openshift/rosa/pkg/ocm"
@@ -37,23 +37,30 @@ func NewNetworkCommand() *cobra.Command {
 					return nil
 				}
 
-				var templateMap map[string]interface{}
-				err = yaml.Unmarshal(templateBody, &templateMap)
+				description, parameters, err := helper.TemplateParameters(templateBody)
 				if err != nil {
 					fmt.Println(err)
 					return nil
 				}
-
-				parameters, ok := templateMap["Parameters"].(map[string]interface{})
-				if !ok {
+				if len(parameters) == 0 {
 					fmt.Printf("No parameters found in the CloudFormation template %s\n", d.Name())
 					return nil
 				}
 
 				fmt.Printf("Available parameters in %s/%s:\n", filepath.Base(filepath.Dir(path)), d.Name())
-				for paramName := range parameters {
-					fmt.Printf("  %s\n", paramName)
+				if description != "" {
+					fmt.Printf("  %s\n", strings.TrimSpace(description))
 				}
+				writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				for _, parameter := range parameters {
+					usage := parameter.Description
+					if parameter.Default != "" {
+						usage += fmt.Sprintf(" (default: %s)", parameter.Default)
+					}
+					fmt.Fprintf(writer, "  %s\t%s\n", parameter.Name, usage)
+				}
+				writer.Flush()
+				fmt.Println()
 			}
 			return nil
 		})
@@ -106,6 +113,9 @@ func NetworkRunner(userOptions *opts.NetworkUserOptions) rosa.CommandRunner {
 			}
 		}
 
+		// Tag the stack so that it can be found by the list, describe and delete network commands
+		parsedTags[tags.NetworkTemplate] = templateCommand
+
 		templateDir := options.args.TemplateDir
 
 		templateFile := helper.SelectTemplate(templateDir, templateCommand)
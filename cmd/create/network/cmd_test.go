@@ -1,7 +1,12 @@
 package network
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
+
 	"go.uber.org/mock/gomock"
+	"gopkg.in/yaml.v3"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -87,4 +92,59 @@ var _ = Describe("Validation functions", func() {
 			Expect(templateSelected).To(Equal("cmd/create/network/templates/test-template.yaml/cloudformation.yaml"))
 		})
 	})
+
+	Context("templates", func() {
+		It("document every parameter and output the resources of the stack", func() {
+			templateFiles, err := filepath.Glob("templates/*/cloudformation.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(templateFiles)).To(BeNumerically(">=", 5))
+			for _, templateFile := range templateFiles {
+				templateBody, err := os.ReadFile(templateFile)
+				Expect(err).ToNot(HaveOccurred())
+				description, params, err := network.TemplateParameters(templateBody)
+				Expect(err).ToNot(HaveOccurred(), templateFile)
+				Expect(description).ToNot(BeEmpty(), templateFile)
+				for _, param := range params {
+					Expect(param.Description).ToNot(BeEmpty(), "%s: %s", templateFile, param.Name)
+				}
+
+				var template struct {
+					Outputs map[string]interface{} `yaml:"Outputs"`
+				}
+				Expect(yaml.Unmarshal(templateBody, &template)).To(Succeed(), templateFile)
+				Expect(template.Outputs).To(HaveKey(network.OutputVpcID), templateFile)
+				Expect(template.Outputs).To(HaveKey(network.OutputPrivateSubnets), templateFile)
+			}
+		})
+
+		It("allows the HTTP and TLS domains of the egress allowlist in the egress-restricted template", func() {
+			templateBody, err := os.ReadFile("templates/rosa-egress-restricted-vpc/cloudformation.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			_, params, err := network.TemplateParameters(templateBody)
+			Expect(err).ToNot(HaveOccurred())
+			var allowedDomains []string
+			for _, param := range params {
+				if param.Name == "AllowedDomains" {
+					allowedDomains = strings.Split(param.Default, ",")
+				}
+			}
+			Expect(allowedDomains).ToNot(BeEmpty())
+
+			endpoints, err := network.Allowlist(network.TopologyClassic, "us-west-2")
+			Expect(err).ToNot(HaveOccurred())
+			for _, endpoint := range endpoints {
+				if endpoint.Port != 80 && endpoint.Port != 443 {
+					continue
+				}
+				allowed := false
+				for _, domain := range allowedDomains {
+					if endpoint.Domain == domain || endpoint.Domain == strings.TrimPrefix(domain, ".") ||
+						strings.HasPrefix(domain, ".") && strings.HasSuffix(endpoint.Domain, domain) {
+						allowed = true
+					}
+				}
+				Expect(allowed).To(BeTrue(), endpoint.Domain)
+			}
+		})
+	})
 })
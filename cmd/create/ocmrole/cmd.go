@@ -29,6 +29,7 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
 	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/conventions"
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
@@ -125,6 +126,12 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	roleConventions, err := conventions.ForKind(conventions.OcmRole)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
 	// Determine if Classic ROSA managed policies are enabled
 	isManagedSet := cmd.Flags().Changed("managed-policies") || cmd.Flags().Changed("mp")
 	if isManagedSet && env == ocm.Production {
@@ -143,6 +150,9 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 
 	prefix := args.prefix
+	if !cmd.Flags().Changed("prefix") {
+		prefix = roleConventions.DefaultPrefix(prefix)
+	}
 	if interactive.Enabled() {
 		prefix, err = interactive.GetString(interactive.Input{
 			Question: "Role prefix",
@@ -167,6 +177,11 @@ func run(cmd *cobra.Command, _ []string) {
 		r.Reporter.Errorf("Expected a valid role prefix matching %s", aws.RoleNameRE.String())
 		os.Exit(1)
 	}
+	err = roleConventions.ValidatePrefix(prefix)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 
 	isAdmin := args.admin
 
@@ -183,7 +198,7 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 	}
 
-	permissionsBoundary := args.permissionsBoundary
+	permissionsBoundary := roleConventions.DefaultPermissionsBoundary(args.permissionsBoundary)
 	if interactive.Enabled() {
 		permissionsBoundary, err = interactive.GetString(interactive.Input{
 			Question: "Permissions boundary ARN",
@@ -206,8 +221,13 @@ func run(cmd *cobra.Command, _ []string) {
 			os.Exit(1)
 		}
 	}
+	err = roleConventions.ValidatePermissionsBoundary(permissionsBoundary)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 
-	path := args.path
+	path := roleConventions.DefaultPath(args.path)
 	if interactive.Enabled() {
 		path, err = interactive.GetString(interactive.Input{
 			Question: "Role Path",
@@ -228,6 +248,11 @@ func run(cmd *cobra.Command, _ []string) {
 			"It must begin and end with '/' and contain only alphanumeric characters and/or '/' characters.")
 		os.Exit(1)
 	}
+	err = roleConventions.ValidatePath(path)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 
 	if interactive.Enabled() {
 		mode, err = interactive.GetOptionMode(cmd, mode, "Role creation mode")
@@ -269,7 +294,7 @@ func run(cmd *cobra.Command, _ []string) {
 	case interactive.ModeAuto:
 		r.Reporter.Infof("Creating role using '%s'", r.Creator.ARN)
 		roleARN, err := createRoles(r, prefix, roleNameRequested, path, permissionsBoundary,
-			orgID, env, isAdmin, policies, managedPolicies)
+			orgID, env, isAdmin, policies, managedPolicies, roleConventions)
 		if err != nil {
 			r.Reporter.Errorf("There was an error creating the ocm role: %s", err)
 			r.OCMClient.LogEvent("ROSACreateOCMRoleModeAuto", map[string]string{
@@ -313,6 +338,7 @@ func run(cmd *cobra.Command, _ []string) {
 			managedPolicies,
 			confirm.Yes(),
 			policies,
+			roleConventions,
 		)
 		if err != nil {
 			r.Reporter.Errorf("Failed to generate commands for manual mode: %v", err)
@@ -328,15 +354,15 @@ func run(cmd *cobra.Command, _ []string) {
 
 func buildCommands(prefix string, roleName string, rolePath string, permissionsBoundary string,
 	creator *aws.Creator, env string, isAdmin bool, managedPolicies bool, autoConfirmLink bool,
-	policies map[string]*cmv1.AWSSTSPolicy) (string, error) {
+	policies map[string]*cmv1.AWSSTSPolicy, roleConventions *conventions.Rules) (string, error) {
 	commands := []string{}
 	policyName := aws.GetPolicyName(roleName)
-	iamTags := map[string]string{
+	iamTags := roleConventions.AddTags(map[string]string{
 		tags.RolePrefix:    prefix,
 		tags.RoleType:      aws.OCMRole,
 		tags.Environment:   env,
 		tags.RedHatManaged: tags.True,
-	}
+	})
 	if managedPolicies {
 		iamTags[common.ManagedPolicies] = tags.True
 	}
@@ -438,7 +464,8 @@ func buildCommands(prefix string, roleName string, rolePath string, permissionsB
 
 func createRoles(r *rosa.Runtime, prefix string, roleName string, rolePath string,
 	permissionsBoundary string, orgID string, env string, isAdmin bool,
-	policies map[string]*cmv1.AWSSTSPolicy, managedPolicies bool) (string, error) {
+	policies map[string]*cmv1.AWSSTSPolicy, managedPolicies bool,
+	roleConventions *conventions.Rules) (string, error) {
 	var policyARN string
 	var err error
 
@@ -469,12 +496,12 @@ func createRoles(r *rosa.Runtime, prefix string, roleName string, rolePath strin
 		return roleARN, nil
 	}
 
-	iamTags := map[string]string{
+	iamTags := roleConventions.AddTags(map[string]string{
 		tags.RolePrefix:    prefix,
 		tags.RoleType:      aws.OCMRole,
 		tags.Environment:   env,
 		tags.RedHatManaged: tags.True,
-	}
+	})
 	if managedPolicies {
 		iamTags[common.ManagedPolicies] = tags.True
 	}
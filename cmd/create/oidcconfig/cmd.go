@@ -39,6 +39,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	interactiveRoles "github.com/openshift/rosa/pkg/interactive/roles"
+	"github.com/openshift/rosa/pkg/oidcconfig"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -49,6 +50,8 @@ var args struct {
 	userPrefix       string
 	managed          bool
 	installerRoleArn string
+	issuerBackend    string
+	issuerUrl        string
 }
 
 var Cmd = &cobra.Command{
@@ -59,7 +62,13 @@ var Cmd = &cobra.Command{
 		"client AWS account and populates it to be compliant with OIDC protocol. " +
 		"It also creates a Secret in Secrets Manager containing the private key.",
 	Example: `  # Create OIDC config
-	rosa create oidc-config`,
+	rosa create oidc-config
+
+  # Create an unmanaged OIDC config in a private S3 bucket served by CloudFront
+	rosa create oidc-config --managed=false --issuer-backend cloudfront
+
+  # Save the documents of an unmanaged OIDC config to upload them to a static web host
+	rosa create oidc-config --managed=false --issuer-backend raw-files --issuer-url https://oidc.example.com`,
 	Run:  run,
 	Args: cobra.NoArgs,
 }
@@ -67,9 +76,10 @@ var Cmd = &cobra.Command{
 const (
 	maxLengthUserPrefix = 15
 
-	rawFilesFlag   = "raw-files"
-	userPrefixFlag = "prefix"
-	managedFlag    = "managed"
+	rawFilesFlag      = "raw-files"
+	userPrefixFlag    = "prefix"
+	managedFlag       = "managed"
+	issuerBackendFlag = "issuer-backend"
 )
 
 func init() {
@@ -97,6 +107,25 @@ func init() {
 		"Indicates whether it is a Red Hat managed or unmanaged (Customer hosted) OIDC Configuration.",
 	)
 
+	flags.StringVar(
+		&args.issuerBackend,
+		issuerBackendFlag,
+		oidcconfig.BackendS3,
+		"Where the documents of an unmanaged OIDC configuration are hosted. Valid options are:\n"+
+			"s3: A public S3 bucket\n"+
+			"cloudfront: A private S3 bucket served by a CloudFront distribution\n"+
+			"raw-files: Any static web host, the documents are saved locally to be uploaded by the client",
+	)
+	Cmd.RegisterFlagCompletionFunc(issuerBackendFlag, issuerBackendCompletion)
+
+	flags.StringVar(
+		&args.issuerUrl,
+		IssuerUrlFlag,
+		"",
+		fmt.Sprintf("HTTPS URL of the static web host serving the documents, required with '--%s %s'.",
+			issuerBackendFlag, oidcconfig.BackendRawFiles),
+	)
+
 	// normalizing installer role argument to support deprecated flag
 	flags.SetNormalizeFunc(arguments.NormalizeFlags)
 	flags.StringVar(
@@ -114,9 +143,19 @@ func init() {
 	output.AddFlag(Cmd)
 }
 
+func issuerBackendCompletion(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return oidcconfig.Backends, cobra.ShellCompDirectiveDefault
+}
+
+// isRawFiles returns true when the documents are saved locally instead of being hosted in the
+// AWS account, either as plain files or as a bundle for a static web host.
+func isRawFiles() bool {
+	return args.rawFiles || args.issuerBackend == oidcconfig.BackendRawFiles
+}
+
 func checkInteractiveModeNeeded(cmd *cobra.Command) {
 	modeNotChanged := !cmd.Flags().Changed("mode")
-	if modeNotChanged && !cmd.Flags().Changed(rawFilesFlag) {
+	if modeNotChanged && !isRawFiles() {
 		interactive.Enable()
 		return
 	}
@@ -173,7 +212,70 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	if !args.rawFiles && interactive.Enabled() && !cmd.Flags().Changed("mode") {
+	err = oidcconfig.ValidateBackend(args.issuerBackend)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	if args.managed && cmd.Flags().Changed(issuerBackendFlag) {
+		r.Reporter.Warnf("--%s param is not supported for managed OIDC config", issuerBackendFlag)
+		os.Exit(1)
+	}
+
+	if args.rawFiles && cmd.Flags().Changed(issuerBackendFlag) {
+		r.Reporter.Warnf("--%s param is not supported alongside --%s param", rawFilesFlag, issuerBackendFlag)
+		os.Exit(1)
+	}
+
+	if !args.managed && !args.rawFiles && interactive.Enabled() && !cmd.Flags().Changed(issuerBackendFlag) {
+		args.issuerBackend, err = interactive.GetOption(interactive.Input{
+			Question: "Issuer backend",
+			Help:     cmd.Flags().Lookup(issuerBackendFlag).Usage,
+			Default:  args.issuerBackend,
+			Options:  oidcconfig.Backends,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid issuer backend: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	if args.issuerBackend == oidcconfig.BackendRawFiles {
+		if mode != "" {
+			r.Reporter.Warnf("--%s %s is not supported alongside --mode param.",
+				issuerBackendFlag, oidcconfig.BackendRawFiles)
+			os.Exit(1)
+		}
+		if args.installerRoleArn != "" {
+			r.Reporter.Warnf("--%s %s is not supported alongside --%s param",
+				issuerBackendFlag, oidcconfig.BackendRawFiles, InstallerRoleArnFlag)
+			os.Exit(1)
+		}
+		if interactive.Enabled() && args.issuerUrl == "" {
+			args.issuerUrl, err = interactive.GetString(interactive.Input{
+				Question: "Issuer URL",
+				Help:     cmd.Flags().Lookup(IssuerUrlFlag).Usage,
+				Required: true,
+			})
+			if err != nil {
+				r.Reporter.Errorf("Expected a valid issuer URL: %s", err)
+				os.Exit(1)
+			}
+		}
+		err = oidcconfig.ValidateIssuerUrl(args.issuerUrl)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	} else if args.issuerUrl != "" {
+		r.Reporter.Warnf("--%s param is only supported alongside --%s %s",
+			IssuerUrlFlag, issuerBackendFlag, oidcconfig.BackendRawFiles)
+		os.Exit(1)
+	}
+
+	if !isRawFiles() && interactive.Enabled() && !cmd.Flags().Changed("mode") {
 		question := "OIDC Config creation mode"
 		if args.managed {
 			r.Reporter.Warnf("For a managed OIDC Config only auto mode is supported. " +
@@ -203,10 +305,16 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 
 	if !args.managed {
-		if !args.rawFiles {
+		if !isRawFiles() {
 			if !output.HasFlag() && r.Reporter.IsTerminal() {
-				r.Reporter.Infof("This command will create a S3 bucket populating it with documents " +
-					"to be compliant with OIDC protocol. It will also create a Secret in Secrets Manager containing the private key")
+				if args.issuerBackend == oidcconfig.BackendCloudFront {
+					r.Reporter.Infof("This command will create a private S3 bucket served by a CloudFront " +
+						"distribution populating it with documents to be compliant with OIDC protocol. " +
+						"It will also create a Secret in Secrets Manager containing the private key")
+				} else {
+					r.Reporter.Infof("This command will create a S3 bucket populating it with documents " +
+						"to be compliant with OIDC protocol. It will also create a Secret in Secrets Manager containing the private key")
+				}
 			}
 			if mode == interactive.ModeAuto && (interactive.Enabled() || (confirm.Yes() && args.installerRoleArn == "")) {
 				args.installerRoleArn = interactiveRoles.
@@ -287,6 +395,10 @@ func run(cmd *cobra.Command, _ []string) {
 			r.Reporter.Errorf("%s", err)
 			os.Exit(1)
 		}
+		if args.issuerBackend == oidcconfig.BackendRawFiles {
+			oidcConfigInput.IssuerUrl = args.issuerUrl
+			oidcConfigInput.DiscoveryDocument = oidcconfigs.GenerateDiscoveryDocument(args.issuerUrl)
+		}
 	}
 
 	oidcConfigStrategy, err := getOidcConfigStrategy(mode, &oidcConfigInput)
@@ -295,7 +407,7 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 	oidcConfigId := oidcConfigStrategy.execute(r)
-	if !args.rawFiles {
+	if !isRawFiles() {
 		arguments.DisableRegionDeprecationWarning = true // disable region deprecation warning
 		providerArgs := []string{"", mode, oidcConfigInput.IssuerUrl}
 		if oidcConfigId != "" {
@@ -358,19 +470,8 @@ type CreateUnmanagedOidcConfigAutoStrategy struct {
 	oidcConfig *oidcconfigs.OidcConfigInput
 }
 
-const (
-	discoveryDocumentKey = ".well-known/openid-configuration"
-	jwksKey              = "keys.json"
-)
-
 func (s *CreateUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) string {
-	bucketUrl := s.oidcConfig.IssuerUrl
 	bucketName := s.oidcConfig.BucketName
-	discoveryDocument := s.oidcConfig.DiscoveryDocument
-	jwks := s.oidcConfig.Jwks
-	privateKey := s.oidcConfig.PrivateKey
-	privateKeySecretName := s.oidcConfig.PrivateKeySecretName
-	installerRoleArn := args.installerRoleArn
 	var spin *spinner.Spinner
 	if !output.HasFlag() && r.Reporter.IsTerminal() {
 		spin = spinner.New(spinner.CharSets[9], 100*time.Millisecond)
@@ -384,14 +485,65 @@ func (s *CreateUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) string
 		r.Reporter.Errorf("There was a problem creating S3 bucket '%s': %s", bucketName, err)
 		os.Exit(1)
 	}
-	err = r.AWSClient.PutPublicReadObjectInS3Bucket(
-		bucketName, strings.NewReader(discoveryDocument), discoveryDocumentKey)
+	putOidcDocuments(r, spin, s.oidcConfig)
+	return registerUnmanagedOidcConfig(r, spin, s.oidcConfig)
+}
+
+type CreateUnmanagedOidcConfigCloudFrontAutoStrategy struct {
+	oidcConfig *oidcconfigs.OidcConfigInput
+}
+
+func (s *CreateUnmanagedOidcConfigCloudFrontAutoStrategy) execute(r *rosa.Runtime) string {
+	bucketName := s.oidcConfig.BucketName
+	stackName := oidcconfig.CloudFrontStackName(bucketName)
+	var spin *spinner.Spinner
+	if !output.HasFlag() && r.Reporter.IsTerminal() {
+		spin = spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+		r.Reporter.Infof("Setting up unmanaged OIDC configuration '%s' served by CloudFront, "+
+			"deploying the distribution can take several minutes", bucketName)
+	}
+	if spin != nil {
+		spin.Start()
+	}
+	outputs, err := r.AWSClient.CreateStackWithParameters(oidcconfig.CloudFrontTemplate, stackName,
+		map[string]string{"BucketName": bucketName},
+		map[string]string{tags.RedHatManaged: tags.True},
+		oidcconfig.CloudFrontStackTimeout)
+	if err != nil {
+		if spin != nil {
+			spin.Stop()
+		}
+		r.Reporter.Errorf("There was a problem creating CloudFormation stack '%s': %s", stackName, err)
+		os.Exit(1)
+	}
+	issuerUrl := outputs[oidcconfig.CloudFrontIssuerUrlOutput]
+	if issuerUrl == "" {
+		if spin != nil {
+			spin.Stop()
+		}
+		r.Reporter.Errorf("CloudFormation stack '%s' has no '%s' output", stackName,
+			oidcconfig.CloudFrontIssuerUrlOutput)
+		os.Exit(1)
+	}
+	s.oidcConfig.IssuerUrl = issuerUrl
+	s.oidcConfig.DiscoveryDocument = oidcconfigs.GenerateDiscoveryDocument(issuerUrl)
+	putOidcDocuments(r, spin, s.oidcConfig)
+	return registerUnmanagedOidcConfig(r, spin, s.oidcConfig)
+}
+
+// putOidcDocuments uploads the discovery document and the JSON Web Key Set to the bucket.
+func putOidcDocuments(r *rosa.Runtime, spin *spinner.Spinner, oidcConfigInput *oidcconfigs.OidcConfigInput) {
+	bucketName := oidcConfigInput.BucketName
+	discoveryDocument := oidcConfigInput.DiscoveryDocument
+	jwks := oidcConfigInput.Jwks
+	err := r.AWSClient.PutPublicReadObjectInS3Bucket(
+		bucketName, strings.NewReader(discoveryDocument), oidcconfig.DiscoveryDocumentKey)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem populating discovery "+
 			"document to S3 bucket '%s': %s", bucketName, err)
 		os.Exit(1)
 	}
-	err = r.AWSClient.PutPublicReadObjectInS3Bucket(bucketName, bytes.NewReader(jwks), jwksKey)
+	err = r.AWSClient.PutPublicReadObjectInS3Bucket(bucketName, bytes.NewReader(jwks), oidcconfig.JwksKey)
 	if err != nil {
 		if spin != nil {
 			spin.Stop()
@@ -400,6 +552,16 @@ func (s *CreateUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) string
 			"to S3 bucket '%s': %s", bucketName, err)
 		os.Exit(1)
 	}
+}
+
+// registerUnmanagedOidcConfig stores the private key in Secrets Manager and registers the
+// configuration in OCM, returning its ID.
+func registerUnmanagedOidcConfig(r *rosa.Runtime, spin *spinner.Spinner,
+	oidcConfigInput *oidcconfigs.OidcConfigInput) string {
+	bucketUrl := oidcConfigInput.IssuerUrl
+	privateKey := oidcConfigInput.PrivateKey
+	privateKeySecretName := oidcConfigInput.PrivateKeySecretName
+	installerRoleArn := args.installerRoleArn
 	secretARN, err := r.AWSClient.CreateSecretInSecretsManager(privateKeySecretName, string(privateKey[:]))
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving private key to secrets manager: %s", err)
@@ -510,7 +672,7 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) strin
 		SetCommand(awscb.PutObject).
 		AddParam(awscb.Body, fmt.Sprintf("./%s", discoveryDocumentFilename)).
 		AddParam(awscb.Bucket, bucketName).
-		AddParam(awscb.Key, discoveryDocumentKey).
+		AddParam(awscb.Key, oidcconfig.DiscoveryDocumentKey).
 		AddParam(awscb.Tagging, fmt.Sprintf("'%s=%s'", tags.RedHatManaged, tags.True)).
 		Build()
 	commands = append(commands, putDiscoveryDocumentCommand)
@@ -525,7 +687,7 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) strin
 		SetCommand(awscb.PutObject).
 		AddParam(awscb.Body, fmt.Sprintf("./%s", jwksFilename)).
 		AddParam(awscb.Bucket, bucketName).
-		AddParam(awscb.Key, jwksKey).
+		AddParam(awscb.Key, oidcconfig.JwksKey).
 		AddParam(awscb.Tagging, fmt.Sprintf("'%s=%s'", tags.RedHatManaged, tags.True)).
 		Build()
 	commands = append(commands, putJwksCommand)
@@ -552,6 +714,147 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) strin
 	return ""
 }
 
+type CreateUnmanagedOidcConfigCloudFrontManualStrategy struct {
+	oidcConfig *oidcconfigs.OidcConfigInput
+}
+
+// issuerUrlPlaceholder stands for the CloudFront URL in the discovery document saved in manual
+// mode, as the URL is only known once the distribution is created.
+const issuerUrlPlaceholder = "ISSUER_URL"
+
+func (s *CreateUnmanagedOidcConfigCloudFrontManualStrategy) execute(r *rosa.Runtime) string {
+	commands := []string{}
+	bucketName := s.oidcConfig.BucketName
+	stackName := oidcconfig.CloudFrontStackName(bucketName)
+	jwks := s.oidcConfig.Jwks
+	privateKey := s.oidcConfig.PrivateKey
+	privateKeyFilename := s.oidcConfig.PrivateKeyFilename
+	privateKeySecretName := s.oidcConfig.PrivateKeySecretName
+	err := helper.SaveDocument(string(privateKey), privateKeyFilename)
+	if err != nil {
+		r.Reporter.Errorf("There was a problem saving private key to a file: %s", err)
+		os.Exit(1)
+	}
+	templateFilename := fmt.Sprintf("cloudfront-%s.yaml", bucketName)
+	err = helper.SaveDocument(oidcconfig.CloudFrontTemplate, templateFilename)
+	if err != nil {
+		r.Reporter.Errorf("There was a problem saving CloudFormation template to a file: %s", err)
+		os.Exit(1)
+	}
+	commands = append(commands, fmt.Sprintf("aws cloudformation deploy --template-file ./%s "+
+		"--stack-name %s --parameter-overrides BucketName=%s --tags %s=%s --region %s",
+		templateFilename, stackName, bucketName, tags.RedHatManaged, tags.True, args.region))
+	commands = append(commands, fmt.Sprintf("rm %s", templateFilename))
+	commands = append(commands, fmt.Sprintf("%s=$(aws cloudformation describe-stacks --stack-name %s "+
+		"--query \"Stacks[0].Outputs[?OutputKey=='%s'].OutputValue\" --output text --region %s)",
+		issuerUrlPlaceholder, stackName, oidcconfig.CloudFrontIssuerUrlOutput, args.region))
+
+	discoveryDocumentTemplateFilename := fmt.Sprintf("discovery-document-template-%s.json", bucketName)
+	err = helper.SaveDocument(oidcconfigs.GenerateDiscoveryDocument(issuerUrlPlaceholder),
+		discoveryDocumentTemplateFilename)
+	if err != nil {
+		r.Reporter.Errorf("There was a problem saving discovery document to a file: %s", err)
+		os.Exit(1)
+	}
+	discoveryDocumentFilename := fmt.Sprintf("discovery-document-%s.json", bucketName)
+	commands = append(commands, fmt.Sprintf("sed \"s|%s|$%s|g\" %s > %s",
+		issuerUrlPlaceholder, issuerUrlPlaceholder, discoveryDocumentTemplateFilename, discoveryDocumentFilename))
+	commands = append(commands, fmt.Sprintf("rm %s", discoveryDocumentTemplateFilename))
+	putDiscoveryDocumentCommand := awscb.NewS3ApiCommandBuilder().
+		SetCommand(awscb.PutObject).
+		AddParam(awscb.Body, fmt.Sprintf("./%s", discoveryDocumentFilename)).
+		AddParam(awscb.Bucket, bucketName).
+		AddParam(awscb.Key, oidcconfig.DiscoveryDocumentKey).
+		AddParam(awscb.Tagging, fmt.Sprintf("'%s=%s'", tags.RedHatManaged, tags.True)).
+		Build()
+	commands = append(commands, putDiscoveryDocumentCommand)
+	commands = append(commands, fmt.Sprintf("rm %s", discoveryDocumentFilename))
+	jwksFilename := fmt.Sprintf("jwks-%s.json", bucketName)
+	err = helper.SaveDocument(string(jwks[:]), jwksFilename)
+	if err != nil {
+		r.Reporter.Errorf("There was a problem saving JSON Web Key Set to a file: %s", err)
+		os.Exit(1)
+	}
+	putJwksCommand := awscb.NewS3ApiCommandBuilder().
+		SetCommand(awscb.PutObject).
+		AddParam(awscb.Body, fmt.Sprintf("./%s", jwksFilename)).
+		AddParam(awscb.Bucket, bucketName).
+		AddParam(awscb.Key, oidcconfig.JwksKey).
+		AddParam(awscb.Tagging, fmt.Sprintf("'%s=%s'", tags.RedHatManaged, tags.True)).
+		Build()
+	commands = append(commands, putJwksCommand)
+	commands = append(commands, fmt.Sprintf("rm %s", jwksFilename))
+	createSecretCommand := awscb.NewSecretsManagerCommandBuilder().
+		SetCommand(awscb.CreateSecret).
+		AddParam(awscb.Name, privateKeySecretName).
+		AddParam(awscb.SecretString, fmt.Sprintf("file://%s", privateKeyFilename)).
+		AddParam(awscb.Description, fmt.Sprintf("\"Secret for %s\"", bucketName)).
+		AddParam(awscb.Region, args.region).
+		AddTags(map[string]string{
+			tags.RedHatManaged: "true",
+		}).
+		Build()
+	commands = append(commands, createSecretCommand)
+	commands = append(commands, fmt.Sprintf("rm %s", privateKeyFilename))
+	commands = append(commands, fmt.Sprintf("echo $%s", issuerUrlPlaceholder))
+	fmt.Println(awscb.JoinCommands(commands))
+	if r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Please run commands above to generate OIDC compliant configuration served by " +
+			"CloudFront in your AWS account. The last command prints the issuer URL. " +
+			"To register this OIDC Configuration, please run the following command:\n" +
+			"rosa register oidc-config\n" +
+			"For more information please refer to the documentation")
+	}
+	return ""
+}
+
+type CreateUnmanagedOidcConfigRawFilesBundleStrategy struct {
+	oidcConfig *oidcconfigs.OidcConfigInput
+}
+
+func (s *CreateUnmanagedOidcConfigRawFilesBundleStrategy) execute(r *rosa.Runtime) string {
+	bucketName := s.oidcConfig.BucketName
+	issuerUrl := s.oidcConfig.IssuerUrl
+	privateKeyFilename := s.oidcConfig.PrivateKeyFilename
+	err := helper.SaveDocument(string(s.oidcConfig.PrivateKey), privateKeyFilename)
+	if err != nil {
+		r.Reporter.Errorf("There was a problem saving private key to a file: %s", err)
+		os.Exit(1)
+	}
+	bundleDirectory := fmt.Sprintf("%s-bundle", bucketName)
+	err = oidcconfig.SaveRawFilesBundle(bundleDirectory, s.oidcConfig.DiscoveryDocument, s.oidcConfig.Jwks)
+	if err != nil {
+		r.Reporter.Errorf("There was a problem saving OIDC documents: %s", err)
+		os.Exit(1)
+	}
+	createSecretCommand := awscb.NewSecretsManagerCommandBuilder().
+		SetCommand(awscb.CreateSecret).
+		AddParam(awscb.Name, s.oidcConfig.PrivateKeySecretName).
+		AddParam(awscb.SecretString, fmt.Sprintf("file://%s", privateKeyFilename)).
+		AddParam(awscb.Description, fmt.Sprintf("\"Secret for %s\"", issuerUrl)).
+		AddParam(awscb.Region, args.region).
+		AddTags(map[string]string{
+			tags.RedHatManaged: "true",
+		}).
+		Build()
+	if !output.HasFlag() && r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Upload the content of directory '%s' to your web host so that the following "+
+			"documents are served over HTTPS:\n"+
+			"\t%s/%s\n"+
+			"\t%s/%s",
+			bundleDirectory, issuerUrl, oidcconfig.DiscoveryDocumentKey, issuerUrl, oidcconfig.JwksKey)
+		r.Reporter.Infof("Then store the private key in Secrets Manager with the following commands:")
+	}
+	fmt.Println(awscb.JoinCommands([]string{createSecretCommand, fmt.Sprintf("rm %s", privateKeyFilename)}))
+	if !output.HasFlag() && r.Reporter.IsTerminal() {
+		r.Reporter.Infof("To register this OIDC Configuration, please run the following command:\n"+
+			"\trosa register oidc-config --issuer-url %s --secret-arn <secret_arn>\n"+
+			"Once registered, check that the documents are served correctly with:\n"+
+			"\trosa verify oidc-config --oidc-config-id <oidc_config_id>", issuerUrl)
+	}
+	return ""
+}
+
 type CreateManagedOidcConfigAutoStrategy struct {
 	oidcConfigInput *oidcconfigs.OidcConfigInput
 }
@@ -604,10 +907,20 @@ func getOidcConfigStrategy(mode string, input *oidcconfigs.OidcConfigInput) (Cre
 	if args.managed {
 		return &CreateManagedOidcConfigAutoStrategy{oidcConfigInput: input}, nil
 	}
+	if args.issuerBackend == oidcconfig.BackendRawFiles {
+		return &CreateUnmanagedOidcConfigRawFilesBundleStrategy{oidcConfig: input}, nil
+	}
+	isCloudFront := args.issuerBackend == oidcconfig.BackendCloudFront
 	switch mode {
 	case interactive.ModeAuto:
+		if isCloudFront {
+			return &CreateUnmanagedOidcConfigCloudFrontAutoStrategy{oidcConfig: input}, nil
+		}
 		return &CreateUnmanagedOidcConfigAutoStrategy{oidcConfig: input}, nil
 	case interactive.ModeManual:
+		if isCloudFront {
+			return &CreateUnmanagedOidcConfigCloudFrontManualStrategy{oidcConfig: input}, nil
+		}
 		return &CreateUnmanagedOidcConfigManualStrategy{oidcConfig: input}, nil
 	default:
 		return nil, weberr.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
@@ -27,6 +27,7 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
 	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/conventions"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	interactiveOidc "github.com/openshift/rosa/pkg/interactive/oidc"
@@ -106,6 +107,12 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	providerConventions, err := conventions.ForKind(conventions.OidcProvider)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
 	// Determine if interactive mode is needed
 	if !isProgrammaticallyCalled && !interactive.Enabled() &&
 		(!cmd.Flags().Changed("cluster") || !cmd.Flags().Changed("mode")) {
@@ -192,7 +199,7 @@ func run(cmd *cobra.Command, argv []string) {
 		if clusterId == "" && clusterKey != "" {
 			clusterId = r.FetchCluster().ID()
 		}
-		err = createProvider(r, oidcEndpointURL, clusterId, isProgrammaticallyCalled)
+		err = createProvider(r, oidcEndpointURL, clusterId, isProgrammaticallyCalled, providerConventions)
 		if err != nil {
 			r.Reporter.Errorf("There was an error creating the OIDC provider: %s", err)
 			r.OCMClient.LogEvent("ROSACreateOIDCProviderModeAuto", map[string]string{
@@ -206,7 +213,7 @@ func run(cmd *cobra.Command, argv []string) {
 			ocm.Response:  ocm.Success,
 		})
 	case interactive.ModeManual:
-		commands, err := buildCommands(r, oidcEndpointURL, clusterId)
+		commands, err := buildCommands(r, oidcEndpointURL, clusterId, providerConventions)
 		if err != nil {
 			r.Reporter.Errorf("There was an error building the list of resources: %s", err)
 			os.Exit(1)
@@ -228,7 +235,8 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 }
 
-func createProvider(r *rosa.Runtime, oidcEndpointUrl string, clusterId string, isProgrammaticallyCalled bool) error {
+func createProvider(r *rosa.Runtime, oidcEndpointUrl string, clusterId string, isProgrammaticallyCalled bool,
+	providerConventions *conventions.Rules) error {
 	inputBuilder := cmv1.NewOidcThumbprintInput()
 	if (isProgrammaticallyCalled || clusterId == "") && args.oidcConfigId != "" {
 		inputBuilder.OidcConfigId(args.oidcConfigId)
@@ -245,7 +253,8 @@ func createProvider(r *rosa.Runtime, oidcEndpointUrl string, clusterId string, i
 	}
 	r.Reporter.Debugf("Using thumbprint '%s'", thumbprint.Thumbprint())
 
-	oidcProviderARN, err := r.AWSClient.CreateOpenIDConnectProvider(oidcEndpointUrl, thumbprint.Thumbprint(), clusterId)
+	oidcProviderARN, err := r.AWSClient.CreateOpenIDConnectProvider(oidcEndpointUrl, thumbprint.Thumbprint(), clusterId,
+		providerConventions.AddTags(map[string]string{}))
 	if err != nil {
 		return err
 	}
@@ -256,7 +265,8 @@ func createProvider(r *rosa.Runtime, oidcEndpointUrl string, clusterId string, i
 	return nil
 }
 
-func buildCommands(r *rosa.Runtime, oidcEndpointUrl string, clusterId string) (string, error) {
+func buildCommands(r *rosa.Runtime, oidcEndpointUrl string, clusterId string,
+	providerConventions *conventions.Rules) (string, error) {
 	commands := []string{}
 
 	input, err := cmv1.NewOidcThumbprintInput().OidcConfigId(args.oidcConfigId).ClusterId(clusterId).Build()
@@ -272,9 +282,9 @@ func buildCommands(r *rosa.Runtime, oidcEndpointUrl string, clusterId string) (s
 	}
 	r.Reporter.Debugf("Using thumbprint '%s'", thumbprint.Thumbprint())
 
-	iamTags := map[string]string{
+	iamTags := providerConventions.AddTags(map[string]string{
 		tags.RedHatManaged: tags.True,
-	}
+	})
 	if clusterId != "" {
 		iamTags[tags.ClusterID] = clusterId
 	}
@@ -28,6 +28,11 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 		r.Reporter.Errorf("Cluster '%s' is not an STS cluster.", clusterKey)
 		os.Exit(1)
 	}
+	err := validateClusterConventions(cluster)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 
 	// Check to see if IAM operator roles have already created
 	missingRoles, err := validateOperatorRoles(r, cluster)
@@ -207,13 +212,13 @@ func createRoles(
 				})
 			}
 
-			operatorPolicyTags := map[string]string{
+			operatorPolicyTags := args.roleConventions.AddTags(map[string]string{
 				common.OpenShiftVersion: accountRoleVersion,
 				tags.RolePrefix:         prefix,
 				tags.RedHatManaged:      helper.True,
 				tags.OperatorNamespace:  operator.Namespace(),
 				tags.OperatorName:       operator.Name(),
-			}
+			})
 
 			if args.forcePolicyCreation || (isSharedVpc && credrequest == aws.IngressOperatorCloudCredentialsRoleType) {
 				policyArn, err = r.AWSClient.ForceEnsurePolicy(policyArn, policyDetails,
@@ -238,11 +243,11 @@ func createRoles(
 		}
 
 		r.Reporter.Debugf("Creating role '%s'", roleName)
-		tagsList := map[string]string{
+		tagsList := args.roleConventions.AddTags(map[string]string{
 			tags.OperatorNamespace: operator.Namespace(),
 			tags.OperatorName:      operator.Name(),
 			tags.RedHatManaged:     helper.True,
-		}
+		})
 		if !ocm.IsOidcConfigReusable(cluster) {
 			tagsList[tags.ClusterID] = cluster.ID()
 		}
@@ -317,13 +322,13 @@ func buildCommands(r *rosa.Runtime, env string,
 		} else {
 			policyARN = computePolicyARN(*r.Creator, prefix, operator.Namespace(), operator.Name(), path)
 			name := aws.GetOperatorPolicyName(prefix, operator.Namespace(), operator.Name())
-			iamTags := map[string]string{
+			iamTags := args.roleConventions.AddTags(map[string]string{
 				common.OpenShiftVersion: defaultPolicyVersion,
 				tags.RolePrefix:         prefix,
 				tags.OperatorNamespace:  operator.Namespace(),
 				tags.OperatorName:       operator.Name(),
 				tags.RedHatManaged:      helper.True,
-			}
+			})
 			operatorPolicyKey := aws.GetOperatorPolicyKey(credrequest, hostedCPPolicies, isSharedVpc)
 			fileName := fmt.Sprintf("file://%s.json", operatorPolicyKey)
 			_, err = r.AWSClient.IsPolicyExists(policyARN)
@@ -366,11 +371,11 @@ func buildCommands(r *rosa.Runtime, env string,
 		if err != nil {
 			return "", err
 		}
-		iamTags := map[string]string{
+		iamTags := args.roleConventions.AddTags(map[string]string{
 			tags.OperatorNamespace: operator.Namespace(),
 			tags.OperatorName:      operator.Name(),
 			tags.RedHatManaged:     helper.True,
-		}
+		})
 		if !ocm.IsOidcConfigReusable(cluster) {
 			iamTags[tags.ClusterID] = cluster.ID()
 		}
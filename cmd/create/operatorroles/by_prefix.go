@@ -29,7 +29,7 @@ func handleOperatorRolesPrefixOptions(r *rosa.Runtime, cmd *cobra.Command) {
 		Question: "Operator roles prefix",
 		Help:     cmd.Flags().Lookup(PrefixFlag).Usage,
 		Required: true,
-		Default:  operatorRolesPrefix,
+		Default:  args.roleConventions.DefaultPrefix(operatorRolesPrefix),
 		Validators: []interactive.Validator{
 			interactive.RegExp(aws.RoleNameRE.String()),
 			interactive.MaxLength(32),
@@ -107,6 +107,11 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 			"This ARN path will be used for subsequent created operator roles and policies.",
 			path, installerRoleArn)
 	}
+	err = args.roleConventions.ValidatePath(path)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 
 	hasStandardNamedInstallerRole, installerRolePrefix := aws.IsStandardNamedAccountRole(installerRoleName,
 		aws.AccountRoles[aws.InstallerAccountRole].Name)
@@ -273,6 +278,11 @@ func validateArgumentsOperatorRolesCreationByPrefix(r *rosa.Runtime, operatorRol
 		r.Reporter.Errorf("Expected valid operator roles prefix matching %s", aws.RoleNameRE.String())
 		os.Exit(1)
 	}
+	err := args.roleConventions.ValidatePrefix(operatorRolesPrefix)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 	parsedURI, err := url.ParseRequestURI(oidcEndpointUrl)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
@@ -329,13 +339,13 @@ func createRolesByPrefix(r *rosa.Runtime, prefix string, permissionsBoundary str
 				})
 			}
 
-			operatorPolicyTags := map[string]string{
+			operatorPolicyTags := args.roleConventions.AddTags(map[string]string{
 				common.OpenShiftVersion: defaultPolicyVersion,
 				tags.RolePrefix:         prefix,
 				tags.RedHatManaged:      helper.True,
 				tags.OperatorNamespace:  operator.Namespace(),
 				tags.OperatorName:       operator.Name(),
-			}
+			})
 
 			if args.forcePolicyCreation || (isSharedVpc && credrequest == aws.IngressOperatorCloudCredentialsRoleType) {
 				_, err := r.AWSClient.ForceEnsurePolicy(policyArn, policyDetails,
@@ -360,11 +370,11 @@ func createRolesByPrefix(r *rosa.Runtime, prefix string, permissionsBoundary str
 		}
 
 		r.Reporter.Debugf("Creating role '%s'", roleName)
-		tagsList := map[string]string{
+		tagsList := args.roleConventions.AddTags(map[string]string{
 			tags.OperatorNamespace: operator.Namespace(),
 			tags.OperatorName:      operator.Name(),
 			tags.RedHatManaged:     helper.True,
-		}
+		})
 		if managedPolicies {
 			tagsList[common.ManagedPolicies] = helper.True
 		}
@@ -425,13 +435,13 @@ func buildCommandsFromPrefix(r *rosa.Runtime, env string,
 		} else {
 			policyARN = computePolicyARN(*r.Creator, prefix, operator.Namespace(), operator.Name(), path)
 			name := aws.GetOperatorPolicyName(prefix, operator.Namespace(), operator.Name())
-			iamTags := map[string]string{
+			iamTags := args.roleConventions.AddTags(map[string]string{
 				common.OpenShiftVersion: defaultPolicyVersion,
 				tags.RolePrefix:         prefix,
 				tags.OperatorNamespace:  operator.Namespace(),
 				tags.OperatorName:       operator.Name(),
 				tags.RedHatManaged:      helper.True,
-			}
+			})
 			operatorPolicyKey := aws.GetOperatorPolicyKey(credrequest, hostedCPPolicies, isSharedVpc)
 			fileName := fmt.Sprintf("file://%s.json", operatorPolicyKey)
 			_, err = r.AWSClient.IsPolicyExists(policyARN)
@@ -474,11 +484,11 @@ func buildCommandsFromPrefix(r *rosa.Runtime, env string,
 		if err != nil {
 			return "", err
 		}
-		iamTags := map[string]string{
+		iamTags := args.roleConventions.AddTags(map[string]string{
 			tags.OperatorNamespace: operator.Namespace(),
 			tags.OperatorName:      operator.Name(),
 			tags.RedHatManaged:     helper.True,
-		}
+		})
 		if managedPolicies {
 			iamTags[common.ManagedPolicies] = helper.True
 		}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/conventions"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
@@ -46,6 +47,7 @@ var args struct {
 	oidcConfigId        string
 	sharedVpcRoleArn    string
 	channelGroup        string
+	roleConventions     *conventions.Rules
 }
 
 var Cmd = &cobra.Command{
@@ -163,6 +165,12 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	args.roleConventions, err = conventions.ForKind(conventions.OperatorRoles)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
 	// Determine if interactive mode is needed
 	if !interactive.Enabled() && !cmd.Flags().Changed("mode") && !isProgmaticallyCalled {
 		interactive.Enable()
@@ -219,7 +227,7 @@ func run(cmd *cobra.Command, argv []string) {
 		handleOperatorRolesPrefixOptions(r, cmd)
 	}
 
-	permissionsBoundary := args.permissionsBoundary
+	permissionsBoundary := args.roleConventions.DefaultPermissionsBoundary(args.permissionsBoundary)
 	if interactive.Enabled() && !isProgmaticallyCalled {
 		permissionsBoundary, err = interactive.GetString(interactive.Input{
 			Question: "Permissions boundary ARN",
@@ -242,6 +250,11 @@ func run(cmd *cobra.Command, argv []string) {
 			os.Exit(1)
 		}
 	}
+	err = args.roleConventions.ValidatePermissionsBoundary(permissionsBoundary)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 
 	policies, err := r.OCMClient.GetPolicies("OperatorRole")
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	awsCommonUtils "github.com/openshift-online/ocm-common/pkg/aws/utils"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	errors "github.com/zgalor/weberr"
 
 	"github.com/openshift/rosa/pkg/aws"
@@ -25,6 +26,20 @@ func computePolicyARN(creator aws.Creator,
 	return fmt.Sprintf("arn:%s:iam::%s:policy/%s", creator.Partition, creator.AccountID, policy)
 }
 
+// validateClusterConventions fails when the operator roles prefix or the path of the account roles
+// of the cluster don't follow the conventions, as the operator roles inherit them.
+func validateClusterConventions(cluster *cmv1.Cluster) error {
+	err := args.roleConventions.ValidatePrefix(cluster.AWS().STS().OperatorRolePrefix())
+	if err != nil {
+		return err
+	}
+	path, err := aws.GetPathFromAccountRole(cluster, aws.AccountRoles[aws.InstallerAccountRole].Name)
+	if err != nil {
+		return err
+	}
+	return args.roleConventions.ValidatePath(path)
+}
+
 func validateIngressOperatorPolicyOverride(r *rosa.Runtime, policyArn string, sharedVpcRoleArn string,
 	installerRolePrefix string) error {
 	_, err := r.AWSClient.IsPolicyExists(policyArn)
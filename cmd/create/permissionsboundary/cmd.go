@@ -0,0 +1,309 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permissionsboundary
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	awsCommonValidations "github.com/openshift-online/ocm-common/pkg/aws/validations"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "permissions-boundary"
+	short = "Create a permissions boundary for the roles created by ROSA"
+	long  = "Create a customer managed policy allowing every action of the permission policies of the " +
+		"account roles, the operator roles or both. The policy can then be used as the permissions " +
+		"boundary of the roles with the '--permissions-boundary' option of 'rosa create account-roles' " +
+		"and 'rosa create operator-roles'.\n\n" +
+		"When the actions don't fit in a managed policy, the read only actions of each service are " +
+		"replaced by wildcards."
+	example = `  # Create a permissions boundary for the account and operator roles
+  rosa create permissions-boundary
+
+  # Create a permissions boundary for the operator roles of hosted control plane clusters
+  rosa create permissions-boundary --for operator-roles --hosted-cp
+
+  # Print the AWS commands that create the permissions boundary instead of running them
+  rosa create permissions-boundary --mode manual
+
+  # Print a Terraform configuration of the permissions boundary
+  rosa create permissions-boundary --iac-format terraform > permissions_boundary.tf`
+
+	forFlag       = "for"
+	prefixFlag    = "prefix"
+	pathFlag      = "path"
+	hostedCPFlag  = "hosted-cp"
+	iacFormatFlag = "iac-format"
+
+	policyFilename = "permissions_boundary_policy"
+)
+
+var aliases = []string{"permissionsboundary", "permission-boundary"}
+
+type CreatePermissionsBoundaryOptions struct {
+	target    string
+	prefix    string
+	path      string
+	hostedCP  bool
+	iacFormat string
+}
+
+func NewCreatePermissionsBoundaryCommand() *cobra.Command {
+	options := &CreatePermissionsBoundaryOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), CreatePermissionsBoundaryRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	flags.StringVar(
+		&options.target,
+		forFlag,
+		aws.PermissionsBoundaryForBoth,
+		fmt.Sprintf("Roles whose permissions are allowed by the boundary, one of: %s.",
+			strings.Join(aws.PermissionsBoundaryTargets, ", ")),
+	)
+	cmd.RegisterFlagCompletionFunc(forFlag, func(_ *cobra.Command, _ []string, _ string) ([]string,
+		cobra.ShellCompDirective) {
+		return aws.PermissionsBoundaryTargets, cobra.ShellCompDirectiveDefault
+	})
+	flags.StringVar(
+		&options.prefix,
+		prefixFlag,
+		aws.DefaultPrefix,
+		"User-defined prefix of the permissions boundary policy name.",
+	)
+	flags.StringVar(
+		&options.path,
+		pathFlag,
+		"",
+		"The arn path of the permissions boundary policy.",
+	)
+	flags.BoolVar(
+		&options.hostedCP,
+		hostedCPFlag,
+		false,
+		"Allow the permissions of the roles of Hosted Control Planes clusters.",
+	)
+	flags.StringVar(
+		&options.iacFormat,
+		iacFormatFlag,
+		"",
+		fmt.Sprintf("Print the permissions boundary as infrastructure as code instead of creating it, "+
+			"one of: %s.", strings.Join(aws.IaCFormats, ", ")),
+	)
+	cmd.RegisterFlagCompletionFunc(iacFormatFlag, func(_ *cobra.Command, _ []string, _ string) ([]string,
+		cobra.ShellCompDirective) {
+		return aws.IaCFormats, cobra.ShellCompDirectiveDefault
+	})
+	interactive.AddModeFlag(cmd)
+	return cmd
+}
+
+func CreatePermissionsBoundaryRunner(options *CreatePermissionsBoundaryOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, cmd *cobra.Command, _ []string) error {
+		if !helper.Contains(aws.PermissionsBoundaryTargets, options.target) {
+			return fmt.Errorf("Invalid value '%s' for '--%s', expected one of: %s", options.target, forFlag,
+				strings.Join(aws.PermissionsBoundaryTargets, ", "))
+		}
+		if options.iacFormat != "" && !helper.Contains(aws.IaCFormats, options.iacFormat) {
+			return fmt.Errorf("Invalid value '%s' for '--%s', expected one of: %s", options.iacFormat,
+				iacFormatFlag, strings.Join(aws.IaCFormats, ", "))
+		}
+		if len(options.prefix) > 32 {
+			return fmt.Errorf("Expected a prefix with no more than 32 characters")
+		}
+		if !aws.RoleNameRE.MatchString(options.prefix) {
+			return fmt.Errorf("Expected a valid prefix matching %s", aws.RoleNameRE.String())
+		}
+		if options.path != "" && !aws.ARNPath.MatchString(options.path) {
+			return fmt.Errorf("The specified value for path is invalid. " +
+				"It must begin and end with '/' and contain only alphanumeric characters and/or '/' characters.")
+		}
+
+		mode, err := interactive.GetMode()
+		if err != nil {
+			return err
+		}
+		if options.iacFormat == "" {
+			if !interactive.Enabled() && !cmd.Flags().Changed("mode") {
+				interactive.Enable()
+			}
+			if interactive.Enabled() {
+				mode, err = interactive.GetOptionMode(cmd, mode, "Permissions boundary creation mode")
+				if err != nil {
+					return fmt.Errorf("Expected a valid permissions boundary creation mode: %s", err)
+				}
+			}
+		}
+
+		policyVersion, err := r.OCMClient.GetPolicyVersion("", ocm.DefaultChannelGroup)
+		if err != nil {
+			return fmt.Errorf("Error getting version: %s", err)
+		}
+		policies, keys, err := getPermissionPolicies(r, options)
+		if err != nil {
+			return err
+		}
+		actions, err := aws.GetPermissionsBoundaryActions(r.Creator.Partition, policies, keys)
+		if err != nil {
+			return err
+		}
+		document, err := aws.GeneratePermissionsBoundaryDocument(actions)
+		if err != nil {
+			return err
+		}
+
+		name := aws.GetPermissionsBoundaryName(options.prefix)
+		policyArn := aws.GetPermissionsBoundaryARN(r.Creator.Partition, r.Creator.AccountID, options.prefix,
+			options.path)
+		iamTags := map[string]string{
+			awsCommonValidations.OpenShiftVersion: policyVersion,
+			tags.RolePrefix:                       options.prefix,
+			tags.RedHatManaged:                    aws.TrueString,
+		}
+
+		if options.iacFormat != "" {
+			template, err := aws.GeneratePermissionsBoundaryTemplate(options.iacFormat, name, options.path,
+				document, iamTags)
+			if err != nil {
+				return err
+			}
+			fmt.Print(template)
+			return nil
+		}
+
+		switch mode {
+		case interactive.ModeAuto:
+			if !confirm.Confirm("create permissions boundary '%s'", name) {
+				return nil
+			}
+			r.Reporter.Debugf("Creating permissions boundary '%s' allowing %d actions", policyArn, len(actions))
+			policyArn, err = r.AWSClient.EnsurePolicy(policyArn, document, policyVersion, iamTags, options.path)
+			if err != nil {
+				return fmt.Errorf("Failed to create permissions boundary '%s': %v", name, err)
+			}
+			r.Reporter.Infof("Created permissions boundary with ARN '%s'", policyArn)
+			r.Reporter.Infof("To use it, run 'rosa create %s --permissions-boundary %s'",
+				rolesCommand(options.target), policyArn)
+			return nil
+		case interactive.ModeManual:
+			filename := aws.GetFormattedFileName(policyFilename)
+			r.Reporter.Debugf("Saving '%s' to the current directory", filename)
+			err = helper.SaveDocument(document, filename)
+			if err != nil {
+				return err
+			}
+			commands := buildCommands(r.AWSClient, policyArn, name, options.path, filename, iamTags)
+			if r.Reporter.IsTerminal() {
+				r.Reporter.Infof("Policy file saved to the current directory")
+				r.Reporter.Infof("Run the following commands to create the permissions boundary:\n")
+			}
+			fmt.Println(awscb.JoinCommands(commands))
+			return nil
+		default:
+			return fmt.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
+		}
+	}
+}
+
+// getPermissionPolicies returns the policies of OCM and the keys of the permission policies of the roles
+func getPermissionPolicies(r *rosa.Runtime, options *CreatePermissionsBoundaryOptions) (
+	map[string]*cmv1.AWSSTSPolicy, []string, error) {
+	policies := map[string]*cmv1.AWSSTSPolicy{}
+	var keys []string
+	if options.target != aws.PermissionsBoundaryForOperatorRoles {
+		accountRolePolicies, err := r.OCMClient.GetPolicies("AccountRole")
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to get account role policies from OCM: %v", err)
+		}
+		for key, policy := range accountRolePolicies {
+			policies[key] = policy
+		}
+		keys = append(keys, aws.GetAccountRolePermissionPolicyKeys(options.hostedCP)...)
+	}
+	if options.target != aws.PermissionsBoundaryForAccountRoles {
+		credRequests, err := r.OCMClient.GetCredRequests(options.hostedCP)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error getting operator credential request from OCM: %v", err)
+		}
+		operatorRolePolicies, err := r.OCMClient.GetPolicies("OperatorRole")
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to get operator role policies from OCM: %v", err)
+		}
+		for key, policy := range operatorRolePolicies {
+			policies[key] = policy
+		}
+		keys = append(keys, aws.GetOperatorRolePermissionPolicyKeys(credRequests, options.hostedCP)...)
+	}
+	return policies, keys, nil
+}
+
+func buildCommands(awsClient aws.Client, policyArn string, name string, path string, filename string,
+	iamTags map[string]string) []string {
+	document := fmt.Sprintf("file://%s", filename)
+	_, err := awsClient.IsPolicyExists(policyArn)
+	if err != nil {
+		createPolicy := awscb.NewIAMCommandBuilder().
+			SetCommand(awscb.CreatePolicy).
+			AddParam(awscb.PolicyName, name).
+			AddParam(awscb.PolicyDocument, document).
+			AddTags(iamTags)
+		if path != "" {
+			createPolicy.AddParam(awscb.Path, path)
+		}
+		return []string{createPolicy.Build()}
+	}
+	createPolicyVersion := awscb.NewIAMCommandBuilder().
+		SetCommand(awscb.CreatePolicyVersion).
+		AddParam(awscb.PolicyArn, policyArn).
+		AddParam(awscb.PolicyDocument, document).
+		AddParamNoValue(awscb.SetAsDefault).
+		Build()
+	tagPolicy := awscb.NewIAMCommandBuilder().
+		SetCommand(awscb.TagPolicy).
+		AddTags(iamTags).
+		AddParam(awscb.PolicyArn, policyArn).
+		Build()
+	return []string{createPolicyVersion, tagPolicy}
+}
+
+func rolesCommand(target string) string {
+	if target == aws.PermissionsBoundaryForBoth {
+		return "account-roles|operator-roles"
+	}
+	return target
+}
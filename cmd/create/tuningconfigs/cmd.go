@@ -19,6 +19,7 @@ package tuningconfigs
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
@@ -27,20 +28,25 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tuningconfigs"
 )
 
 var args struct {
 	name     string
 	specPath string
+	template string
 }
 
 var Cmd = &cobra.Command{
 	Use:     "tuning-configs",
 	Aliases: []string{"tuningconfig", "tuningconfigs", "tuning-config"},
 	Short:   "Add tuning config",
-	Long:    "Add a tuning config to a cluster.",
+	Long:    "Add a tuning config to a cluster.\n\nBuilt-in templates:\n" + tuningconfigs.TemplatesHelp(),
 	Example: `  # Add a tuning config with name "tuned1" and spec from a file "file1" to a cluster named "mycluster"
- rosa create tuning-config --name=tuned1 --spec-path=file1 --cluster=mycluster"`,
+ rosa create tuning-config --name=tuned1 --spec-path=file1 --cluster=mycluster
+
+  # Add a tuning config with name "tuned2" from the built-in "hugepages" template to a cluster named "mycluster"
+ rosa create tuning-config --name=tuned2 --template=hugepages --cluster=mycluster`,
 	Run:  run,
 	Args: cobra.NoArgs,
 }
@@ -62,6 +68,13 @@ func init() {
 		"",
 		"Path of the file containing the spec section of the tuning config to add.",
 	)
+	flags.StringVar(
+		&args.template,
+		"template",
+		"",
+		fmt.Sprintf("Name of a built-in template to use instead of a spec file. Options are: %s.",
+			strings.Join(tuningconfigs.TemplateNames(), ", ")),
+	)
 
 	interactive.AddFlag(flags)
 }
@@ -95,12 +108,17 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 	}
 
+	template := args.template
 	specPath := args.specPath
-	if specPath == "" && !interactive.Enabled() {
+	if template != "" && specPath != "" {
+		r.Reporter.Errorf("Only one of '--template' or '--spec-path' can be specified")
+		os.Exit(1)
+	}
+	if template == "" && specPath == "" && !interactive.Enabled() {
 		interactive.Enable()
 		r.Reporter.Infof("Enabling interactive mode")
 	}
-	if interactive.Enabled() {
+	if template == "" && interactive.Enabled() {
 		specPath, err = interactive.GetString(interactive.Input{
 			Question: "Path of the file containing the spec of the tuning config",
 			Help:     cmd.Flags().Lookup("spec-path").Usage,
@@ -113,7 +131,22 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 	}
 
-	tuningConfig, err := buildTuningConfigFromInputFile(specPath, name, clusterKey)
+	spec, err := loadSpec(specPath, template, name)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+
+	warnings, err := tuningconfigs.ValidateSpec(spec)
+	for _, warning := range warnings {
+		r.Reporter.Warnf("%s", warning)
+	}
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+
+	tuningConfig, err := buildTuningConfig(spec, name, clusterKey)
 	if err != nil {
 		r.Reporter.Errorf("%v", err)
 		os.Exit(1)
@@ -129,13 +162,25 @@ func run(cmd *cobra.Command, _ []string) {
 	r.Reporter.Infof("To view all tuning configs, run 'rosa list tuning-configs -c %s'", clusterKey)
 }
 
-func buildTuningConfigFromInputFile(specPath string, name string, clusterKey string) (*cmv1.TuningConfig, error) {
-	specJson, err := input.UnmarshalInputFile(specPath)
+// loadSpec reads the spec section of the tuning config either from the given file or,
+// when a template name is given, from the matching built-in template.
+func loadSpec(specPath string, template string, name string) (map[string]interface{}, error) {
+	if template != "" {
+		tuningConfigTemplate, err := tuningconfigs.GetTemplate(template)
+		if err != nil {
+			return nil, err
+		}
+		return tuningConfigTemplate.Spec(name), nil
+	}
+	spec, err := input.UnmarshalInputFile(specPath)
 	if err != nil {
 		return nil, fmt.Errorf("Expected a valid TuneD spec file: %v", err)
 	}
+	return spec, nil
+}
 
-	tuningConfigBuilder := cmv1.NewTuningConfig().Name(name).Spec(specJson)
+func buildTuningConfig(spec map[string]interface{}, name string, clusterKey string) (*cmv1.TuningConfig, error) {
+	tuningConfigBuilder := cmv1.NewTuningConfig().Name(name).Spec(spec)
 
 	tuningConfig, err := tuningConfigBuilder.Build()
 	if err != nil {
@@ -6,22 +6,44 @@ import (
 )
 
 var _ = Describe("TuningConfigs Create Tests", func() {
-	Context("buildTuningConfigFromInputFile", func() {
+	Context("buildTuningConfig", func() {
 		name := "test-tuning-config"
 		clusterKey := "test-cluster"
 
 		It("OK: Should work for json format", func() {
 			path := "spec.json"
-			tuningConfig, err := buildTuningConfigFromInputFile(path, name, clusterKey)
+			spec, err := loadSpec(path, "", name)
+			Expect(err).ToNot(HaveOccurred())
+			tuningConfig, err := buildTuningConfig(spec, name, clusterKey)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(tuningConfig.Name()).To(Equal(name))
 		})
 
 		It("OK: Should work for yaml format", func() {
 			path := "spec.yaml"
-			tuningConfig, err := buildTuningConfigFromInputFile(path, name, clusterKey)
+			spec, err := loadSpec(path, "", name)
+			Expect(err).ToNot(HaveOccurred())
+			tuningConfig, err := buildTuningConfig(spec, name, clusterKey)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(tuningConfig.Name()).To(Equal(name))
 		})
+
+		It("OK: Should work for a built-in template", func() {
+			spec, err := loadSpec("", "network-latency", name)
+			Expect(err).ToNot(HaveOccurred())
+			tuningConfig, err := buildTuningConfig(spec, name, clusterKey)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tuningConfig.Spec()).To(HaveKey("profile"))
+		})
+
+		It("KO: Should fail for an unknown template", func() {
+			_, err := loadSpec("", "unknown", name)
+			Expect(err).To(MatchError(ContainSubstring("Unknown tuning config template 'unknown'")))
+		})
+
+		It("KO: Should fail for a missing spec file", func() {
+			_, err := loadSpec("missing.yaml", "", name)
+			Expect(err).To(MatchError(ContainSubstring("Expected a valid TuneD spec file")))
+		})
 	})
 })
@@ -28,6 +28,7 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
 	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/conventions"
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
@@ -100,6 +101,12 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	roleConventions, err := conventions.ForKind(conventions.UserRole)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
 	// Determine if interactive mode is needed
 	if !interactive.Enabled() && (!cmd.Flags().Changed("mode")) {
 		interactive.Enable()
@@ -110,6 +117,9 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 
 	prefix := args.prefix
+	if !cmd.Flags().Changed("prefix") {
+		prefix = roleConventions.DefaultPrefix(prefix)
+	}
 	if interactive.Enabled() {
 		prefix, err = interactive.GetString(interactive.Input{
 			Question: "Role prefix",
@@ -134,7 +144,12 @@ func run(cmd *cobra.Command, _ []string) {
 		r.Reporter.Errorf("Expected a valid role prefix matching %s", aws.RoleNameRE.String())
 		os.Exit(1)
 	}
-	permissionsBoundary := args.permissionsBoundary
+	err = roleConventions.ValidatePrefix(prefix)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	permissionsBoundary := roleConventions.DefaultPermissionsBoundary(args.permissionsBoundary)
 	if interactive.Enabled() {
 		permissionsBoundary, err = interactive.GetString(interactive.Input{
 			Question: "Permissions boundary ARN",
@@ -157,8 +172,13 @@ func run(cmd *cobra.Command, _ []string) {
 			os.Exit(1)
 		}
 	}
+	err = roleConventions.ValidatePermissionsBoundary(permissionsBoundary)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 
-	path := args.path
+	path := roleConventions.DefaultPath(args.path)
 	if interactive.Enabled() {
 		path, err = interactive.GetString(interactive.Input{
 			Question: "Role Path",
@@ -179,6 +199,11 @@ func run(cmd *cobra.Command, _ []string) {
 			"It must begin and end with '/' and contain only alphanumeric characters and/or '/' characters.")
 		os.Exit(1)
 	}
+	err = roleConventions.ValidatePath(path)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 
 	if interactive.Enabled() {
 		mode, err = interactive.GetOptionMode(cmd, mode, "Role creation mode")
@@ -205,7 +230,7 @@ func run(cmd *cobra.Command, _ []string) {
 	case interactive.ModeAuto:
 		r.Reporter.Infof("Creating ocm user role using '%s'", r.Creator.ARN)
 		roleARN, err := createRoles(r, prefix, path, currentAccount.Username(), env,
-			currentAccount.ID(), permissionsBoundary, policies)
+			currentAccount.ID(), permissionsBoundary, policies, roleConventions)
 		if err != nil {
 			r.Reporter.Errorf("There was an error creating the ocm user role: %s", err)
 			r.OCMClient.LogEvent("ROSACreateUserRoleModeAuto", map[string]string{
@@ -237,6 +262,7 @@ func run(cmd *cobra.Command, _ []string) {
 			r.Creator,
 			env,
 			permissionsBoundary,
+			roleConventions,
 		)
 		fmt.Println(commands)
 
@@ -247,17 +273,17 @@ func run(cmd *cobra.Command, _ []string) {
 }
 
 func buildCommands(prefix string, path string, userName string,
-	creator *aws.Creator, env string, permissionsBoundary string) string {
+	creator *aws.Creator, env string, permissionsBoundary string, roleConventions *conventions.Rules) string {
 	commands := []string{}
 	roleName := aws.GetUserRoleName(prefix, aws.OCMUserRole, userName)
 
 	roleARN := aws.GetRoleARN(creator.AccountID, roleName, path, creator.Partition)
-	iamTags := map[string]string{
+	iamTags := roleConventions.AddTags(map[string]string{
 		tags.RolePrefix:    prefix,
 		tags.RoleType:      aws.OCMUserRole,
 		tags.Environment:   env,
 		tags.RedHatManaged: "true",
-	}
+	})
 	createRole := awscb.NewIAMCommandBuilder().
 		SetCommand(awscb.CreateRole).
 		AddParam(awscb.RoleName, roleName).
@@ -274,7 +300,7 @@ func buildCommands(prefix string, path string, userName string,
 
 func createRoles(r *rosa.Runtime,
 	prefix string, path string, userName string, env string, accountID string, permissionsBoundary string,
-	policies map[string]*cmv1.AWSSTSPolicy) (string, error) {
+	policies map[string]*cmv1.AWSSTSPolicy, roleConventions *conventions.Rules) (string, error) {
 	roleName := aws.GetUserRoleName(prefix, aws.OCMUserRole, userName)
 	if !confirm.Prompt(true, "Create the '%s' role?", roleName) {
 		os.Exit(0)
@@ -298,12 +324,12 @@ func createRoles(r *rosa.Runtime,
 	}
 	r.Reporter.Debugf("Creating role '%s'", roleName)
 	roleARN, err = r.AWSClient.EnsureRole(r.Reporter, roleName, policy, permissionsBoundary,
-		"", map[string]string{
+		"", roleConventions.AddTags(map[string]string{
 			tags.RolePrefix:    prefix,
 			tags.RoleType:      aws.OCMUserRole,
 			tags.Environment:   env,
 			tags.RedHatManaged: "true",
-		}, path, false)
+		}), path, false)
 	if err != nil {
 		return "", err
 	}
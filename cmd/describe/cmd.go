@@ -30,6 +30,7 @@ import (
 	"github.com/openshift/rosa/cmd/describe/installation"
 	"github.com/openshift/rosa/cmd/describe/kubeletconfig"
 	"github.com/openshift/rosa/cmd/describe/machinepool"
+	"github.com/openshift/rosa/cmd/describe/network"
 	"github.com/openshift/rosa/cmd/describe/service"
 	"github.com/openshift/rosa/cmd/describe/tuningconfigs"
 	"github.com/openshift/rosa/cmd/describe/upgrade"
@@ -54,7 +55,7 @@ func init() {
 		machinePoolCommand, kubeletconfig,
 		autoscaler.NewDescribeAutoscalerCommand(), ingressCommand,
 		externalauthprovider.Cmd, breakglasscredential.Cmd,
-		accessrequestCommand,
+		accessrequestCommand, network.NewDescribeNetworkCommand(),
 	}
 	for _, cmd := range cmds {
 		Cmd.AddCommand(cmd)
@@ -0,0 +1,129 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/network"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use     = "network"
+	short   = "Show details of a network stack"
+	long    = "Show the VPC, subnets and NAT gateways of a stack created by 'rosa create network'."
+	example = `  # Describe the network stack 'quickstart-stack'
+  rosa describe network quickstart-stack --region us-west-2`
+)
+
+type DescribeNetworkOptions struct {
+	name string
+}
+
+func NewDescribeNetworkCommand() *cobra.Command {
+	options := &DescribeNetworkOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: []string{"networks"},
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.MaximumNArgs(1),
+		Hidden:  true,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithAWS(), DescribeNetworkRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.name, "name", "", "Name of the network stack.")
+	output.AddFlag(cmd)
+	return cmd
+}
+
+func DescribeNetworkRunner(options *DescribeNetworkOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, argv []string) error {
+		name := options.name
+		if len(argv) == 1 {
+			name = argv[0]
+		}
+		if name == "" {
+			return fmt.Errorf("Expected the name of the network stack as argument or with '--name'")
+		}
+
+		stack, err := network.GetStack(r.AWSClient, name)
+		if err != nil {
+			return err
+		}
+
+		if output.HasFlag() {
+			return output.Print(stack)
+		}
+
+		fmt.Print(describeStack(stack))
+		return nil
+	}
+}
+
+func describeStack(stack *network.Stack) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Name:                       %s\n", stack.Name)
+	fmt.Fprintf(&b, "Status:                     %s\n", stack.Status)
+	if stack.StatusReason != "" {
+		fmt.Fprintf(&b, "Status reason:              %s\n", stack.StatusReason)
+	}
+	fmt.Fprintf(&b, "Template:                   %s\n", stack.Template)
+	if stack.CreatedAt != nil {
+		fmt.Fprintf(&b, "Created:                    %s\n", stack.CreatedAt.UTC().Format("Jan _2 2006 15:04:05 MST"))
+	}
+	fmt.Fprintf(&b, "VPC ID:                     %s\n", stack.VpcID)
+	if stack.VpcCidr != "" {
+		fmt.Fprintf(&b, "VPC CIDR:                   %s\n", stack.VpcCidr)
+	}
+	fmt.Fprintf(&b, "Public subnets:%s", describeSubnets(stack.PublicSubnets))
+	fmt.Fprintf(&b, "Private subnets:%s", describeSubnets(stack.PrivateSubnets))
+	fmt.Fprintf(&b, "NAT gateways:%s", describeList(stack.NatGateways))
+	return b.String()
+}
+
+func describeSubnets(subnets []network.Subnet) string {
+	items := make([]string, 0, len(subnets))
+	for _, subnet := range subnets {
+		if subnet.AvailabilityZone == "" {
+			items = append(items, subnet.ID)
+			continue
+		}
+		items = append(items, fmt.Sprintf("%s (%s)", subnet.ID, subnet.AvailabilityZone))
+	}
+	return describeList(items)
+}
+
+func describeList(items []string) string {
+	if len(items) == 0 {
+		return "\n"
+	}
+	var b strings.Builder
+	b.WriteString("\n")
+	for _, item := range items {
+		fmt.Fprintf(&b, " - %s\n", item)
+	}
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package network
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/network"
+)
+
+var _ = Describe("Describe network", func() {
+	It("lists the subnets with their availability zones", func() {
+		stack := &network.Stack{
+			Name:     "quickstart-stack",
+			Status:   "CREATE_COMPLETE",
+			Template: "rosa-quickstart-default-vpc",
+			VpcID:    "vpc-1",
+			VpcCidr:  "10.0.0.0/16",
+			PublicSubnets: []network.Subnet{
+				{ID: "subnet-pub-a", AvailabilityZone: "us-west-2a"},
+			},
+			PrivateSubnets: []network.Subnet{
+				{ID: "subnet-priv-a", AvailabilityZone: "us-west-2a"},
+			},
+			NatGateways: []string{"nat-a"},
+		}
+		Expect(describeStack(stack)).To(Equal(
+			"Name:                       quickstart-stack\n" +
+				"Status:                     CREATE_COMPLETE\n" +
+				"Template:                   rosa-quickstart-default-vpc\n" +
+				"VPC ID:                     vpc-1\n" +
+				"VPC CIDR:                   10.0.0.0/16\n" +
+				"Public subnets:\n" +
+				" - subnet-pub-a (us-west-2a)\n" +
+				"Private subnets:\n" +
+				" - subnet-priv-a (us-west-2a)\n" +
+				"NAT gateways:\n" +
+				" - nat-a\n"))
+	})
+})
@@ -29,6 +29,8 @@ import (
 	"github.com/openshift/rosa/cmd/dlt/ingress"
 	"github.com/openshift/rosa/cmd/dlt/kubeletconfig"
 	"github.com/openshift/rosa/cmd/dlt/machinepool"
+	"github.com/openshift/rosa/cmd/dlt/machinepoolschedule"
+	"github.com/openshift/rosa/cmd/dlt/network"
 	"github.com/openshift/rosa/cmd/dlt/ocmrole"
 	"github.com/openshift/rosa/cmd/dlt/oidcconfig"
 	"github.com/openshift/rosa/cmd/dlt/oidcprovider"
@@ -56,6 +58,8 @@ func init() {
 	Cmd.AddCommand(ingress.Cmd)
 	machinepoolCommand := machinepool.NewDeleteMachinePoolCommand()
 	Cmd.AddCommand(machinepoolCommand)
+	machinePoolSchedule := machinepoolschedule.NewDeleteMachinePoolScheduleCommand()
+	Cmd.AddCommand(machinePoolSchedule)
 	Cmd.AddCommand(upgrade.Cmd)
 	Cmd.AddCommand(oidcconfig.Cmd)
 	Cmd.AddCommand(oidcprovider.Cmd)
@@ -71,6 +75,7 @@ func init() {
 	kubeletconfig := kubeletconfig.NewDeleteKubeletConfigCommand()
 	Cmd.AddCommand(kubeletconfig)
 	Cmd.AddCommand(externalauthprovider.Cmd)
+	Cmd.AddCommand(network.NewDeleteNetworkCommand())
 
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
@@ -84,6 +89,7 @@ func init() {
 		service.Cmd, autoscalerCommand, idp.Cmd,
 		cluster.Cmd, dnsdomains.Cmd, externalauthprovider.Cmd,
 		kubeletconfig, machinepoolCommand, tuningconfigs.Cmd,
+		machinePoolSchedule,
 	}
 	arguments.MarkRegionDeprecated(Cmd, globallyAvailableCommands)
 }
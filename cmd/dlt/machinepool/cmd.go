@@ -19,7 +19,9 @@ package machinepool
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/interactive/confirm"
@@ -33,7 +35,10 @@ const (
 	short   = "Delete machine pool"
 	long    = "Delete the additional machine pool from a cluster."
 	example = `  # Delete machine pool with ID mp-1 from a cluster named 'mycluster'
-  rosa delete machinepool --cluster=mycluster mp-1`
+  rosa delete machinepool --cluster=mycluster mp-1
+
+  # Delete every machine pool labelled 'pool-type=gpu' from a cluster named 'mycluster'
+  rosa delete machinepools --cluster=mycluster --selector pool-type=gpu`
 )
 
 var (
@@ -59,6 +64,8 @@ func NewDeleteMachinePoolCommand() *cobra.Command {
 		"Machine pool of the cluster to target",
 	)
 
+	machinepool.AddBulkFlags(flags, &options.bulk)
+
 	ocm.AddClusterFlag(cmd)
 	confirm.AddFlag(cmd.Flags())
 	return cmd
@@ -76,6 +83,10 @@ func DeleteMachinePoolRunner(userOptions *DeleteMachinepoolUserOptions) rosa.Com
 		clusterKey := runtime.GetClusterKey()
 		cluster := runtime.FetchCluster()
 
+		if options.Bulk().IsBulk() {
+			return deleteMachinePools(runtime, clusterKey, cluster, options.Bulk())
+		}
+
 		service := machinepool.NewMachinePoolService()
 		err = service.DeleteMachinePool(runtime, options.Machinepool(), clusterKey, cluster)
 		if err != nil {
@@ -84,3 +95,28 @@ func DeleteMachinePoolRunner(userOptions *DeleteMachinepoolUserOptions) rosa.Com
 		return nil
 	}
 }
+
+// deleteMachinePools deletes every machine pool matching the selector
+func deleteMachinePools(runtime *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster,
+	bulk *machinepool.BulkOptions) error {
+	targets, err := machinepool.SelectMachinePoolsToDelete(runtime, cluster, clusterKey, bulk)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		runtime.Reporter.Warnf("No machine pools on cluster '%s' match the selector", clusterKey)
+		return nil
+	}
+
+	ids := machinepool.BulkTargetIDs(targets)
+	if !confirm.Confirm("delete %d machine pools (%s) on cluster '%s'", len(ids), strings.Join(ids, ", "),
+		clusterKey) {
+		return nil
+	}
+
+	results := machinepool.BulkDelete(runtime, cluster, targets, bulk.Parallelism)
+	if err := machinepool.PrintBulkResults(results); err != nil {
+		return fmt.Errorf("Error deleting machinepools: %v", err)
+	}
+	return nil
+}
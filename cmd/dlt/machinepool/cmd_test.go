@@ -123,6 +123,95 @@ var _ = Describe("Delete machine pool", func() {
 					"'%s' on hosted cluster '%s'?", nodePoolName, clusterId)))
 			})
 		})
+		Context("Selector", func() {
+			It("Deletes every machine pool matching the selector", func() {
+				gpuPool := test.MockNodePool(func(n *cmv1.NodePoolBuilder) {
+					n.ID("gpu-1").Labels(map[string]string{"pool-type": "gpu"})
+				})
+				otherGpuPool := test.MockNodePool(func(n *cmv1.NodePoolBuilder) {
+					n.ID("gpu-2").Labels(map[string]string{"pool-type": "gpu"})
+				})
+				cpuPool := test.MockNodePool(func(n *cmv1.NodePoolBuilder) {
+					n.ID("cpu-1").Labels(map[string]string{"pool-type": "cpu"})
+				})
+				t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReady))
+				t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+					test.FormatNodePoolList([]*cmv1.NodePool{otherGpuPool, cpuPool, gpuPool})))
+				t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, ""))
+				t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, ""))
+				args := NewDeleteMachinepoolUserOptions()
+				args.bulk.Selector = "pool-type=gpu"
+				args.bulk.Parallelism = 1
+				runner := DeleteMachinePoolRunner(args)
+				err := t.StdOutReader.Record()
+				Expect(err).ToNot(HaveOccurred())
+				cmd := NewDeleteMachinePoolCommand()
+				err = cmd.Flag("cluster").Value.Set(clusterId)
+				Expect(err).ToNot(HaveOccurred())
+				err = cmd.Flag("yes").Value.Set("true")
+				Expect(err).ToNot(HaveOccurred())
+				err = runner(context.Background(), t.RosaRuntime, cmd, []string{})
+				Expect(err).To(BeNil())
+				stdout, err := t.StdOutReader.Read()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stdout).To(Equal("MACHINE POOL  RESULT     DETAILS\n" +
+					"gpu-1         succeeded  deleted\n" +
+					"gpu-2         succeeded  deleted\n"))
+			})
+			It("Refuses to delete every machine pool", func() {
+				gpuPool := test.MockNodePool(func(n *cmv1.NodePoolBuilder) {
+					n.ID("gpu-1").Labels(map[string]string{"pool-type": "gpu"})
+				})
+				t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReady))
+				t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+					test.FormatNodePoolList([]*cmv1.NodePool{gpuPool})))
+				args := NewDeleteMachinepoolUserOptions()
+				args.bulk.Selector = "pool-type=gpu"
+				args.bulk.Parallelism = 1
+				runner := DeleteMachinePoolRunner(args)
+				cmd := NewDeleteMachinePoolCommand()
+				err := cmd.Flag("cluster").Value.Set(clusterId)
+				Expect(err).ToNot(HaveOccurred())
+				err = cmd.Flag("yes").Value.Set("true")
+				Expect(err).ToNot(HaveOccurred())
+				err = runner(context.Background(), t.RosaRuntime, cmd, []string{})
+				Expect(err).To(MatchError(ContainSubstring("matches every machine pool")))
+				// No delete request was sent
+				Expect(t.ApiServer.ReceivedRequests()).To(HaveLen(2))
+			})
+			It("Refuses to delete the default machine pool of classic clusters", func() {
+				workerPool, err := cmv1.NewMachinePool().ID("worker").Build()
+				Expect(err).ToNot(HaveOccurred())
+				gpuPool, err := cmv1.NewMachinePool().ID("gpu-1").Build()
+				Expect(err).ToNot(HaveOccurred())
+				otherPool, err := cmv1.NewMachinePool().ID("infra").Labels(map[string]string{"keep": "true"}).Build()
+				Expect(err).ToNot(HaveOccurred())
+				t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, classicClusterReady))
+				t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+					test.FormatMachinePoolList([]*cmv1.MachinePool{workerPool, gpuPool, otherPool})))
+				args := NewDeleteMachinepoolUserOptions()
+				args.bulk.Selector = "!keep"
+				args.bulk.Parallelism = 1
+				runner := DeleteMachinePoolRunner(args)
+				cmd := NewDeleteMachinePoolCommand()
+				err = cmd.Flag("cluster").Value.Set(clusterId)
+				Expect(err).ToNot(HaveOccurred())
+				err = cmd.Flag("yes").Value.Set("true")
+				Expect(err).ToNot(HaveOccurred())
+				err = runner(context.Background(), t.RosaRuntime, cmd, []string{})
+				Expect(err).To(MatchError(ContainSubstring("matches the default machine pool 'worker'")))
+				Expect(t.ApiServer.ReceivedRequests()).To(HaveLen(2))
+			})
+			It("Fails when both a machine pool name and a selector are given", func() {
+				args := NewDeleteMachinepoolUserOptions()
+				args.bulk.Selector = "pool-type=gpu"
+				args.bulk.Parallelism = 1
+				runner := DeleteMachinePoolRunner(args)
+				err := runner(context.Background(), t.RosaRuntime, NewDeleteMachinePoolCommand(),
+					[]string{nodePoolName})
+				Expect(err).To(MatchError(ContainSubstring("can't be used together with '--selector'")))
+			})
+		})
 		Context("ROSA Classic", func() {
 			It("Works without passing `--machinepool`", func() {
 				t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, classicClusterReady))
@@ -9,6 +9,7 @@ import (
 
 type DeleteMachinepoolUserOptions struct {
 	machinepool string
+	bulk        machinepool.BulkOptions
 }
 
 type DeleteMachinepoolOptions struct {
@@ -32,8 +33,20 @@ func (m *DeleteMachinepoolOptions) Machinepool() string {
 	return m.args.machinepool
 }
 
+// Bulk returns the options used to delete every machine pool matching a selector
+func (m *DeleteMachinepoolOptions) Bulk() *machinepool.BulkOptions {
+	return &m.args.bulk
+}
+
 func (m *DeleteMachinepoolOptions) Bind(args *DeleteMachinepoolUserOptions, argv []string) error {
 	m.args = args
+	if m.args.bulk.IsBulk() {
+		if m.args.machinepool != "" {
+			return fmt.Errorf("The '--machinepool' option can't be used together with '--%s' or '--%s'",
+				machinepool.SelectorFlag, machinepool.AllFlag)
+		}
+		return m.args.bulk.Validate(argv)
+	}
 	if m.Machinepool() == "" {
 		if len(argv) > 0 {
 			m.args.machinepool = argv[0]
@@ -0,0 +1,76 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepoolschedule
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/schedules"
+)
+
+const (
+	use     = "machinepool-schedule NAME"
+	short   = "Delete a machine pool scaling schedule"
+	long    = "Delete a machine pool scaling schedule stored on this machine. The machine pool keeps its current size."
+	example = `  # Delete the schedule named 'gpu-night'
+  rosa delete machinepool-schedule gpu-night`
+)
+
+var aliases = []string{"machinepool-schedules", "machine-pool-schedule", "machine-pool-schedules"}
+
+func NewDeleteMachinePoolScheduleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.ExactArgs(1),
+		Run:     rosa.DefaultRunner(rosa.DefaultRuntime(), DeleteMachinePoolScheduleRunner()),
+	}
+	return cmd
+}
+
+func DeleteMachinePoolScheduleRunner() rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, argv []string) error {
+		name := argv[0]
+		store, err := schedules.Load()
+		if err != nil {
+			return err
+		}
+		schedule := store.Find(name)
+		if schedule == nil {
+			return store.Remove(name)
+		}
+		if !confirm.Confirm("delete schedule '%s' of machine pool '%s' on cluster '%s'",
+			name, schedule.MachinePool, schedule.ClusterName) {
+			return nil
+		}
+		if err = store.Remove(name); err != nil {
+			return err
+		}
+		if err = store.Save(); err != nil {
+			return err
+		}
+		r.Reporter.Infof("Successfully deleted schedule '%s'", name)
+		return nil
+	}
+}
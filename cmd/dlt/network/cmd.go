@@ -0,0 +1,116 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/network"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "network"
+	short = "Delete a network stack"
+	long  = "Delete an AWS CloudFormation stack created by 'rosa create network' and wait for its deletion. " +
+		"Stacks whose subnets are still used by a cluster can't be deleted."
+	example = `  # Delete the network stack 'quickstart-stack'
+  rosa delete network quickstart-stack --region us-west-2`
+
+	// deleteTimeout is how long to wait for the deletion, NAT gateways take several minutes to go away
+	deleteTimeout = 30 * time.Minute
+)
+
+type DeleteNetworkOptions struct {
+	name string
+}
+
+func NewDeleteNetworkCommand() *cobra.Command {
+	options := &DeleteNetworkOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: []string{"networks"},
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.MaximumNArgs(1),
+		Hidden:  true,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), DeleteNetworkRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.name, "name", "", "Name of the network stack.")
+	confirm.AddFlag(flags)
+	return cmd
+}
+
+func DeleteNetworkRunner(options *DeleteNetworkOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, argv []string) error {
+		name := options.name
+		if len(argv) == 1 {
+			name = argv[0]
+		}
+		if name == "" {
+			return fmt.Errorf("Expected the name of the network stack as argument or with '--name'")
+		}
+
+		stack, err := network.GetStack(r.AWSClient, name)
+		if err != nil {
+			return err
+		}
+
+		clusters, err := r.OCMClient.GetAllClusters(r.Creator)
+		if err != nil {
+			return fmt.Errorf("Failed to get clusters: %v", err)
+		}
+		users := network.ClustersUsingStack(clusters, stack)
+		if len(users) > 0 {
+			return fmt.Errorf("Network stack '%s' can't be deleted, its subnets are used by clusters: %s",
+				name, strings.Join(users, ", "))
+		}
+
+		if !confirm.Confirm("delete network stack '%s' in region '%s'", name, r.AWSClient.GetRegion()) {
+			return nil
+		}
+
+		var spin *spinner.Spinner
+		if r.Reporter.IsTerminal() {
+			spin = spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+		}
+		r.Reporter.Infof("Deleting network stack '%s'", name)
+		if spin != nil {
+			spin.Start()
+		}
+		err = r.AWSClient.DeleteStackWithTimeout(name, deleteTimeout)
+		if spin != nil {
+			spin.Stop()
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to delete network stack '%s': %v", name, err)
+		}
+
+		r.Reporter.Infof("Successfully deleted network stack '%s'", name)
+		return nil
+	}
+}
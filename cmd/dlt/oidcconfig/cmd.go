@@ -19,7 +19,6 @@ package oidcconfig
 import (
 	"fmt"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
@@ -34,6 +33,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	interactiveOidc "github.com/openshift/rosa/pkg/interactive/oidc"
+	"github.com/openshift/rosa/pkg/oidcconfig"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -50,8 +50,7 @@ var Cmd = &cobra.Command{
 
 const (
 	//nolint
-	OidcConfigIdFlag          = "oidc-config-id"
-	prefixForPrivateKeySecret = "rosa-private-key-"
+	OidcConfigIdFlag = "oidc-config-id"
 )
 
 var args struct {
@@ -134,6 +133,7 @@ type OidcConfigInput struct {
 	PrivateKeySecretArn string
 	BucketName          string
 	IssuerUrl           string
+	Backend             string
 	Managed             bool
 }
 
@@ -144,8 +144,11 @@ func buildOidcConfigInput(r *rosa.Runtime) OidcConfigInput {
 		os.Exit(1)
 	}
 	secretArn := oidcConfig.SecretArn()
+	issuerUrl := oidcConfig.IssuerUrl()
+	backend := ""
 	bucketName := ""
 	if !oidcConfig.Managed() {
+		backend = oidcconfig.BackendFromIssuerUrl(issuerUrl)
 		parsedSecretArn, _ := arn.Parse(secretArn)
 		if args.region != parsedSecretArn.Region {
 			r.Reporter.Errorf("Secret region '%s' differs from chosen region '%s', "+
@@ -160,14 +163,12 @@ func buildOidcConfigInput(r *rosa.Runtime) OidcConfigInput {
 		// The secret when creating from ROSA options has the following format
 		// rosa-private-key-<prefix>-oidc-<random-hash-length-4>-<random-aws-created-hash>
 		// The bucket is expected to be <prefix>-oidc-<random-hash-length-4>
-		bucketName = strings.TrimPrefix(secretResourceName, prefixForPrivateKeySecret)
-		index := strings.LastIndex(bucketName, "-")
-		if index != -1 {
-			bucketName = bucketName[:index]
+		// The documents of raw files configurations are hosted by the user, there is no bucket
+		if backend != oidcconfig.BackendRawFiles {
+			bucketName = oidcconfig.BucketNameFromSecretName(secretResourceName)
 		}
 	}
 
-	issuerUrl := oidcConfig.IssuerUrl()
 	hasClusterUsingOidcConfig, err := r.OCMClient.HasAClusterUsingOidcEndpointUrl(issuerUrl)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem checking if any clusters are using OIDC config '%s' : %v", issuerUrl, err)
@@ -181,6 +182,7 @@ func buildOidcConfigInput(r *rosa.Runtime) OidcConfigInput {
 		BucketName:          bucketName,
 		PrivateKeySecretArn: secretArn,
 		IssuerUrl:           issuerUrl,
+		Backend:             backend,
 		Managed:             oidcConfig.Managed(),
 	}
 }
@@ -196,10 +198,14 @@ type deleteUnmanagedOidcConfigAutoStrategy struct {
 func (s *deleteUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) {
 	bucketName := s.oidcConfig.BucketName
 	privateKeySecretArn := s.oidcConfig.PrivateKeySecretArn
+	name := bucketName
+	if s.oidcConfig.Backend == oidcconfig.BackendRawFiles {
+		name = s.oidcConfig.IssuerUrl
+	}
 	var spin *spinner.Spinner
 	if r.Reporter.IsTerminal() {
 		spin = spinner.New(spinner.CharSets[9], 100*time.Millisecond)
-		r.Reporter.Infof("Deleting OIDC configuration '%s'", bucketName)
+		r.Reporter.Infof("Deleting OIDC configuration '%s'", name)
 	}
 	if spin != nil {
 		spin.Start()
@@ -209,16 +215,30 @@ func (s *deleteUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) {
 		r.Reporter.Errorf("There was a problem deleting private key from secrets manager: %s", err)
 		os.Exit(1)
 	}
-	err = r.AWSClient.DeleteS3Bucket(bucketName)
-	if err != nil {
-		r.Reporter.Errorf("There was a problem deleting S3 bucket '%s': %s", bucketName, err)
-		os.Exit(1)
+	if s.oidcConfig.Backend != oidcconfig.BackendRawFiles {
+		err = r.AWSClient.DeleteS3Bucket(bucketName)
+		if err != nil {
+			r.Reporter.Errorf("There was a problem deleting S3 bucket '%s': %s", bucketName, err)
+			os.Exit(1)
+		}
+	}
+	if s.oidcConfig.Backend == oidcconfig.BackendCloudFront {
+		stackName := oidcconfig.CloudFrontStackName(bucketName)
+		err = r.AWSClient.DeleteStack(stackName)
+		if err != nil {
+			r.Reporter.Errorf("There was a problem deleting CloudFormation stack '%s': %s", stackName, err)
+			os.Exit(1)
+		}
 	}
 	if spin != nil {
 		spin.Stop()
 	}
 	if r.Reporter.IsTerminal() {
 		r.Reporter.Infof("Deleted OIDC configuration")
+		if s.oidcConfig.Backend == oidcconfig.BackendRawFiles {
+			r.Reporter.Infof("Remember to remove the documents served under '%s' from your web host",
+				s.oidcConfig.IssuerUrl)
+		}
 	}
 }
 
@@ -236,6 +256,14 @@ func (s *deleteUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) {
 		AddParam(awscb.Region, args.region).
 		Build()
 	commands = append(commands, deleteSecretCommand)
+	if s.oidcConfig.Backend == oidcconfig.BackendRawFiles {
+		fmt.Println(awscb.JoinCommands(commands))
+		if r.Reporter.IsTerminal() {
+			r.Reporter.Infof("Remember to remove the documents served under '%s' from your web host",
+				s.oidcConfig.IssuerUrl)
+		}
+		return
+	}
 	emptyS3BucketCommand := awscb.NewS3CommandBuilder().
 		SetCommand(awscb.Remove).
 		AddValueNoParam(fmt.Sprintf("s3://%s", bucketName)).
@@ -247,6 +275,10 @@ func (s *deleteUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) {
 		AddValueNoParam(fmt.Sprintf("s3://%s", bucketName)).
 		Build()
 	commands = append(commands, deleteS3BucketCommand)
+	if s.oidcConfig.Backend == oidcconfig.BackendCloudFront {
+		commands = append(commands, fmt.Sprintf("aws cloudformation delete-stack --stack-name %s --region %s",
+			oidcconfig.CloudFrontStackName(bucketName), args.region))
+	}
 	fmt.Println(awscb.JoinCommands(commands))
 }
 
@@ -18,7 +18,10 @@ package machinepool
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/interactive/confirm"
@@ -37,7 +40,9 @@ const (
 	# Enable autoscaling and Set 3-5 replicas on machine pool 'mp1' on cluster 'mycluster'
 	rosa edit machinepool --enable-autoscaling --min-replicas=3 --max-replicas=5 --cluster=mycluster mp1
 	# Set the node drain grace period to 1 hour on machine pool 'mp1' on cluster 'mycluster'
-	rosa edit machinepool --node-drain-grace-period="1 hour" --cluster=mycluster mp1`
+	rosa edit machinepool --node-drain-grace-period="1 hour" --cluster=mycluster mp1
+	# Scale every machine pool labelled 'pool-type=gpu' on cluster 'mycluster' down to 0 replicas
+	rosa edit machinepools --selector pool-type=gpu --replicas=0 --cluster=mycluster`
 )
 
 var (
@@ -161,6 +166,8 @@ func NewEditMachinePoolCommand() *cobra.Command {
 			"absolute number i.e. 1, or a percentage i.e. '20%'.",
 	)
 
+	machinepool.AddBulkFlags(flags, &options.bulk)
+
 	output.AddFlag(cmd)
 	ocm.AddClusterFlag(cmd)
 	return cmd
@@ -178,7 +185,48 @@ func EditMachinePoolRunner(userOptions *EditMachinepoolUserOptions) rosa.Command
 		clusterKey := runtime.GetClusterKey()
 		cluster := runtime.FetchCluster()
 
+		if options.Bulk().IsBulk() {
+			return editMachinePools(runtime, cmd, clusterKey, cluster, options.Bulk())
+		}
+
 		service := machinepool.NewMachinePoolService()
 		return service.EditMachinePool(cmd, options.Machinepool(), clusterKey, cluster, runtime)
 	}
 }
+
+// editMachinePools changes the replicas or autoscaling settings of every machine pool matching
+// the selector
+func editMachinePools(runtime *rosa.Runtime, cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster,
+	bulk *machinepool.BulkOptions) error {
+	scaling, err := machinepool.BulkScalingFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	if err := machinepool.ValidateClusterState(cluster, clusterKey); err != nil {
+		return err
+	}
+
+	targets, err := machinepool.SelectMachinePools(runtime, cluster, clusterKey, bulk)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		runtime.Reporter.Warnf("No machine pools on cluster '%s' match the selector", clusterKey)
+		return nil
+	}
+	if err := machinepool.ValidateBulkScaling(cluster, targets, scaling); err != nil {
+		return err
+	}
+
+	ids := machinepool.BulkTargetIDs(targets)
+	if !confirm.Confirm("edit %d machine pools (%s) on cluster '%s'", len(ids), strings.Join(ids, ", "),
+		clusterKey) {
+		return nil
+	}
+
+	results := machinepool.BulkEditScaling(runtime, cluster, targets, scaling, bulk.Parallelism)
+	if err := machinepool.PrintBulkResults(results); err != nil {
+		return fmt.Errorf("Failed to edit machine pools on cluster '%s': %v", clusterKey, err)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
+	"github.com/openshift/rosa/pkg/machinepool"
 	"github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -21,6 +22,7 @@ type EditMachinepoolUserOptions struct {
 	nodeDrainGracePeriod string
 	maxSurge             string
 	maxUnavailable       string
+	bulk                 machinepool.BulkOptions
 }
 
 type EditMachinepoolOptions struct {
@@ -44,8 +46,20 @@ func (m *EditMachinepoolOptions) Machinepool() string {
 	return m.args.machinepool
 }
 
+// Bulk returns the options used to edit every machine pool matching a selector
+func (m *EditMachinepoolOptions) Bulk() *machinepool.BulkOptions {
+	return &m.args.bulk
+}
+
 func (m *EditMachinepoolOptions) Bind(args *EditMachinepoolUserOptions, argv []string) error {
 	m.args = args
+	if m.args.bulk.IsBulk() {
+		if m.args.machinepool != "" {
+			return fmt.Errorf("The '--machinepool' option can't be used together with '--%s' or '--%s'",
+				machinepool.SelectorFlag, machinepool.AllFlag)
+		}
+		return m.args.bulk.Validate(argv)
+	}
 	if m.args.machinepool == "" {
 		if len(argv) > 0 {
 			m.args.machinepool = argv[0]
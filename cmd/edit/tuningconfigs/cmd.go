@@ -25,8 +25,10 @@ import (
 
 	"github.com/openshift/rosa/pkg/input"
 	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tuningconfigs"
 )
 
 var args struct {
@@ -37,9 +39,13 @@ var Cmd = &cobra.Command{
 	Use:     "tuning-configs",
 	Aliases: []string{"tuningconfig", "tuningconfigs", "tuning-config"},
 	Short:   "Edit tuning config",
-	Long:    "Edit a tuning config for a cluster.",
+	Long: "Edit a tuning config for a cluster. The profiles and recommendations that change " +
+		"are listed and must be confirmed before the tuning config is updated.",
 	Example: `  # Update the tuning config with name 'tuning-1' with the spec defined in file1
-  rosa edit tuning-config --cluster=mycluster tuning-1 --spec-path file1`,
+  rosa edit tuning-config --cluster=mycluster tuning-1 --spec-path file1
+
+  # Apply the change without being asked to confirm the listed profile changes
+  rosa edit tuning-config --cluster=mycluster tuning-1 --spec-path file1 --yes`,
 	Run: run,
 	Args: func(_ *cobra.Command, argv []string) error {
 		if len(argv) != 1 {
@@ -108,6 +114,32 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	warnings, err := tuningconfigs.ValidateSpec(tuningConfigPatch.Spec().(map[string]interface{}))
+	for _, warning := range warnings {
+		r.Reporter.Warnf("%s", warning)
+	}
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+
+	changes, err := tuningconfigs.DiffSpecs(tuningConfig.Spec(), tuningConfigPatch.Spec())
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	if len(changes) == 0 {
+		r.Reporter.Infof("Tuning config '%s' is already up to date, nothing to update", tuningConfig.Name())
+		os.Exit(0)
+	}
+	fmt.Printf("Changes to tuning config '%s':\n", tuningConfig.Name())
+	for _, change := range changes {
+		fmt.Printf("  %s\n", change)
+	}
+	if !confirm.Confirm("update tuning config '%s' on cluster '%s'", tuningConfig.Name(), clusterKey) {
+		os.Exit(0)
+	}
+
 	r.Reporter.Debugf("Updating tuning config '%s' on cluster '%s'", tuningConfig.Name(), clusterKey)
 	_, err = r.OCMClient.UpdateTuningConfig(cluster.ID(), tuningConfigPatch)
 	if err != nil {
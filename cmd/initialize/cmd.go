@@ -191,7 +191,7 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 
 	// Validate AWS quota
-	// Call `verify quota` as part of init
+	// Call `verify quota` as part of init, which only verifies the static minimums
 	quota.Cmd.Run(cmd, argv)
 
 	// Ensure that there is an AWS user to create all the resources needed by the cluster:
@@ -32,6 +32,8 @@ import (
 	"github.com/openshift/rosa/cmd/list/instancetypes"
 	"github.com/openshift/rosa/cmd/list/kubeletconfig"
 	"github.com/openshift/rosa/cmd/list/machinepool"
+	"github.com/openshift/rosa/cmd/list/machinepoolschedules"
+	"github.com/openshift/rosa/cmd/list/network"
 	"github.com/openshift/rosa/cmd/list/ocmroles"
 	"github.com/openshift/rosa/cmd/list/oidcconfig"
 	"github.com/openshift/rosa/cmd/list/oidcprovider"
@@ -62,6 +64,8 @@ func init() {
 	Cmd.AddCommand(ingress.Cmd)
 	machinePoolCommand := machinepool.NewListMachinePoolCommand()
 	Cmd.AddCommand(machinePoolCommand)
+	machinePoolSchedules := machinepoolschedules.NewListMachinePoolSchedulesCommand()
+	Cmd.AddCommand(machinePoolSchedules)
 	Cmd.AddCommand(region.Cmd)
 	Cmd.AddCommand(upgrade.Cmd)
 	Cmd.AddCommand(user.Cmd)
@@ -83,6 +87,7 @@ func init() {
 	Cmd.AddCommand(kubeletconfig)
 	accessrequest := accessrequests.NewListAccessRequestsCommand()
 	Cmd.AddCommand(accessrequest)
+	Cmd.AddCommand(network.NewListNetworksCommand())
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
 	arguments.AddRegionFlag(flags)
@@ -97,6 +102,7 @@ func init() {
 		operatorroles.Cmd, region.Cmd, rhRegion.Cmd,
 		service.Cmd, tuningconfigs.Cmd, upgrade.Cmd,
 		user.Cmd, version.Cmd, kubeletconfig, accessrequest,
+		machinePoolSchedules,
 	}
 	arguments.MarkRegionDeprecated(Cmd, globallyAvailableCommands)
 }
@@ -0,0 +1,112 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepoolschedules
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/schedules"
+)
+
+const (
+	use     = "machinepool-schedules"
+	short   = "List machine pool scaling schedules"
+	long    = "List the machine pool scaling schedules stored on this machine."
+	example = `  # List all machine pool schedules
+  rosa list machinepool-schedules
+
+  # List the machine pool schedules of cluster 'mycluster'
+  rosa list machinepool-schedules -c mycluster`
+)
+
+var aliases = []string{"machinepool-schedule", "machine-pool-schedules", "machine-pool-schedule"}
+
+func NewListMachinePoolSchedulesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Aliases: aliases,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.DefaultRuntime(), ListMachinePoolSchedulesRunner()),
+	}
+
+	output.AddFlag(cmd)
+	ocm.AddOptionalClusterFlag(cmd)
+	return cmd
+}
+
+func ListMachinePoolSchedulesRunner() rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		clusterKey := r.GetClusterKey()
+		store, err := schedules.Load()
+		if err != nil {
+			return err
+		}
+		list := store.ForCluster(clusterKey)
+
+		if output.HasFlag() {
+			if list == nil {
+				list = []*schedules.Schedule{}
+			}
+			return output.Print(list)
+		}
+
+		if len(list) == 0 {
+			if clusterKey != "" {
+				r.Reporter.Infof("There are no machine pool schedules for cluster '%s'", clusterKey)
+			} else {
+				r.Reporter.Infof("There are no machine pool schedules")
+			}
+			return nil
+		}
+
+		now := time.Now()
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(writer, "NAME\tCLUSTER\tMACHINE POOL\tCRON\tSIZE\tLAST RUN\tNEXT RUN\n")
+		for _, schedule := range list {
+			lastRun := "never"
+			if schedule.LastRun != nil {
+				lastRun = schedule.LastRun.UTC().Format(time.RFC3339)
+			}
+			next, err := schedule.Next(now)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				schedule.Name,
+				schedule.ClusterName,
+				schedule.MachinePool,
+				schedule.Cron,
+				schedule.Size(),
+				lastRun,
+				next.UTC().Format(time.RFC3339),
+			)
+		}
+		return writer.Flush()
+	}
+}
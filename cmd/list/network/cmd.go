@@ -0,0 +1,85 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/network"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use     = "networks"
+	short   = "List network stacks"
+	long    = "List the AWS CloudFormation stacks created by 'rosa create network' in the region."
+	example = `  # List the network stacks of region 'us-east-1'
+  rosa list networks --region us-east-1`
+)
+
+func NewListNetworksCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: []string{"network"},
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Hidden:  true,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithAWS(), ListNetworksRunner()),
+	}
+
+	output.AddFlag(cmd)
+	return cmd
+}
+
+func ListNetworksRunner() rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		stacks, err := network.ListStacks(r.AWSClient)
+		if err != nil {
+			return err
+		}
+
+		if output.HasFlag() {
+			return output.Print(stacks)
+		}
+
+		if len(stacks) == 0 {
+			r.Reporter.Infof("There are no network stacks in region '%s'", r.AWSClient.GetRegion())
+			return nil
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(writer, "NAME\tSTATUS\tTEMPLATE\tVPC ID\tCREATED\n")
+		for _, stack := range stacks {
+			created := ""
+			if stack.CreatedAt != nil {
+				created = stack.CreatedAt.UTC().Format(time.RFC3339)
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+				stack.Name, stack.Status, stack.Template, stack.VpcID, created)
+		}
+		return writer.Flush()
+	}
+}
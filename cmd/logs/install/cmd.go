@@ -27,13 +27,16 @@ import (
 	"github.com/spf13/cobra"
 	errors "github.com/zgalor/weberr"
 
+	"github.com/openshift/rosa/pkg/loganalyzer"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
 var args struct {
-	tail  int
-	watch bool
+	tail    int
+	watch   bool
+	analyze bool
 }
 
 var Cmd = &cobra.Command{
@@ -44,7 +47,10 @@ var Cmd = &cobra.Command{
   rosa logs install mycluster --tail=100
 
   # Show install logs for a cluster using the --cluster flag
-  rosa logs install --cluster=mycluster`,
+  rosa logs install --cluster=mycluster
+
+  # Find known failures in the install logs of a cluster named "mycluster"
+  rosa logs install mycluster --analyze`,
 	Run:  run,
 	Args: cobra.MaximumNArgs(1),
 }
@@ -68,6 +74,16 @@ func init() {
 		false,
 		"After getting the logs, watch for changes.",
 	)
+
+	flags.BoolVar(
+		&args.analyze,
+		"analyze",
+		false,
+		"Instead of printing the logs, summarize the installation phases and report known failures "+
+			"with remediation hints.",
+	)
+
+	output.AddFlag(Cmd)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -83,12 +99,22 @@ func run(cmd *cobra.Command, argv []string) {
 	// Allow the command to be called programmatically
 	if len(argv) == 1 && !cmd.Flag("cluster").Changed {
 		ocm.SetClusterKey(argv[0])
-		watch = true
+		watch = !args.analyze
 	}
 	clusterKey := r.GetClusterKey()
 
+	if args.analyze && watch {
+		r.Reporter.Errorf("The '--analyze' and '--watch' flags can't be used together")
+		os.Exit(1)
+	}
+	if output.HasFlag() && !args.analyze {
+		r.Reporter.Errorf("The '--output' flag is only supported with '--analyze'")
+		os.Exit(1)
+	}
+
 	cluster := r.FetchCluster()
-	if cluster.State() == cmv1.ClusterStateReady {
+	// The logs of installed clusters can still be analyzed
+	if cluster.State() == cmv1.ClusterStateReady && !args.analyze {
 		r.Reporter.Infof("Cluster '%s' has been successfully installed", clusterKey)
 		os.Exit(0)
 	}
@@ -126,6 +152,17 @@ func run(cmd *cobra.Command, argv []string) {
 			os.Exit(1)
 		}
 	}
+	if args.analyze {
+		if logs == nil {
+			return
+		}
+		err = analyzeLog(logs)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+		return
+	}
 	printLog(logs, nil)
 
 	if watch {
@@ -173,6 +210,16 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 }
 
+// Summarize the logs and report the known failures
+func analyzeLog(logs *cmv1.Log) error {
+	analysis := loganalyzer.Analyze(logs.Content())
+	if output.HasFlag() {
+		return output.Print(analysis)
+	}
+	fmt.Print(analysis.Describe())
+	return nil
+}
+
 var lastLine string
 
 // Print next log lines
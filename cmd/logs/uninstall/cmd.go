@@ -27,13 +27,16 @@ import (
 	"github.com/spf13/cobra"
 	errors "github.com/zgalor/weberr"
 
+	"github.com/openshift/rosa/pkg/loganalyzer"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
 var args struct {
-	tail  int
-	watch bool
+	tail    int
+	watch   bool
+	analyze bool
 }
 
 var Cmd = &cobra.Command{
@@ -44,7 +47,10 @@ var Cmd = &cobra.Command{
   rosa logs uninstall mycluster --tail=100
 
   # Show uninstall logs for a cluster using the --cluster flag
-  rosa logs uninstall --cluster=mycluster`,
+  rosa logs uninstall --cluster=mycluster
+
+  # Find known failures in the uninstall logs of a cluster named "mycluster"
+  rosa logs uninstall mycluster --analyze`,
 	Run:  run,
 	Args: cobra.MaximumNArgs(1),
 }
@@ -68,6 +74,15 @@ func init() {
 		false,
 		"After getting the logs, watch for changes.",
 	)
+
+	flags.BoolVar(
+		&args.analyze,
+		"analyze",
+		false,
+		"Instead of printing the logs, report known failures with remediation hints.",
+	)
+
+	output.AddFlag(Cmd)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -83,10 +98,19 @@ func run(cmd *cobra.Command, argv []string) {
 	// Allow the command to be called programmatically
 	if len(argv) == 1 && !cmd.Flag("cluster").Changed {
 		ocm.SetClusterKey(argv[0])
-		watch = true
+		watch = !args.analyze
 	}
 	clusterKey := r.GetClusterKey()
 
+	if args.analyze && watch {
+		r.Reporter.Errorf("The '--analyze' and '--watch' flags can't be used together")
+		os.Exit(1)
+	}
+	if output.HasFlag() && !args.analyze {
+		r.Reporter.Errorf("The '--output' flag is only supported with '--analyze'")
+		os.Exit(1)
+	}
+
 	cluster := r.FetchCluster()
 	if cluster.State() != cmv1.ClusterStateUninstalling && !watch {
 		r.Reporter.Warnf("Cluster '%s' is not currently uninstalling", clusterKey)
@@ -112,6 +136,17 @@ func run(cmd *cobra.Command, argv []string) {
 			os.Exit(1)
 		}
 	}
+	if args.analyze {
+		if logs == nil {
+			return
+		}
+		err = analyzeLog(logs)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+		return
+	}
 	printLog(logs, nil)
 
 	if watch {
@@ -150,6 +185,16 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 }
 
+// Summarize the logs and report the known failures
+func analyzeLog(logs *cmv1.Log) error {
+	analysis := loganalyzer.Analyze(logs.Content())
+	if output.HasFlag() {
+		return output.Print(analysis)
+	}
+	fmt.Print(analysis.Describe())
+	return nil
+}
+
 var lastLine string
 
 // Print next log lines
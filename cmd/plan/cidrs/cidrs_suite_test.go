@@ -0,0 +1,13 @@
+package cidrs
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPlanCIDRs(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Plan CIDRs Suite")
+}
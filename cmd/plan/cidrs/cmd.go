@@ -0,0 +1,252 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cidrs
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/cidrplan"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "cidrs"
+	short = "Propose the networks of a cluster"
+	long  = "Propose machine, service and pod CIDRs and a host prefix that don't overlap with the VPC of the " +
+		"cluster, or with the networks reachable through peering or a transit gateway, and report the number " +
+		"of nodes and pods per node they have room for.\n\n" +
+		"The VPC is read from AWS when subnets are given, otherwise the planning is done offline. Networks " +
+		"given with '--machine-cidr', '--service-cidr', '--pod-cidr' and '--host-prefix' are only checked. " +
+		"The command fails when the networks overlap or are too small."
+	example = `  # Plan the networks of a cluster with up to 200 nodes in the VPC of the given subnets
+  rosa plan cidrs --subnet-ids subnet-0b4d3c2a1f0e9d8c7,subnet-0a1b2c3d4e5f6a7b8 --nodes 200
+
+  # Plan offline, keeping clear of a peered network
+  rosa plan cidrs --vpc-cidrs 10.0.0.0/16 --reserved-cidrs 10.128.0.0/16 --nodes 50
+
+  # Check existing networks
+  rosa plan cidrs --vpc-cidrs 10.0.0.0/16 --service-cidr 172.30.0.0/16 --pod-cidr 10.128.0.0/14 \
+    --host-prefix 23`
+
+	subnetIDsFlag     = "subnet-ids"
+	vpcCIDRsFlag      = "vpc-cidrs"
+	reservedCIDRsFlag = "reserved-cidrs"
+)
+
+type PlanCIDRsOptions struct {
+	subnetIDs     []string
+	vpcCIDRs      []string
+	reservedCIDRs []string
+	nodes         int
+	machineCIDR   net.IPNet
+	serviceCIDR   net.IPNet
+	podCIDR       net.IPNet
+	hostPrefix    int
+}
+
+func NewPlanCIDRsCommand() *cobra.Command {
+	options := &PlanCIDRsOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.DefaultRuntime(), PlanCIDRsRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	flags.StringSliceVar(
+		&options.subnetIDs,
+		subnetIDsFlag,
+		nil,
+		"Subnet IDs of the cluster. The subnets of their VPC are read from AWS and checked for overlaps.",
+	)
+	flags.StringSliceVar(
+		&options.vpcCIDRs,
+		vpcCIDRsFlag,
+		nil,
+		"CIDR blocks of the VPC, to plan without reading it from AWS.",
+	)
+	flags.StringSliceVar(
+		&options.reservedCIDRs,
+		reservedCIDRsFlag,
+		nil,
+		"Networks reachable from the VPC, for example through peering or a transit gateway.",
+	)
+	flags.IntVar(
+		&options.nodes,
+		"nodes",
+		0,
+		"Number of nodes the cluster must be able to grow to.",
+	)
+	flags.IPNetVar(
+		&options.machineCIDR,
+		"machine-cidr",
+		net.IPNet{},
+		"Block of IP addresses used by OpenShift while installing the cluster, instead of the proposed one.",
+	)
+	flags.IPNetVar(
+		&options.serviceCIDR,
+		"service-cidr",
+		net.IPNet{},
+		"Block of IP addresses for services, instead of the proposed one.",
+	)
+	flags.IPNetVar(
+		&options.podCIDR,
+		"pod-cidr",
+		net.IPNet{},
+		"Block of IP addresses from which Pod IP addresses are allocated, instead of the proposed one.",
+	)
+	flags.IntVar(
+		&options.hostPrefix,
+		"host-prefix",
+		0,
+		"Subnet prefix length to assign to each individual node, instead of the proposed one.",
+	)
+	output.AddFlag(cmd)
+	arguments.AddProfileFlag(flags)
+	arguments.AddRegionFlag(flags)
+	return cmd
+}
+
+func PlanCIDRsRunner(options *PlanCIDRsOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		if options.nodes < 0 {
+			return fmt.Errorf("Invalid value %d for '--nodes', it should be a positive number", options.nodes)
+		}
+		input := &cidrplan.Input{
+			Nodes:       options.nodes,
+			MachineCIDR: optionalCIDR(options.machineCIDR),
+			ServiceCIDR: optionalCIDR(options.serviceCIDR),
+			PodCIDR:     optionalCIDR(options.podCIDR),
+			HostPrefix:  options.hostPrefix,
+		}
+		var err error
+		input.VpcCIDRs, err = parseCIDRs(options.vpcCIDRs, vpcCIDRsFlag)
+		if err != nil {
+			return err
+		}
+		input.Reserved, err = parseCIDRs(options.reservedCIDRs, reservedCIDRsFlag)
+		if err != nil {
+			return err
+		}
+
+		if len(options.subnetIDs) > 0 {
+			r.WithAWS()
+			vpcSubnets, err := r.AWSClient.GetVPCSubnets(options.subnetIDs[0])
+			if err != nil {
+				return fmt.Errorf("Failed to get the subnets of the VPC of subnet '%s': %v",
+					options.subnetIDs[0], err)
+			}
+			found := map[string]bool{}
+			for _, vpcSubnet := range vpcSubnets {
+				id := awssdk.ToString(vpcSubnet.SubnetId)
+				_, cidr, err := net.ParseCIDR(awssdk.ToString(vpcSubnet.CidrBlock))
+				if err != nil {
+					return fmt.Errorf("Failed to parse the CIDR block of subnet '%s': %v", id, err)
+				}
+				input.Subnets = append(input.Subnets, cidrplan.Subnet{ID: id, CIDR: cidr})
+				found[id] = true
+			}
+			for _, subnetID := range options.subnetIDs {
+				if !found[subnetID] {
+					return fmt.Errorf("Subnet '%s' isn't in the same VPC as subnet '%s'",
+						subnetID, options.subnetIDs[0])
+				}
+			}
+		}
+
+		plan, err := cidrplan.Build(input)
+		if err != nil {
+			return err
+		}
+
+		if output.HasFlag() {
+			err = output.Print(plan)
+			if err != nil {
+				return err
+			}
+		} else {
+			fmt.Print(describePlan(plan))
+		}
+		if len(plan.Issues) > 0 {
+			for _, issue := range plan.Issues {
+				r.Reporter.Warnf("%s", issue)
+			}
+			return fmt.Errorf("The planned networks have %d issues", len(plan.Issues))
+		}
+		if !output.HasFlag() {
+			r.Reporter.Infof("To create a cluster with these networks, run:\n\n%s", createCommand(plan, options))
+		}
+		return nil
+	}
+}
+
+func describePlan(plan *cidrplan.Plan) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Machine CIDR:               %s\n", plan.MachineCIDR)
+	fmt.Fprintf(&b, "Service CIDR:               %s\n", plan.ServiceCIDR)
+	fmt.Fprintf(&b, "Pod CIDR:                   %s\n", plan.PodCIDR)
+	fmt.Fprintf(&b, "Host prefix:                /%d\n", plan.HostPrefix)
+	fmt.Fprintf(&b, "Max nodes:                  %d\n", plan.MaxNodes)
+	fmt.Fprintf(&b, "Pods per node:              %d\n", plan.PodsPerNode)
+	return b.String()
+}
+
+func createCommand(plan *cidrplan.Plan, options *PlanCIDRsOptions) string {
+	args := []string{"rosa create cluster"}
+	if len(options.subnetIDs) > 0 {
+		args = append(args, fmt.Sprintf("--subnet-ids %s", strings.Join(options.subnetIDs, ",")))
+	}
+	args = append(args,
+		fmt.Sprintf("--machine-cidr %s", plan.MachineCIDR),
+		fmt.Sprintf("--service-cidr %s", plan.ServiceCIDR),
+		fmt.Sprintf("--pod-cidr %s", plan.PodCIDR),
+		fmt.Sprintf("--host-prefix %d", plan.HostPrefix),
+	)
+	return strings.Join(args, " ")
+}
+
+func optionalCIDR(cidr net.IPNet) *net.IPNet {
+	if ocm.IsEmptyCIDR(cidr) {
+		return nil
+	}
+	return &cidr
+}
+
+func parseCIDRs(values []string, flag string) ([]*net.IPNet, error) {
+	cidrs := []*net.IPNet{}
+	for _, value := range values {
+		_, cidr, err := net.ParseCIDR(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid CIDR '%s' for '--%s': %v", value, flag, err)
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	return cidrs, nil
+}
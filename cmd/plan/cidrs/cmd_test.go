@@ -0,0 +1,40 @@
+package cidrs
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/cidrplan"
+)
+
+var _ = Describe("Plan CIDRs", func() {
+	plan := &cidrplan.Plan{
+		MachineCIDR: "10.0.0.0/16",
+		ServiceCIDR: "172.30.0.0/16",
+		PodCIDR:     "10.128.0.0/14",
+		HostPrefix:  23,
+		MaxNodes:    512,
+		PodsPerNode: 510,
+	}
+
+	It("describes the plan", func() {
+		Expect(describePlan(plan)).To(Equal(
+			"Machine CIDR:               10.0.0.0/16\n" +
+				"Service CIDR:               172.30.0.0/16\n" +
+				"Pod CIDR:                   10.128.0.0/14\n" +
+				"Host prefix:                /23\n" +
+				"Max nodes:                  512\n" +
+				"Pods per node:              510\n"))
+	})
+
+	It("suggests the create cluster command", func() {
+		options := &PlanCIDRsOptions{subnetIDs: []string{"subnet-a", "subnet-b"}}
+		Expect(createCommand(plan, options)).To(Equal("rosa create cluster --subnet-ids subnet-a,subnet-b " +
+			"--machine-cidr 10.0.0.0/16 --service-cidr 172.30.0.0/16 --pod-cidr 10.128.0.0/14 --host-prefix 23"))
+	})
+
+	It("rejects invalid CIDRs", func() {
+		_, err := parseCIDRs([]string{"10.0.0.0/16", "10.1.0.0"}, vpcCIDRsFlag)
+		Expect(err).To(MatchError(ContainSubstring("Invalid CIDR '10.1.0.0' for '--vpc-cidrs'")))
+	})
+})
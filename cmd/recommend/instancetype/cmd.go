@@ -0,0 +1,232 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancetype
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/instancetypes"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "instance-type"
+	short = "Recommend instance types for your workloads"
+	long  = "Rank the instance types available in the region by how well they fit the requested CPU, " +
+		"memory, GPU and architecture, and by the remaining quota."
+	example = `  # Recommend instance types with at least 8 cores and 32 GiB of memory
+  rosa recommend instance-type --cpu 8 --memory 32Gi
+
+  # Recommend arm64 instance types for a hosted cluster in us-east-1
+  rosa recommend instance-type --cpu 4 --memory 16Gi --arch arm64 --hosted-cp --region us-east-1
+
+  # Recommend GPU instance types
+  rosa recommend instance-type --cpu 8 --gpu`
+)
+
+var aliases = []string{"instance-types", "instancetype", "instancetypes"}
+
+type RecommendInstanceTypeOptions struct {
+	cpu          int
+	memory       string
+	gpu          bool
+	architecture string
+	hostedCP     bool
+	multiAZ      bool
+	roleArn      string
+	externalId   string
+	limit        int
+}
+
+// recommendationOutput is the JSON and YAML representation of a recommendation.
+type recommendationOutput struct {
+	ID           string   `json:"id"`
+	Category     string   `json:"category"`
+	CPUCores     int      `json:"cpu_cores"`
+	MemoryGiB    float64  `json:"memory_gib"`
+	Architecture string   `json:"architecture"`
+	HasQuota     bool     `json:"has_quota"`
+	Zones        []string `json:"zones,omitempty"`
+}
+
+func NewRecommendInstanceTypeCommand() *cobra.Command {
+	options := &RecommendInstanceTypeOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), RecommendInstanceTypeRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	flags.IntVar(
+		&options.cpu,
+		"cpu",
+		0,
+		"Minimum number of CPU cores.",
+	)
+	flags.StringVar(
+		&options.memory,
+		"memory",
+		"",
+		"Minimum amount of memory, for example '32Gi'. Plain numbers are GiB.",
+	)
+	flags.BoolVar(
+		&options.gpu,
+		"gpu",
+		false,
+		"Recommend accelerated computing instance types.",
+	)
+	flags.StringVar(
+		&options.architecture,
+		"arch",
+		"",
+		"Architecture of the instance types, either 'amd64' or 'arm64'.",
+	)
+	flags.BoolVar(
+		&options.hostedCP,
+		"hosted-cp",
+		false,
+		"Recommend instance types for a cluster with Hosted Control Planes.",
+	)
+	flags.BoolVar(
+		&options.multiAZ,
+		"multi-az",
+		false,
+		"Check quota for a multi-AZ cluster.",
+	)
+	flags.StringVar(
+		&options.roleArn,
+		"role-arn",
+		"",
+		"STS Role ARN used to list the instance types offered in the region.",
+	)
+	flags.StringVar(
+		&options.externalId,
+		"external-id",
+		"",
+		"An optional unique identifier that might be required when you assume a role in another account.",
+	)
+	flags.IntVar(
+		&options.limit,
+		"limit",
+		10,
+		"Maximum number of instance types to recommend.",
+	)
+	arguments.AddProfileFlag(flags)
+	arguments.AddRegionFlag(flags)
+	output.AddFlag(cmd)
+	return cmd
+}
+
+func RecommendInstanceTypeRunner(options *RecommendInstanceTypeOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		memory, err := instancetypes.ParseMemory(options.memory)
+		if err != nil {
+			return err
+		}
+		requirements := instancetypes.Requirements{
+			CPU:          options.cpu,
+			MemoryGiB:    memory,
+			GPU:          options.gpu,
+			Architecture: options.architecture,
+			MultiAZ:      options.multiAZ,
+			HostedCP:     options.hostedCP,
+		}
+		if err = requirements.Validate(); err != nil {
+			return err
+		}
+		if options.limit < 1 {
+			return fmt.Errorf("Limit must be a positive integer")
+		}
+
+		region := r.AWSClient.GetRegion()
+		regionList, _, err := r.OCMClient.GetRegionList(false, options.roleArn, options.externalId, "",
+			r.AWSClient, options.hostedCP, false)
+		if err != nil {
+			return err
+		}
+		if !helper.Contains(regionList, region) {
+			return fmt.Errorf("Region '%s' not found", region)
+		}
+
+		r.Reporter.Debugf("Fetching instance types in region '%s'", region)
+		machineTypes, err := r.OCMClient.GetAvailableMachineTypesInRegion(region, nil, options.roleArn,
+			r.AWSClient, options.externalId)
+		if err != nil {
+			return fmt.Errorf("Failed to fetch instance types: %v", err)
+		}
+
+		zones, err := r.AWSClient.DescribeAvailabilityZones()
+		if err != nil {
+			return fmt.Errorf("Failed to list availability zones of region '%s': %v", region, err)
+		}
+		machineTypesPerZone := map[string]ocm.MachineTypeList{}
+		for _, zone := range zones {
+			r.Reporter.Debugf("Fetching instance types in availability zone '%s'", zone)
+			machineTypesPerZone[zone], err = r.OCMClient.GetAvailableMachineTypesInRegion(region,
+				[]string{zone}, options.roleArn, r.AWSClient, options.externalId)
+			if err != nil {
+				return fmt.Errorf("Failed to fetch instance types in availability zone '%s': %v", zone, err)
+			}
+		}
+
+		recommendations := instancetypes.Recommend(machineTypes, requirements,
+			instancetypes.ZoneAvailability(machineTypesPerZone))
+		if len(recommendations) > options.limit {
+			recommendations = recommendations[:options.limit]
+		}
+
+		if output.HasFlag() {
+			result := []recommendationOutput{}
+			for _, recommendation := range recommendations {
+				machineType := recommendation.MachineType.MachineType
+				result = append(result, recommendationOutput{
+					ID:           machineType.ID(),
+					Category:     string(machineType.Category()),
+					CPUCores:     int(machineType.CPU().Value()),
+					MemoryGiB:    instancetypes.MemoryGiB(machineType),
+					Architecture: string(machineType.Architecture()),
+					HasQuota:     recommendation.HasQuota,
+					Zones:        recommendation.Zones,
+				})
+			}
+			return output.Print(result)
+		}
+
+		if len(recommendations) == 0 {
+			r.Reporter.Infof("There are no instance types in region '%s' that fit these requirements", region)
+			return nil
+		}
+		if !recommendations[0].HasQuota {
+			r.Reporter.Warnf("None of the instance types that fit these requirements has enough quota")
+		}
+		return instancetypes.Print(os.Stdout, recommendations)
+	}
+}
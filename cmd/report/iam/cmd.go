@@ -0,0 +1,140 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iam
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/inventory"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "iam"
+	short = "Report the IAM resources created by ROSA"
+	long  = "Generate an inventory of the account roles, operator roles, OCM roles, user roles and OIDC " +
+		"providers created by ROSA in the AWS account, for access reviews.\n\n" +
+		"Each resource is reported with its ARN, type, version, attached policies and whether AWS " +
+		"manages them, permissions boundary, the OCM organization or user it is linked to, the clusters " +
+		"using it, and when it was last used."
+	example = `  # Print the inventory as JSON
+  rosa report iam
+
+  # Save the inventory as a spreadsheet
+  rosa report iam --format csv --output-file iam-inventory.csv
+
+  # Save the inventory as a web page
+  rosa report iam --format html --output-file iam-inventory.html`
+
+	formatFlag     = "format"
+	outputFileFlag = "output-file"
+)
+
+type ReportIAMOptions struct {
+	format     string
+	outputFile string
+}
+
+func NewReportIAMCommand() *cobra.Command {
+	options := &ReportIAMOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), ReportIAMRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	flags.StringVar(
+		&options.format,
+		formatFlag,
+		inventory.FormatJSON,
+		fmt.Sprintf("Format of the report, one of: %s.", strings.Join(inventory.Formats, ", ")),
+	)
+	cmd.RegisterFlagCompletionFunc(formatFlag, func(_ *cobra.Command, _ []string, _ string) ([]string,
+		cobra.ShellCompDirective) {
+		return inventory.Formats, cobra.ShellCompDirectiveDefault
+	})
+	flags.StringVar(
+		&options.outputFile,
+		outputFileFlag,
+		"",
+		"File to write the report to instead of the standard output.",
+	)
+	arguments.AddProfileFlag(flags)
+	arguments.AddRegionFlag(flags)
+	return cmd
+}
+
+func ReportIAMRunner(options *ReportIAMOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		if !helper.Contains(inventory.Formats, options.format) {
+			return fmt.Errorf("Invalid value '%s' for '--%s', expected one of: %s", options.format, formatFlag,
+				strings.Join(inventory.Formats, ", "))
+		}
+
+		var spin *spinner.Spinner
+		if r.Reporter.IsTerminal() && options.outputFile != "" {
+			spin = spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+			r.Reporter.Infof("Fetching IAM resources")
+			spin.Start()
+		}
+		collector := &inventory.Collector{
+			AWSClient: r.AWSClient,
+			OCMClient: r.OCMClient,
+			Creator:   r.Creator,
+		}
+		resources, err := collector.Collect()
+		if spin != nil {
+			spin.Stop()
+		}
+		if err != nil {
+			return err
+		}
+
+		var w io.Writer = os.Stdout
+		if options.outputFile != "" {
+			file, err := os.Create(options.outputFile)
+			if err != nil {
+				return fmt.Errorf("Failed to create report file '%s': %v", options.outputFile, err)
+			}
+			defer file.Close()
+			w = file
+		}
+		err = inventory.Write(w, options.format, resources, time.Now())
+		if err != nil {
+			return fmt.Errorf("Failed to write report: %v", err)
+		}
+		if options.outputFile != "" {
+			r.Reporter.Infof("Saved the inventory of %d IAM resources to '%s'", len(resources), options.outputFile)
+		}
+		return nil
+	}
+}
@@ -23,7 +23,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/adopt"
 	"github.com/openshift/rosa/cmd/attach"
+	"github.com/openshift/rosa/cmd/cleanup"
 	"github.com/openshift/rosa/cmd/completion"
 	"github.com/openshift/rosa/cmd/config"
 	"github.com/openshift/rosa/cmd/create"
@@ -42,12 +44,21 @@ import (
 	"github.com/openshift/rosa/cmd/login"
 	"github.com/openshift/rosa/cmd/logout"
 	"github.com/openshift/rosa/cmd/logs"
+	"github.com/openshift/rosa/cmd/plan"
+	"github.com/openshift/rosa/cmd/recommend"
 	"github.com/openshift/rosa/cmd/register"
+	"github.com/openshift/rosa/cmd/report"
 	"github.com/openshift/rosa/cmd/resume"
 	"github.com/openshift/rosa/cmd/revoke"
+	"github.com/openshift/rosa/cmd/rotate"
+	"github.com/openshift/rosa/cmd/schedules"
+	"github.com/openshift/rosa/cmd/status"
+	"github.com/openshift/rosa/cmd/support"
+	"github.com/openshift/rosa/cmd/tag"
 	"github.com/openshift/rosa/cmd/token"
 	"github.com/openshift/rosa/cmd/uninstall"
 	"github.com/openshift/rosa/cmd/unlink"
+	"github.com/openshift/rosa/cmd/untag"
 	"github.com/openshift/rosa/cmd/upgrade"
 	"github.com/openshift/rosa/cmd/verify"
 	"github.com/openshift/rosa/cmd/version"
@@ -76,6 +87,8 @@ func init() {
 	arguments.AddDebugFlag(fs)
 
 	// Register the subcommands:
+	root.AddCommand(adopt.Cmd)
+	root.AddCommand(cleanup.Cmd)
 	root.AddCommand(completion.Cmd)
 	root.AddCommand(create.Cmd)
 	root.AddCommand(describe.Cmd)
@@ -90,9 +103,18 @@ func init() {
 	root.AddCommand(login.Cmd)
 	root.AddCommand(logout.Cmd)
 	root.AddCommand(logs.Cmd)
+	root.AddCommand(plan.Cmd)
+	root.AddCommand(recommend.Cmd)
 	root.AddCommand(register.Cmd)
+	root.AddCommand(report.Cmd)
 	root.AddCommand(revoke.Cmd)
+	root.AddCommand(rotate.Cmd)
+	root.AddCommand(schedules.Cmd)
+	root.AddCommand(status.Cmd)
+	root.AddCommand(support.Cmd)
+	root.AddCommand(tag.Cmd)
 	root.AddCommand(uninstall.Cmd)
+	root.AddCommand(untag.Cmd)
 	root.AddCommand(upgrade.Cmd)
 	root.AddCommand(verify.Cmd)
 	root.AddCommand(version.NewRosaVersionCommand())
@@ -0,0 +1,443 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidcconfigkeys
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/openshift-online/ocm-common/pkg/rosa/oidcconfigs"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/oidcconfig"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "oidc-config-keys"
+	short = "Rotate the signing key of an unmanaged OIDC configuration"
+	long  = "Generate a new signing key for an unmanaged OIDC configuration. The rotation runs in two " +
+		"phases. The first one publishes the JSON Web Key Set with both the current and the new public " +
+		"keys, then replaces the private key stored in Secrets Manager and records the time of the rotation " +
+		"on the secret. Once tokens signed with the previous key have expired, after the overlap period, run " +
+		"the command again with '--finalize' to remove the previous public key from the JSON Web Key Set.\n\n" +
+		"The key cannot be rotated again until the rotation is finalized, and the rotation cannot be " +
+		"finalized before the overlap period has passed."
+	example = `  # Rotate the signing key of an unmanaged OIDC configuration
+  rosa rotate oidc-config-keys --oidc-config-id <oidc_config_id>
+
+  # One hour later, remove the previous public key
+  rosa rotate oidc-config-keys --oidc-config-id <oidc_config_id> --finalize
+
+  # Keep the previous public key published for a day
+  rosa rotate oidc-config-keys --oidc-config-id <oidc_config_id> --overlap 24h
+
+  # Print the AWS commands that rotate the key instead of running them
+  rosa rotate oidc-config-keys --oidc-config-id <oidc_config_id> --mode manual`
+
+	oidcConfigIdFlag = "oidc-config-id"
+	finalizeFlag     = "finalize"
+	overlapFlag      = "overlap"
+
+	// Projected service account tokens are valid for one hour, keeping the previous key for as long
+	// ensures that no token signed with it is still in use when it is removed.
+	minOverlap = time.Hour
+
+	fetchTimeout = 30 * time.Second
+)
+
+var aliases = []string{"oidcconfigkeys", "oidc-config-key"}
+
+type RotateOidcConfigKeysOptions struct {
+	oidcConfigId string
+	finalize     bool
+	overlap      time.Duration
+	// httpClient reads the keys published by the issuer
+	httpClient *http.Client
+}
+
+func NewRotateOidcConfigKeysCommand() *cobra.Command {
+	options := &RotateOidcConfigKeysOptions{httpClient: &http.Client{Timeout: fetchTimeout}}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), RotateOidcConfigKeysRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	flags.StringVar(
+		&options.oidcConfigId,
+		oidcConfigIdFlag,
+		"",
+		"ID of the unmanaged OIDC configuration whose signing key is rotated (required).",
+	)
+	flags.BoolVar(
+		&options.finalize,
+		finalizeFlag,
+		false,
+		"Remove the previous public key once tokens signed with it have expired.",
+	)
+	flags.DurationVar(
+		&options.overlap,
+		overlapFlag,
+		minOverlap,
+		"How long the previous public key stays published along the new one after the rotation. "+
+			"Finalizing the rotation is refused before it has passed.",
+	)
+	cmd.MarkFlagRequired(oidcConfigIdFlag)
+	interactive.AddModeFlag(cmd)
+	confirm.AddFlag(flags)
+	arguments.AddProfileFlag(flags)
+	arguments.AddRegionFlag(flags)
+	return cmd
+}
+
+// rotation holds the documents needed by a phase of the rotation of the signing key of an OIDC
+// configuration.
+type rotation struct {
+	oidcConfigId string
+	issuerUrl    string
+	bucketName   string
+	secretArn    string
+	region       string
+	overlap      time.Duration
+	// currentPrivateKey is the key stored in Secrets Manager when the phase starts
+	currentPrivateKey []byte
+	// newPrivateKey and overlapJwks, that publishes the current and the new keys, are only set
+	// when rotating, finalJwks, that only publishes the current key, when finalizing
+	newPrivateKey []byte
+	overlapJwks   []byte
+	finalJwks     []byte
+}
+
+func RotateOidcConfigKeysRunner(options *RotateOidcConfigKeysOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, cmd *cobra.Command, _ []string) error {
+		mode, err := interactive.GetMode()
+		if err != nil {
+			return err
+		}
+		if !interactive.Enabled() && !cmd.Flags().Changed("mode") {
+			interactive.Enable()
+		}
+		if interactive.Enabled() {
+			mode, err = interactive.GetOptionMode(cmd, mode, "OIDC config key rotation mode")
+			if err != nil {
+				return fmt.Errorf("Expected a valid OIDC config key rotation mode: %s", err)
+			}
+		}
+
+		if options.overlap < minOverlap {
+			return fmt.Errorf("Expected an overlap of at least %s, the lifetime of service account tokens",
+				minOverlap)
+		}
+		if options.finalize && cmd.Flags().Changed(overlapFlag) {
+			return fmt.Errorf("The overlap is set when rotating the key, '--%s' can't be used with '--%s'",
+				overlapFlag, finalizeFlag)
+		}
+
+		rotation, err := prepareRotation(r, options)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case mode == interactive.ModeAuto && options.finalize:
+			return finalizeAuto(r, rotation)
+		case mode == interactive.ModeAuto:
+			return rotateAuto(r, rotation)
+		case mode == interactive.ModeManual && options.finalize:
+			return finalizeManual(r, rotation)
+		case mode == interactive.ModeManual:
+			return rotateManual(r, rotation)
+		default:
+			return fmt.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
+		}
+	}
+}
+
+func prepareRotation(r *rosa.Runtime, options *RotateOidcConfigKeysOptions) (*rotation, error) {
+	oidcConfigId := options.oidcConfigId
+	oidcConfig, err := r.OCMClient.GetOidcConfig(oidcConfigId)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get OIDC config '%s': %v", oidcConfigId, err)
+	}
+	if oidcConfig.Managed() {
+		return nil, fmt.Errorf("OIDC config '%s' is managed by Red Hat, its signing key cannot be rotated",
+			oidcConfigId)
+	}
+	if oidcconfig.BackendFromIssuerUrl(oidcConfig.IssuerUrl()) == oidcconfig.BackendRawFiles {
+		return nil, fmt.Errorf("The documents of OIDC config '%s' are served by your web host under '%s', "+
+			"ROSA cannot publish its keys. Generate the new key and upload the JSON Web Key Set yourself",
+			oidcConfigId, oidcConfig.IssuerUrl())
+	}
+	secretArn := oidcConfig.SecretArn()
+	bucketName, err := oidcconfig.BucketNameFromIssuerUrl(oidcConfig.IssuerUrl())
+	if err != nil {
+		// Issuers served by CloudFront read from the bucket named after the secret
+		secretName, err := aws.GetResourceIdFromSecretArn(secretArn)
+		if err != nil {
+			return nil, fmt.Errorf("There was a problem parsing secret ARN '%s': %v", secretArn, err)
+		}
+		bucketName = oidcconfig.BucketNameFromSecretName(secretName)
+	}
+	parsedArn, err := arn.Parse(secretArn)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse secret ARN '%s' of OIDC config '%s': %v",
+			secretArn, oidcConfigId, err)
+	}
+	if parsedArn.Region != r.AWSClient.GetRegion() {
+		return nil, fmt.Errorf("The private key of OIDC config '%s' is stored in region '%s', "+
+			"run the command again with '--region %s'", oidcConfigId, parsedArn.Region, parsedArn.Region)
+	}
+
+	r.Reporter.Debugf("Reading current private key from secret '%s'", secretArn)
+	currentPrivateKey, err := r.AWSClient.GetSecretInSecretsManager(secretArn)
+	if err != nil {
+		return nil, fmt.Errorf("There was a problem reading the private key from secrets manager: %v", err)
+	}
+	rotation := &rotation{
+		oidcConfigId:      oidcConfigId,
+		issuerUrl:         oidcConfig.IssuerUrl(),
+		bucketName:        bucketName,
+		secretArn:         secretArn,
+		region:            parsedArn.Region,
+		overlap:           options.overlap,
+		currentPrivateKey: []byte(currentPrivateKey),
+	}
+
+	if options.finalize {
+		err = checkOverlap(r, rotation)
+		if err != nil {
+			return nil, err
+		}
+		rotation.finalJwks, err = oidcconfig.BuildJSONWebKeySet(rotation.currentPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		return rotation, nil
+	}
+	// Replacing the published keys while the previous rotation isn't finalized would unpublish a key
+	// that may still sign tokens
+	keyIds, err := oidcconfig.PublishedKeyIds(options.httpClient, rotation.issuerUrl)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read the keys published by OIDC config '%s': %v", oidcConfigId, err)
+	}
+	if len(keyIds) > 1 {
+		return nil, fmt.Errorf("OIDC config '%s' publishes %d keys, the previous rotation is not finalized. "+
+			"Finalize it with 'rosa rotate oidc-config-keys --%s %s --%s'", oidcConfigId, len(keyIds),
+			oidcConfigIdFlag, oidcConfigId, finalizeFlag)
+	}
+	rotation.newPrivateKey, _, err = oidcconfigs.CreateKeyPair()
+	if err != nil {
+		return nil, fmt.Errorf("There was a problem generating key pair: %v", err)
+	}
+	rotation.overlapJwks, err = oidcconfig.BuildJSONWebKeySet(rotation.currentPrivateKey, rotation.newPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return rotation, nil
+}
+
+func rotateAuto(r *rosa.Runtime, rotation *rotation) error {
+	if !confirm.Confirm("rotate the signing key of OIDC config '%s'", rotation.oidcConfigId) {
+		return nil
+	}
+
+	r.Reporter.Infof("Publishing the new public key along the current one in S3 bucket '%s'",
+		rotation.bucketName)
+	err := r.AWSClient.PutPublicReadObjectInS3Bucket(rotation.bucketName,
+		bytes.NewReader(rotation.overlapJwks), oidcconfig.JwksKey)
+	if err != nil {
+		return fmt.Errorf("There was a problem populating JWKS to S3 bucket '%s': %v", rotation.bucketName, err)
+	}
+
+	r.Reporter.Infof("Replacing the private key in secret '%s'", rotation.secretArn)
+	err = r.AWSClient.UpdateSecretInSecretsManager(rotation.secretArn, string(rotation.newPrivateKey))
+	if err != nil {
+		return fmt.Errorf("There was a problem saving private key to secrets manager: %v", err)
+	}
+	err = r.AWSClient.TagSecret(rotation.secretArn, map[string]string{
+		tags.OidcKeyRotatedAt: time.Now().UTC().Format(time.RFC3339),
+		tags.OidcKeyOverlap:   rotation.overlap.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("There was a problem recording the rotation on secret '%s': %v", rotation.secretArn, err)
+	}
+
+	r.Reporter.Infof("Rotated the signing key of OIDC config '%s'. %s", rotation.oidcConfigId,
+		finalizeHint(rotation))
+	return nil
+}
+
+func rotateManual(r *rosa.Runtime, rotation *rotation) error {
+	privateKeyFilename := fmt.Sprintf("rosa-private-key-%s-rotated.key", rotation.bucketName)
+	overlapJwksFilename := fmt.Sprintf("jwks-rotation-%s.json", rotation.bucketName)
+	documents := map[string][]byte{
+		privateKeyFilename:  rotation.newPrivateKey,
+		overlapJwksFilename: rotation.overlapJwks,
+	}
+	for filename, document := range documents {
+		err := helper.SaveDocument(string(document), filename)
+		if err != nil {
+			return fmt.Errorf("There was a problem saving '%s': %v", filename, err)
+		}
+	}
+
+	commands := []string{}
+	commands = append(commands, putJwksCommand(rotation.bucketName, overlapJwksFilename))
+	commands = append(commands, fmt.Sprintf("rm %s", overlapJwksFilename))
+	putSecretValueCommand := awscb.NewSecretsManagerCommandBuilder().
+		SetCommand(awscb.PutSecretValue).
+		AddParam(awscb.SecretID, rotation.secretArn).
+		AddParam(awscb.SecretString, fmt.Sprintf("file://%s", privateKeyFilename)).
+		AddParam(awscb.Region, rotation.region).
+		Build()
+	commands = append(commands, putSecretValueCommand)
+	commands = append(commands, fmt.Sprintf("rm %s", privateKeyFilename))
+	tagSecretCommand := awscb.NewSecretsManagerCommandBuilder().
+		SetCommand(awscb.TagResource).
+		AddParam(awscb.SecretID, rotation.secretArn).
+		AddParam(awscb.Region, rotation.region).
+		AddTags(map[string]string{
+			tags.OidcKeyRotatedAt: "$(date -u +%Y-%m-%dT%H:%M:%SZ)",
+			tags.OidcKeyOverlap:   rotation.overlap.String(),
+		}).
+		Build()
+	commands = append(commands, tagSecretCommand)
+	fmt.Println(awscb.JoinCommands(commands))
+	if r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Please run commands above to rotate the signing key of OIDC config '%s'. %s",
+			rotation.oidcConfigId, finalizeHint(rotation))
+	}
+	return nil
+}
+
+// confirmFinalize lists the clusters that validate tokens against the issuer of the OIDC
+// configuration and asks for a confirmation before the previous key is removed.
+func confirmFinalize(r *rosa.Runtime, rotation *rotation) (bool, error) {
+	clusters, err := r.OCMClient.GetClustersUsingOidcEndpointUrl(rotation.issuerUrl)
+	if err != nil {
+		return false, fmt.Errorf("There was a problem checking if any clusters are using OIDC config '%s': %v",
+			rotation.oidcConfigId, err)
+	}
+	if len(clusters) == 0 {
+		return confirm.Confirm("remove the previous signing key of OIDC config '%s'", rotation.oidcConfigId), nil
+	}
+	names := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		names = append(names, fmt.Sprintf("'%s' (%s)", cluster.Name(), cluster.ID()))
+	}
+	r.Reporter.Warnf("OIDC config '%s' is used by clusters %s. Their service account tokens signed with "+
+		"the previous key are rejected once it is removed", rotation.oidcConfigId, strings.Join(names, ", "))
+	return confirm.Confirm("remove the previous signing key of OIDC config '%s' used by %d cluster(s)",
+		rotation.oidcConfigId, len(clusters)), nil
+}
+
+func finalizeAuto(r *rosa.Runtime, rotation *rotation) error {
+	proceed, err := confirmFinalize(r, rotation)
+	if err != nil || !proceed {
+		return err
+	}
+
+	r.Reporter.Infof("Removing the previous public key from S3 bucket '%s'", rotation.bucketName)
+	err = r.AWSClient.PutPublicReadObjectInS3Bucket(rotation.bucketName,
+		bytes.NewReader(rotation.finalJwks), oidcconfig.JwksKey)
+	if err != nil {
+		return fmt.Errorf("There was a problem populating JWKS to S3 bucket '%s': %v", rotation.bucketName, err)
+	}
+	r.Reporter.Infof("Finalized the rotation of the signing key of OIDC config '%s'", rotation.oidcConfigId)
+	return nil
+}
+
+func finalizeManual(r *rosa.Runtime, rotation *rotation) error {
+	proceed, err := confirmFinalize(r, rotation)
+	if err != nil || !proceed {
+		return err
+	}
+
+	finalJwksFilename := fmt.Sprintf("jwks-%s.json", rotation.bucketName)
+	err = helper.SaveDocument(string(rotation.finalJwks), finalJwksFilename)
+	if err != nil {
+		return fmt.Errorf("There was a problem saving '%s': %v", finalJwksFilename, err)
+	}
+	commands := []string{}
+	commands = append(commands, putJwksCommand(rotation.bucketName, finalJwksFilename))
+	commands = append(commands, fmt.Sprintf("rm %s", finalJwksFilename))
+	fmt.Println(awscb.JoinCommands(commands))
+	if r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Please run commands above to remove the previous signing key of OIDC config '%s'",
+			rotation.oidcConfigId)
+	}
+	return nil
+}
+
+// checkOverlap checks that the overlap period recorded on the secret by the rotation has passed
+func checkOverlap(r *rosa.Runtime, rotation *rotation) error {
+	secretTags, err := r.AWSClient.GetSecretTags(rotation.secretArn)
+	if err != nil {
+		return fmt.Errorf("There was a problem reading the tags of secret '%s': %v", rotation.secretArn, err)
+	}
+	rotatedAt, err := time.Parse(time.RFC3339, secretTags[tags.OidcKeyRotatedAt])
+	if err != nil {
+		return fmt.Errorf("Secret '%s' has no valid '%s' tag, the signing key of OIDC config '%s' was not "+
+			"rotated by ROSA", rotation.secretArn, tags.OidcKeyRotatedAt, rotation.oidcConfigId)
+	}
+	overlap, err := time.ParseDuration(secretTags[tags.OidcKeyOverlap])
+	if err != nil || overlap < minOverlap {
+		overlap = minOverlap
+	}
+	finalizeAfter := rotatedAt.Add(overlap)
+	if time.Now().Before(finalizeAfter) {
+		return fmt.Errorf("The signing key of OIDC config '%s' was rotated at %s with an overlap of %s, "+
+			"the rotation can be finalized after %s", rotation.oidcConfigId, rotatedAt.Format(time.RFC3339),
+			overlap, finalizeAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func finalizeHint(rotation *rotation) string {
+	return fmt.Sprintf("Once tokens signed with the previous key have expired, in %s, remove it with "+
+		"'rosa rotate oidc-config-keys --%s %s --%s'", rotation.overlap, oidcConfigIdFlag,
+		rotation.oidcConfigId, finalizeFlag)
+}
+
+func putJwksCommand(bucketName string, filename string) string {
+	return awscb.NewS3ApiCommandBuilder().
+		SetCommand(awscb.PutObject).
+		AddParam(awscb.Body, fmt.Sprintf("./%s", filename)).
+		AddParam(awscb.Bucket, bucketName).
+		AddParam(awscb.Key, oidcconfig.JwksKey).
+		AddParam(awscb.Tagging, fmt.Sprintf("'%s=%s'", tags.RedHatManaged, tags.True)).
+		Build()
+}
@@ -0,0 +1,219 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package oidcconfigkeys
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openshift-online/ocm-common/pkg/rosa/oidcconfigs"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/cobra"
+
+	mock "github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/oidcconfig"
+	. "github.com/openshift/rosa/pkg/test"
+)
+
+func TestRotateOidcConfigKeys(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "rosa rotate oidc-config-keys")
+}
+
+// issuerTransport serves the published JSON Web Key Set of any issuer
+type issuerTransport struct {
+	jwks []byte
+}
+
+func (t *issuerTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	if request.URL.Path != "/"+oidcconfig.JwksKey {
+		recorder.WriteHeader(http.StatusNotFound)
+		return recorder.Result(), nil
+	}
+	recorder.Write(t.jwks)
+	return recorder.Result(), nil
+}
+
+var _ = Describe("rosa rotate oidc-config-keys", func() {
+	const (
+		oidcConfigId = "2abc"
+		bucketName   = "oidc-bucket"
+		secretArn    = "arn:aws:secretsmanager:us-east-1:123456789012:secret:rosa-private-key-oidc-bucket"
+	)
+
+	var (
+		t          *TestingRuntime
+		c          *cobra.Command
+		mockClient *mock.MockClient
+		privateKey []byte
+		transport  *issuerTransport
+		options    *RotateOidcConfigKeysOptions
+	)
+
+	BeforeEach(func() {
+		c = NewRotateOidcConfigKeysCommand()
+		Expect(c.Flags().Set("mode", "auto")).To(Succeed())
+		DeferCleanup(func() {
+			Expect(c.Flags().Set("yes", "false")).To(Succeed())
+		})
+
+		t = NewTestRuntime()
+		mockClient = mock.NewMockClient(gomock.NewController(GinkgoT()))
+		t.RosaRuntime.AWSClient = mockClient
+
+		var err error
+		privateKey, _, err = oidcconfigs.CreateKeyPair()
+		Expect(err).ToNot(HaveOccurred())
+		transport = &issuerTransport{}
+		transport.jwks, err = oidcconfig.BuildJSONWebKeySet(privateKey)
+		Expect(err).ToNot(HaveOccurred())
+		options = &RotateOidcConfigKeysOptions{
+			oidcConfigId: oidcConfigId,
+			overlap:      time.Hour,
+			httpClient:   &http.Client{Transport: transport},
+		}
+		oidcConfig, err := cmv1.NewOidcConfig().
+			ID(oidcConfigId).
+			Managed(false).
+			SecretArn(secretArn).
+			IssuerUrl("https://" + bucketName + ".s3.us-east-1.amazonaws.com").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, FormatResource(oidcConfig)))
+		mockClient.EXPECT().GetRegion().Return("us-east-1").AnyTimes()
+		mockClient.EXPECT().GetSecretInSecretsManager(secretArn).Return(string(privateKey), nil)
+	})
+
+	expectJwks := func(privateKeys ...[]byte) {
+		expected, err := oidcconfig.BuildJSONWebKeySet(privateKeys...)
+		Expect(err).ToNot(HaveOccurred())
+		mockClient.EXPECT().PutPublicReadObjectInS3Bucket(bucketName, gomock.Any(), oidcconfig.JwksKey).
+			DoAndReturn(func(_ string, body io.ReadSeeker, _ string) error {
+				jwks, err := io.ReadAll(body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(jwks).To(MatchJSON(expected))
+				return nil
+			})
+	}
+
+	expectRotatedAt := func(rotatedAt time.Time, overlap string) {
+		mockClient.EXPECT().GetSecretTags(secretArn).Return(map[string]string{
+			tags.OidcKeyRotatedAt: rotatedAt.UTC().Format(time.RFC3339),
+			tags.OidcKeyOverlap:   overlap,
+		}, nil)
+	}
+
+	It("Publishes both keys, swaps the private key and records the rotation", func() {
+		Expect(c.Flags().Set("yes", "true")).To(Succeed())
+		options.overlap = 24 * time.Hour
+		var newPrivateKey string
+		mockClient.EXPECT().PutPublicReadObjectInS3Bucket(bucketName, gomock.Any(), oidcconfig.JwksKey).
+			Return(nil)
+		mockClient.EXPECT().UpdateSecretInSecretsManager(secretArn, gomock.Any()).
+			DoAndReturn(func(_ string, secret string) error {
+				newPrivateKey = secret
+				return nil
+			})
+		mockClient.EXPECT().TagSecret(secretArn, gomock.Any()).
+			DoAndReturn(func(_ string, secretTags map[string]string) error {
+				Expect(secretTags).To(HaveKeyWithValue(tags.OidcKeyOverlap, "24h0m0s"))
+				Expect(secretTags).To(HaveKey(tags.OidcKeyRotatedAt))
+				return nil
+			})
+
+		runner := RotateOidcConfigKeysRunner(options)
+		Expect(runner(context.Background(), t.RosaRuntime, c, nil)).To(Succeed())
+		Expect(newPrivateKey).ToNot(BeEmpty())
+		Expect(newPrivateKey).ToNot(Equal(string(privateKey)))
+	})
+
+	It("Refuses to rotate while the previous rotation is not finalized", func() {
+		otherPrivateKey, _, err := oidcconfigs.CreateKeyPair()
+		Expect(err).ToNot(HaveOccurred())
+		transport.jwks, err = oidcconfig.BuildJSONWebKeySet(privateKey, otherPrivateKey)
+		Expect(err).ToNot(HaveOccurred())
+
+		runner := RotateOidcConfigKeysRunner(options)
+		Expect(runner(context.Background(), t.RosaRuntime, c, nil)).To(MatchError(ContainSubstring(
+			"OIDC config '2abc' publishes 2 keys, the previous rotation is not finalized")))
+	})
+
+	It("Refuses to finalize before the overlap has passed", func() {
+		expectRotatedAt(time.Now().Add(-2*time.Hour), "24h0m0s")
+
+		options.finalize = true
+		runner := RotateOidcConfigKeysRunner(options)
+		Expect(runner(context.Background(), t.RosaRuntime, c, nil)).To(MatchError(ContainSubstring(
+			"with an overlap of 24h0m0s, the rotation can be finalized after")))
+	})
+
+	It("Removes the previous key when finalizing", func() {
+		Expect(c.Flags().Set("yes", "true")).To(Succeed())
+		expectRotatedAt(time.Now().Add(-2*time.Hour), "1h0m0s")
+		t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+			`{"kind": "ClusterList", "page": 1, "size": 0, "total": 0, "items": []}`))
+		expectJwks(privateKey)
+
+		options.finalize = true
+		runner := RotateOidcConfigKeysRunner(options)
+		Expect(runner(context.Background(), t.RosaRuntime, c, nil)).To(Succeed())
+		Expect(t.ApiServer.ReceivedRequests()[1].URL.Query().Get("search")).To(
+			Equal("aws.sts.oidc_endpoint_url = 'https://oidc-bucket.s3.us-east-1.amazonaws.com'"))
+	})
+
+	It("Does not remove the previous key of an OIDC config used by clusters without confirmation", func() {
+		cluster, err := cmv1.NewCluster().ID(MockClusterID).Name(MockClusterName).Build()
+		Expect(err).ToNot(HaveOccurred())
+		t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, FormatClusterList([]*cmv1.Cluster{cluster})))
+		expectRotatedAt(time.Now().Add(-2*time.Hour), "1h0m0s")
+
+		options.finalize = true
+		runner := RotateOidcConfigKeysRunner(options)
+		Expect(runner(context.Background(), t.RosaRuntime, c, nil)).To(Succeed())
+	})
+})
+
+var _ = Describe("rosa rotate oidc-config-keys of a raw files OIDC config", func() {
+	It("Refuses to rotate keys that ROSA cannot publish", func() {
+		c := NewRotateOidcConfigKeysCommand()
+		Expect(c.Flags().Set("mode", "auto")).To(Succeed())
+		t := NewTestRuntime()
+		t.RosaRuntime.AWSClient = mock.NewMockClient(gomock.NewController(GinkgoT()))
+		oidcConfig, err := cmv1.NewOidcConfig().
+			ID("2abc").
+			Managed(false).
+			SecretArn("arn:aws:secretsmanager:us-east-1:123456789012:secret:rosa-private-key-acme-oidc-a1b2").
+			IssuerUrl("https://oidc.example.com/acme").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, FormatResource(oidcConfig)))
+
+		runner := RotateOidcConfigKeysRunner(&RotateOidcConfigKeysOptions{oidcConfigId: "2abc", overlap: time.Hour})
+		Expect(runner(context.Background(), t.RosaRuntime, c, nil)).To(MatchError(ContainSubstring(
+			"are served by your web host under 'https://oidc.example.com/acme', ROSA cannot publish its keys")))
+	})
+})
@@ -0,0 +1,36 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedules
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/schedules/rundue"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "schedules",
+	Short: "Evaluate machine pool scaling schedules",
+	Long: "Evaluate the machine pool scaling schedules created with 'rosa create machinepool-schedule'.\n\n" +
+		"Schedules are stored on this machine, so 'rosa schedules run-due' needs to be run periodically, " +
+		"for example every few minutes from cron or a CI pipeline, for them to take effect.",
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	Cmd.AddCommand(rundue.NewRunDueCommand())
+}
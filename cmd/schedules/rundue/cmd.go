@@ -0,0 +1,178 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rundue
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/machinepool"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/schedules"
+)
+
+const (
+	use   = "run-due"
+	short = "Apply the machine pool schedules that are due"
+	long  = "Apply the machine pool schedules that have an occurrence since they last ran. When several " +
+		"occurrences of the schedules of a machine pool were missed only the latest one is applied."
+	example = `  # Apply the schedules that are due
+  rosa schedules run-due
+
+  # Show the schedules that are due without changing any machine pool
+  rosa schedules run-due --dry-run
+
+  # Apply the schedules every five minutes from crontab
+  */5 * * * * rosa schedules run-due`
+)
+
+const (
+	statusApplied = "applied"
+	statusSkipped = "skipped"
+	statusDue     = "due"
+	statusFailed  = "failed"
+)
+
+type RunDueOptions struct {
+	dryRun bool
+}
+
+func NewRunDueCommand() *cobra.Command {
+	options := &RunDueOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCM(), RunDueRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(
+		&options.dryRun,
+		"dry-run",
+		false,
+		"List the schedules that are due without applying them.",
+	)
+	return cmd
+}
+
+type result struct {
+	schedule *schedules.Schedule
+	status   string
+	message  string
+}
+
+func RunDueRunner(options *RunDueOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		store, err := schedules.Load()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		occurrences := store.DueOccurrences(now)
+		statuses := map[*schedules.Schedule]string{}
+		results := make([]result, len(occurrences))
+		// Apply the most recent occurrence of each machine pool first, the superseded ones follow it
+		for i, occurrence := range occurrences {
+			schedule := occurrence.Schedule
+			switch {
+			case occurrence.Err != nil:
+				results[i] = result{schedule: schedule, status: statusFailed, message: occurrence.Err.Error()}
+			case occurrence.SupersededBy != nil:
+				continue
+			case options.dryRun:
+				results[i] = result{schedule: schedule, status: statusDue,
+					message: fmt.Sprintf("set to %s for %s", schedule.Size(), occurrence.Time.Format(time.RFC3339))}
+			default:
+				status, message := apply(r, schedule)
+				if status != statusFailed {
+					schedule.LastRun = &now
+				}
+				results[i] = result{schedule: schedule, status: status, message: message}
+			}
+			statuses[schedule] = results[i].status
+		}
+		for i, occurrence := range occurrences {
+			if occurrence.SupersededBy == nil {
+				continue
+			}
+			// Superseded schedules are done once the schedule replacing them was applied
+			if !options.dryRun && statuses[occurrence.SupersededBy] != statusFailed {
+				occurrence.Schedule.LastRun = &now
+			}
+			results[i] = result{schedule: occurrence.Schedule, status: statusSkipped,
+				message: fmt.Sprintf("superseded by schedule '%s'", occurrence.SupersededBy.Name)}
+		}
+
+		if len(results) == 0 {
+			r.Reporter.Infof("There are no machine pool schedules due")
+			return nil
+		}
+
+		if !options.dryRun {
+			err = store.Save()
+			if err != nil {
+				return err
+			}
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(writer, "SCHEDULE\tCLUSTER\tMACHINE POOL\tRESULT\tDETAILS\n")
+		failed := 0
+		for _, result := range results {
+			if result.status == statusFailed {
+				failed++
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", result.schedule.Name, result.schedule.ClusterName,
+				result.schedule.MachinePool, result.status, result.message)
+		}
+		writer.Flush()
+		if failed > 0 {
+			return fmt.Errorf("Failed to apply %d out of %d schedules that were due", failed, len(results))
+		}
+		return nil
+	}
+}
+
+// apply sets the machine pool of the schedule to the scheduled size. Failed schedules keep their
+// last run so that they are retried on the next evaluation.
+func apply(r *rosa.Runtime, schedule *schedules.Schedule) (string, string) {
+	cluster, err := r.OCMClient.GetCluster(schedule.ClusterID, nil)
+	if err != nil {
+		return statusFailed, err.Error()
+	}
+	target, err := machinepool.GetBulkTarget(r, cluster, schedule.MachinePool)
+	if err != nil {
+		return statusFailed, err.Error()
+	}
+	message, err := machinepool.ApplyScaling(r, cluster, target, schedule.Scaling())
+	if err != nil {
+		return statusFailed, err.Error()
+	}
+	if message == "" {
+		return statusSkipped, fmt.Sprintf("already at %s", schedule.Size())
+	}
+	return statusApplied, message
+}
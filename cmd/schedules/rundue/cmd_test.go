@@ -0,0 +1,108 @@
+package rundue
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/constants"
+	"github.com/openshift/rosa/pkg/schedules"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+func intPtr(value int) *int {
+	return &value
+}
+
+var _ = Describe("Run due schedules", func() {
+	var (
+		t    *test.TestingRuntime
+		path string
+	)
+
+	BeforeEach(func() {
+		t = test.NewTestRuntime()
+		path = filepath.Join(GinkgoT().TempDir(), "rosa-schedules.json")
+		os.Setenv(constants.RosaSchedulesFile, path)
+		DeferCleanup(os.Unsetenv, constants.RosaSchedulesFile)
+
+		now := time.Now().UTC()
+		store, err := schedules.LoadFrom(path)
+		Expect(err).ToNot(HaveOccurred())
+		// Both schedules of the 'gpu' machine pool are due, the every minute one occurred last
+		Expect(store.Add(&schedules.Schedule{
+			Name:        "gpu-every-minute",
+			ClusterID:   test.MockClusterID,
+			ClusterName: test.MockClusterName,
+			MachinePool: "gpu",
+			Cron:        "* * * * *",
+			Replicas:    intPtr(2),
+			CreatedAt:   now.Add(-time.Hour),
+		})).To(Succeed())
+		Expect(store.Add(&schedules.Schedule{
+			Name:        "gpu-new-year",
+			ClusterID:   test.MockClusterID,
+			ClusterName: test.MockClusterName,
+			MachinePool: "gpu",
+			Cron:        "0 0 1 1 *",
+			Replicas:    intPtr(0),
+			CreatedAt:   now.AddDate(-2, 0, 0),
+		})).To(Succeed())
+		Expect(store.Save()).To(Succeed())
+	})
+
+	run := func(options *RunDueOptions) (string, error) {
+		Expect(t.StdOutReader.Record()).To(Succeed())
+		err := RunDueRunner(options)(context.Background(), t.RosaRuntime, NewRunDueCommand(), []string{})
+		stdout, readErr := t.StdOutReader.Read()
+		Expect(readErr).ToNot(HaveOccurred())
+		return stdout, err
+	}
+
+	It("lists the due schedules without applying them in dry run", func() {
+		stdout, err := run(&RunDueOptions{dryRun: true})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(MatchRegexp(`gpu-every-minute\s+\S+\s+gpu\s+due\s+set to 2 replicas`))
+		Expect(stdout).To(MatchRegexp(`gpu-new-year\s+\S+\s+gpu\s+skipped\s+superseded by schedule ` +
+			`'gpu-every-minute'`))
+		Expect(t.ApiServer.ReceivedRequests()).To(BeEmpty())
+
+		store, err := schedules.LoadFrom(path)
+		Expect(err).ToNot(HaveOccurred())
+		for _, schedule := range store.Schedules {
+			Expect(schedule.LastRun).To(BeNil())
+		}
+	})
+
+	It("applies only the most recent occurrence of each machine pool", func() {
+		cluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.State(cmv1.ClusterStateReady)
+		})
+		machinePool, err := cmv1.NewMachinePool().ID("gpu").Replicas(0).Build()
+		Expect(err).ToNot(HaveOccurred())
+		t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatClusterList([]*cmv1.Cluster{cluster})))
+		t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatResource(machinePool)))
+		t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatResource(machinePool)))
+
+		stdout, err := run(&RunDueOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(MatchRegexp(`gpu-every-minute\s+\S+\s+gpu\s+applied\s+2 replicas`))
+		Expect(stdout).To(MatchRegexp(`gpu-new-year\s+\S+\s+gpu\s+skipped\s+superseded`))
+		requests := t.ApiServer.ReceivedRequests()
+		Expect(requests).To(HaveLen(3))
+		Expect(requests[2].Method).To(Equal(http.MethodPatch))
+
+		store, err := schedules.LoadFrom(path)
+		Expect(err).ToNot(HaveOccurred())
+		for _, schedule := range store.Schedules {
+			Expect(schedule.LastRun).ToNot(BeNil(), schedule.Name)
+		}
+	})
+})
@@ -0,0 +1,13 @@
+package rundue
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRunDue(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Run due schedules suite")
+}
@@ -0,0 +1,148 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/clusterhealth"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "cluster"
+	short = "Show the health of a cluster"
+	long  = "Show a health summary of a cluster, gathered from its state, limited support reasons, inflight " +
+		"checks, scheduled upgrades, machine or node pools, break glass credentials and add-ons. Each check " +
+		"gets a severity, and the health of the cluster is the worst of them.\n\n" +
+		"With '--all' one row is shown per cluster of the account, with the clusters needing the most " +
+		"attention first."
+	example = `  # Show the health of cluster "mycluster"
+  rosa status cluster -c mycluster
+
+  # Show the health of all the clusters
+  rosa status cluster --all`
+
+	// parallelism is the number of clusters whose health is fetched at a time with '--all'
+	parallelism = 5
+)
+
+type StatusClusterOptions struct {
+	all bool
+}
+
+func NewStatusClusterCommand() *cobra.Command {
+	options := &StatusClusterOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), StatusClusterRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	ocm.AddOptionalClusterFlag(cmd)
+	flags.BoolVar(
+		&options.all,
+		"all",
+		false,
+		"Show the health of all the clusters, one row per cluster.",
+	)
+	output.AddFlag(cmd)
+	return cmd
+}
+
+func StatusClusterRunner(options *StatusClusterOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, cmd *cobra.Command, _ []string) error {
+		clusterSet := cmd.Flags().Changed("cluster")
+		if options.all == clusterSet {
+			return fmt.Errorf("Either '--cluster' or '--all' is required")
+		}
+
+		if options.all {
+			clusters, err := r.OCMClient.GetAllClusters(r.Creator)
+			if err != nil {
+				return fmt.Errorf("Failed to get clusters: %v", err)
+			}
+			statuses := clusterhealth.GetAll(r.OCMClient, clusters, parallelism)
+			if output.HasFlag() {
+				return output.Print(statuses)
+			}
+			if len(statuses) == 0 {
+				r.Reporter.Infof("There are no clusters for this AWS account")
+				return nil
+			}
+			return printFleet(statuses)
+		}
+
+		status := clusterhealth.Get(r.OCMClient, r.FetchCluster())
+		if output.HasFlag() {
+			return output.Print(status)
+		}
+		return printStatus(status)
+	}
+}
+
+func printStatus(status *clusterhealth.Status) error {
+	fmt.Printf("Cluster:                    %s (%s)\n", status.Name, status.ClusterID)
+	fmt.Printf("State:                      %s\n", status.State)
+	fmt.Printf("Version:                    %s\n", status.Version)
+	fmt.Printf("Health:                     %s\n\n", status.Severity)
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprint(writer, "SEVERITY\tCHECK\tSUMMARY\n")
+	for _, check := range status.Checks {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", check.Severity, check.Name, check.Summary)
+		for _, detail := range check.Details {
+			fmt.Fprintf(writer, "\t\t  - %s\n", detail)
+		}
+	}
+	return writer.Flush()
+}
+
+func printFleet(statuses []*clusterhealth.Status) error {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprint(writer, "NAME\tID\tSTATE\tVERSION\tHEALTH\tISSUES\n")
+	for _, status := range statuses {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", status.Name, status.ClusterID, status.State,
+			status.Version, status.Severity, describeIssues(status))
+	}
+	return writer.Flush()
+}
+
+// describeIssues returns the summaries of the checks needing attention, or '-' when there are none
+func describeIssues(status *clusterhealth.Status) string {
+	summaries := []string{}
+	for _, issue := range status.Issues() {
+		summaries = append(summaries, issue.Summary)
+	}
+	if len(summaries) == 0 {
+		return "-"
+	}
+	return strings.Join(summaries, "; ")
+}
@@ -0,0 +1,26 @@
+package cluster
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/clusterhealth"
+)
+
+var _ = Describe("Status cluster", func() {
+	It("lists the summaries of the issues of the cluster", func() {
+		status := &clusterhealth.Status{
+			Checks: []clusterhealth.Check{
+				{Name: "state", Severity: clusterhealth.SeverityOK, Summary: "Cluster is ready"},
+				{Name: "upgrade", Severity: clusterhealth.SeverityInfo, Summary: "Upgrades available: 4.15.11"},
+				{Name: "limited support", Severity: clusterhealth.SeverityCritical, Summary: "Limited support"},
+				{Name: "add-ons", Severity: clusterhealth.SeverityWarning, Summary: "1 of 2 add-on(s) failed"},
+			},
+		}
+		Expect(describeIssues(status)).To(Equal("Limited support; 1 of 2 add-on(s) failed"))
+	})
+
+	It("shows a dash for healthy clusters", func() {
+		Expect(describeIssues(&clusterhealth.Status{})).To(Equal("-"))
+	})
+})
@@ -0,0 +1,105 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/info"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/supportbundle"
+)
+
+const (
+	use   = "bundle"
+	short = "Collect a support bundle of a cluster"
+	long  = "Collect the description, install logs, upgrade policies, limited support reasons, inflight " +
+		"checks, IAM roles and policies, OIDC configuration and network verification of a cluster into a " +
+		"timestamped tarball to attach to a Red Hat support case.\n\n" +
+		"Secrets and tokens are redacted from all the documents. The tarball contains a manifest listing " +
+		"the documents, and the ones that couldn't be collected along with the reason."
+	example = `  # Collect a support bundle of cluster "mycluster" in the current directory
+  rosa support bundle -c mycluster
+
+  # Collect it in another directory
+  rosa support bundle -c mycluster --dir /tmp/cases`
+)
+
+type SupportBundleOptions struct {
+	dir string
+}
+
+func NewSupportBundleCommand() *cobra.Command {
+	options := &SupportBundleOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), SupportBundleRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	ocm.AddClusterFlag(cmd)
+	flags.StringVar(
+		&options.dir,
+		"dir",
+		".",
+		"Directory to write the support bundle to.",
+	)
+	return cmd
+}
+
+func SupportBundleRunner(options *SupportBundleOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		cluster := r.FetchCluster()
+
+		r.Reporter.Infof("Collecting the support bundle of cluster '%s'", r.ClusterKey)
+		createdAt := time.Now()
+		bundle := supportbundle.Collect(r.OCMClient, r.AWSClient, cluster, info.DefaultVersion, createdAt)
+		for _, document := range bundle.Failed() {
+			r.Reporter.Warnf("Failed to collect %s: %s", document.Description, document.Error)
+		}
+
+		path := filepath.Join(options.dir, supportbundle.FileName(cluster, createdAt))
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("Failed to create support bundle: %v", err)
+		}
+		defer file.Close()
+		err = bundle.Write(file)
+		if err != nil {
+			return err
+		}
+		err = file.Close()
+		if err != nil {
+			return fmt.Errorf("Failed to write support bundle: %v", err)
+		}
+		r.Reporter.Infof("Wrote support bundle '%s' with %d of %d documents", path,
+			len(bundle.Manifest.Documents)-len(bundle.Failed()), len(bundle.Manifest.Documents))
+		return nil
+	}
+}
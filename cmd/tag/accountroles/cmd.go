@@ -0,0 +1,137 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accountroles
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tagging"
+)
+
+const (
+	use        = "account-roles"
+	prefixFlag = "prefix"
+
+	tagExample = `  # Tag the account roles of prefix 'ManagedOpenShift'
+  rosa tag account-roles --prefix ManagedOpenShift --tags "cost-center:1234,team:platform"
+
+  # Tag the account roles used by cluster 'mycluster'
+  rosa tag account-roles --cluster mycluster --tags "cost-center:1234"`
+	untagExample = `  # Remove the 'team' tag from the account roles of prefix 'ManagedOpenShift'
+  rosa untag account-roles --prefix ManagedOpenShift --tag-keys team`
+)
+
+var aliases = []string{"accountroles", "account-role"}
+
+type AccountRolesOptions struct {
+	prefix  string
+	tags    []string
+	tagKeys []string
+}
+
+func NewTagAccountRolesCommand() *cobra.Command {
+	options := &AccountRolesOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   "Tag account roles",
+		Long: "Set tags on the classic and hosted control plane account roles of a prefix, or on the account " +
+			"roles used by a cluster. Account roles can be shared by several clusters.",
+		Example: tagExample,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), TagAccountRolesRunner(options)),
+	}
+	addFlags(cmd, options)
+	tagging.AddTagsFlag(cmd.Flags(), &options.tags)
+	cmd.MarkFlagRequired(tagging.TagsFlag)
+	return cmd
+}
+
+func NewUntagAccountRolesCommand() *cobra.Command {
+	options := &AccountRolesOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   "Untag account roles",
+		Long: "Remove tags from the classic and hosted control plane account roles of a prefix, or from the " +
+			"account roles used by a cluster.",
+		Example: untagExample,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), UntagAccountRolesRunner(options)),
+	}
+	addFlags(cmd, options)
+	tagging.AddTagKeysFlag(cmd.Flags(), &options.tagKeys)
+	cmd.MarkFlagRequired(tagging.TagKeysFlag)
+	return cmd
+}
+
+func addFlags(cmd *cobra.Command, options *AccountRolesOptions) {
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	flags.StringVar(
+		&options.prefix,
+		prefixFlag,
+		"",
+		"Prefix of the account roles.",
+	)
+	ocm.AddOptionalClusterFlag(cmd)
+}
+
+func TagAccountRolesRunner(options *AccountRolesOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, cmd *cobra.Command, _ []string) error {
+		tagList, err := tagging.ParseTags(options.tags)
+		if err != nil {
+			return err
+		}
+		resources, err := findAccountRoles(r, cmd, options)
+		if err != nil {
+			return err
+		}
+		return tagging.TagAll(r, resources, tagList)
+	}
+}
+
+func UntagAccountRolesRunner(options *AccountRolesOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, cmd *cobra.Command, _ []string) error {
+		resources, err := findAccountRoles(r, cmd, options)
+		if err != nil {
+			return err
+		}
+		return tagging.UntagAll(r, resources, options.tagKeys)
+	}
+}
+
+func findAccountRoles(r *rosa.Runtime, cmd *cobra.Command, options *AccountRolesOptions) (
+	[]tagging.Resource, error) {
+	byCluster := cmd.Flags().Changed("cluster")
+	if byCluster == (options.prefix != "") {
+		return nil, fmt.Errorf("Either '--%s' or '--cluster' must be specified", prefixFlag)
+	}
+	if byCluster {
+		cluster := r.FetchCluster()
+		if cluster.AWS().STS().RoleARN() == "" {
+			return nil, fmt.Errorf("Cluster '%s' is not an STS cluster, it has no account roles", r.ClusterKey)
+		}
+		return tagging.ClusterAccountRoles(cluster)
+	}
+	return tagging.AccountRolesByPrefix(r.AWSClient, options.prefix)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tag
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/tag/accountroles"
+	"github.com/openshift/rosa/cmd/tag/iamresources"
+	"github.com/openshift/rosa/cmd/tag/machinepool"
+	"github.com/openshift/rosa/cmd/tag/oidcconfig"
+	"github.com/openshift/rosa/cmd/tag/oidcprovider"
+	"github.com/openshift/rosa/cmd/tag/operatorroles"
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Tag AWS resources managed by ROSA",
+	Long:  "Set tags on the AWS resources managed by ROSA after they were created",
+	Args:  cobra.NoArgs,
+}
+
+func init() {
+	Cmd.AddCommand(accountroles.NewTagAccountRolesCommand())
+	Cmd.AddCommand(operatorroles.NewTagOperatorRolesCommand())
+	Cmd.AddCommand(oidcprovider.NewTagOidcProviderCommand())
+	Cmd.AddCommand(oidcconfig.NewTagOidcConfigCommand())
+	Cmd.AddCommand(machinepool.NewTagMachinePoolCommand())
+	Cmd.AddCommand(iamresources.NewTagIAMResourcesCommand())
+
+	flags := Cmd.PersistentFlags()
+	arguments.AddProfileFlag(flags)
+	arguments.AddRegionFlag(flags)
+	confirm.AddFlag(flags)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iamresources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tagging"
+)
+
+const (
+	use                      = "iam-resources"
+	propagateFromClusterFlag = "propagate-from-cluster"
+
+	tagExample = `  # Copy the tags of cluster 'mycluster' onto its account roles, operator roles and OIDC provider
+  rosa tag iam-resources --cluster mycluster --propagate-from-cluster
+
+  # Set a tag on all the IAM resources of cluster 'mycluster'
+  rosa tag iam-resources --cluster mycluster --tags "cost-center:1234"`
+	untagExample = `  # Remove the 'team' tag from all the IAM resources of cluster 'mycluster'
+  rosa untag iam-resources --cluster mycluster --tag-keys team`
+)
+
+var aliases = []string{"iamresources", "iam-resource"}
+
+type IAMResourcesOptions struct {
+	propagateFromCluster bool
+	tags                 []string
+	tagKeys              []string
+}
+
+func NewTagIAMResourcesCommand() *cobra.Command {
+	options := &IAMResourcesOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   "Tag the IAM resources of a cluster",
+		Long: "Set tags on the account roles, operator roles and OIDC provider of an STS cluster. With " +
+			"'--propagate-from-cluster', the tags the cluster was created with are copied onto them, " +
+			"the tags of '--tags' taking precedence. Account roles can be shared by several clusters.",
+		Example: tagExample,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), TagIAMResourcesRunner(options)),
+	}
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	ocm.AddClusterFlag(cmd)
+	tagging.AddTagsFlag(flags, &options.tags)
+	flags.BoolVar(
+		&options.propagateFromCluster,
+		propagateFromClusterFlag,
+		false,
+		"Copy the tags of the cluster onto its IAM resources.",
+	)
+	return cmd
+}
+
+func NewUntagIAMResourcesCommand() *cobra.Command {
+	options := &IAMResourcesOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   "Untag the IAM resources of a cluster",
+		Long:    "Remove tags from the account roles, operator roles and OIDC provider of an STS cluster.",
+		Example: untagExample,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), UntagIAMResourcesRunner(options)),
+	}
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	ocm.AddClusterFlag(cmd)
+	tagging.AddTagKeysFlag(flags, &options.tagKeys)
+	cmd.MarkFlagRequired(tagging.TagKeysFlag)
+	return cmd
+}
+
+func TagIAMResourcesRunner(options *IAMResourcesOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		if !options.propagateFromCluster && len(options.tags) == 0 {
+			return fmt.Errorf("Either '--%s' or '--%s' must be specified", tagging.TagsFlag,
+				propagateFromClusterFlag)
+		}
+		userTags, err := tagging.ParseTags(options.tags)
+		if err != nil {
+			return err
+		}
+		cluster := r.FetchCluster()
+		tagList := map[string]string{}
+		if options.propagateFromCluster {
+			tagList = tagging.ClusterTags(cluster)
+			if len(tagList) == 0 && len(userTags) == 0 {
+				r.Reporter.Infof("Cluster '%s' has no tags to propagate", r.ClusterKey)
+				return nil
+			}
+		}
+		for key, value := range userTags {
+			tagList[key] = value
+		}
+		resources, err := tagging.ClusterIAMResources(r.AWSClient, cluster)
+		if err != nil {
+			return err
+		}
+		return tagging.TagAll(r, resources, tagList)
+	}
+}
+
+func UntagIAMResourcesRunner(options *IAMResourcesOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		resources, err := tagging.ClusterIAMResources(r.AWSClient, r.FetchCluster())
+		if err != nil {
+			return err
+		}
+		return tagging.UntagAll(r, resources, options.tagKeys)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tagging"
+)
+
+const (
+	use             = "machinepool"
+	machinePoolFlag = "machinepool"
+
+	tagExample = `  # Set cost allocation tags on machine pool 'workers' of cluster 'mycluster'
+  rosa tag machinepool --cluster mycluster --machinepool workers --tags "cost-center:1234"`
+	untagExample = `  # Remove the 'cost-center' tag from machine pool 'workers' of cluster 'mycluster'
+  rosa untag machinepool --cluster mycluster --machinepool workers --tag-keys cost-center`
+)
+
+var aliases = []string{"machinepools", "machine-pool", "machine-pools"}
+
+type MachinePoolOptions struct {
+	machinePool string
+	tags        []string
+	tagKeys     []string
+}
+
+func NewTagMachinePoolCommand() *cobra.Command {
+	options := &MachinePoolOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   "Tag machine pool",
+		Long: "Set the cost allocation tags OCM applies to the instances of a machine pool. OCM refuses " +
+			"the change for the machine pools whose tags can't be changed after creation.",
+		Example: tagExample,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCM(), TagMachinePoolRunner(options)),
+	}
+	addFlags(cmd, options)
+	tagging.AddTagsFlag(cmd.Flags(), &options.tags)
+	cmd.MarkFlagRequired(tagging.TagsFlag)
+	return cmd
+}
+
+func NewUntagMachinePoolCommand() *cobra.Command {
+	options := &MachinePoolOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   "Untag machine pool",
+		Long: "Remove cost allocation tags from a machine pool. OCM refuses the change for the machine " +
+			"pools whose tags can't be changed after creation.",
+		Example: untagExample,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCM(), UntagMachinePoolRunner(options)),
+	}
+	addFlags(cmd, options)
+	tagging.AddTagKeysFlag(cmd.Flags(), &options.tagKeys)
+	cmd.MarkFlagRequired(tagging.TagKeysFlag)
+	return cmd
+}
+
+func addFlags(cmd *cobra.Command, options *MachinePoolOptions) {
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	ocm.AddClusterFlag(cmd)
+	flags.StringVar(
+		&options.machinePool,
+		machinePoolFlag,
+		"",
+		"Name of the machine pool (required).",
+	)
+	cmd.MarkFlagRequired(machinePoolFlag)
+}
+
+func TagMachinePoolRunner(options *MachinePoolOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		tagList, err := tagging.ParseTags(options.tags)
+		if err != nil {
+			return err
+		}
+		return tagging.TagMachinePool(r, r.FetchCluster(), options.machinePool, tagList)
+	}
+}
+
+func UntagMachinePoolRunner(options *MachinePoolOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		return tagging.UntagMachinePool(r, r.FetchCluster(), options.machinePool, options.tagKeys)
+	}
+}
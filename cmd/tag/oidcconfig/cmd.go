@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidcconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tagging"
+)
+
+const (
+	use              = "oidc-config"
+	oidcConfigIdFlag = "oidc-config-id"
+
+	tagExample = `  # Tag the S3 bucket and the secret of an unmanaged OIDC config
+  rosa tag oidc-config --oidc-config-id <oidc_config_id> --tags "cost-center:1234,team:platform"`
+	untagExample = `  # Remove the 'team' tag from the S3 bucket and the secret of an unmanaged OIDC config
+  rosa untag oidc-config --oidc-config-id <oidc_config_id> --tag-keys team`
+)
+
+var aliases = []string{"oidcconfig"}
+
+type OidcConfigOptions struct {
+	oidcConfigId string
+	tags         []string
+	tagKeys      []string
+}
+
+func NewTagOidcConfigCommand() *cobra.Command {
+	options := &OidcConfigOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   "Tag OIDC config",
+		Long: "Set tags on the S3 bucket serving the discovery documents and on the secret holding the " +
+			"private key of an unmanaged OIDC config.",
+		Example: tagExample,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), TagOidcConfigRunner(options)),
+	}
+	addFlags(cmd, options)
+	tagging.AddTagsFlag(cmd.Flags(), &options.tags)
+	cmd.MarkFlagRequired(tagging.TagsFlag)
+	return cmd
+}
+
+func NewUntagOidcConfigCommand() *cobra.Command {
+	options := &OidcConfigOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   "Untag OIDC config",
+		Long: "Remove tags from the S3 bucket serving the discovery documents and from the secret holding " +
+			"the private key of an unmanaged OIDC config.",
+		Example: untagExample,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), UntagOidcConfigRunner(options)),
+	}
+	addFlags(cmd, options)
+	tagging.AddTagKeysFlag(cmd.Flags(), &options.tagKeys)
+	cmd.MarkFlagRequired(tagging.TagKeysFlag)
+	return cmd
+}
+
+func addFlags(cmd *cobra.Command, options *OidcConfigOptions) {
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	flags.StringVar(
+		&options.oidcConfigId,
+		oidcConfigIdFlag,
+		"",
+		"Registered OIDC configuration ID whose resources to change (required).",
+	)
+	cmd.MarkFlagRequired(oidcConfigIdFlag)
+}
+
+func TagOidcConfigRunner(options *OidcConfigOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		tagList, err := tagging.ParseTags(options.tags)
+		if err != nil {
+			return err
+		}
+		resources, err := findOidcConfigResources(r, options)
+		if err != nil {
+			return err
+		}
+		return tagging.TagAll(r, resources, tagList)
+	}
+}
+
+func UntagOidcConfigRunner(options *OidcConfigOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		resources, err := findOidcConfigResources(r, options)
+		if err != nil {
+			return err
+		}
+		return tagging.UntagAll(r, resources, options.tagKeys)
+	}
+}
+
+func findOidcConfigResources(r *rosa.Runtime, options *OidcConfigOptions) ([]tagging.Resource, error) {
+	oidcConfig, err := r.OCMClient.GetOidcConfig(options.oidcConfigId)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get OIDC config '%s': %v", options.oidcConfigId, err)
+	}
+	return tagging.OidcConfigStorage(oidcConfig, r.AWSClient.GetRegion())
+}
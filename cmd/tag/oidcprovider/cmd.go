@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidcprovider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tagging"
+)
+
+const (
+	use              = "oidc-provider"
+	oidcConfigIdFlag = "oidc-config-id"
+
+	tagExample = `  # Tag the OIDC provider of cluster 'mycluster'
+  rosa tag oidc-provider --cluster mycluster --tags "cost-center:1234,team:platform"
+
+  # Tag the OIDC provider of an OIDC config
+  rosa tag oidc-provider --oidc-config-id <oidc_config_id> --tags "cost-center:1234"`
+	untagExample = `  # Remove the 'team' tag from the OIDC provider of cluster 'mycluster'
+  rosa untag oidc-provider --cluster mycluster --tag-keys team`
+)
+
+var aliases = []string{"oidcprovider"}
+
+type OidcProviderOptions struct {
+	oidcConfigId string
+	tags         []string
+	tagKeys      []string
+}
+
+func NewTagOidcProviderCommand() *cobra.Command {
+	options := &OidcProviderOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   "Tag OIDC provider",
+		Long:    "Set tags on the OIDC provider of a cluster or of an OIDC config.",
+		Example: tagExample,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), TagOidcProviderRunner(options)),
+	}
+	addFlags(cmd, options)
+	tagging.AddTagsFlag(cmd.Flags(), &options.tags)
+	cmd.MarkFlagRequired(tagging.TagsFlag)
+	return cmd
+}
+
+func NewUntagOidcProviderCommand() *cobra.Command {
+	options := &OidcProviderOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   "Untag OIDC provider",
+		Long:    "Remove tags from the OIDC provider of a cluster or of an OIDC config.",
+		Example: untagExample,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), UntagOidcProviderRunner(options)),
+	}
+	addFlags(cmd, options)
+	tagging.AddTagKeysFlag(cmd.Flags(), &options.tagKeys)
+	cmd.MarkFlagRequired(tagging.TagKeysFlag)
+	return cmd
+}
+
+func addFlags(cmd *cobra.Command, options *OidcProviderOptions) {
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	flags.StringVar(
+		&options.oidcConfigId,
+		oidcConfigIdFlag,
+		"",
+		"Registered OIDC configuration ID whose provider to change.",
+	)
+	ocm.AddOptionalClusterFlag(cmd)
+}
+
+func TagOidcProviderRunner(options *OidcProviderOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, cmd *cobra.Command, _ []string) error {
+		tagList, err := tagging.ParseTags(options.tags)
+		if err != nil {
+			return err
+		}
+		resources, err := findOidcProvider(r, cmd, options)
+		if err != nil {
+			return err
+		}
+		return tagging.TagAll(r, resources, tagList)
+	}
+}
+
+func UntagOidcProviderRunner(options *OidcProviderOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, cmd *cobra.Command, _ []string) error {
+		resources, err := findOidcProvider(r, cmd, options)
+		if err != nil {
+			return err
+		}
+		return tagging.UntagAll(r, resources, options.tagKeys)
+	}
+}
+
+func findOidcProvider(r *rosa.Runtime, cmd *cobra.Command, options *OidcProviderOptions) (
+	[]tagging.Resource, error) {
+	byCluster := cmd.Flags().Changed("cluster")
+	if byCluster == (options.oidcConfigId != "") {
+		return nil, fmt.Errorf("Either '--%s' or '--cluster' must be specified", oidcConfigIdFlag)
+	}
+	if byCluster {
+		cluster := r.FetchCluster()
+		if cluster.AWS().STS().RoleARN() == "" {
+			return nil, fmt.Errorf("Cluster '%s' is not an STS cluster, it has no OIDC provider", r.ClusterKey)
+		}
+		return tagging.OidcProvider(r.AWSClient, cluster.AWS().STS().OIDCEndpointURL())
+	}
+	oidcConfig, err := r.OCMClient.GetOidcConfig(options.oidcConfigId)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get OIDC config '%s': %v", options.oidcConfigId, err)
+	}
+	return tagging.OidcProvider(r.AWSClient, oidcConfig.IssuerUrl())
+}
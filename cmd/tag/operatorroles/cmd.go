@@ -0,0 +1,147 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatorroles
+
+import (
+	"context"
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tagging"
+)
+
+const (
+	use        = "operator-roles"
+	prefixFlag = "prefix"
+
+	tagExample = `  # Tag the operator roles of cluster 'mycluster'
+  rosa tag operator-roles --cluster mycluster --tags "cost-center:1234,team:platform"
+
+  # Tag the operator roles of prefix 'mycluster-x1y2'
+  rosa tag operator-roles --prefix mycluster-x1y2 --tags "cost-center:1234"`
+	untagExample = `  # Remove the 'team' tag from the operator roles of cluster 'mycluster'
+  rosa untag operator-roles --cluster mycluster --tag-keys team`
+)
+
+var aliases = []string{"operatorroles", "operator-role"}
+
+type OperatorRolesOptions struct {
+	prefix  string
+	tags    []string
+	tagKeys []string
+}
+
+func NewTagOperatorRolesCommand() *cobra.Command {
+	options := &OperatorRolesOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   "Tag operator roles",
+		Long:    "Set tags on the operator roles of a cluster, or on the operator roles of a prefix.",
+		Example: tagExample,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), TagOperatorRolesRunner(options)),
+	}
+	addFlags(cmd, options)
+	tagging.AddTagsFlag(cmd.Flags(), &options.tags)
+	cmd.MarkFlagRequired(tagging.TagsFlag)
+	return cmd
+}
+
+func NewUntagOperatorRolesCommand() *cobra.Command {
+	options := &OperatorRolesOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   "Untag operator roles",
+		Long:    "Remove tags from the operator roles of a cluster, or from the operator roles of a prefix.",
+		Example: untagExample,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), UntagOperatorRolesRunner(options)),
+	}
+	addFlags(cmd, options)
+	tagging.AddTagKeysFlag(cmd.Flags(), &options.tagKeys)
+	cmd.MarkFlagRequired(tagging.TagKeysFlag)
+	return cmd
+}
+
+func addFlags(cmd *cobra.Command, options *OperatorRolesOptions) {
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	flags.StringVar(
+		&options.prefix,
+		prefixFlag,
+		"",
+		"Prefix of the operator roles.",
+	)
+	ocm.AddOptionalClusterFlag(cmd)
+}
+
+func TagOperatorRolesRunner(options *OperatorRolesOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, cmd *cobra.Command, _ []string) error {
+		tagList, err := tagging.ParseTags(options.tags)
+		if err != nil {
+			return err
+		}
+		resources, err := findOperatorRoles(r, cmd, options)
+		if err != nil {
+			return err
+		}
+		return tagging.TagAll(r, resources, tagList)
+	}
+}
+
+func UntagOperatorRolesRunner(options *OperatorRolesOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, cmd *cobra.Command, _ []string) error {
+		resources, err := findOperatorRoles(r, cmd, options)
+		if err != nil {
+			return err
+		}
+		return tagging.UntagAll(r, resources, options.tagKeys)
+	}
+}
+
+func findOperatorRoles(r *rosa.Runtime, cmd *cobra.Command, options *OperatorRolesOptions) (
+	[]tagging.Resource, error) {
+	byCluster := cmd.Flags().Changed("cluster")
+	if byCluster == (options.prefix != "") {
+		return nil, fmt.Errorf("Either '--%s' or '--cluster' must be specified", prefixFlag)
+	}
+	if byCluster {
+		cluster := r.FetchCluster()
+		if cluster.AWS().STS().RoleARN() == "" {
+			return nil, fmt.Errorf("Cluster '%s' is not an STS cluster, it has no operator roles", r.ClusterKey)
+		}
+		return tagging.ClusterOperatorRoles(cluster)
+	}
+	// The prefix doesn't tell the topology of the cluster, so roles of both are looked for
+	credRequests := map[string]*cmv1.STSOperator{}
+	for _, hostedCP := range []bool{false, true} {
+		topologyCredRequests, err := r.OCMClient.GetCredRequests(hostedCP)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting operator credential request from OCM: %v", err)
+		}
+		for name, credRequest := range topologyCredRequests {
+			credRequests[name] = credRequest
+		}
+	}
+	return tagging.OperatorRolesByPrefix(r.AWSClient, options.prefix, credRequests)
+}
@@ -21,6 +21,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 
 	commonUtils "github.com/openshift-online/ocm-common/pkg/utils"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -33,7 +34,9 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/upgradeprecheck"
 )
 
 var args struct {
@@ -44,6 +47,7 @@ var args struct {
 	controlPlane             bool
 	schedule                 string
 	allowMinorVersionUpdates bool
+	precheckOnly             bool
 }
 
 var nodeDrainOptions = []string{
@@ -64,7 +68,10 @@ var Cmd = &cobra.Command{
   rosa upgrade cluster --cluster=mycluster --interactive
 
   # Schedule a cluster upgrade within the hour
-  rosa upgrade cluster -c mycluster --version 4.12.20`,
+  rosa upgrade cluster -c mycluster --version 4.12.20
+
+  # Check what blocks the upgrade of the cluster named "mycluster" without scheduling it
+  rosa upgrade cluster -c mycluster --version 4.12.20 --precheck-only`,
 	Run:  run,
 	Args: cobra.NoArgs,
 }
@@ -135,6 +142,15 @@ func init() {
 		"For Hosted Control Plane, whether the upgrade should cover only the control plane",
 	)
 
+	flags.BoolVar(
+		&args.precheckOnly,
+		"precheck-only",
+		false,
+		"Report what blocks the upgrade of the cluster without scheduling it. Exits with an error when "+
+			"there are blockers.",
+	)
+
+	output.AddFlag(Cmd)
 	confirm.AddFlag(flags)
 }
 
@@ -160,6 +176,13 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 	}
 	isHypershift := cluster.Hypershift().Enabled()
 
+	if output.HasFlag() && !args.precheckOnly {
+		return fmt.Errorf("The '--output' option is only supported with '--precheck-only'")
+	}
+	if args.precheckOnly {
+		return runPrecheck(r, cluster, clusterKey)
+	}
+
 	// Check parameters preconditions
 	if args.controlPlane && !isHypershift {
 		return fmt.Errorf("The '--control-plane' option is only supported for Hosted Control Planes")
@@ -646,3 +669,56 @@ func checkGates(r *rosa.Runtime, cluster *cmv1.Cluster, gates []*cmv1.VersionGat
 	}
 	return nil
 }
+
+// runPrecheck reports what blocks the upgrade of the cluster, failing when there are blockers
+func runPrecheck(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string) error {
+	report, err := upgradeprecheck.Run(r.OCMClient, r.AWSClient, &upgradeprecheck.Input{
+		Cluster:       cluster,
+		TargetVersion: args.version,
+		Creator:       r.Creator,
+	})
+	if err != nil {
+		return err
+	}
+	if output.HasFlag() {
+		err = output.Print(report)
+	} else {
+		err = printPrecheck(report)
+	}
+	if err != nil {
+		return err
+	}
+	blockers := report.Blockers()
+	if len(blockers) > 0 {
+		return fmt.Errorf("Upgrade of cluster '%s' has %d blocker(s)", clusterKey, len(blockers))
+	}
+	if !output.HasFlag() {
+		r.Reporter.Infof("No blockers found for the upgrade of cluster '%s'", clusterKey)
+	}
+	return nil
+}
+
+func printPrecheck(report *upgradeprecheck.Report) error {
+	targetVersion := report.TargetVersion
+	if targetVersion == "" {
+		targetVersion = "-"
+	}
+	availableUpgrades := "-"
+	if len(report.AvailableUpgrades) > 0 {
+		availableUpgrades = strings.Join(report.AvailableUpgrades, ", ")
+	}
+	fmt.Printf("Cluster:                    %s (%s)\n", report.ClusterName, report.ClusterID)
+	fmt.Printf("Current version:            %s\n", report.CurrentVersion)
+	fmt.Printf("Target version:             %s\n", targetVersion)
+	fmt.Printf("Available upgrades:         %s\n\n", availableUpgrades)
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprint(writer, "SEVERITY\tCHECK\tMESSAGE\n")
+	for _, finding := range report.Findings {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", finding.Severity, finding.Check, finding.Message)
+		for _, detail := range finding.Details {
+			fmt.Fprintf(writer, "\t\t  - %s\n", detail)
+		}
+	}
+	return writer.Flush()
+}
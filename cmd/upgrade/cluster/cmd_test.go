@@ -12,6 +12,7 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	. "github.com/openshift-online/ocm-sdk-go/testing"
 
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/test"
 )
 
@@ -293,6 +294,15 @@ var _ = Describe("Upgrade", Ordered, func() {
 		Expect(err.Error()).To(
 			ContainSubstring("node-drain-grace-period flag is not supported to hosted clusters"))
 	})
+	It("Fails if output is used without precheck-only", func() {
+		output.SetOutput("json")
+		defer output.SetOutput("")
+		args.precheckOnly = false
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReadyWithUpdates))
+		err := runWithRuntime(testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("The '--output' option is only supported with '--precheck-only'"))
+	})
 })
 
 func formatControlPlaneUpgradePolicyList(upgradePolicies []*cmv1.ControlPlaneUpgradePolicy) string {
@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"fmt"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/machinepool"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// upgradeMachinePools schedules the same upgrade on every machine pool matching the selector.
+// Machine pools that already have an upgrade scheduled, or for which the requested version is
+// not an available upgrade, are skipped.
+func upgradeMachinePools(r *rosa.Runtime, cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster,
+	scheduling ocm.UpgradeScheduling, version string) error {
+	if interactive.Enabled() {
+		return fmt.Errorf("Interactive mode is not supported when upgrading several machine pools")
+	}
+	if scheduling.AutomaticUpgrades {
+		schedule, err := interactive.BuildAutomaticUpgradeSchedule(cmd, scheduling.Schedule)
+		if err != nil {
+			return err
+		}
+		scheduling.Schedule = schedule
+	} else {
+		if version == "" {
+			return fmt.Errorf("The '--version' option is required when upgrading several machine pools " +
+				"without '--schedule'")
+		}
+		if (scheduling.ScheduleDate == "") != (scheduling.ScheduleTime == "") {
+			return fmt.Errorf("The '--schedule-date' and '--schedule-time' options must be used together")
+		}
+		nextRun, err := interactive.BuildManualUpgradeSchedule(cmd, scheduling.ScheduleDate, scheduling.ScheduleTime)
+		if err != nil {
+			return err
+		}
+		scheduling.NextRun = nextRun
+		version = ocm.GetRawVersionId(version)
+	}
+
+	targets, err := machinepool.SelectMachinePools(r, cluster, clusterKey, &args.bulk)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		r.Reporter.Warnf("No machine pools on cluster '%s' match the selector", clusterKey)
+		return nil
+	}
+
+	ids := machinepool.BulkTargetIDs(targets)
+	question := fmt.Sprintf("upgrade %d machine pools (%s) on cluster '%s' to version '%s'",
+		len(ids), strings.Join(ids, ", "), clusterKey, version)
+	if scheduling.AutomaticUpgrades {
+		question = fmt.Sprintf("schedule automatic upgrades for %d machine pools (%s) on cluster '%s' at '%s'",
+			len(ids), strings.Join(ids, ", "), clusterKey, scheduling.Schedule)
+	}
+	if !confirm.Confirm("%s", question) {
+		return nil
+	}
+
+	results := machinepool.RunBulkOperation(targets, args.bulk.Parallelism,
+		func(target *machinepool.BulkTarget) machinepool.BulkResult {
+			return scheduleNodePoolUpgrade(r, cluster, clusterKey, target.NodePool, scheduling, version)
+		})
+	if err := machinepool.PrintBulkResults(results); err != nil {
+		return fmt.Errorf("Failed to schedule machine pool upgrades on cluster '%s': %v", clusterKey, err)
+	}
+	return nil
+}
+
+func scheduleNodePoolUpgrade(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string, nodePool *cmv1.NodePool,
+	scheduling ocm.UpgradeScheduling, version string) machinepool.BulkResult {
+	_, scheduledUpgrade, err := r.OCMClient.GetHypershiftNodePoolUpgrade(cluster.ID(), clusterKey, nodePool.ID())
+	if err != nil {
+		return machinepool.BulkResult{Status: machinepool.BulkStatusFailed, Message: err.Error()}
+	}
+	if scheduledUpgrade != nil {
+		return machinepool.BulkResult{
+			Status: machinepool.BulkStatusSkipped,
+			Message: fmt.Sprintf("%s upgrade to version %s already exists", scheduledUpgrade.State().Value(),
+				scheduledUpgrade.Version()),
+		}
+	}
+
+	if !scheduling.AutomaticUpgrades {
+		if nodePool.Version().RawID() == version {
+			return machinepool.BulkResult{
+				Status:  machinepool.BulkStatusSkipped,
+				Message: fmt.Sprintf("already at version %s", version),
+			}
+		}
+		if !helper.Contains(ocm.GetNodePoolAvailableUpgrades(nodePool), version) {
+			return machinepool.BulkResult{
+				Status: machinepool.BulkStatusSkipped,
+				Message: fmt.Sprintf("version %s is not an available upgrade from %s", version,
+					nodePool.Version().RawID()),
+			}
+		}
+	}
+
+	upgradePolicy, err := r.OCMClient.BuildNodeUpgradePolicy(version, nodePool.ID(), scheduling)
+	if err != nil {
+		return machinepool.BulkResult{Status: machinepool.BulkStatusFailed, Message: err.Error()}
+	}
+	_, err = r.OCMClient.ScheduleNodePoolUpgrade(cluster.ID(), nodePool.ID(), upgradePolicy)
+	if err != nil {
+		return machinepool.BulkResult{Status: machinepool.BulkStatusFailed, Message: err.Error()}
+	}
+	if scheduling.AutomaticUpgrades {
+		return machinepool.BulkResult{Status: machinepool.BulkStatusSucceeded, Message: "automatic upgrades scheduled"}
+	}
+	return machinepool.BulkResult{
+		Status:  machinepool.BulkStatusSucceeded,
+		Message: fmt.Sprintf("upgrade to %s scheduled", version),
+	}
+}
@@ -38,6 +38,7 @@ var args struct {
 	scheduleTime             string
 	schedule                 string
 	allowMinorVersionUpdates bool
+	bulk                     machinepool.BulkOptions
 }
 
 var Cmd = &cobra.Command{
@@ -49,7 +50,10 @@ var Cmd = &cobra.Command{
   rosa upgrade machinepool np1 --cluster=mycluster --interactive
 
   # Schedule a machinepool upgrade within the hour
-  rosa upgrade machinepool np1 -c mycluster --version 4.12.20`,
+  rosa upgrade machinepool np1 -c mycluster --version 4.12.20
+
+  # Schedule an upgrade of every machinepool of the cluster named "mycluster" within the hour
+  rosa upgrade machinepools -c mycluster --all --version 4.12.20`,
 	Run:  run,
 	Args: machinepool.NewMachinepoolArgsFunction(false),
 }
@@ -100,6 +104,8 @@ func init() {
 	// Hidden for now as not supported yet
 	flags.MarkHidden("allow-minor-version-updates")
 
+	machinepool.AddBulkFlags(flags, &args.bulk)
+
 	confirm.AddFlag(flags)
 	interactive.AddFlag(flags)
 }
@@ -117,7 +123,6 @@ func run(cmd *cobra.Command, argv []string) {
 func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 	clusterKey := r.GetClusterKey()
 	cluster := r.FetchCluster()
-	machinePoolID := argv[0]
 	var err error
 	currentUpgradeScheduling := ocm.UpgradeScheduling{
 		Schedule:                 args.schedule,
@@ -149,6 +154,17 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 		return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
 	}
 
+	if args.bulk.IsBulk() {
+		if err = args.bulk.Validate(argv); err != nil {
+			return err
+		}
+		return upgradeMachinePools(r, cmd, clusterKey, cluster, currentUpgradeScheduling, args.version)
+	}
+	if len(argv) != 1 {
+		return machinepool.ErrMissingMachinePoolIdentifier
+	}
+	machinePoolID := argv[0]
+
 	if !machinepool.MachinePoolKeyRE.MatchString(machinePoolID) {
 		return fmt.Errorf("Expected a valid identifier for the machine pool")
 	}
@@ -41,6 +41,7 @@ import (
 	"github.com/openshift/rosa/pkg/ocm"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/upgradeplan"
 )
 
 var args struct {
@@ -49,6 +50,9 @@ var args struct {
 	clusterUpgradeVersion       string
 	policyUpgradeversion        string
 	channelGroup                string
+	plan                        bool
+	planFile                    string
+	applyPlan                   string
 }
 
 var Cmd = &cobra.Command{
@@ -57,7 +61,13 @@ var Cmd = &cobra.Command{
 	Short:   "Upgrade cluster-specific IAM roles to the latest version.",
 	Long:    "Upgrade cluster-specific IAM roles to the latest version before upgrading your cluster.",
 	Example: `  # Upgrade cluster roles for ROSA STS clusters
-		rosa upgrade roles -c <cluster_key>`,
+		rosa upgrade roles -c <cluster_key>
+
+  # List the changes to the roles for the upgrade and save them for review
+		rosa upgrade roles -c <cluster_key> --cluster-version 4.15.3 --plan --plan-file plan.json
+
+  # Apply the reviewed changes
+		rosa upgrade roles -c <cluster_key> --cluster-version 4.15.3 --apply-plan plan.json`,
 	Args: cobra.MaximumNArgs(2),
 	Run:  run,
 }
@@ -66,6 +76,9 @@ const (
 	clusterVersionFlag = "cluster-version"
 	policyVersionFlag  = "policy-version"
 	channelGroupFlag   = "channel-group"
+	planFlag           = "plan"
+	planFileFlag       = "plan-file"
+	applyPlanFlag      = "apply-plan"
 
 	ArbitraryPolicyNotAvail = "STS arbitrary policies feature is currently not available"
 )
@@ -101,6 +114,28 @@ func init() {
 	)
 	flags.MarkHidden(channelGroupFlag)
 
+	flags.BoolVar(
+		&args.plan,
+		planFlag,
+		false,
+		"List the policy documents, trust policies and tags that the upgrade would change, "+
+			"without changing anything.",
+	)
+
+	flags.StringVar(
+		&args.planFile,
+		planFileFlag,
+		"",
+		"File to save the plan to, so that it can be reviewed and applied later with --apply-plan.",
+	)
+
+	flags.StringVar(
+		&args.applyPlan,
+		applyPlanFlag,
+		"",
+		"Apply the changes of a plan saved with --plan-file, if the roles haven't changed since.",
+	)
+
 	confirm.AddFlag(flags)
 	interactive.AddFlag(flags)
 }
@@ -125,9 +160,19 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 	args.isInvokedFromClusterUpgrade = isInvokedFromClusterUpgrade
 
+	if args.applyPlan != "" && (args.plan || args.planFile != "") {
+		reporter.Errorf("Flag '--%s' can't be used with '--%s' or '--%s'", applyPlanFlag, planFlag, planFileFlag)
+		os.Exit(1)
+	}
+
 	clusterKey := r.GetClusterKey()
 	cluster = r.FetchCluster()
 
+	if args.applyPlan != "" {
+		runApplyPlan(r, cluster)
+		return
+	}
+
 	mode, err := interactive.GetMode()
 	if err != nil {
 		reporter.Errorf("%s", err)
@@ -171,6 +216,11 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	if args.plan || args.planFile != "" {
+		runPlan(r, cluster, credRequests, env)
+		return
+	}
+
 	// Determine if interactive mode is needed
 	if !interactive.Enabled() && !cmd.Flags().Changed("mode") && !skipInteractive {
 		interactive.Enable()
@@ -197,16 +247,8 @@ func run(cmd *cobra.Command, argv []string) {
 		return
 	}
 
-	policyVersion := args.policyUpgradeversion
-	isPolicyVersionChosen := policyVersion != ""
-	channelGroup := args.channelGroup
-	policyVersion, err = ocmClient.GetPolicyVersion(policyVersion, channelGroup)
-	if err != nil {
-		reporter.Errorf("Error getting version: %s", err)
-		os.Exit(1)
-	}
-
-	err = checkPolicyAndClusterVersionCompatibility(policyVersion, clusterUpgradeVersion)
+	isPolicyVersionChosen := args.policyUpgradeversion != ""
+	policyVersion, err := getPolicyVersion(ocmClient, clusterUpgradeVersion)
 	if err != nil {
 		reporter.Errorf("%v", err)
 		os.Exit(1)
@@ -498,50 +540,130 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 }
 
-func LogError(key string, ocmClient *ocm.Client, defaultPolicyVersion string, err error, reporter *rprtr.Object) {
-	reporter.Debugf("Logging throttle error")
-	if strings.Contains(err.Error(), "Throttling") {
-		ocmClient.LogEvent(key, map[string]string{
-			ocm.Response:   ocm.Failure,
-			ocm.Version:    defaultPolicyVersion,
-			ocm.IsThrottle: "true",
-		})
+func getPolicyVersion(ocmClient *ocm.Client, clusterUpgradeVersion string) (string, error) {
+	policyVersion, err := ocmClient.GetPolicyVersion(args.policyUpgradeversion, args.channelGroup)
+	if err != nil {
+		return "", fmt.Errorf("Error getting version: %s", err)
+	}
+	err = checkPolicyAndClusterVersionCompatibility(policyVersion, clusterUpgradeVersion)
+	if err != nil {
+		return "", err
 	}
+	return policyVersion, nil
 }
 
-func handleAccountRolePolicyARN(
-	mode string,
-	awsClient aws.Client,
-	roleName string,
-	prefix string,
-	rolePath string,
-	partition string,
-	accountID string,
-	policiesDetails []aws.PolicyDetail,
-) (string, error) {
-	var err error
-	if policiesDetails == nil {
-		policiesDetails, err = awsClient.GetAttachedPolicy(&roleName)
-		if err != nil {
-			return "", err
-		}
+// runPlan lists the changes the upgrade would make to the roles of the cluster, without making them
+func runPlan(r *rosa.Runtime, cluster *v1.Cluster, credRequests map[string]*v1.STSOperator, env string) {
+	policyVersion, err := getPolicyVersion(r.OCMClient, args.clusterUpgradeVersion)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	accountRolePolicies, err := r.OCMClient.GetPolicies("")
+	if err != nil {
+		r.Reporter.Errorf("Failed to get account role policies: %s", err)
+		os.Exit(1)
+	}
+	operatorRolePolicies, err := r.OCMClient.GetPolicies("OperatorRole")
+	if err != nil {
+		r.Reporter.Errorf("Failed to get operator role policies: %s", err)
+		os.Exit(1)
+	}
+	missingOperators, err := r.OCMClient.FindMissingOperatorRolesForUpgrade(cluster,
+		args.clusterUpgradeVersion, credRequests)
+	if err != nil {
+		r.Reporter.Errorf("Error finding operator roles for upgrade '%s'", err)
+		os.Exit(1)
 	}
 
-	attachedPoliciesDetail := aws.FindAllAttachedPolicyDetails(policiesDetails)
+	var spin *spinner.Spinner
+	if r.Reporter.IsTerminal() {
+		spin = spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+		r.Reporter.Infof("Comparing the roles of cluster '%s' with the ones expected for version '%s'",
+			r.ClusterKey, args.clusterUpgradeVersion)
+		spin.Start()
+	}
+	plan, err := upgradeplan.Build(r.AWSClient, r.Creator, &upgradeplan.Input{
+		Cluster:              cluster,
+		ClusterVersion:       args.clusterUpgradeVersion,
+		PolicyVersion:        policyVersion,
+		Env:                  env,
+		AccountRolePolicies:  accountRolePolicies,
+		OperatorRolePolicies: operatorRolePolicies,
+		CredRequests:         credRequests,
+		MissingOperators:     missingOperators,
+	})
+	if spin != nil {
+		spin.Stop()
+	}
+	if err != nil {
+		r.Reporter.Errorf("Failed to plan the upgrade of the roles: %v", err)
+		os.Exit(1)
+	}
 
-	generatedPolicyARN := aws.GetPolicyARN(partition, accountID, roleName, rolePath)
-	if len(attachedPoliciesDetail) == 0 {
-		return generatedPolicyARN, nil
+	if len(plan.Changes) == 0 {
+		r.Reporter.Infof("Account and operator roles of cluster '%s' are already up-to-date for version '%s'",
+			r.ClusterKey, args.clusterUpgradeVersion)
+		return
 	}
+	fmt.Print(plan.Describe())
+	if args.planFile == "" {
+		return
+	}
+	err = plan.Save(args.planFile)
+	if err != nil {
+		r.Reporter.Errorf("Failed to save the plan: %v", err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Saved the plan to '%s'. Once reviewed, apply it with:\n\n"+
+		"\trosa upgrade roles -c %s --cluster-version %s --apply-plan %s\n",
+		args.planFile, r.ClusterKey, args.clusterUpgradeVersion, args.planFile)
+}
 
-	policyArn, err := awsClient.GetAccountRoleDefaultPolicy(roleName, prefix)
+// runApplyPlan makes the changes of a plan saved by runPlan
+func runApplyPlan(r *rosa.Runtime, cluster *v1.Cluster) {
+	plan, err := upgradeplan.Load(args.applyPlan)
 	if err != nil {
-		return "", err
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
 	}
-	if policyArn == "" {
-		return generatedPolicyARN, nil
+	err = plan.Validate(cluster.ID(), args.clusterUpgradeVersion, r.Creator.AccountID)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	if len(plan.Changes) == 0 {
+		r.Reporter.Infof("The plan has no changes to apply")
+		return
+	}
+	fmt.Print(plan.Describe())
+	if !confirm.Confirm("apply the %d changes of the plan to the roles of cluster '%s'",
+		len(plan.Changes), r.ClusterKey) {
+		os.Exit(0)
+	}
+	err = upgradeplan.Apply(r.AWSClient, r.Reporter, plan)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	for _, change := range plan.Changes {
+		if change.Type == upgradeplan.ChangeOperatorRole {
+			helper.DisplaySpinnerWithDelay(r.Reporter, "Waiting for operator roles to reconcile", 5*time.Second)
+			break
+		}
+	}
+	r.Reporter.Infof("Applied the plan to the roles of cluster '%s'", r.ClusterKey)
+}
+
+func LogError(key string, ocmClient *ocm.Client, defaultPolicyVersion string, err error, reporter *rprtr.Object) {
+	reporter.Debugf("Logging throttle error")
+	if strings.Contains(err.Error(), "Throttling") {
+		ocmClient.LogEvent(key, map[string]string{
+			ocm.Response:   ocm.Failure,
+			ocm.Version:    defaultPolicyVersion,
+			ocm.IsThrottle: "true",
+		})
 	}
-	return policyArn, nil
 }
 
 func upgradeAccountRolePoliciesFromCluster(
@@ -585,7 +707,7 @@ func upgradeAccountRolePoliciesFromCluster(
 		}
 		filename := fmt.Sprintf("sts_%s_permission_policy", file)
 
-		policyARN, err := handleAccountRolePolicyARN(mode, awsClient, roleName, prefix, rolePath,
+		policyARN, err := upgradeplan.AccountRolePolicyARN(awsClient, roleName, prefix, rolePath,
 			partition, accountID, rolePolicyDetails[roleName])
 		if err != nil {
 			return err
@@ -648,8 +770,7 @@ func buildAccountRoleCommandsFromCluster(
 				return "", err
 			}
 
-			policyARN, err := handleAccountRolePolicyARN(
-				mode,
+			policyARN, err := upgradeplan.AccountRolePolicyARN(
 				awsClient,
 				accRoleName,
 				prefix,
@@ -806,8 +927,7 @@ func upgradeOperatorRolePoliciesFromCluster(
 			if err != nil {
 				return err
 			}
-			policyARN, err = handleOperatorRolePolicyARN(
-				mode,
+			policyARN, err = upgradeplan.OperatorRolePolicyARN(
 				awsClient,
 				operatorRoleName,
 				operatorRolePolicyPrefix,
@@ -891,8 +1011,7 @@ func buildOperatorRoleCommandsFromCluster(
 			if err != nil {
 				return "", err
 			}
-			foundPolicyARN, err := handleOperatorRolePolicyARN(
-				mode,
+			foundPolicyARN, err := upgradeplan.OperatorRolePolicyARN(
 				awsClient,
 				operatorRoleName,
 				operatorRolePolicyPrefix,
@@ -943,48 +1062,6 @@ func buildOperatorRoleCommandsFromCluster(
 	return awscb.JoinCommands(commands), nil
 }
 
-func handleOperatorRolePolicyARN(
-	mode string,
-	awsClient aws.Client,
-	operatorRoleName string,
-	operatorRolePolicyPrefix string,
-	operatorPolicyPath string,
-	operator *v1.STSOperator,
-	partition string,
-	accountID string,
-	policiesDetails []aws.PolicyDetail,
-) (string, error) {
-	var err error
-	if policiesDetails == nil {
-		policiesDetails, err = awsClient.GetAttachedPolicy(&operatorRoleName)
-		if err != nil {
-			return "", err
-		}
-	}
-	generatedPolicyARN := aws.GetOperatorPolicyARN(
-		partition,
-		accountID,
-		operatorRolePolicyPrefix,
-		operator.Namespace(),
-		operator.Name(),
-		operatorPolicyPath,
-	)
-	attachedPoliciesDetails := aws.FindAllAttachedPolicyDetails(policiesDetails)
-
-	if len(attachedPoliciesDetails) == 0 {
-		return generatedPolicyARN, nil
-	}
-
-	policyArn, err := awsClient.GetOperatorRoleDefaultPolicy(operatorRoleName)
-	if err != nil {
-		return "", err
-	}
-	if policyArn == "" {
-		return generatedPolicyARN, nil
-	}
-	return policyArn, nil
-}
-
 func createOperatorRole(
 	mode string,
 	r *rosa.Runtime,
@@ -21,6 +21,7 @@ import (
 
 	"github.com/openshift/rosa/cmd/verify/network"
 	"github.com/openshift/rosa/cmd/verify/oc"
+	"github.com/openshift/rosa/cmd/verify/oidcconfig"
 	"github.com/openshift/rosa/cmd/verify/permissions"
 	"github.com/openshift/rosa/cmd/verify/quota"
 	"github.com/openshift/rosa/cmd/verify/rosa"
@@ -36,6 +37,7 @@ var Cmd = &cobra.Command{
 func init() {
 	Cmd.AddCommand(network.Cmd)
 	Cmd.AddCommand(oc.Cmd)
+	Cmd.AddCommand(oidcconfig.NewVerifyOidcConfigCommand())
 	Cmd.AddCommand(permissions.Cmd)
 	Cmd.AddCommand(quota.Cmd)
 	Cmd.AddCommand(rosa.NewVerifyRosaCommand())
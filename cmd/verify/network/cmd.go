@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -30,6 +31,7 @@ import (
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/network"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -43,6 +45,9 @@ var args struct {
 	watch      bool
 	tags       []string
 	hostedCp   bool
+	topology   string
+	allowlist  bool
+	details    bool
 }
 
 var Cmd = makeCmd()
@@ -53,7 +58,14 @@ func makeCmd() *cobra.Command {
 		Short: "Verify VPC subnets are configured correctly",
 		Long:  "Verify that the VPC subnets are configured correctly.",
 		Example: `  # Verify two subnets
-	rosa verify network --subnet-ids subnet-03046a9b92b5014fb,subnet-03046a9c92b5014fb`,
+	rosa verify network --subnet-ids subnet-03046a9b92b5014fb,subnet-03046a9c92b5014fb
+
+  # Print the firewall allowlist of a hosted control plane cluster as JSON
+	rosa verify network --allowlist --topology hcp --region us-east-1 --output json
+
+  # Print the results of two subnets with the egress endpoints that failed as a single JSON list
+	rosa verify network --status-only --details --region us-east-1 --output json \
+	--subnet-ids subnet-03046a9b92b5014fb,subnet-03046a9c92b5014fb`,
 		Run:  run,
 		Args: cobra.NoArgs,
 	}
@@ -68,6 +80,9 @@ const (
 	subnetIDsFlag  = "subnet-ids"
 	watchFlag      = "watch"
 	hostedCpFlag   = "hosted-cp"
+	topologyFlag   = "topology"
+	allowlistFlag  = "allowlist"
+	detailsFlag    = "details"
 
 	NetworkVerifyPending NetworkVerifyState = "pending"
 	NetworkVerifyRunning NetworkVerifyState = "running"
@@ -134,10 +149,42 @@ func initFlags(cmd *cobra.Command) {
 		false,
 		"Run network verifier with hosted control plane platform configuration",
 	)
+
+	flags.StringVar(
+		&args.topology,
+		topologyFlag,
+		"",
+		fmt.Sprintf("Topology of the cluster the egress endpoints are checked for, one of: %s. "+
+			"Defaults to the topology of the cluster.", strings.Join(network.Topologies, ", ")),
+	)
+	cmd.RegisterFlagCompletionFunc(topologyFlag, func(_ *cobra.Command, _ []string, _ string) ([]string,
+		cobra.ShellCompDirective) {
+		return network.Topologies, cobra.ShellCompDirectiveDefault
+	})
+
+	flags.BoolVar(
+		&args.allowlist,
+		allowlistFlag,
+		false,
+		"Print the domains and ports the cluster must be able to reach for the topology and region, "+
+			"instead of verifying subnets.",
+	)
+
+	flags.BoolVar(
+		&args.details,
+		detailsFlag,
+		false,
+		"Print the results of all subnets as a single list, including the egress endpoints that failed "+
+			"with their category and firewall rule. Only used together with '--output'.",
+	)
 }
 
 func run(cmd *cobra.Command, _ []string) {
-	r := rosa.NewRuntime().WithAWS().WithOCM()
+	r := rosa.NewRuntime()
+	// The allowlist is built offline unless it is for a cluster
+	if !args.allowlist || cmd.Flags().Changed(clusterFlag) {
+		r.WithAWS().WithOCM()
+	}
 	defer r.Cleanup()
 	err := runWithRuntime(r, cmd)
 	if err != nil {
@@ -155,6 +202,18 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		cluster = r.FetchCluster()
 	}
 
+	topology, err := getTopology(cmd, cluster)
+	if err != nil {
+		return err
+	}
+
+	if args.allowlist {
+		if args.region, err = getRegion(cmd, cluster); err != nil {
+			return err
+		}
+		return printAllowlist(topology, args.region)
+	}
+
 	if !cmd.Flags().Changed(subnetIDsFlag) {
 		if cluster != nil {
 			if !helper.IsBYOVPC(cluster) {
@@ -236,6 +295,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		}
 	}
 
+	results := []*network.VerificationResult{}
 	if args.watch && len(args.subnetIDs) > 0 {
 		var spin *spinner.Spinner
 		if r.Reporter.IsTerminal() {
@@ -253,7 +313,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 					status.State() == string(NetworkVerifyRunning)) {
 					continue
 				}
-				printStatus(r, spin, subnet, status, err)
+				results = append(results, printStatus(r, spin, subnet, status, err, topology))
 
 				// Remove completed subnets, no need to check these again
 				args.subnetIDs[i] = args.subnetIDs[len(args.subnetIDs)-1]
@@ -273,7 +333,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		for i := 0; i < len(args.subnetIDs); i++ {
 			subnet := args.subnetIDs[i]
 			status, err := r.OCMClient.GetVerifyNetworkSubnet(subnet)
-			printStatus(r, nil, subnet, status, err)
+			results = append(results, printStatus(r, nil, subnet, status, err, topology))
 			if status.State() == string(NetworkVerifyPending) || status.State() == string(NetworkVerifyRunning) {
 				pending = true
 			}
@@ -290,11 +350,21 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		}
 	}
 
+	if output.HasFlag() && args.details {
+		return output.Print(results)
+	}
 	return nil
 }
 
+// printStatus reports the verification status of the subnet and returns its result. With '--details'
+// the results are printed together by the caller when an output format is requested.
 func printStatus(r *rosa.Runtime, spin *spinner.Spinner, subnet string,
-	status *cmv1.SubnetNetworkVerification, err error) {
+	status *cmv1.SubnetNetworkVerification, err error, topology string) *network.VerificationResult {
+	result := network.NewVerificationResult(subnet, status, err, topology)
+	if output.HasFlag() && args.details {
+		return result
+	}
+
 	if spin != nil {
 		spin.Stop()
 	}
@@ -302,7 +372,8 @@ func printStatus(r *rosa.Runtime, spin *spinner.Spinner, subnet string,
 	if err != nil {
 		r.Reporter.Infof("%s: %s", subnet, err.Error())
 	} else if status.State() == string(NetworkVerifyFailed) {
-		r.Reporter.Infof("%s: %s Unable to verify egress to: %v", subnet, status.State(), status.Details())
+		r.Reporter.Infof("%s: %s Unable to verify egress to:%s", subnet, status.State(),
+			describeFailures(result.Failures))
 	} else if output.HasFlag() {
 		err := output.Print(status)
 		if err != nil {
@@ -323,6 +394,53 @@ func printStatus(r *rosa.Runtime, spin *spinner.Spinner, subnet string,
 	if spin != nil {
 		spin.Restart()
 	}
+	return result
+}
+
+func describeFailures(failures []network.EgressFailure) string {
+	var b strings.Builder
+	for _, failure := range failures {
+		if failure.Domain == "" {
+			fmt.Fprintf(&b, "\n - %s", failure.Detail)
+			continue
+		}
+		fmt.Fprintf(&b, "\n - %s:%d (%s): %s", failure.Domain, failure.Port, failure.Category, failure.Rule)
+	}
+	return b.String()
+}
+
+func printAllowlist(topology string, region string) error {
+	endpoints, err := network.Allowlist(topology, region)
+	if err != nil {
+		return err
+	}
+	if output.HasFlag() {
+		return output.Print(endpoints)
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "DOMAIN\tPORT\tCATEGORY\n")
+	for _, endpoint := range endpoints {
+		fmt.Fprintf(writer, "%s\t%d\t%s\n", endpoint.Domain, endpoint.Port, endpoint.Category)
+	}
+	return writer.Flush()
+}
+
+// getTopology returns the topology given with '--topology', or else the one of the cluster
+func getTopology(cmd *cobra.Command, cluster *cmv1.Cluster) (string, error) {
+	if cmd.Flags().Changed(topologyFlag) {
+		if !helper.Contains(network.Topologies, args.topology) {
+			return "", fmt.Errorf("Invalid value '%s' for '--%s', expected one of: %s", args.topology,
+				topologyFlag, strings.Join(network.Topologies, ", "))
+		}
+		return args.topology, nil
+	}
+	if cluster != nil {
+		return network.ClusterTopology(cluster), nil
+	}
+	if args.hostedCp {
+		return network.TopologyHostedCP, nil
+	}
+	return network.TopologyClassic, nil
 }
 
 func getRegion(cmd *cobra.Command, cluster *cmv1.Cluster) (region string, err error) {
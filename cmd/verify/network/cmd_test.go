@@ -2,6 +2,7 @@ package network
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2/dsl/core"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 	"github.com/openshift/rosa/pkg/test"
 )
@@ -404,6 +406,85 @@ INFO: subnet-0f87f640e56934cbc, platform: aws, tags: {"t1":"v1"}: passed
 			ContainSubstring(
 				"'--hosted-cp' flag is not required when running the network verifier with cluster"))
 	})
+	It("Prints the failed egress endpoints with their category and firewall rule", func() {
+		// GET /api/clusters_mgmt/v1/network_verifications/subnetA
+		apiServer.AppendHandlers(
+			RespondWithJSON(
+				http.StatusOK,
+				`{
+					"id": "subnet-0b761d44d3d9a4663",
+					"state": "failed",
+					"platform": "aws",
+					"details": ["egressURL error: https://quay.io:443 (timeout)"]
+				}`,
+			),
+		)
+		cmd.Flags().Lookup(statusOnlyFlag).Changed = true
+		cmd.Flags().Set(subnetIDsFlag, "subnet-0b761d44d3d9a4663")
+		cmd.Flags().Set("region", "us-east-1")
+		stdout, stderr, err := test.RunWithOutputCapture(runWithRuntime, r, cmd)
+		Expect(err).To(BeNil())
+		Expect(stderr).To(Equal(""))
+		Expect(stdout).To(Equal("INFO: subnet-0b761d44d3d9a4663: failed Unable to verify egress to:\n" +
+			" - quay.io:443 (registry): Allow TCP egress to 'quay.io' on port 443\n"))
+	})
+	It("Prints the status of each subnet as JSON", func() {
+		// GET /api/clusters_mgmt/v1/network_verifications/subnetA
+		apiServer.AppendHandlers(
+			RespondWithJSON(
+				http.StatusOK,
+				`{
+					"id": "subnet-0b761d44d3d9a4663",
+					"state": "passed",
+					"platform": "aws"
+				}`,
+			),
+		)
+		output.SetOutput("json")
+		DeferCleanup(output.SetOutput, "")
+		cmd.Flags().Lookup(statusOnlyFlag).Changed = true
+		cmd.Flags().Set(subnetIDsFlag, "subnet-0b761d44d3d9a4663")
+		cmd.Flags().Set("region", "us-east-1")
+		stdout, stderr, err := test.RunWithOutputCapture(runWithRuntime, r, cmd)
+		Expect(err).To(BeNil())
+		Expect(stderr).To(Equal(""))
+		Expect(strings.TrimSpace(stdout)).To(HavePrefix("{"))
+		Expect(stdout).To(ContainSubstring(`"id": "subnet-0b761d44d3d9a4663"`))
+		Expect(stdout).To(ContainSubstring(`"state": "passed"`))
+	})
+	It("Prints the results of all subnets as a JSON list with --details", func() {
+		// GET /api/clusters_mgmt/v1/network_verifications/subnetA
+		apiServer.AppendHandlers(
+			RespondWithJSON(
+				http.StatusOK,
+				`{
+					"id": "subnet-0b761d44d3d9a4663",
+					"state": "failed",
+					"platform": "aws",
+					"details": ["egressURL error: https://quay.io:443 (timeout)"]
+				}`,
+			),
+		)
+		output.SetOutput("json")
+		DeferCleanup(output.SetOutput, "")
+		cmd.Flags().Lookup(statusOnlyFlag).Changed = true
+		cmd.Flags().Set(detailsFlag, "true")
+		cmd.Flags().Set(subnetIDsFlag, "subnet-0b761d44d3d9a4663")
+		cmd.Flags().Set("region", "us-east-1")
+		stdout, stderr, err := test.RunWithOutputCapture(runWithRuntime, r, cmd)
+		Expect(err).To(BeNil())
+		Expect(stderr).To(Equal(""))
+		Expect(strings.TrimSpace(stdout)).To(HavePrefix("["))
+		Expect(stdout).To(ContainSubstring(`"domain": "quay.io"`))
+	})
+	It("Fails if --topology is invalid", func() {
+		cmd.Flags().Set(allowlistFlag, "true")
+		cmd.Flags().Set(topologyFlag, "foo")
+		err := runWithRuntime(r, cmd)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(
+			ContainSubstring("Invalid value 'foo' for '--topology'"))
+	})
 	It("Fails if --cluster is not BYO VPC", func() {
 		cmd.Flags().Lookup(statusOnlyFlag).Changed = true
 		cmd.Flags().Set(clusterFlag, "tomckay-vpc")
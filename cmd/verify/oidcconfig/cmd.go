@@ -0,0 +1,147 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidcconfig
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/oidcconfig"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "oidc-config"
+	short = "Verify the documents served by an OIDC configuration"
+	long  = "Fetch the discovery document and the JSON Web Key Set from the issuer URL of a registered " +
+		"OIDC configuration and check that they are valid, that the signing key stored in Secrets Manager " +
+		"is published and that the thumbprint of the issuer is registered on the OIDC provider."
+	example = `  # Verify an OIDC configuration
+  rosa verify oidc-config --oidc-config-id <oidc_config_id>`
+
+	oidcConfigIdFlag = "oidc-config-id"
+
+	fetchTimeout = 30 * time.Second
+)
+
+var aliases = []string{"oidcconfig"}
+
+type VerifyOidcConfigOptions struct {
+	oidcConfigId string
+}
+
+func NewVerifyOidcConfigCommand() *cobra.Command {
+	options := &VerifyOidcConfigOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), VerifyOidcConfigRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(
+		&options.oidcConfigId,
+		oidcConfigIdFlag,
+		"",
+		"Registered ID of the OIDC configuration to verify (required).",
+	)
+	cmd.MarkFlagRequired(oidcConfigIdFlag)
+	arguments.AddProfileFlag(flags)
+	arguments.AddRegionFlag(flags)
+	return cmd
+}
+
+func VerifyOidcConfigRunner(options *VerifyOidcConfigOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		oidcConfig, err := r.OCMClient.GetOidcConfig(options.oidcConfigId)
+		if err != nil {
+			return fmt.Errorf("Failed to get OIDC config '%s': %v", options.oidcConfigId, err)
+		}
+		issuerUrl := oidcConfig.IssuerUrl()
+		r.Reporter.Infof("Verifying OIDC config '%s' served at '%s'", options.oidcConfigId, issuerUrl)
+
+		verification := oidcconfig.Verify(&http.Client{Timeout: fetchTimeout}, issuerUrl)
+		if len(verification.Problems) == 0 {
+			r.Reporter.Infof("Discovery document and JSON Web Key Set are valid, published keys: %s",
+				strings.Join(verification.KeyIds, ", "))
+		}
+
+		if !oidcConfig.Managed() && len(verification.KeyIds) > 0 {
+			privateKey, err := r.AWSClient.GetSecretInSecretsManager(oidcConfig.SecretArn())
+			if err != nil {
+				r.Reporter.Warnf("Unable to read the private key from secret '%s', skipping signing key check: %v",
+					oidcConfig.SecretArn(), err)
+			} else {
+				verification.VerifyPrivateKey([]byte(privateKey))
+			}
+		}
+
+		verifyThumbprint(r, options.oidcConfigId, issuerUrl, verification)
+
+		for _, warning := range verification.Warnings {
+			r.Reporter.Warnf("%s", warning)
+		}
+		if len(verification.Problems) > 0 {
+			return fmt.Errorf("OIDC config '%s' failed verification:\n  - %s", options.oidcConfigId,
+				strings.Join(verification.Problems, "\n  - "))
+		}
+		r.Reporter.Infof("OIDC config '%s' is valid", options.oidcConfigId)
+		return nil
+	}
+}
+
+func verifyThumbprint(r *rosa.Runtime, oidcConfigId string, issuerUrl string,
+	verification *oidcconfig.Verification) {
+	input, err := cmv1.NewOidcThumbprintInput().OidcConfigId(oidcConfigId).Build()
+	if err != nil {
+		r.Reporter.Warnf("Unable to build thumbprint request, skipping thumbprint check: %v", err)
+		return
+	}
+	thumbprint, err := r.OCMClient.FetchOidcThumbprint(input)
+	if err != nil {
+		r.Reporter.Warnf("Unable to fetch the thumbprint of issuer '%s', skipping thumbprint check: %v",
+			issuerUrl, err)
+		return
+	}
+	registered, found, err := r.AWSClient.GetOpenIDConnectProviderThumbprints(issuerUrl,
+		r.Creator.Partition, r.Creator.AccountID)
+	if err != nil {
+		r.Reporter.Warnf("Unable to get the OIDC provider of issuer '%s', skipping thumbprint check: %v",
+			issuerUrl, err)
+		return
+	}
+	if !found {
+		r.Reporter.Warnf("There is no OIDC provider for issuer '%s' in AWS account '%s', "+
+			"create it with 'rosa create oidc-provider --oidc-config-id %s'",
+			issuerUrl, r.Creator.AccountID, oidcConfigId)
+		return
+	}
+	r.Reporter.Debugf("Comparing thumbprint '%s' with registered thumbprints: %s",
+		thumbprint.Thumbprint(), strings.Join(registered, ", "))
+	verification.VerifyThumbprint(thumbprint.Thumbprint(), registered)
+}
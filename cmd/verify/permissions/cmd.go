@@ -20,6 +20,9 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 
 	"github.com/spf13/cobra"
 
@@ -32,23 +35,48 @@ var Cmd = &cobra.Command{
 	Use:     "permissions",
 	Aliases: []string{"scp"},
 	Short:   "Verify AWS permissions are ok for non-STS cluster install",
-	Long:    "Verify AWS permissions needed to create a non-STS cluster are configured as expected",
+	Long: "Verify AWS permissions needed to create a non-STS cluster are configured as expected.\n\n" +
+		"With '--for', simulate the AWS actions a rosa command calls with the credentials of the " +
+		"current user or role, and report which actions are denied by its identity policies, its " +
+		"permissions boundary or a service control policy of the organization.",
 	Example: `  # Verify AWS permissions are configured correctly
   rosa verify permissions
 
   # Verify AWS permissions in a different region
-  rosa verify permissions --region=us-west-2`,
+  rosa verify permissions --region=us-west-2
+
+  # Verify the current credentials can create account roles
+  rosa verify permissions --for create-account-roles`,
 	Run:  run,
 	Args: cobra.NoArgs,
 }
 
+const forFlag = "for"
+
+var args struct {
+	forCommand string
+}
+
 func init() {
 	flags := Cmd.Flags()
 
+	flags.StringVar(
+		&args.forCommand,
+		forFlag,
+		"",
+		fmt.Sprintf("Verify the permissions needed by a rosa command. Valid options are: %s.",
+			strings.Join(aws.PermissionsCommands(), ", ")),
+	)
+	Cmd.RegisterFlagCompletionFunc(forFlag, forCompletion)
+
 	arguments.AddProfileFlag(flags)
 	arguments.AddRegionFlag(flags)
 }
 
+func forCompletion(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return aws.PermissionsCommands(), cobra.ShellCompDirectiveDefault
+}
+
 func run(_ *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithOCM()
 	defer r.Cleanup()
@@ -74,6 +102,11 @@ func run(_ *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if args.forCommand != "" {
+		verifyCommandPermissions(r, args.forCommand)
+		return
+	}
+
 	r.Reporter.Infof("Verifying permissions for non-STS clusters")
 	r.Reporter.Infof("Validating SCP policies...")
 	policies, err := r.OCMClient.GetPolicies("OSDSCPPolicy")
@@ -99,3 +132,59 @@ func run(_ *cobra.Command, _ []string) {
 	}
 	r.Reporter.Infof("AWS SCP policies ok")
 }
+
+func verifyCommandPermissions(r *rosa.Runtime, command string) {
+	actions, err := aws.GetCommandPermissions(command)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	creator, err := r.AWSClient.GetCreator()
+	if err != nil {
+		r.Reporter.Errorf("Failed to get AWS creator: %v", err)
+		os.Exit(1)
+	}
+	principal, err := arn.Parse(creator.ARN)
+	if err != nil {
+		r.Reporter.Errorf("Failed to parse ARN '%s': %v", creator.ARN, err)
+		os.Exit(1)
+	}
+	if principal.Resource == "root" {
+		r.Reporter.Errorf("The permissions of the root user can't be simulated, " +
+			"run the command with the credentials of an IAM user or role")
+		os.Exit(1)
+	}
+
+	r.Reporter.Infof("Verifying permissions of '%s' for 'rosa %s'", creator.ARN,
+		strings.Replace(command, "-", " ", 1))
+	decisions, err := r.AWSClient.SimulatePermissions(creator.ARN, actions)
+	if err != nil {
+		r.Reporter.Errorf("Unable to simulate permissions, 'iam:SimulatePrincipalPolicy' is required: %v", err)
+		os.Exit(1)
+	}
+
+	denied := 0
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ACTION\tRESULT\tDENIED BY\tPOLICIES\n")
+	for _, decision := range decisions {
+		result := "allowed"
+		if !decision.Allowed {
+			denied++
+			result = "denied"
+			if decision.ExplicitDeny {
+				result = "explicitly denied"
+			}
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", decision.Action, result, decision.DeniedBy,
+			strings.Join(decision.Policies, ", "))
+	}
+	writer.Flush()
+
+	if denied > 0 {
+		r.Reporter.Errorf("%d of %d actions needed by 'rosa %s' are denied",
+			denied, len(decisions), strings.Replace(command, "-", " ", 1))
+		os.Exit(1)
+	}
+	r.Reporter.Infof("All %d actions needed by 'rosa %s' are allowed", len(decisions),
+		strings.Replace(command, "-", " ", 1))
+}
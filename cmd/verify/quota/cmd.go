@@ -18,25 +18,56 @@ package quota
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/quotaplan"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
+var args struct {
+	hostedCP           bool
+	multiAZ            bool
+	computeMachineType string
+	computeNodes       int
+	maxComputeNodes    int
+	workerDiskSize     string
+	requestIncrease    bool
+}
+
+const use = "quota"
+
 var Cmd = &cobra.Command{
-	Use:   "quota",
+	Use:   use,
 	Short: "Verify AWS quota is ok for cluster install",
-	Long:  "Verify AWS quota needed to create a cluster is configured as expected",
+	Long: "Verify AWS quota needed to create a cluster is configured as expected.\n\n" +
+		"The capacity the cluster needs is computed from its shape: the instance types and number of " +
+		"control plane, infra and compute nodes, their volumes, and the Elastic IPs and network interfaces " +
+		"of each availability zone. It is compared with the service quotas minus the current usage of the region.\n\n" +
+		"The quotas of the VPCs, internet gateways, load balancers, EBS snapshots, io1 volumes and provisioned " +
+		"IOPS are verified against static minimums. 'rosa init' and 'rosa create account-roles' verify the " +
+		"static minimums of every quota only, without counting the usage of the region.",
 	Example: `  # Verify AWS quotas are configured correctly
   rosa verify quota
 
   # Verify AWS quotas in a different region
-  rosa verify quota --region=us-west-2`,
+  rosa verify quota --region=us-west-2
+
+  # Verify AWS quotas for a multi-AZ cluster autoscaling up to 30 m5.2xlarge nodes
+  rosa verify quota --multi-az --compute-machine-type m5.2xlarge --replicas 3 --max-replicas 30
+
+  # Request the missing quotas for a hosted cluster
+  rosa verify quota --hosted-cp --replicas 6 --request-increase`,
 	Args: cobra.NoArgs,
 	Run:  run,
 }
@@ -46,9 +77,55 @@ func init() {
 
 	arguments.AddRegionFlag(flags)
 	arguments.AddProfileFlag(flags)
+
+	flags.BoolVar(
+		&args.hostedCP,
+		"hosted-cp",
+		false,
+		"Verify the quotas for a cluster with a hosted control plane.",
+	)
+	flags.BoolVar(
+		&args.multiAZ,
+		"multi-az",
+		false,
+		"Verify the quotas for a cluster deployed to multiple availability zones.",
+	)
+	flags.StringVar(
+		&args.computeMachineType,
+		"compute-machine-type",
+		quotaplan.DefaultComputeInstanceType,
+		"Instance type of the compute nodes.",
+	)
+	flags.IntVar(
+		&args.computeNodes,
+		"replicas",
+		0,
+		fmt.Sprintf("Number of compute nodes. Defaults to %d, or 3 for multi-AZ clusters.",
+			quotaplan.DefaultComputeNodes),
+	)
+	flags.IntVar(
+		&args.maxComputeNodes,
+		"max-replicas",
+		0,
+		"Maximum number of compute nodes when they are autoscaled.",
+	)
+	flags.StringVar(
+		&args.workerDiskSize,
+		"worker-disk-size",
+		fmt.Sprintf("%dGiB", quotaplan.DefaultComputeVolumeSize),
+		"Root disk size of the compute nodes, with a unit suffix like GiB or TiB.",
+	)
+	flags.BoolVar(
+		&args.requestIncrease,
+		"request-increase",
+		false,
+		"Request an increase of the service quotas that are too low.",
+	)
+	confirm.AddFlag(flags)
+	output.AddFlag(Cmd)
 }
 
-func run(_ *cobra.Command, _ []string) {
+func run(cmd *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithOCM()
 	defer r.Cleanup()
 
@@ -73,19 +150,125 @@ func run(_ *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	if r.Reporter.IsTerminal() {
+	if r.Reporter.IsTerminal() && !output.HasFlag() {
 		r.Reporter.Infof("Validating AWS quota...")
 	}
-	_, err = r.AWSClient.ValidateQuota()
+	var requirements []quotaplan.Requirement
+	if cmd.Name() == use {
+		var shape *quotaplan.Shape
+		shape, err = clusterShape()
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+		requirements, err = quotaplan.Check(r.AWSClient, shape)
+	} else {
+		// Other commands verify the static minimums only, which don't need the permissions to count
+		// the usage of the region
+		requirements, err = quotaplan.CheckStatic(r.AWSClient)
+	}
 	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	insufficient := quotaplan.Insufficient(requirements)
+
+	if output.HasFlag() {
+		err = output.Print(requirements)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+	} else if cmd.Name() == use || len(insufficient) > 0 {
+		// The details are left out when the quotas are verified by other commands, unless they fail
+		printRequirements(requirements)
+	}
+
+	if len(insufficient) > 0 {
 		r.OCMClient.LogEvent("ROSAVerifyQuotaInsufficient", nil)
 		r.Reporter.Errorf("Insufficient AWS quotas")
-		r.Reporter.Errorf("%v", err)
+		lines := []string{}
+		for _, requirement := range insufficient {
+			lines = append(lines, fmt.Sprintf(
+				"- Service %s quota code %s %s not valid, expected quota of at least %s, but got %s",
+				requirement.ServiceCode, requirement.QuotaCode, requirement.QuotaName,
+				formatValue(requirement.Usage+requirement.Required), formatValue(requirement.Quota)))
+		}
+		r.Reporter.Errorf("Service quota is insufficient for the following service quota codes:\n%s",
+			strings.Join(lines, "\n"))
+		if args.requestIncrease {
+			requestIncreases(r, insufficient)
+		}
 		os.Exit(1)
 	}
-	if r.Reporter.IsTerminal() {
+	if r.Reporter.IsTerminal() && !output.HasFlag() {
 		r.Reporter.Infof("AWS quota ok. " +
 			"If cluster installation fails, validate actual AWS resource usage against " +
 			"https://docs.openshift.com/rosa/rosa_getting_started/rosa-required-aws-service-quotas.html")
 	}
 }
+
+func clusterShape() (*quotaplan.Shape, error) {
+	volumeSize, err := ocm.ParseDiskSizeToGigibyte(args.workerDiskSize)
+	if err != nil {
+		return nil, fmt.Errorf("Expected a valid worker disk size '%s': %v", args.workerDiskSize, err)
+	}
+	computeNodes := args.computeNodes
+	if computeNodes == 0 {
+		computeNodes = quotaplan.DefaultComputeNodes
+		if args.multiAZ {
+			computeNodes = 3
+		}
+	}
+	return &quotaplan.Shape{
+		HostedCP:            args.hostedCP,
+		MultiAZ:             args.multiAZ,
+		ComputeInstanceType: args.computeMachineType,
+		ComputeNodes:        computeNodes,
+		MaxComputeNodes:     args.maxComputeNodes,
+		ComputeVolumeSize:   volumeSize,
+	}, nil
+}
+
+func printRequirements(requirements []quotaplan.Requirement) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "SERVICE\tQUOTA CODE\tQUOTA NAME\tREQUIRED\tIN USE\tQUOTA\tMISSING\n")
+	for _, requirement := range requirements {
+		usage := formatValue(requirement.Usage)
+		if requirement.Static {
+			usage = "-"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			requirement.ServiceCode,
+			requirement.QuotaCode,
+			requirement.QuotaName,
+			formatValue(requirement.Required),
+			usage,
+			formatValue(requirement.Quota),
+			formatValue(requirement.Missing),
+		)
+	}
+	writer.Flush()
+}
+
+func requestIncreases(r *rosa.Runtime, requirements []quotaplan.Requirement) {
+	for _, requirement := range requirements {
+		if !confirm.Confirm("request an increase of service %s quota code %s '%s' to %s",
+			requirement.ServiceCode, requirement.QuotaCode, requirement.QuotaName,
+			formatValue(math.Ceil(requirement.Usage+requirement.Required))) {
+			continue
+		}
+		id, err := quotaplan.RequestIncrease(r.AWSClient, requirement)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			continue
+		}
+		r.Reporter.Infof("Requested an increase of service %s quota code %s, request ID '%s'",
+			requirement.ServiceCode, requirement.QuotaCode, id)
+	}
+	r.Reporter.Infof("Run 'rosa verify quota' again once the requests are approved")
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -39,6 +39,22 @@ type Ec2ApiClient interface {
 	DescribeInstanceTypeOfferings(ctx context.Context,
 		params *ec2.DescribeInstanceTypeOfferingsInput, optFns ...func(*ec2.Options),
 	) (*ec2.DescribeInstanceTypeOfferingsOutput, error)
+
+	DescribeInstanceTypes(ctx context.Context, params *ec2.DescribeInstanceTypesInput, optFns ...func(*ec2.Options),
+	) (*ec2.DescribeInstanceTypesOutput, error)
+
+	DescribeInstances(ctx context.Context, params *ec2.DescribeInstancesInput, optFns ...func(*ec2.Options),
+	) (*ec2.DescribeInstancesOutput, error)
+
+	DescribeAddresses(ctx context.Context, params *ec2.DescribeAddressesInput, optFns ...func(*ec2.Options),
+	) (*ec2.DescribeAddressesOutput, error)
+
+	DescribeVolumes(ctx context.Context, params *ec2.DescribeVolumesInput, optFns ...func(*ec2.Options),
+	) (*ec2.DescribeVolumesOutput, error)
+
+	DescribeNetworkInterfaces(ctx context.Context,
+		params *ec2.DescribeNetworkInterfacesInput, optFns ...func(*ec2.Options),
+	) (*ec2.DescribeNetworkInterfacesOutput, error)
 }
 
 // interface guard to ensure that all methods defined in the Ec2ApiClient
@@ -152,6 +152,10 @@ type IamApiClient interface {
 		params *iam.PutRolePolicyInput, optFns ...func(*iam.Options),
 	) (*iam.PutRolePolicyOutput, error)
 
+	SimulatePrincipalPolicy(ctx context.Context,
+		params *iam.SimulatePrincipalPolicyInput, optFns ...func(*iam.Options),
+	) (*iam.SimulatePrincipalPolicyOutput, error)
+
 	TagPolicy(ctx context.Context,
 		params *iam.TagPolicyInput, optFns ...func(*iam.Options),
 	) (*iam.TagPolicyOutput, error)
@@ -164,6 +168,18 @@ type IamApiClient interface {
 		params *iam.TagRoleInput, optFns ...func(*iam.Options),
 	) (*iam.TagRoleOutput, error)
 
+	UntagRole(ctx context.Context,
+		params *iam.UntagRoleInput, optFns ...func(*iam.Options),
+	) (*iam.UntagRoleOutput, error)
+
+	TagOpenIDConnectProvider(ctx context.Context,
+		params *iam.TagOpenIDConnectProviderInput, optFns ...func(*iam.Options),
+	) (*iam.TagOpenIDConnectProviderOutput, error)
+
+	UntagOpenIDConnectProvider(ctx context.Context,
+		params *iam.UntagOpenIDConnectProviderInput, optFns ...func(*iam.Options),
+	) (*iam.UntagOpenIDConnectProviderOutput, error)
+
 	UpdateAssumeRolePolicy(ctx context.Context,
 		params *iam.UpdateAssumeRolePolicyInput, optFns ...func(*iam.Options),
 	) (*iam.UpdateAssumeRolePolicyOutput, error)
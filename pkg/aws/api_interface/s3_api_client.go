@@ -46,6 +46,12 @@ type S3ApiClient interface {
 	PutBucketTagging(ctx context.Context, params *s3.PutBucketTaggingInput, optFns ...func(*s3.Options),
 	) (*s3.PutBucketTaggingOutput, error)
 
+	GetBucketTagging(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options),
+	) (*s3.GetBucketTaggingOutput, error)
+
+	DeleteBucketTagging(ctx context.Context, params *s3.DeleteBucketTaggingInput, optFns ...func(*s3.Options),
+	) (*s3.DeleteBucketTaggingOutput, error)
+
 	PutPublicAccessBlock(ctx context.Context, params *s3.PutPublicAccessBlockInput, optFns ...func(*s3.Options),
 	) (*s3.PutPublicAccessBlockOutput, error)
 
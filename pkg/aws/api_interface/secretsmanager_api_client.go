@@ -35,6 +35,18 @@ type SecretsManagerApiClient interface {
 	CreateSecret(ctx context.Context,
 		params *secretsmanager.CreateSecretInput, optFns ...func(*secretsmanager.Options),
 	) (*secretsmanager.CreateSecretOutput, error)
+
+	PutSecretValue(ctx context.Context,
+		params *secretsmanager.PutSecretValueInput, optFns ...func(*secretsmanager.Options),
+	) (*secretsmanager.PutSecretValueOutput, error)
+
+	TagResource(ctx context.Context,
+		params *secretsmanager.TagResourceInput, optFns ...func(*secretsmanager.Options),
+	) (*secretsmanager.TagResourceOutput, error)
+
+	UntagResource(ctx context.Context,
+		params *secretsmanager.UntagResourceInput, optFns ...func(*secretsmanager.Options),
+	) (*secretsmanager.UntagResourceOutput, error)
 }
 
 // interface guard to ensure that all methods defined in the SecretsManagerApiClient
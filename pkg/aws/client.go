@@ -33,6 +33,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cloudformationtypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
@@ -107,16 +108,26 @@ type Client interface {
 	ValidateCredentials() (isValid bool, err error)
 	EnsureOsdCcsAdminUser(stackName string, adminUserName string, awsRegion string) (bool, error)
 	DeleteOsdCcsAdminUser(stackName string) error
+	CreateStackWithParameters(cfTemplateBody string, stackName string, params map[string]string,
+		tags map[string]string, timeout time.Duration) (map[string]string, error)
+	DeleteStack(stackName string) error
+	DeleteStackWithTimeout(stackName string, timeout time.Duration) error
+	DescribeStack(stackName string) (*cloudformationtypes.Stack, error)
+	ListStacksWithTag(key string) ([]cloudformationtypes.Stack, error)
 	AccessKeyGetter
 	GetCreator() (*Creator, error)
 	ValidateSCP(*string, map[string]*cmv1.AWSSTSPolicy) (bool, error)
+	SimulatePermissions(principalArn string, actions []string) ([]PermissionDecision, error)
 	ListSubnets(subnetIds ...string) ([]ec2types.Subnet, error)
 	GetSubnetAvailabilityZone(subnetID string) (string, error)
 	GetAvailabilityZoneType(availabilityZoneName string) (string, error)
 	GetVPCSubnets(subnetID string) ([]ec2types.Subnet, error)
 	GetVPCPrivateSubnets(subnetID string) ([]ec2types.Subnet, error)
 	FilterVPCsPrivateSubnets(subnets []ec2types.Subnet) ([]ec2types.Subnet, error)
-	ValidateQuota() (bool, error)
+	GetServiceQuotaValue(serviceCode string, quotaCode string) (float64, error)
+	RequestServiceQuotaIncrease(serviceCode string, quotaCode string, desiredValue float64) (string, error)
+	GetInstanceTypeVCPUs(instanceTypes []string) (map[string]int, error)
+	GetQuotaUsage() (*QuotaUsage, error)
 	TagUserRegion(username string, region string) error
 	GetClusterRegionTagForUser(username string) (string, error)
 	EnsureRole(reporter *reporter.Object, name string, policy string, permissionsBoundary string,
@@ -128,9 +139,12 @@ type Client interface {
 	EnsurePolicy(policyArn string, document string, version string, tagList map[string]string,
 		path string) (string, error)
 	AttachRolePolicy(reporter *reporter.Object, roleName string, policyARN string) error
-	CreateOpenIDConnectProvider(issuerURL string, thumbprint string, clusterID string) (string, error)
+	CreateOpenIDConnectProvider(issuerURL string, thumbprint string, clusterID string,
+		tagList map[string]string) (string, error)
 	DeleteOpenIDConnectProvider(providerURL string) error
 	HasOpenIDConnectProvider(issuerURL string, partition string, accountID string) (bool, error)
+	GetOpenIDConnectProviderThumbprints(issuerURL string, partition string, accountID string) ([]string, bool, error)
+	GetOpenIDConnectProvider(oidcProviderARN string) (*iam.GetOpenIDConnectProviderOutput, error)
 	FindRoleARNs(roleType string, version string) ([]string, error)
 	FindRoleARNsClassic(roleType string, version string) ([]string, error)
 	FindRoleARNsHostedCp(roleType string, version string) ([]string, error)
@@ -183,6 +197,16 @@ type Client interface {
 	) (bool, error)
 	UpdateTag(roleName string, defaultPolicyVersion string) error
 	AddRoleTag(roleName string, key string, value string) error
+	TagRole(roleName string, tagList map[string]string) error
+	UntagRole(roleName string, keys []string) error
+	TagOpenIDConnectProvider(providerARN string, tagList map[string]string) error
+	UntagOpenIDConnectProvider(providerARN string, keys []string) error
+	TagS3Bucket(bucketName string, tagList map[string]string) error
+	UntagS3Bucket(bucketName string, keys []string) error
+	TagSecret(secretArn string, tagList map[string]string) error
+	UntagSecret(secretArn string, keys []string) error
+	GetSecretTags(secretArn string) (map[string]string, error)
+	UpdateAssumeRolePolicy(roleName string, policy string) error
 	IsPolicyCompatible(policyArn string, version string) (bool, error)
 	GetAccountRoleVersion(roleName string) (string, error)
 	IsPolicyExists(policyARN string) (*iam.GetPolicyOutput, error)
@@ -207,6 +231,8 @@ type Client interface {
 	PutPublicReadObjectInS3Bucket(bucketName string, body io.ReadSeeker, key string) error
 	CreateSecretInSecretsManager(name string, secret string) (string, error)
 	DeleteSecretInSecretsManager(secretArn string) error
+	GetSecretInSecretsManager(secretArn string) (string, error)
+	UpdateSecretInSecretsManager(secretArn string, secret string) error
 	ValidateAccountRoleVersionCompatibility(roleName string, roleType string, minVersion string) (bool, error)
 	GetDefaultPolicyDocument(policyArn string) (string, error)
 	GetAccountRoleByArn(roleArn string) (Role, error)
@@ -1292,6 +1318,26 @@ func (c *awsClient) DeleteSecretInSecretsManager(secretArn string) error {
 	return nil
 }
 
+func (c *awsClient) GetSecretInSecretsManager(secretArn string) (string, error) {
+	getSecretValueResponse, err := c.smClient.GetSecretValue(context.Background(),
+		&secretsmanager.GetSecretValueInput{
+			SecretId: aws.String(secretArn),
+		})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(getSecretValueResponse.SecretString), nil
+}
+
+func (c *awsClient) UpdateSecretInSecretsManager(secretArn string, secret string) error {
+	_, err := c.smClient.PutSecretValue(context.Background(),
+		&secretsmanager.PutSecretValueInput{
+			SecretId:     aws.String(secretArn),
+			SecretString: aws.String(secret),
+		})
+	return err
+}
+
 func (c *awsClient) GetSecurityGroupIds(vpcId string) ([]ec2types.SecurityGroup, error) {
 	describeSecurityGroupsInput := &ec2.DescribeSecurityGroupsInput{
 		Filters: []ec2types.Filter{
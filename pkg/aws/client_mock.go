@@ -11,11 +11,13 @@ package aws
 import (
 	io "io"
 	reflect "reflect"
+	time "time"
 
 	aws "github.com/aws/aws-sdk-go-v2/aws"
-	types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	types "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	types0 "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	iam "github.com/aws/aws-sdk-go-v2/service/iam"
-	types0 "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	types1 "github.com/aws/aws-sdk-go-v2/service/iam/types"
 	servicequotas "github.com/aws/aws-sdk-go-v2/service/servicequotas"
 	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	reporter "github.com/openshift/rosa/pkg/reporter"
@@ -134,18 +136,18 @@ func (mr *MockClientMockRecorder) CheckStackReadyOrNotExisting(stackName any) *g
 }
 
 // CreateOpenIDConnectProvider mocks base method.
-func (m *MockClient) CreateOpenIDConnectProvider(issuerURL, thumbprint, clusterID string) (string, error) {
+func (m *MockClient) CreateOpenIDConnectProvider(issuerURL, thumbprint, clusterID string, tagList map[string]string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOpenIDConnectProvider", issuerURL, thumbprint, clusterID)
+	ret := m.ctrl.Call(m, "CreateOpenIDConnectProvider", issuerURL, thumbprint, clusterID, tagList)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateOpenIDConnectProvider indicates an expected call of CreateOpenIDConnectProvider.
-func (mr *MockClientMockRecorder) CreateOpenIDConnectProvider(issuerURL, thumbprint, clusterID any) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateOpenIDConnectProvider(issuerURL, thumbprint, clusterID, tagList any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOpenIDConnectProvider", reflect.TypeOf((*MockClient)(nil).CreateOpenIDConnectProvider), issuerURL, thumbprint, clusterID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOpenIDConnectProvider", reflect.TypeOf((*MockClient)(nil).CreateOpenIDConnectProvider), issuerURL, thumbprint, clusterID, tagList)
 }
 
 // CreateS3Bucket mocks base method.
@@ -177,6 +179,21 @@ func (mr *MockClientMockRecorder) CreateSecretInSecretsManager(name, secret any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecretInSecretsManager", reflect.TypeOf((*MockClient)(nil).CreateSecretInSecretsManager), name, secret)
 }
 
+// CreateStackWithParameters mocks base method.
+func (m *MockClient) CreateStackWithParameters(cfTemplateBody, stackName string, params, tags map[string]string, timeout time.Duration) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStackWithParameters", cfTemplateBody, stackName, params, tags, timeout)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStackWithParameters indicates an expected call of CreateStackWithParameters.
+func (mr *MockClientMockRecorder) CreateStackWithParameters(cfTemplateBody, stackName, params, tags, timeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStackWithParameters", reflect.TypeOf((*MockClient)(nil).CreateStackWithParameters), cfTemplateBody, stackName, params, tags, timeout)
+}
+
 // DeleteAccountRole mocks base method.
 func (m *MockClient) DeleteAccountRole(roleName, prefix string, managedPolicies bool) error {
 	m.ctrl.T.Helper()
@@ -289,6 +306,34 @@ func (mr *MockClientMockRecorder) DeleteSecretInSecretsManager(secretArn any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecretInSecretsManager", reflect.TypeOf((*MockClient)(nil).DeleteSecretInSecretsManager), secretArn)
 }
 
+// DeleteStack mocks base method.
+func (m *MockClient) DeleteStack(stackName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStack", stackName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStack indicates an expected call of DeleteStack.
+func (mr *MockClientMockRecorder) DeleteStack(stackName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStack", reflect.TypeOf((*MockClient)(nil).DeleteStack), stackName)
+}
+
+// DeleteStackWithTimeout mocks base method.
+func (m *MockClient) DeleteStackWithTimeout(stackName string, timeout time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStackWithTimeout", stackName, timeout)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStackWithTimeout indicates an expected call of DeleteStackWithTimeout.
+func (mr *MockClientMockRecorder) DeleteStackWithTimeout(stackName, timeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStackWithTimeout", reflect.TypeOf((*MockClient)(nil).DeleteStackWithTimeout), stackName, timeout)
+}
+
 // DeleteUserRole mocks base method.
 func (m *MockClient) DeleteUserRole(roleName string) error {
 	m.ctrl.T.Helper()
@@ -318,6 +363,21 @@ func (mr *MockClientMockRecorder) DescribeAvailabilityZones() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAvailabilityZones", reflect.TypeOf((*MockClient)(nil).DescribeAvailabilityZones))
 }
 
+// DescribeStack mocks base method.
+func (m *MockClient) DescribeStack(stackName string) (*types.Stack, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeStack", stackName)
+	ret0, _ := ret[0].(*types.Stack)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeStack indicates an expected call of DescribeStack.
+func (mr *MockClientMockRecorder) DescribeStack(stackName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeStack", reflect.TypeOf((*MockClient)(nil).DescribeStack), stackName)
+}
+
 // DetachRolePolicies mocks base method.
 func (m *MockClient) DetachRolePolicies(roleName string) error {
 	m.ctrl.T.Helper()
@@ -392,7 +452,7 @@ func (mr *MockClientMockRecorder) EnsureRole(reporter, name, policy, permissions
 }
 
 // FetchPublicSubnetMap mocks base method.
-func (m *MockClient) FetchPublicSubnetMap(subnets []types.Subnet) (map[string]bool, error) {
+func (m *MockClient) FetchPublicSubnetMap(subnets []types0.Subnet) (map[string]bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FetchPublicSubnetMap", subnets)
 	ret0, _ := ret[0].(map[string]bool)
@@ -407,10 +467,10 @@ func (mr *MockClientMockRecorder) FetchPublicSubnetMap(subnets any) *gomock.Call
 }
 
 // FilterVPCsPrivateSubnets mocks base method.
-func (m *MockClient) FilterVPCsPrivateSubnets(subnets []types.Subnet) ([]types.Subnet, error) {
+func (m *MockClient) FilterVPCsPrivateSubnets(subnets []types0.Subnet) ([]types0.Subnet, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FilterVPCsPrivateSubnets", subnets)
-	ret0, _ := ret[0].([]types.Subnet)
+	ret0, _ := ret[0].([]types0.Subnet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -752,6 +812,21 @@ func (mr *MockClientMockRecorder) GetInstanceProfilesForRole(role any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceProfilesForRole", reflect.TypeOf((*MockClient)(nil).GetInstanceProfilesForRole), role)
 }
 
+// GetInstanceTypeVCPUs mocks base method.
+func (m *MockClient) GetInstanceTypeVCPUs(instanceTypes []string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstanceTypeVCPUs", instanceTypes)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstanceTypeVCPUs indicates an expected call of GetInstanceTypeVCPUs.
+func (mr *MockClientMockRecorder) GetInstanceTypeVCPUs(instanceTypes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceTypeVCPUs", reflect.TypeOf((*MockClient)(nil).GetInstanceTypeVCPUs), instanceTypes)
+}
+
 // GetLocalAWSAccessKeys mocks base method.
 func (m *MockClient) GetLocalAWSAccessKeys() (*AccessKey, error) {
 	m.ctrl.T.Helper()
@@ -767,6 +842,21 @@ func (mr *MockClientMockRecorder) GetLocalAWSAccessKeys() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocalAWSAccessKeys", reflect.TypeOf((*MockClient)(nil).GetLocalAWSAccessKeys))
 }
 
+// GetOpenIDConnectProvider mocks base method.
+func (m *MockClient) GetOpenIDConnectProvider(oidcProviderARN string) (*iam.GetOpenIDConnectProviderOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenIDConnectProvider", oidcProviderARN)
+	ret0, _ := ret[0].(*iam.GetOpenIDConnectProviderOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenIDConnectProvider indicates an expected call of GetOpenIDConnectProvider.
+func (mr *MockClientMockRecorder) GetOpenIDConnectProvider(oidcProviderARN any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenIDConnectProvider", reflect.TypeOf((*MockClient)(nil).GetOpenIDConnectProvider), oidcProviderARN)
+}
+
 // GetOpenIDConnectProviderByClusterIdTag mocks base method.
 func (m *MockClient) GetOpenIDConnectProviderByClusterIdTag(clusterID string) (string, error) {
 	m.ctrl.T.Helper()
@@ -797,6 +887,22 @@ func (mr *MockClientMockRecorder) GetOpenIDConnectProviderByOidcEndpointUrl(oidc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenIDConnectProviderByOidcEndpointUrl", reflect.TypeOf((*MockClient)(nil).GetOpenIDConnectProviderByOidcEndpointUrl), oidcEndpointUrl)
 }
 
+// GetOpenIDConnectProviderThumbprints mocks base method.
+func (m *MockClient) GetOpenIDConnectProviderThumbprints(issuerURL, partition, accountID string) ([]string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenIDConnectProviderThumbprints", issuerURL, partition, accountID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOpenIDConnectProviderThumbprints indicates an expected call of GetOpenIDConnectProviderThumbprints.
+func (mr *MockClientMockRecorder) GetOpenIDConnectProviderThumbprints(issuerURL, partition, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenIDConnectProviderThumbprints", reflect.TypeOf((*MockClient)(nil).GetOpenIDConnectProviderThumbprints), issuerURL, partition, accountID)
+}
+
 // GetOperatorRoleDefaultPolicy mocks base method.
 func (m *MockClient) GetOperatorRoleDefaultPolicy(roleName string) (string, error) {
 	m.ctrl.T.Helper()
@@ -858,6 +964,21 @@ func (mr *MockClientMockRecorder) GetOperatorRolesFromAccountByPrefix(prefix, cr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperatorRolesFromAccountByPrefix", reflect.TypeOf((*MockClient)(nil).GetOperatorRolesFromAccountByPrefix), prefix, credRequest)
 }
 
+// GetQuotaUsage mocks base method.
+func (m *MockClient) GetQuotaUsage() (*QuotaUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuotaUsage")
+	ret0, _ := ret[0].(*QuotaUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuotaUsage indicates an expected call of GetQuotaUsage.
+func (mr *MockClientMockRecorder) GetQuotaUsage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotaUsage", reflect.TypeOf((*MockClient)(nil).GetQuotaUsage))
+}
+
 // GetRegion mocks base method.
 func (m *MockClient) GetRegion() string {
 	m.ctrl.T.Helper()
@@ -888,10 +1009,10 @@ func (mr *MockClientMockRecorder) GetRoleARNPath(prefix any) *gomock.Call {
 }
 
 // GetRoleByARN mocks base method.
-func (m *MockClient) GetRoleByARN(roleARN string) (types0.Role, error) {
+func (m *MockClient) GetRoleByARN(roleARN string) (types1.Role, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetRoleByARN", roleARN)
-	ret0, _ := ret[0].(types0.Role)
+	ret0, _ := ret[0].(types1.Role)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -903,10 +1024,10 @@ func (mr *MockClientMockRecorder) GetRoleByARN(roleARN any) *gomock.Call {
 }
 
 // GetRoleByName mocks base method.
-func (m *MockClient) GetRoleByName(roleName string) (types0.Role, error) {
+func (m *MockClient) GetRoleByName(roleName string) (types1.Role, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetRoleByName", roleName)
-	ret0, _ := ret[0].(types0.Role)
+	ret0, _ := ret[0].(types1.Role)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -917,11 +1038,41 @@ func (mr *MockClientMockRecorder) GetRoleByName(roleName any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByName", reflect.TypeOf((*MockClient)(nil).GetRoleByName), roleName)
 }
 
+// GetSecretInSecretsManager mocks base method.
+func (m *MockClient) GetSecretInSecretsManager(secretArn string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretInSecretsManager", secretArn)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretInSecretsManager indicates an expected call of GetSecretInSecretsManager.
+func (mr *MockClientMockRecorder) GetSecretInSecretsManager(secretArn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretInSecretsManager", reflect.TypeOf((*MockClient)(nil).GetSecretInSecretsManager), secretArn)
+}
+
+// GetSecretTags mocks base method.
+func (m *MockClient) GetSecretTags(secretArn string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretTags", secretArn)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretTags indicates an expected call of GetSecretTags.
+func (mr *MockClientMockRecorder) GetSecretTags(secretArn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretTags", reflect.TypeOf((*MockClient)(nil).GetSecretTags), secretArn)
+}
+
 // GetSecurityGroupIds mocks base method.
-func (m *MockClient) GetSecurityGroupIds(vpcId string) ([]types.SecurityGroup, error) {
+func (m *MockClient) GetSecurityGroupIds(vpcId string) ([]types0.SecurityGroup, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSecurityGroupIds", vpcId)
-	ret0, _ := ret[0].([]types.SecurityGroup)
+	ret0, _ := ret[0].([]types0.SecurityGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -932,6 +1083,21 @@ func (mr *MockClientMockRecorder) GetSecurityGroupIds(vpcId any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityGroupIds", reflect.TypeOf((*MockClient)(nil).GetSecurityGroupIds), vpcId)
 }
 
+// GetServiceQuotaValue mocks base method.
+func (m *MockClient) GetServiceQuotaValue(serviceCode, quotaCode string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceQuotaValue", serviceCode, quotaCode)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceQuotaValue indicates an expected call of GetServiceQuotaValue.
+func (mr *MockClientMockRecorder) GetServiceQuotaValue(serviceCode, quotaCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceQuotaValue", reflect.TypeOf((*MockClient)(nil).GetServiceQuotaValue), serviceCode, quotaCode)
+}
+
 // GetSubnetAvailabilityZone mocks base method.
 func (m *MockClient) GetSubnetAvailabilityZone(subnetID string) (string, error) {
 	m.ctrl.T.Helper()
@@ -948,10 +1114,10 @@ func (mr *MockClientMockRecorder) GetSubnetAvailabilityZone(subnetID any) *gomoc
 }
 
 // GetVPCPrivateSubnets mocks base method.
-func (m *MockClient) GetVPCPrivateSubnets(subnetID string) ([]types.Subnet, error) {
+func (m *MockClient) GetVPCPrivateSubnets(subnetID string) ([]types0.Subnet, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetVPCPrivateSubnets", subnetID)
-	ret0, _ := ret[0].([]types.Subnet)
+	ret0, _ := ret[0].([]types0.Subnet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -963,10 +1129,10 @@ func (mr *MockClientMockRecorder) GetVPCPrivateSubnets(subnetID any) *gomock.Cal
 }
 
 // GetVPCSubnets mocks base method.
-func (m *MockClient) GetVPCSubnets(subnetID string) ([]types.Subnet, error) {
+func (m *MockClient) GetVPCSubnets(subnetID string) ([]types0.Subnet, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetVPCSubnets", subnetID)
-	ret0, _ := ret[0].([]types.Subnet)
+	ret0, _ := ret[0].([]types0.Subnet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1262,15 +1428,30 @@ func (mr *MockClientMockRecorder) ListOperatorRoles(version, clusterID, prefix a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOperatorRoles", reflect.TypeOf((*MockClient)(nil).ListOperatorRoles), version, clusterID, prefix)
 }
 
+// ListStacksWithTag mocks base method.
+func (m *MockClient) ListStacksWithTag(key string) ([]types.Stack, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStacksWithTag", key)
+	ret0, _ := ret[0].([]types.Stack)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStacksWithTag indicates an expected call of ListStacksWithTag.
+func (mr *MockClientMockRecorder) ListStacksWithTag(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStacksWithTag", reflect.TypeOf((*MockClient)(nil).ListStacksWithTag), key)
+}
+
 // ListSubnets mocks base method.
-func (m *MockClient) ListSubnets(subnetIds ...string) ([]types.Subnet, error) {
+func (m *MockClient) ListSubnets(subnetIds ...string) ([]types0.Subnet, error) {
 	m.ctrl.T.Helper()
 	varargs := []any{}
 	for _, a := range subnetIds {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "ListSubnets", varargs...)
-	ret0, _ := ret[0].([]types.Subnet)
+	ret0, _ := ret[0].([]types0.Subnet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1324,6 +1505,92 @@ func (mr *MockClientMockRecorder) PutRolePolicy(roleName, policyName, policy any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutRolePolicy", reflect.TypeOf((*MockClient)(nil).PutRolePolicy), roleName, policyName, policy)
 }
 
+// RequestServiceQuotaIncrease mocks base method.
+func (m *MockClient) RequestServiceQuotaIncrease(serviceCode, quotaCode string, desiredValue float64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestServiceQuotaIncrease", serviceCode, quotaCode, desiredValue)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestServiceQuotaIncrease indicates an expected call of RequestServiceQuotaIncrease.
+func (mr *MockClientMockRecorder) RequestServiceQuotaIncrease(serviceCode, quotaCode, desiredValue any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestServiceQuotaIncrease", reflect.TypeOf((*MockClient)(nil).RequestServiceQuotaIncrease), serviceCode, quotaCode, desiredValue)
+}
+
+// SimulatePermissions mocks base method.
+func (m *MockClient) SimulatePermissions(principalArn string, actions []string) ([]PermissionDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulatePermissions", principalArn, actions)
+	ret0, _ := ret[0].([]PermissionDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulatePermissions indicates an expected call of SimulatePermissions.
+func (mr *MockClientMockRecorder) SimulatePermissions(principalArn, actions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulatePermissions", reflect.TypeOf((*MockClient)(nil).SimulatePermissions), principalArn, actions)
+}
+
+// TagOpenIDConnectProvider mocks base method.
+func (m *MockClient) TagOpenIDConnectProvider(providerARN string, tagList map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagOpenIDConnectProvider", providerARN, tagList)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TagOpenIDConnectProvider indicates an expected call of TagOpenIDConnectProvider.
+func (mr *MockClientMockRecorder) TagOpenIDConnectProvider(providerARN, tagList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagOpenIDConnectProvider", reflect.TypeOf((*MockClient)(nil).TagOpenIDConnectProvider), providerARN, tagList)
+}
+
+// TagRole mocks base method.
+func (m *MockClient) TagRole(roleName string, tagList map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagRole", roleName, tagList)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TagRole indicates an expected call of TagRole.
+func (mr *MockClientMockRecorder) TagRole(roleName, tagList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagRole", reflect.TypeOf((*MockClient)(nil).TagRole), roleName, tagList)
+}
+
+// TagS3Bucket mocks base method.
+func (m *MockClient) TagS3Bucket(bucketName string, tagList map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagS3Bucket", bucketName, tagList)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TagS3Bucket indicates an expected call of TagS3Bucket.
+func (mr *MockClientMockRecorder) TagS3Bucket(bucketName, tagList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagS3Bucket", reflect.TypeOf((*MockClient)(nil).TagS3Bucket), bucketName, tagList)
+}
+
+// TagSecret mocks base method.
+func (m *MockClient) TagSecret(secretArn string, tagList map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagSecret", secretArn, tagList)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TagSecret indicates an expected call of TagSecret.
+func (mr *MockClientMockRecorder) TagSecret(secretArn, tagList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagSecret", reflect.TypeOf((*MockClient)(nil).TagSecret), secretArn, tagList)
+}
+
 // TagUserRegion mocks base method.
 func (m *MockClient) TagUserRegion(username, region string) error {
 	m.ctrl.T.Helper()
@@ -1338,6 +1605,90 @@ func (mr *MockClientMockRecorder) TagUserRegion(username, region any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagUserRegion", reflect.TypeOf((*MockClient)(nil).TagUserRegion), username, region)
 }
 
+// UntagOpenIDConnectProvider mocks base method.
+func (m *MockClient) UntagOpenIDConnectProvider(providerARN string, keys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagOpenIDConnectProvider", providerARN, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UntagOpenIDConnectProvider indicates an expected call of UntagOpenIDConnectProvider.
+func (mr *MockClientMockRecorder) UntagOpenIDConnectProvider(providerARN, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagOpenIDConnectProvider", reflect.TypeOf((*MockClient)(nil).UntagOpenIDConnectProvider), providerARN, keys)
+}
+
+// UntagRole mocks base method.
+func (m *MockClient) UntagRole(roleName string, keys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagRole", roleName, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UntagRole indicates an expected call of UntagRole.
+func (mr *MockClientMockRecorder) UntagRole(roleName, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagRole", reflect.TypeOf((*MockClient)(nil).UntagRole), roleName, keys)
+}
+
+// UntagS3Bucket mocks base method.
+func (m *MockClient) UntagS3Bucket(bucketName string, keys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagS3Bucket", bucketName, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UntagS3Bucket indicates an expected call of UntagS3Bucket.
+func (mr *MockClientMockRecorder) UntagS3Bucket(bucketName, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagS3Bucket", reflect.TypeOf((*MockClient)(nil).UntagS3Bucket), bucketName, keys)
+}
+
+// UntagSecret mocks base method.
+func (m *MockClient) UntagSecret(secretArn string, keys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagSecret", secretArn, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UntagSecret indicates an expected call of UntagSecret.
+func (mr *MockClientMockRecorder) UntagSecret(secretArn, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagSecret", reflect.TypeOf((*MockClient)(nil).UntagSecret), secretArn, keys)
+}
+
+// UpdateAssumeRolePolicy mocks base method.
+func (m *MockClient) UpdateAssumeRolePolicy(roleName, policy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAssumeRolePolicy", roleName, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAssumeRolePolicy indicates an expected call of UpdateAssumeRolePolicy.
+func (mr *MockClientMockRecorder) UpdateAssumeRolePolicy(roleName, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAssumeRolePolicy", reflect.TypeOf((*MockClient)(nil).UpdateAssumeRolePolicy), roleName, policy)
+}
+
+// UpdateSecretInSecretsManager mocks base method.
+func (m *MockClient) UpdateSecretInSecretsManager(secretArn, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecretInSecretsManager", secretArn, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecretInSecretsManager indicates an expected call of UpdateSecretInSecretsManager.
+func (mr *MockClientMockRecorder) UpdateSecretInSecretsManager(secretArn, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecretInSecretsManager", reflect.TypeOf((*MockClient)(nil).UpdateSecretInSecretsManager), secretArn, secret)
+}
+
 // UpdateTag mocks base method.
 func (m *MockClient) UpdateTag(roleName, defaultPolicyVersion string) error {
 	m.ctrl.T.Helper()
@@ -1424,21 +1775,6 @@ func (mr *MockClientMockRecorder) ValidateOperatorRolesManagedPolicies(cluster,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateOperatorRolesManagedPolicies", reflect.TypeOf((*MockClient)(nil).ValidateOperatorRolesManagedPolicies), cluster, operatorRoles, policies, hostedCPPolicies)
 }
 
-// ValidateQuota mocks base method.
-func (m *MockClient) ValidateQuota() (bool, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateQuota")
-	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// ValidateQuota indicates an expected call of ValidateQuota.
-func (mr *MockClientMockRecorder) ValidateQuota() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateQuota", reflect.TypeOf((*MockClient)(nil).ValidateQuota))
-}
-
 // ValidateRoleARNAccountIDMatchCallerAccountID mocks base method.
 func (m *MockClient) ValidateRoleARNAccountIDMatchCallerAccountID(roleARN string) error {
 	m.ctrl.T.Helper()
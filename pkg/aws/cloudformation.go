@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
@@ -178,6 +179,51 @@ func (c *awsClient) CheckStackReadyOrNotExisting(stackName string) (stackReady b
 }
 
 func (c *awsClient) DeleteOsdCcsAdminUser(stackName string) error {
+	return c.DeleteStack(stackName)
+}
+
+// CreateStackWithParameters creates a CloudFormation stack from the template, waits up to the
+// timeout for it to complete and returns the outputs of the stack by key.
+func (c *awsClient) CreateStackWithParameters(cfTemplateBody string, stackName string, params map[string]string,
+	tags map[string]string, timeout time.Duration) (map[string]string, error) {
+	input := buildCreateStackInput(cfTemplateBody, stackName)
+	for key, value := range params {
+		input.Parameters = append(input.Parameters, cloudformationtypes.Parameter{
+			ParameterKey:   aws.String(key),
+			ParameterValue: aws.String(value),
+		})
+	}
+	for key, value := range tags {
+		input.Tags = append(input.Tags, cloudformationtypes.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(value),
+		})
+	}
+	_, err := c.cfClient.CreateStack(context.Background(), input)
+	if err != nil {
+		return nil, err
+	}
+
+	waiter := cloudformation.NewStackCreateCompleteWaiter(c.cfClient)
+	stacks, err := waiter.WaitForOutput(context.Background(), buildDescribeStacksInput(stackName), timeout)
+	if err != nil {
+		return nil, err
+	}
+	outputs := map[string]string{}
+	for _, stack := range stacks.Stacks {
+		for _, output := range stack.Outputs {
+			outputs[aws.ToString(output.OutputKey)] = aws.ToString(output.OutputValue)
+		}
+	}
+	return outputs, nil
+}
+
+func (c *awsClient) DeleteStack(stackName string) error {
+	return c.DeleteStackWithTimeout(stackName, maxWaitDur)
+}
+
+// DeleteStackWithTimeout deletes the stack and waits up to the timeout for the deletion to complete
+func (c *awsClient) DeleteStackWithTimeout(stackName string, timeout time.Duration) error {
 	deleteStackInput := &cloudformation.DeleteStackInput{
 		StackName: aws.String(stackName),
 	}
@@ -193,7 +239,7 @@ func (c *awsClient) DeleteOsdCcsAdminUser(stackName string) error {
 	}
 
 	// Wait until cloudformation stack deletes
-	err = waitForStackDeleteComplete(context.Background(), c.cfClient, stackName)
+	err = waitForStackDeleteComplete(context.Background(), c.cfClient, stackName, timeout)
 	if err != nil {
 		return err
 	}
@@ -201,6 +247,44 @@ func (c *awsClient) DeleteOsdCcsAdminUser(stackName string) error {
 	return nil
 }
 
+// DescribeStack returns the stack, or nil if it doesn't exist
+func (c *awsClient) DescribeStack(stackName string) (*cloudformationtypes.Stack, error) {
+	output, err := c.cfClient.DescribeStacks(context.Background(), buildDescribeStacksInput(stackName))
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "ValidationError" &&
+			strings.Contains(apiErr.ErrorMessage(), "does not exist") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(output.Stacks) == 0 {
+		return nil, nil
+	}
+	return &output.Stacks[0], nil
+}
+
+// ListStacksWithTag returns the stacks, other than deleted ones, that have the tag whatever its value
+func (c *awsClient) ListStacksWithTag(key string) ([]cloudformationtypes.Stack, error) {
+	stacks := []cloudformationtypes.Stack{}
+	paginator := cloudformation.NewDescribeStacksPaginator(c.cfClient, &cloudformation.DescribeStacksInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		for _, stack := range output.Stacks {
+			for _, tag := range stack.Tags {
+				if aws.ToString(tag.Key) == key {
+					stacks = append(stacks, stack)
+					break
+				}
+			}
+		}
+	}
+	return stacks, nil
+}
+
 // Build cloudformation create stack input
 func buildCreateStackInput(cfTemplateBody, stackName string) *cloudformation.CreateStackInput {
 	// Special cloudformation capabilities are required to create IAM resources in AWS
@@ -0,0 +1,130 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ROSA commands whose required AWS permissions can be verified with 'rosa verify permissions'
+const (
+	CreateClusterPermissions              = "create-cluster"
+	CreateAccountRolesPermissions         = "create-account-roles"
+	CreateOperatorRolesPermissions        = "create-operator-roles"
+	CreateOidcConfigPermissions           = "create-oidc-config"
+	CreateOidcConfigCloudFrontPermissions = "create-oidc-config-cloudfront"
+)
+
+// Actions needed to create and update the roles and customer managed policies of ROSA
+var rolePermissions = []string{
+	"iam:AttachRolePolicy",
+	"iam:CreatePolicy",
+	"iam:CreatePolicyVersion",
+	"iam:CreateRole",
+	"iam:DeletePolicyVersion",
+	"iam:GetPolicy",
+	"iam:GetRole",
+	"iam:ListAttachedRolePolicies",
+	"iam:ListPolicyTags",
+	"iam:ListPolicyVersions",
+	"iam:ListRoleTags",
+	"iam:ListRoles",
+	"iam:PutRolePermissionsBoundary",
+	"iam:TagPolicy",
+	"iam:TagRole",
+	"iam:UpdateAssumeRolePolicy",
+}
+
+// Actions needed to store the private key of an unmanaged OIDC configuration and to create the OIDC
+// provider of its issuer
+var oidcConfigPermissions = []string{
+	"sts:GetCallerIdentity",
+	"iam:CreateOpenIDConnectProvider",
+	"iam:GetOpenIDConnectProvider",
+	"iam:ListOpenIDConnectProviders",
+	"iam:TagOpenIDConnectProvider",
+	"s3:PutObject",
+	"s3:PutObjectTagging",
+	"secretsmanager:CreateSecret",
+	"secretsmanager:TagResource",
+}
+
+// commandPermissions lists the AWS actions each command calls with the credentials of the user.
+// Actions performed afterwards by the installer or by the cluster using the account and operator
+// roles are not included, they are granted to those roles.
+var commandPermissions = map[string][]string{
+	CreateClusterPermissions: {
+		"sts:GetCallerIdentity",
+		"iam:GetOpenIDConnectProvider",
+		"iam:GetPolicy",
+		"iam:GetRole",
+		"iam:ListAttachedRolePolicies",
+		"iam:ListOpenIDConnectProviders",
+		"iam:ListPolicyTags",
+		"iam:ListRoleTags",
+		"iam:ListRoles",
+		"ec2:DescribeAvailabilityZones",
+		"ec2:DescribeInstanceTypeOfferings",
+		"ec2:DescribeRegions",
+		"ec2:DescribeRouteTables",
+		"ec2:DescribeSecurityGroups",
+		"ec2:DescribeSubnets",
+		"ec2:DescribeVpcs",
+		"kms:DescribeKey",
+		"servicequotas:GetServiceQuota",
+		"servicequotas:ListServiceQuotas",
+	},
+	CreateAccountRolesPermissions: append([]string{
+		"sts:GetCallerIdentity",
+	}, rolePermissions...),
+	CreateOperatorRolesPermissions: append([]string{
+		"sts:GetCallerIdentity",
+		"iam:GetOpenIDConnectProvider",
+	}, rolePermissions...),
+	// The S3 backend, the raw files backend saves the documents locally without calling AWS
+	CreateOidcConfigPermissions: append([]string{
+		"s3:CreateBucket",
+		"s3:PutBucketPolicy",
+		"s3:PutBucketPublicAccessBlock",
+		"s3:PutBucketTagging",
+	}, oidcConfigPermissions...),
+	// CloudFormation creates the resources of the stack with the credentials of the user
+	CreateOidcConfigCloudFrontPermissions: append([]string{
+		"cloudformation:CreateStack",
+		"cloudformation:DescribeStacks",
+		"cloudformation:TagResource",
+		"cloudfront:CreateDistribution",
+		"cloudfront:CreateOriginAccessControl",
+		"cloudfront:GetDistribution",
+		"cloudfront:GetOriginAccessControl",
+		"cloudfront:TagResource",
+		"s3:CreateBucket",
+		"s3:GetBucketPolicy",
+		"s3:PutBucketPolicy",
+		"s3:PutBucketPublicAccessBlock",
+		"s3:PutBucketTagging",
+	}, oidcConfigPermissions...),
+}
+
+// PermissionsCommands returns the commands whose required permissions are known.
+func PermissionsCommands() []string {
+	commands := make([]string, 0, len(commandPermissions))
+	for command := range commandPermissions {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+// GetCommandPermissions returns the sorted AWS actions the command calls.
+func GetCommandPermissions(command string) ([]string, error) {
+	actions, ok := commandPermissions[command]
+	if !ok {
+		return nil, fmt.Errorf("Invalid command '%s', expected one of: %s", command,
+			strings.Join(PermissionsCommands(), ", "))
+	}
+	result := make([]string, len(actions))
+	copy(result, actions)
+	sort.Strings(result)
+	return result, nil
+}
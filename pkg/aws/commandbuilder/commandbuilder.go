@@ -34,6 +34,7 @@ const (
 	CreateOpenIdConnectProvider   Command = "create-open-id-connect-provider"
 	DeleteOpenIdConnectProvider   Command = "delete-open-id-connect-provider"
 	DeleteRolePermissionsBoundary Command = "delete-role-permissions-boundary"
+	UpdateAssumeRolePolicy        Command = "update-assume-role-policy"
 	//S3Api
 	CreateBucket         Command = "create-bucket"
 	PutObject            Command = "put-object"
@@ -44,8 +45,10 @@ const (
 	Remove       Command = "rm"
 	RemoveBucket Command = "rb"
 	//SecretsManager
-	CreateSecret Command = "create-secret"
-	DeleteSecret Command = "delete-secret"
+	CreateSecret   Command = "create-secret"
+	DeleteSecret   Command = "delete-secret"
+	PutSecretValue Command = "put-secret-value"
+	TagResource    Command = "tag-resource"
 )
 
 type Param string
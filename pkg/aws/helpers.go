@@ -1021,13 +1021,14 @@ func waitForStackUpdateComplete(ctx context.Context, cfClient client.CloudFormat
 
 }
 
-func waitForStackDeleteComplete(ctx context.Context, cfClient client.CloudFormationApiClient, stackName string) error {
+func waitForStackDeleteComplete(ctx context.Context, cfClient client.CloudFormationApiClient, stackName string,
+	timeout time.Duration) error {
 	waiter := cloudformation.NewStackDeleteCompleteWaiter(cfClient)
 
 	params := buildDescribeStacksInput(stackName)
 
 	// You can also use WaitForOutput if you need the output
-	return waiter.Wait(ctx, params, maxWaitDur, func(o *cloudformation.StackDeleteCompleteWaiterOptions) {
+	return waiter.Wait(ctx, params, timeout, func(o *cloudformation.StackDeleteCompleteWaiterOptions) {
 		// Optionally set MinDelay, MaxDelay, and other options here
 	})
 }
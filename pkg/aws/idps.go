@@ -34,8 +34,8 @@ const (
 	OIDCClientIDSTSAWS    = "sts.amazonaws.com"
 )
 
-func (c *awsClient) CreateOpenIDConnectProvider(providerURL string, thumbprint string, clusterID string) (
-	string, error) {
+func (c *awsClient) CreateOpenIDConnectProvider(providerURL string, thumbprint string, clusterID string,
+	tagList map[string]string) (string, error) {
 	iamTags := []iamtypes.Tag{
 		{
 			Key:   aws.String(tags.RedHatManaged),
@@ -48,6 +48,7 @@ func (c *awsClient) CreateOpenIDConnectProvider(providerURL string, thumbprint s
 			Value: aws.String(clusterID),
 		})
 	}
+	iamTags = append(iamTags, getTags(tagList)...)
 	output, err := c.iamClient.CreateOpenIDConnectProvider(context.TODO(), &iam.CreateOpenIDConnectProviderInput{
 		ClientIDList: []string{
 			OIDCClientIDOpenShift,
@@ -87,6 +88,44 @@ func (c *awsClient) HasOpenIDConnectProvider(issuerURL string, partition string,
 	return true, nil
 }
 
+// GetOpenIDConnectProviderThumbprints returns the thumbprints of the OIDC provider of the issuer,
+// and false if there is no such provider in the account.
+func (c *awsClient) GetOpenIDConnectProviderThumbprints(issuerURL string, partition string,
+	accountID string) ([]string, bool, error) {
+	parsedIssuerURL, err := url.ParseRequestURI(issuerURL)
+	if err != nil {
+		return nil, false, err
+	}
+	providerURL := fmt.Sprintf("%s%s", parsedIssuerURL.Host, parsedIssuerURL.Path)
+
+	oidcProviderARN := GetOIDCProviderARN(partition, accountID, providerURL)
+	output, err := c.iamClient.GetOpenIDConnectProvider(context.TODO(), &iam.GetOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: aws.String(oidcProviderARN),
+	})
+	if err != nil {
+		if awserr.IsNoSuchEntityException(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return output.ThumbprintList, true, nil
+}
+
+// GetOpenIDConnectProvider returns the OIDC provider with the given ARN, including its creation
+// date and its tags.
+func (c *awsClient) GetOpenIDConnectProvider(oidcProviderARN string) (*iam.GetOpenIDConnectProviderOutput, error) {
+	output, err := c.iamClient.GetOpenIDConnectProvider(context.TODO(), &iam.GetOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: aws.String(oidcProviderARN),
+	})
+	if err != nil {
+		if awserr.IsNoSuchEntityException(err) {
+			return nil, fmt.Errorf("The OIDC provider '%s' does not exist", oidcProviderARN)
+		}
+		return nil, err
+	}
+	return output, nil
+}
+
 func (c *awsClient) DeleteOpenIDConnectProvider(oidcProviderARN string) error {
 	_, err := c.iamClient.DeleteOpenIDConnectProvider(context.TODO(), &iam.DeleteOpenIDConnectProviderInput{
 		OpenIDConnectProviderArn: aws.String(oidcProviderARN),
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper"
 )
 
 // SimulateParams captures any additional details that should be used
@@ -79,3 +83,80 @@ func (c *awsClient) ValidateSCP(target *string, policies map[string]*cmv1.AWSSTS
 
 	return true, nil
 }
+
+const (
+	// DeniedByIdentityPolicy actions are not allowed, or explicitly denied, by the policies of the principal
+	DeniedByIdentityPolicy = "identity policy"
+	// DeniedByPermissionsBoundary actions are not allowed by the permissions boundary of the principal
+	DeniedByPermissionsBoundary = "permissions boundary"
+	// DeniedBySCP actions are denied by a service control policy of the AWS organization
+	DeniedBySCP = "service control policy"
+)
+
+// PermissionDecision is the result of simulating an action for a principal.
+type PermissionDecision struct {
+	Action       string
+	Allowed      bool
+	ExplicitDeny bool
+	DeniedBy     string
+	// Policies are the IDs of the policies whose statements matched the action
+	Policies []string
+}
+
+// NewPermissionDecision explains the result of a policy simulation. Service control policies
+// and permissions boundaries are reported first as allowing the action in the identity policies
+// doesn't help when they deny it.
+func NewPermissionDecision(result iamtypes.EvaluationResult) PermissionDecision {
+	decision := PermissionDecision{
+		Action:       aws.ToString(result.EvalActionName),
+		Allowed:      result.EvalDecision == iamtypes.PolicyEvaluationDecisionTypeAllowed,
+		ExplicitDeny: result.EvalDecision == iamtypes.PolicyEvaluationDecisionTypeExplicitDeny,
+	}
+	for _, statement := range result.MatchedStatements {
+		policyId := aws.ToString(statement.SourcePolicyId)
+		if policyId != "" && !helper.Contains(decision.Policies, policyId) {
+			decision.Policies = append(decision.Policies, policyId)
+		}
+	}
+	if decision.Allowed {
+		return decision
+	}
+	switch {
+	case result.OrganizationsDecisionDetail != nil && !result.OrganizationsDecisionDetail.AllowedByOrganizations:
+		decision.DeniedBy = DeniedBySCP
+	case result.PermissionsBoundaryDecisionDetail != nil &&
+		!result.PermissionsBoundaryDecisionDetail.AllowedByPermissionsBoundary:
+		decision.DeniedBy = DeniedByPermissionsBoundary
+	default:
+		decision.DeniedBy = DeniedByIdentityPolicy
+	}
+	return decision
+}
+
+// SimulatePermissions simulates the actions for the principal in the current region, taking
+// into account its identity policies, permissions boundary and service control policies.
+func (c *awsClient) SimulatePermissions(principalArn string, actions []string) ([]PermissionDecision, error) {
+	input := &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principalArn),
+		ActionNames:     actions,
+		ContextEntries: []iamtypes.ContextEntry{
+			{
+				ContextKeyName:   aws.String("aws:RequestedRegion"),
+				ContextKeyType:   iamtypes.ContextKeyTypeEnumStringList,
+				ContextKeyValues: []string{c.GetRegion()},
+			},
+		},
+	}
+	var decisions []PermissionDecision
+	paginator := iam.NewSimulatePrincipalPolicyPaginator(c.iamClient, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("Error simulating policy: %v", err)
+		}
+		for _, result := range output.EvaluationResults {
+			decisions = append(decisions, NewPermissionDecision(result))
+		}
+	}
+	return decisions, nil
+}
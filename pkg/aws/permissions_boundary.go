@@ -0,0 +1,258 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// Roles whose permission policies can be included in a permissions boundary
+const (
+	PermissionsBoundaryForAccountRoles  = "account-roles"
+	PermissionsBoundaryForOperatorRoles = "operator-roles"
+	PermissionsBoundaryForBoth          = "both"
+)
+
+var PermissionsBoundaryTargets = []string{
+	PermissionsBoundaryForAccountRoles,
+	PermissionsBoundaryForOperatorRoles,
+	PermissionsBoundaryForBoth,
+}
+
+// Infrastructure as code formats the permissions boundary can be written in
+const (
+	IaCFormatCloudFormation = "cloudformation"
+	IaCFormatTerraform      = "terraform"
+)
+
+var IaCFormats = []string{IaCFormatCloudFormation, IaCFormatTerraform}
+
+// maxManagedPolicySize is the IAM quota for the size of a managed policy, whitespace excluded
+const maxManagedPolicySize = 6144
+
+// Prefixes of the read only actions that are replaced by a wildcard when the permissions
+// boundary doesn't fit in a managed policy
+var readActionPrefixes = []string{"Describe", "Get", "List"}
+
+func GetPermissionsBoundaryName(prefix string) string {
+	return fmt.Sprintf("%s-Permissions-Boundary", prefix)
+}
+
+func GetPermissionsBoundaryARN(partition string, accountID string, prefix string, path string) string {
+	return getPolicyARN(partition, accountID, GetPermissionsBoundaryName(prefix), path)
+}
+
+// GetAccountRolePermissionPolicyKeys returns the keys of the permission policies of all account roles
+func GetAccountRolePermissionPolicyKeys(hostedCP bool) []string {
+	var keys []string
+	if hostedCP {
+		for roleType := range HCPAccountRoles {
+			keys = append(keys, GetHcpAccountRolePolicyKeys(roleType)...)
+		}
+	} else {
+		for roleType := range AccountRoles {
+			keys = append(keys, GetAccountRolePolicyKeys(roleType)...)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GetOperatorRolePermissionPolicyKeys returns the keys of the permission policies of the operator
+// roles of the credential requests
+func GetOperatorRolePermissionPolicyKeys(credRequests map[string]*cmv1.STSOperator, hostedCP bool) []string {
+	keys := make([]string, 0, len(credRequests))
+	for credRequest := range credRequests {
+		keys = append(keys, GetOperatorPolicyKey(credRequest, hostedCP, false))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GetPermissionsBoundaryActions returns the sorted union of the actions allowed by the policies of
+// the keys. Policies missing from OCM are skipped, and actions already covered by a wildcard
+// action of the union are dropped.
+func GetPermissionsBoundaryActions(partition string, policies map[string]*cmv1.AWSSTSPolicy,
+	keys []string) ([]string, error) {
+	set := map[string]bool{}
+	for _, key := range keys {
+		policyDetails := GetPolicyDetails(policies, key)
+		if policyDetails == "" {
+			continue
+		}
+		policyDetails = InterpolatePolicyDocument(partition, policyDetails, map[string]string{
+			"partition": partition,
+		})
+		doc, err := ParsePolicyDocument(policyDetails)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse policy '%s': %v", key, err)
+		}
+		for _, action := range doc.GetAllowedActions() {
+			set[action] = true
+		}
+	}
+	if len(set) == 0 {
+		return nil, fmt.Errorf("No permission policies found for the roles")
+	}
+	return uniqueActions(set), nil
+}
+
+// GeneratePermissionsBoundaryDocument returns a policy allowing the actions on all resources. When
+// the policy exceeds the size of a managed policy, the read only actions of each service are
+// replaced by wildcards.
+func GeneratePermissionsBoundaryDocument(actions []string) (string, error) {
+	doc, size, err := permissionsBoundaryDocument(actions)
+	if err != nil {
+		return "", err
+	}
+	if size <= maxManagedPolicySize {
+		return doc, nil
+	}
+	doc, size, err = permissionsBoundaryDocument(compactReadActions(actions))
+	if err != nil {
+		return "", err
+	}
+	if size > maxManagedPolicySize {
+		return "", fmt.Errorf("Permissions boundary of %d characters exceeds the managed policy limit of %d "+
+			"characters, create a permissions boundary for account and operator roles separately",
+			size, maxManagedPolicySize)
+	}
+	return doc, nil
+}
+
+func permissionsBoundaryDocument(actions []string) (string, int, error) {
+	policy := NewPolicyDocument()
+	policy.AllowActions(actions...)
+	compact, err := json.Marshal(policy)
+	if err != nil {
+		return "", 0, err
+	}
+	doc, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return "", 0, err
+	}
+	return string(doc), len(compact), nil
+}
+
+// compactReadActions replaces the read only actions of a service by a wildcard action
+func compactReadActions(actions []string) []string {
+	set := map[string]bool{}
+	for _, action := range actions {
+		service, name, found := strings.Cut(action, ":")
+		if found {
+			for _, prefix := range readActionPrefixes {
+				if strings.HasPrefix(name, prefix) {
+					action = fmt.Sprintf("%s:%s*", service, prefix)
+					break
+				}
+			}
+		}
+		set[action] = true
+	}
+	return uniqueActions(set)
+}
+
+// uniqueActions returns the sorted actions of the set not already matched by a wildcard action
+func uniqueActions(set map[string]bool) []string {
+	var wildcards []string
+	for action := range set {
+		if strings.HasSuffix(action, "*") {
+			wildcards = append(wildcards, strings.ToLower(strings.TrimSuffix(action, "*")))
+		}
+	}
+	actions := make([]string, 0, len(set))
+	for action := range set {
+		covered := false
+		for _, wildcard := range wildcards {
+			lowerAction := strings.ToLower(action)
+			if lowerAction != wildcard+"*" && strings.HasPrefix(lowerAction, wildcard) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			actions = append(actions, action)
+		}
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+const cloudFormationPermissionsBoundaryTemplate = `AWSTemplateFormatVersion: '2010-09-09'
+Description: Permissions boundary for the roles created by ROSA
+Resources:
+  PermissionsBoundary:
+    Type: AWS::IAM::ManagedPolicy
+    Properties:
+      ManagedPolicyName: {{ .Name }}
+      Path: {{ .Path }}
+      PolicyDocument:
+{{ indent 8 .Document }}
+Outputs:
+  PermissionsBoundaryArn:
+    Description: ARN to pass to '--permissions-boundary'
+    Value: !Ref PermissionsBoundary
+`
+
+const terraformPermissionsBoundaryTemplate = `resource "aws_iam_policy" "permissions_boundary" {
+  name   = "{{ .Name }}"
+  path   = "{{ .Path }}"
+  policy = <<EOF
+{{ .Document }}
+EOF
+
+  tags = {
+{{- range $key, $value := .Tags }}
+    "{{ $key }}" = "{{ $value }}"
+{{- end }}
+  }
+}
+
+output "permissions_boundary_arn" {
+  description = "ARN to pass to '--permissions-boundary'"
+  value       = aws_iam_policy.permissions_boundary.arn
+}
+`
+
+// GeneratePermissionsBoundaryTemplate returns the permissions boundary policy as infrastructure as
+// code. CloudFormation managed policies don't support tags, so they are only set with Terraform.
+func GeneratePermissionsBoundaryTemplate(format string, name string, path string, document string,
+	tagList map[string]string) (string, error) {
+	var text string
+	switch format {
+	case IaCFormatCloudFormation:
+		text = cloudFormationPermissionsBoundaryTemplate
+	case IaCFormatTerraform:
+		text = terraformPermissionsBoundaryTemplate
+	default:
+		return "", fmt.Errorf("Invalid format '%s', expected one of: %s", format, strings.Join(IaCFormats, ", "))
+	}
+	if path == "" {
+		path = "/"
+	}
+	tmpl, err := template.New(format).Funcs(template.FuncMap{
+		"indent": func(spaces int, text string) string {
+			padding := strings.Repeat(" ", spaces)
+			return padding + strings.ReplaceAll(text, "\n", "\n"+padding)
+		},
+	}).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var output bytes.Buffer
+	err = tmpl.Execute(&output, map[string]interface{}{
+		"Name":     name,
+		"Path":     path,
+		"Document": document,
+		"Tags":     tagList,
+	})
+	if err != nil {
+		return "", err
+	}
+	return output.String(), nil
+}
@@ -0,0 +1,99 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Permissions boundary", func() {
+	buildPolicies := func(details map[string]string) map[string]*cmv1.AWSSTSPolicy {
+		policies := map[string]*cmv1.AWSSTSPolicy{}
+		for key, detail := range details {
+			policy, err := cmv1.NewAWSSTSPolicy().ID(key).Details(detail).Build()
+			Expect(err).NotTo(HaveOccurred())
+			policies[key] = policy
+		}
+		return policies
+	}
+
+	Context("GetPermissionsBoundaryActions", func() {
+		It("returns the union of the actions of the policies", func() {
+			policies := buildPolicies(map[string]string{
+				"first": `{"Version": "2012-10-17", "Statement": [
+					{"Effect": "Allow", "Action": ["ec2:RunInstances", "s3:GetObject"], "Resource": "*"},
+					{"Effect": "Deny", "Action": "iam:CreateRole", "Resource": "*"}]}`,
+				"second": `{"Version": "2012-10-17", "Statement": [
+					{"Effect": "Allow", "Action": "ec2:RunInstances", "Resource": "arn:aws:ec2:*:*:instance/*"},
+					{"Effect": "Allow", "Action": ["s3:*", "kms:Decrypt"], "Resource": "*"}]}`,
+			})
+			actions, err := GetPermissionsBoundaryActions("aws", policies, []string{"first", "second", "missing"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actions).To(Equal([]string{"ec2:RunInstances", "kms:Decrypt", "s3:*"}))
+		})
+		It("fails when none of the policies exist", func() {
+			_, err := GetPermissionsBoundaryActions("aws", nil, []string{"missing"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("GeneratePermissionsBoundaryDocument", func() {
+		It("allows the actions on all resources", func() {
+			document, err := GeneratePermissionsBoundaryDocument([]string{"ec2:RunInstances", "s3:GetObject"})
+			Expect(err).NotTo(HaveOccurred())
+			policy, err := ParsePolicyDocument(document)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy.Statement).To(HaveLen(1))
+			Expect(policy.Statement[0].Resource).To(Equal("*"))
+			Expect(policy.GetAllowedActions()).To(Equal([]string{"ec2:RunInstances", "s3:GetObject"}))
+		})
+		It("replaces read only actions by wildcards when the policy is too large", func() {
+			actions := []string{"ec2:RunInstances"}
+			for i := 0; i < 300; i++ {
+				actions = append(actions, fmt.Sprintf("ec2:DescribeResource%d", i))
+			}
+			document, err := GeneratePermissionsBoundaryDocument(actions)
+			Expect(err).NotTo(HaveOccurred())
+			policy, err := ParsePolicyDocument(document)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy.GetAllowedActions()).To(Equal([]string{"ec2:Describe*", "ec2:RunInstances"}))
+		})
+		It("fails when the policy can't fit in a managed policy", func() {
+			var actions []string
+			for i := 0; i < 300; i++ {
+				actions = append(actions, fmt.Sprintf("ec2:CreateResource%d", i))
+			}
+			_, err := GeneratePermissionsBoundaryDocument(actions)
+			Expect(err).To(MatchError(ContainSubstring("exceeds the managed policy limit")))
+		})
+	})
+
+	Context("GeneratePermissionsBoundaryTemplate", func() {
+		document := `{
+  "Statement": []
+}`
+		It("generates CloudFormation templates", func() {
+			template, err := GeneratePermissionsBoundaryTemplate(IaCFormatCloudFormation, "boundary", "",
+				document, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(template).To(ContainSubstring("Type: AWS::IAM::ManagedPolicy"))
+			Expect(template).To(ContainSubstring("Path: /\n"))
+			Expect(template).To(ContainSubstring("        {\n          \"Statement\": []\n        }"))
+		})
+		It("generates Terraform configurations with tags", func() {
+			template, err := GeneratePermissionsBoundaryTemplate(IaCFormatTerraform, "boundary", "/rosa/",
+				document, map[string]string{"red-hat-managed": "true"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(template).To(ContainSubstring(`path   = "/rosa/"`))
+			Expect(template).To(ContainSubstring(`"red-hat-managed" = "true"`))
+			Expect(strings.Count(template, document)).To(Equal(1))
+		})
+		It("fails for unknown formats", func() {
+			_, err := GeneratePermissionsBoundaryTemplate("pulumi", "boundary", "", document, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,114 @@
+package aws
+
+import (
+	"context"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Permissions", func() {
+	Context("GetCommandPermissions", func() {
+		It("returns sorted actions", func() {
+			actions, err := GetCommandPermissions(CreateAccountRolesPermissions)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actions).To(ContainElements("iam:CreateRole", "sts:GetCallerIdentity"))
+			Expect(actions[0]).To(Equal("iam:AttachRolePolicy"))
+		})
+		It("includes the CloudFormation and CloudFront actions of the CloudFront backend", func() {
+			actions, err := GetCommandPermissions(CreateOidcConfigCloudFrontPermissions)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actions).To(ContainElements("cloudformation:CreateStack", "cloudfront:CreateDistribution",
+				"secretsmanager:CreateSecret"))
+			actions, err = GetCommandPermissions(CreateOidcConfigPermissions)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actions).NotTo(ContainElement("cloudformation:CreateStack"))
+		})
+		It("fails for unknown commands", func() {
+			_, err := GetCommandPermissions("create-machinepool")
+			Expect(err).To(MatchError(ContainSubstring("expected one of: create-account-roles")))
+		})
+	})
+
+	Context("NewPermissionDecision", func() {
+		It("reports allowed actions", func() {
+			decision := NewPermissionDecision(iamtypes.EvaluationResult{
+				EvalActionName: awsSdk.String("iam:GetRole"),
+				EvalDecision:   iamtypes.PolicyEvaluationDecisionTypeAllowed,
+				MatchedStatements: []iamtypes.Statement{
+					{SourcePolicyId: awsSdk.String("AdministratorAccess")},
+					{SourcePolicyId: awsSdk.String("AdministratorAccess")},
+				},
+			})
+			Expect(decision.Allowed).To(BeTrue())
+			Expect(decision.DeniedBy).To(BeEmpty())
+			Expect(decision.Policies).To(Equal([]string{"AdministratorAccess"}))
+		})
+		It("reports actions denied by a service control policy", func() {
+			decision := NewPermissionDecision(iamtypes.EvaluationResult{
+				EvalActionName:              awsSdk.String("iam:CreateRole"),
+				EvalDecision:                iamtypes.PolicyEvaluationDecisionTypeImplicitDeny,
+				OrganizationsDecisionDetail: &iamtypes.OrganizationsDecisionDetail{AllowedByOrganizations: false},
+				PermissionsBoundaryDecisionDetail: &iamtypes.PermissionsBoundaryDecisionDetail{
+					AllowedByPermissionsBoundary: false,
+				},
+			})
+			Expect(decision.Allowed).To(BeFalse())
+			Expect(decision.DeniedBy).To(Equal(DeniedBySCP))
+		})
+		It("reports actions denied by the permissions boundary", func() {
+			decision := NewPermissionDecision(iamtypes.EvaluationResult{
+				EvalActionName:              awsSdk.String("iam:CreateRole"),
+				EvalDecision:                iamtypes.PolicyEvaluationDecisionTypeImplicitDeny,
+				OrganizationsDecisionDetail: &iamtypes.OrganizationsDecisionDetail{AllowedByOrganizations: true},
+				PermissionsBoundaryDecisionDetail: &iamtypes.PermissionsBoundaryDecisionDetail{
+					AllowedByPermissionsBoundary: false,
+				},
+			})
+			Expect(decision.DeniedBy).To(Equal(DeniedByPermissionsBoundary))
+		})
+		It("reports actions explicitly denied by an identity policy", func() {
+			decision := NewPermissionDecision(iamtypes.EvaluationResult{
+				EvalActionName:    awsSdk.String("iam:CreateRole"),
+				EvalDecision:      iamtypes.PolicyEvaluationDecisionTypeExplicitDeny,
+				MatchedStatements: []iamtypes.Statement{{SourcePolicyId: awsSdk.String("DenyIAM")}},
+			})
+			Expect(decision.ExplicitDeny).To(BeTrue())
+			Expect(decision.DeniedBy).To(Equal(DeniedByIdentityPolicy))
+			Expect(decision.Policies).To(Equal([]string{"DenyIAM"}))
+		})
+	})
+
+	Context("SimulatePermissions", func() {
+		It("simulates the actions in the current region with the IAM client", func() {
+			mockIamAPI := mocks.NewMockIamApiClient(gomock.NewController(GinkgoT()))
+			client := awsClient{
+				iamClient: mockIamAPI,
+				cfg:       awsSdk.Config{Region: "us-east-1"},
+			}
+			mockIamAPI.EXPECT().SimulatePrincipalPolicy(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, input *iam.SimulatePrincipalPolicyInput,
+					_ ...func(*iam.Options)) (*iam.SimulatePrincipalPolicyOutput, error) {
+					Expect(awsSdk.ToString(input.PolicySourceArn)).To(Equal("arn:aws:iam::123456789012:user/me"))
+					Expect(input.ContextEntries[0].ContextKeyValues).To(Equal([]string{"us-east-1"}))
+					return &iam.SimulatePrincipalPolicyOutput{
+						EvaluationResults: []iamtypes.EvaluationResult{{
+							EvalActionName: awsSdk.String("iam:CreateRole"),
+							EvalDecision:   iamtypes.PolicyEvaluationDecisionTypeAllowed,
+						}},
+					}, nil
+				})
+			decisions, err := client.SimulatePermissions("arn:aws:iam::123456789012:user/me",
+				[]string{"iam:CreateRole"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decisions).To(HaveLen(1))
+			Expect(decisions[0].Allowed).To(BeTrue())
+		})
+	})
+})
@@ -232,6 +232,15 @@ func (c *awsClient) EnsureRole(reporter *reporter.Object, name string, policy st
 	return roleArn, nil
 }
 
+// UpdateAssumeRolePolicy replaces the trust policy of the role.
+func (c *awsClient) UpdateAssumeRolePolicy(roleName string, policy string) error {
+	_, err := c.iamClient.UpdateAssumeRolePolicy(context.Background(), &iam.UpdateAssumeRolePolicyInput{
+		RoleName:       aws.String(roleName),
+		PolicyDocument: aws.String(policy),
+	})
+	return err
+}
+
 func (c *awsClient) ValidateRoleNameAvailable(name string) (err error) {
 	_, err = c.iamClient.GetRole(context.Background(), &iam.GetRoleInput{
 		RoleName: aws.String(name),
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -277,6 +278,54 @@ func getPolicyDocument(policyDocument *string) (*PolicyDocument, error) {
 	return &data, nil
 }
 
+// TrustPolicyMatches returns true if the URL encoded trust policy of a role grants the same
+// statements as the expected policy. Lists of a single element are considered equal to the
+// element and the order of lists is ignored, as IAM doesn't preserve either.
+func TrustPolicyMatches(current *string, expected string) (bool, error) {
+	currentPolicy, err := url.QueryUnescape(aws.ToString(current))
+	if err != nil {
+		return false, err
+	}
+	var currentDoc, expectedDoc struct {
+		Statement interface{} `json:"Statement"`
+	}
+	err = json.Unmarshal([]byte(currentPolicy), &currentDoc)
+	if err != nil {
+		return false, fmt.Errorf("Failed to parse trust policy: %v", err)
+	}
+	err = json.Unmarshal([]byte(expected), &expectedDoc)
+	if err != nil {
+		return false, fmt.Errorf("Failed to parse expected trust policy: %v", err)
+	}
+	return reflect.DeepEqual(normalizePolicyElement(currentDoc.Statement),
+		normalizePolicyElement(expectedDoc.Statement)), nil
+}
+
+func normalizePolicyElement(element interface{}) interface{} {
+	switch value := element.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			normalized[key] = normalizePolicyElement(item)
+		}
+		return normalized
+	case []interface{}:
+		if len(value) == 1 {
+			return normalizePolicyElement(value[0])
+		}
+		normalized := make([]interface{}, len(value))
+		for i, item := range value {
+			normalized[i] = normalizePolicyElement(item)
+		}
+		sort.SliceStable(normalized, func(i, j int) bool {
+			return fmt.Sprint(normalized[i]) < fmt.Sprint(normalized[j])
+		})
+		return normalized
+	default:
+		return value
+	}
+}
+
 func GenerateRolePolicyDoc(partition, oidcEndpointUrl,
 	accountID, serviceAccounts, policyDetails string) (string, error) {
 	oidcEndpointURL, err := url.ParseRequestURI(oidcEndpointUrl)
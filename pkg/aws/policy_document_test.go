@@ -0,0 +1,54 @@
+package aws
+
+import (
+	"net/url"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TrustPolicyMatches", func() {
+	const expected = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {
+        "Federated": "arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc"
+      },
+      "Action": "sts:AssumeRoleWithWebIdentity",
+      "Condition": {
+        "StringEquals": {
+          "oidc.example.com/abc:sub": ["system:serviceaccount:ns:a", "system:serviceaccount:ns:b"]
+        }
+      }
+    }
+  ]
+}`
+
+	It("ignores encoding, single element lists and ordering", func() {
+		current := url.QueryEscape(`{"Version":"2012-10-17","Statement":{"Effect":"Allow",` +
+			`"Principal":{"Federated":["arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc"]},` +
+			`"Action":["sts:AssumeRoleWithWebIdentity"],"Condition":{"StringEquals":{"oidc.example.com/abc:sub":` +
+			`["system:serviceaccount:ns:b","system:serviceaccount:ns:a"]}}}}`)
+		matches, err := TrustPolicyMatches(awsSdk.String(current), expected)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(BeTrue())
+	})
+
+	It("detects a different OIDC provider", func() {
+		current := url.QueryEscape(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow",` +
+			`"Principal":{"Federated":"arn:aws:iam::123456789012:oidc-provider/oidc.example.com/old"},` +
+			`"Action":"sts:AssumeRoleWithWebIdentity","Condition":{"StringEquals":{"oidc.example.com/old:sub":` +
+			`["system:serviceaccount:ns:a","system:serviceaccount:ns:b"]}}}]}`)
+		matches, err := TrustPolicyMatches(awsSdk.String(current), expected)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(BeFalse())
+	})
+
+	It("fails on invalid policies", func() {
+		_, err := TrustPolicyMatches(awsSdk.String("not-json"), expected)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -6,153 +6,163 @@ import (
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
-	servicequotastypes "github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
 )
 
-type quota struct {
-	ServiceCode  string
-	QuotaName    string
-	QuotaCode    string
-	DesiredValue *float64
-}
-
 const IAMServiceCode = "iam"
 
-// List of service quotas we verify for cluster installs
-// to support 5 x multi zone clusters
-var serviceQuotaServices = []quota{
-	{
-		ServiceCode:  "ec2",
-		QuotaCode:    "L-0263D0A3",
-		QuotaName:    "Number of EIPs - VPC EIPs",
-		DesiredValue: aws.Float64(5.0),
-	},
-	{
-		ServiceCode:  "ec2",
-		QuotaCode:    "L-1216C47A",
-		QuotaName:    "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances",
-		DesiredValue: aws.Float64(100.0),
-	},
-	{
-		ServiceCode:  "vpc",
-		QuotaCode:    "L-F678F1CE",
-		QuotaName:    "VPCs per Region",
-		DesiredValue: aws.Float64(5.0),
-	},
-	{
-		ServiceCode:  "vpc",
-		QuotaCode:    "L-A4707A72",
-		QuotaName:    "Internet gateways per Region",
-		DesiredValue: aws.Float64(5.0),
-	},
-	{
-		ServiceCode:  "vpc",
-		QuotaCode:    "L-DF5E4CA3",
-		QuotaName:    "Network interfaces per Region",
-		DesiredValue: aws.Float64(5000.0),
-	},
-	{
-		ServiceCode:  "ebs",
-		QuotaCode:    "L-D18FCD1D",
-		QuotaName:    "General Purpose SSD (gp2) volume storage",
-		DesiredValue: aws.Float64(50.0),
-	},
-	{
-		ServiceCode:  "ebs",
-		QuotaCode:    "L-309BACF6",
-		QuotaName:    "Number of EBS snapshots",
-		DesiredValue: aws.Float64(300.0),
-	},
-	{
-		ServiceCode:  "ebs",
-		QuotaCode:    "L-B3A130E6",
-		QuotaName:    "Provisioned IOPS",
-		DesiredValue: aws.Float64(300000.0),
-	},
-	{
-		ServiceCode:  "ebs",
-		QuotaCode:    "L-FD252861",
-		QuotaName:    "Provisioned IOPS SSD (io1) volume storage",
-		DesiredValue: aws.Float64(50.0),
-	},
-	{
-		ServiceCode:  "elasticloadbalancing",
-		QuotaCode:    "L-53DA6B97",
-		QuotaName:    "Application Load Balancers per Region",
-		DesiredValue: aws.Float64(50.0),
-	},
-	{
-		ServiceCode:  "elasticloadbalancing",
-		QuotaCode:    "L-E9E9831D",
-		QuotaName:    "Classic Load Balancers per Region",
-		DesiredValue: aws.Float64(20.0),
-	},
-}
+// Service quotas of the resources created for a cluster
+const (
+	QuotaCodeStandardVCPUs     = "L-1216C47A"
+	QuotaCodeElasticIPs        = "L-0263D0A3"
+	QuotaCodeGp3Storage        = "L-7A658B76"
+	QuotaCodeNetworkInterfaces = "L-DF5E4CA3"
+)
 
-// ValidateQuota
-func (c *awsClient) ValidateQuota() (bool, error) {
-	var invalidQuotas []string
-	for _, quota := range serviceQuotaServices {
-		serviceQuotas, err := ListServiceQuotas(c, quota.ServiceCode)
-		if err != nil {
-			return false, fmt.Errorf("Error listing AWS service quotas: %s %v", quota.ServiceCode, err)
-		}
+// QuotaUsage is the usage in the region of the resources counted by the service quotas of a cluster
+type QuotaUsage struct {
+	// StandardVCPUs are the vCPUs of the running on-demand instances of the standard families
+	StandardVCPUs int
+	ElasticIPs    int
+	// Gp3StorageGiB is the size of the gp3 volumes
+	Gp3StorageGiB     int
+	NetworkInterfaces int
+}
 
-		serviceQuota, err := GetServiceQuota(serviceQuotas, quota.QuotaCode)
-		if err != nil || (serviceQuota).Value == nil {
-			return false, fmt.Errorf("Error getting AWS service quota: %s %v", quota.ServiceCode, err)
-		}
+// standardInstanceFamilies are the first letters of the instance families counted by the
+// 'Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances' quota, except for the accelerated
+// families starting with these letters
+var standardInstanceFamilies = "acdhimrtz"
+var nonStandardInstanceFamilies = []string{"dl", "hpc", "inf", "trn"}
 
-		if *serviceQuota.Value < *quota.DesiredValue {
-			invalidQuotas = append(invalidQuotas, fmt.Sprintf(
-				"- Service %s quota code %s %s not valid, expected quota of at least %d, but got %d",
-				quota.ServiceCode, quota.QuotaCode, quota.QuotaName,
-				int(*quota.DesiredValue), int(*serviceQuota.Value)))
+// IsStandardInstanceType checks if the instance type is counted by the standard on-demand vCPU quota
+func IsStandardInstanceType(instanceType string) bool {
+	if instanceType == "" || !strings.ContainsRune(standardInstanceFamilies, rune(instanceType[0])) {
+		return false
+	}
+	for _, family := range nonStandardInstanceFamilies {
+		if strings.HasPrefix(instanceType, family) {
+			return false
 		}
-
-		c.logger.Debug(fmt.Sprintf("Service %s quota code %s is ok", quota.ServiceCode, quota.QuotaCode))
 	}
+	return true
+}
 
-	if len(invalidQuotas) > 0 {
-		return false, fmt.Errorf("Service quota is insufficient for the following service quota codes:\n%s",
-			strings.Join(invalidQuotas, "\n"))
+// GetServiceQuotaValue returns the value of the service quota in the region
+func (c *awsClient) GetServiceQuotaValue(serviceCode string, quotaCode string) (float64, error) {
+	output, err := c.serviceQuotasClient.GetServiceQuota(context.Background(), &servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(serviceCode),
+		QuotaCode:   aws.String(quotaCode),
+	})
+	if err != nil {
+		return 0, err
 	}
-
-	return true, nil
+	if output.Quota == nil || output.Quota.Value == nil {
+		return 0, fmt.Errorf("Service %s quota code %s has no value", serviceCode, quotaCode)
+	}
+	return *output.Quota.Value, nil
 }
 
-// ListServiceQuotas list available quotas for service
-func ListServiceQuotas(client *awsClient, serviceCode string) ([]servicequotastypes.ServiceQuota, error) {
-	// Create a ServiceQuotas client
-	var serviceQuotas []servicequotastypes.ServiceQuota
-
-	// Paginate through quota results
-	paginator := servicequotas.NewListServiceQuotasPaginator(
-		client.serviceQuotasClient, &servicequotas.ListServiceQuotasInput{
-			ServiceCode: &serviceCode,
+// RequestServiceQuotaIncrease requests the service quota to be raised to the desired value and
+// returns the identifier of the request
+func (c *awsClient) RequestServiceQuotaIncrease(serviceCode string, quotaCode string,
+	desiredValue float64) (string, error) {
+	output, err := c.serviceQuotasClient.RequestServiceQuotaIncrease(context.Background(),
+		&servicequotas.RequestServiceQuotaIncreaseInput{
+			ServiceCode:  aws.String(serviceCode),
+			QuotaCode:    aws.String(quotaCode),
+			DesiredValue: aws.Float64(desiredValue),
 		})
+	if err != nil {
+		return "", err
+	}
+	if output.RequestedQuota == nil {
+		return "", nil
+	}
+	return aws.ToString(output.RequestedQuota.Id), nil
+}
 
+// GetInstanceTypeVCPUs returns the default number of vCPUs of the instance types
+func (c *awsClient) GetInstanceTypeVCPUs(instanceTypes []string) (map[string]int, error) {
+	input := &ec2.DescribeInstanceTypesInput{}
+	for _, instanceType := range instanceTypes {
+		input.InstanceTypes = append(input.InstanceTypes, ec2types.InstanceType(instanceType))
+	}
+	vcpus := map[string]int{}
+	paginator := ec2.NewDescribeInstanceTypesPaginator(c.ec2Client, input)
 	for paginator.HasMorePages() {
 		page, err := paginator.NextPage(context.Background())
 		if err != nil {
 			return nil, err
 		}
-		serviceQuotas = append(serviceQuotas, page.Quotas...)
+		for _, info := range page.InstanceTypes {
+			if info.VCpuInfo != nil {
+				vcpus[string(info.InstanceType)] = int(aws.ToInt32(info.VCpuInfo.DefaultVCpus))
+			}
+		}
 	}
-
-	return serviceQuotas, nil
+	return vcpus, nil
 }
 
-// GetServiceQuota extract service quota for the list of service quotas
-func GetServiceQuota(serviceQuotas []servicequotastypes.ServiceQuota,
-	quotaCode string) (servicequotastypes.ServiceQuota, error) {
-	for _, serviceQuota := range serviceQuotas {
-		if *serviceQuota.QuotaCode == quotaCode {
-			return serviceQuota, nil
+// GetQuotaUsage counts the resources of the region that the service quotas of a cluster apply to
+func (c *awsClient) GetQuotaUsage() (*QuotaUsage, error) {
+	usage := &QuotaUsage{}
+
+	instances := ec2.NewDescribeInstancesPaginator(c.ec2Client, &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("instance-state-name"), Values: []string{"pending", "running"}},
+		},
+	})
+	for instances.HasMorePages() {
+		page, err := instances.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list instances: %v", err)
+		}
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				if instance.InstanceLifecycle == ec2types.InstanceLifecycleTypeSpot ||
+					!IsStandardInstanceType(string(instance.InstanceType)) || instance.CpuOptions == nil {
+					continue
+				}
+				usage.StandardVCPUs += int(aws.ToInt32(instance.CpuOptions.CoreCount) *
+					aws.ToInt32(instance.CpuOptions.ThreadsPerCore))
+			}
+		}
+	}
+
+	addresses, err := c.ec2Client.DescribeAddresses(context.Background(), &ec2.DescribeAddressesInput{
+		Filters: []ec2types.Filter{{Name: aws.String("domain"), Values: []string{"vpc"}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list Elastic IP addresses: %v", err)
+	}
+	usage.ElasticIPs = len(addresses.Addresses)
+
+	volumes := ec2.NewDescribeVolumesPaginator(c.ec2Client, &ec2.DescribeVolumesInput{
+		Filters: []ec2types.Filter{{Name: aws.String("volume-type"), Values: []string{"gp3"}}},
+	})
+	for volumes.HasMorePages() {
+		page, err := volumes.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list volumes: %v", err)
+		}
+		for _, volume := range page.Volumes {
+			usage.Gp3StorageGiB += int(aws.ToInt32(volume.Size))
+		}
+	}
+
+	networkInterfaces := ec2.NewDescribeNetworkInterfacesPaginator(c.ec2Client, &ec2.DescribeNetworkInterfacesInput{})
+	for networkInterfaces.HasMorePages() {
+		page, err := networkInterfaces.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list network interfaces: %v", err)
 		}
+		usage.NetworkInterfaces += len(page.NetworkInterfaces)
 	}
-	return servicequotastypes.ServiceQuota{}, fmt.Errorf("Unable to find quota with service code: %s", quotaCode)
+
+	return usage, nil
 }
 
 func (c *awsClient) GetIAMServiceQuota(quotaCode string) (
@@ -0,0 +1,72 @@
+package aws
+
+import (
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Quota", func() {
+	It("knows the instance types counted by the standard vCPU quota", func() {
+		Expect(IsStandardInstanceType("m5.xlarge")).To(BeTrue())
+		Expect(IsStandardInstanceType("r6i.2xlarge")).To(BeTrue())
+		Expect(IsStandardInstanceType("g4dn.xlarge")).To(BeFalse())
+		Expect(IsStandardInstanceType("inf1.xlarge")).To(BeFalse())
+	})
+
+	It("counts the resources used in the region", func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockEC2API := mocks.NewMockEc2ApiClient(mockCtrl)
+		client := New(
+			awsSdk.Config{},
+			logrus.New(),
+			mocks.NewMockIamApiClient(mockCtrl),
+			mockEC2API,
+			mocks.NewMockOrganizationsApiClient(mockCtrl),
+			mocks.NewMockS3ApiClient(mockCtrl),
+			mocks.NewMockSecretsManagerApiClient(mockCtrl),
+			mocks.NewMockStsApiClient(mockCtrl),
+			mocks.NewMockCloudFormationApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			&AccessKey{},
+			false,
+		)
+		instance := func(instanceType string, lifecycle ec2types.InstanceLifecycleType) ec2types.Instance {
+			return ec2types.Instance{
+				InstanceType:      ec2types.InstanceType(instanceType),
+				InstanceLifecycle: lifecycle,
+				CpuOptions:        &ec2types.CpuOptions{CoreCount: awsSdk.Int32(2), ThreadsPerCore: awsSdk.Int32(2)},
+			}
+		}
+		mockEC2API.EXPECT().DescribeInstances(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			&ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+				instance("m5.xlarge", ""),
+				instance("m5.xlarge", ec2types.InstanceLifecycleTypeSpot),
+				instance("g4dn.xlarge", ""),
+			}}}}, nil)
+		mockEC2API.EXPECT().DescribeAddresses(gomock.Any(), gomock.Any()).Return(
+			&ec2.DescribeAddressesOutput{Addresses: []ec2types.Address{{}, {}}}, nil)
+		mockEC2API.EXPECT().DescribeVolumes(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			&ec2.DescribeVolumesOutput{Volumes: []ec2types.Volume{
+				{Size: awsSdk.Int32(300)}, {Size: awsSdk.Int32(350)},
+			}}, nil)
+		mockEC2API.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []ec2types.NetworkInterface{{}, {}, {}}}, nil)
+
+		usage, err := client.GetQuotaUsage()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*usage).To(Equal(QuotaUsage{
+			StandardVCPUs:     4,
+			ElasticIPs:        2,
+			Gp3StorageGiB:     650,
+			NetworkInterfaces: 3,
+		}))
+	})
+})
@@ -0,0 +1,156 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	secretsmanagertypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/smithy-go"
+)
+
+// TagRole sets the tags on the role, overriding the values of the keys already set
+func (c *awsClient) TagRole(roleName string, tagList map[string]string) error {
+	_, err := c.iamClient.TagRole(context.Background(), &iam.TagRoleInput{
+		RoleName: aws.String(roleName),
+		Tags:     getTags(tagList),
+	})
+	return err
+}
+
+func (c *awsClient) UntagRole(roleName string, keys []string) error {
+	_, err := c.iamClient.UntagRole(context.Background(), &iam.UntagRoleInput{
+		RoleName: aws.String(roleName),
+		TagKeys:  keys,
+	})
+	return err
+}
+
+// TagOpenIDConnectProvider sets the tags on the provider, overriding the values of the keys already set
+func (c *awsClient) TagOpenIDConnectProvider(providerARN string, tagList map[string]string) error {
+	_, err := c.iamClient.TagOpenIDConnectProvider(context.Background(), &iam.TagOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: aws.String(providerARN),
+		Tags:                     getTags(tagList),
+	})
+	return err
+}
+
+func (c *awsClient) UntagOpenIDConnectProvider(providerARN string, keys []string) error {
+	_, err := c.iamClient.UntagOpenIDConnectProvider(context.Background(), &iam.UntagOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: aws.String(providerARN),
+		TagKeys:                  keys,
+	})
+	return err
+}
+
+// TagS3Bucket sets the tags on the bucket, overriding the values of the keys already set. S3 replaces
+// the whole tag set of a bucket, so the tags already set are read first to keep them.
+func (c *awsClient) TagS3Bucket(bucketName string, tagList map[string]string) error {
+	bucketTags, err := c.getS3BucketTags(bucketName)
+	if err != nil {
+		return err
+	}
+	for key, value := range tagList {
+		bucketTags[key] = value
+	}
+	return c.putS3BucketTags(bucketName, bucketTags)
+}
+
+func (c *awsClient) UntagS3Bucket(bucketName string, keys []string) error {
+	bucketTags, err := c.getS3BucketTags(bucketName)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		delete(bucketTags, key)
+	}
+	if len(bucketTags) == 0 {
+		_, err = c.s3Client.DeleteBucketTagging(context.Background(), &s3.DeleteBucketTaggingInput{
+			Bucket: aws.String(bucketName),
+		})
+		return err
+	}
+	return c.putS3BucketTags(bucketName, bucketTags)
+}
+
+func (c *awsClient) getS3BucketTags(bucketName string) (map[string]string, error) {
+	bucketTags := map[string]string{}
+	output, err := c.s3Client.GetBucketTagging(context.Background(), &s3.GetBucketTaggingInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchTagSet" {
+			return bucketTags, nil
+		}
+		return nil, err
+	}
+	for _, tag := range output.TagSet {
+		bucketTags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return bucketTags, nil
+}
+
+func (c *awsClient) putS3BucketTags(bucketName string, bucketTags map[string]string) error {
+	keys := make([]string, 0, len(bucketTags))
+	for key := range bucketTags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tagSet := make([]s3types.Tag, 0, len(keys))
+	for _, key := range keys {
+		tagSet = append(tagSet, s3types.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(bucketTags[key]),
+		})
+	}
+	_, err := c.s3Client.PutBucketTagging(context.Background(), &s3.PutBucketTaggingInput{
+		Bucket:  aws.String(bucketName),
+		Tagging: &s3types.Tagging{TagSet: tagSet},
+	})
+	return err
+}
+
+// TagSecret sets the tags on the secret, overriding the values of the keys already set
+func (c *awsClient) TagSecret(secretArn string, tagList map[string]string) error {
+	secretTags := []secretsmanagertypes.Tag{}
+	for key, value := range tagList {
+		secretTags = append(secretTags, secretsmanagertypes.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(value),
+		})
+	}
+	_, err := c.smClient.TagResource(context.Background(), &secretsmanager.TagResourceInput{
+		SecretId: aws.String(secretArn),
+		Tags:     secretTags,
+	})
+	return err
+}
+
+// GetSecretTags returns the tags of the secret
+func (c *awsClient) GetSecretTags(secretArn string) (map[string]string, error) {
+	output, err := c.smClient.DescribeSecret(context.Background(), &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String(secretArn),
+	})
+	if err != nil {
+		return nil, err
+	}
+	secretTags := map[string]string{}
+	for _, tag := range output.Tags {
+		secretTags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return secretTags, nil
+}
+
+func (c *awsClient) UntagSecret(secretArn string, keys []string) error {
+	_, err := c.smClient.UntagResource(context.Background(), &secretsmanager.UntagResourceInput{
+		SecretId: aws.String(secretArn),
+		TagKeys:  keys,
+	})
+	return err
+}
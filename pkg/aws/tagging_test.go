@@ -0,0 +1,87 @@
+package aws
+
+import (
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Tagging", func() {
+	var (
+		client    Client
+		mockCtrl  *gomock.Controller
+		mockS3API *mocks.MockS3ApiClient
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockS3API = mocks.NewMockS3ApiClient(mockCtrl)
+		client = New(
+			awsSdk.Config{},
+			logrus.New(),
+			mocks.NewMockIamApiClient(mockCtrl),
+			mocks.NewMockEc2ApiClient(mockCtrl),
+			mocks.NewMockOrganizationsApiClient(mockCtrl),
+			mockS3API,
+			mocks.NewMockSecretsManagerApiClient(mockCtrl),
+			mocks.NewMockStsApiClient(mockCtrl),
+			mocks.NewMockCloudFormationApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			&AccessKey{},
+			false,
+		)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	tag := func(key string, value string) s3types.Tag {
+		return s3types.Tag{Key: awsSdk.String(key), Value: awsSdk.String(value)}
+	}
+
+	Context("TagS3Bucket", func() {
+		It("keeps the tags already set on the bucket", func() {
+			mockS3API.EXPECT().GetBucketTagging(gomock.Any(), gomock.Any()).Return(&s3.GetBucketTaggingOutput{
+				TagSet: []s3types.Tag{tag("red-hat-managed", "true"), tag("team", "platform")},
+			}, nil)
+			mockS3API.EXPECT().PutBucketTagging(gomock.Any(), &s3.PutBucketTaggingInput{
+				Bucket: awsSdk.String("bucket"),
+				Tagging: &s3types.Tagging{TagSet: []s3types.Tag{
+					tag("cost-center", "1234"), tag("red-hat-managed", "true"), tag("team", "openshift"),
+				}},
+			}).Return(&s3.PutBucketTaggingOutput{}, nil)
+			err := client.TagS3Bucket("bucket", map[string]string{"cost-center": "1234", "team": "openshift"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("tags buckets having no tags", func() {
+			mockS3API.EXPECT().GetBucketTagging(gomock.Any(), gomock.Any()).Return(nil,
+				&smithy.GenericAPIError{Code: "NoSuchTagSet"})
+			mockS3API.EXPECT().PutBucketTagging(gomock.Any(), &s3.PutBucketTaggingInput{
+				Bucket:  awsSdk.String("bucket"),
+				Tagging: &s3types.Tagging{TagSet: []s3types.Tag{tag("team", "platform")}},
+			}).Return(&s3.PutBucketTaggingOutput{}, nil)
+			err := client.TagS3Bucket("bucket", map[string]string{"team": "platform"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("UntagS3Bucket", func() {
+		It("deletes the tag set when no tags are left", func() {
+			mockS3API.EXPECT().GetBucketTagging(gomock.Any(), gomock.Any()).Return(&s3.GetBucketTaggingOutput{
+				TagSet: []s3types.Tag{tag("team", "platform")},
+			}, nil)
+			mockS3API.EXPECT().DeleteBucketTagging(gomock.Any(), gomock.Any()).Return(
+				&s3.DeleteBucketTaggingOutput{}, nil)
+			Expect(client.UntagS3Bucket("bucket", []string{"team"})).To(Succeed())
+		})
+	})
+})
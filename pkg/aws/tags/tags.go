@@ -19,6 +19,8 @@ limitations under the License.
 
 package tags
 
+import "strings"
+
 // Prefix used by all the tag names:
 const prefix = "rosa_"
 
@@ -54,4 +56,32 @@ const OperatorName = "operator_name"
 
 const InUse = "in_use"
 
+// OidcKeyRotatedAt tags the secret of an unmanaged OIDC config with the time its signing key was rotated
+const OidcKeyRotatedAt = prefix + "oidc_key_rotated_at"
+
+// OidcKeyOverlap tags the secret of an unmanaged OIDC config with how long the previous signing key stays
+// published after a rotation
+const OidcKeyOverlap = prefix + "oidc_key_overlap"
+
+// CleanupProtect keeps a resource from being deleted by 'rosa cleanup orphans'
+const CleanupProtect = prefix + "cleanup_protect"
+
+// NetworkTemplate tags the CloudFormation stacks created by 'rosa create network' with the template
+// they were created from
+const NetworkTemplate = prefix + "network_template"
+
 const True = "true"
+
+// ReservedPrefixes are the prefixes of the tag keys used by ROSA and AWS, which can't be set or
+// removed by users.
+var ReservedPrefixes = []string{prefix, "red-hat-", "aws:"}
+
+// ReservedPrefix returns the reserved prefix the tag key starts with, if any.
+func ReservedPrefix(key string) (string, bool) {
+	for _, reserved := range ReservedPrefixes {
+		if strings.HasPrefix(key, reserved) {
+			return reserved, true
+		}
+	}
+	return "", false
+}
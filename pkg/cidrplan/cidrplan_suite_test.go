@@ -0,0 +1,13 @@
+package cidrplan
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCIDRPlan(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CIDR plan suite")
+}
@@ -0,0 +1,322 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cidrplan
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"net"
+)
+
+// Limits and defaults of the cluster networks
+const (
+	HostPrefixMin = 23
+	HostPrefixMax = 26
+
+	DefaultMachineCIDR = "10.0.0.0/16"
+	DefaultServiceCIDR = "172.30.0.0/16"
+	DefaultPodCIDR     = "10.128.0.0/14"
+	DefaultHostPrefix  = HostPrefixMin
+
+	// minPodPrefixLength is the largest pod network proposed, to keep clear of the rest of the private ranges
+	minPodPrefixLength = 10
+)
+
+// Ranges scanned for free service and pod networks when the defaults are taken
+var (
+	servicePools = []string{"172.16.0.0/12", "10.0.0.0/8", "192.168.0.0/16"}
+	podPools     = []string{"10.0.0.0/8", "100.64.0.0/10", "172.16.0.0/12"}
+)
+
+// Subnet is an existing subnet of the VPC of the cluster
+type Subnet struct {
+	ID   string
+	CIDR *net.IPNet
+}
+
+// Input describes the existing networks and the wanted cluster. The machine, service and pod networks
+// and the host prefix are proposed unless they are set.
+type Input struct {
+	// VpcCIDRs are the CIDR blocks of the VPC. The machine network covers them, or else the subnets.
+	VpcCIDRs []*net.IPNet
+	// Subnets are the existing subnets of the VPC
+	Subnets []Subnet
+	// Reserved are the networks reachable from the VPC, through peering or a transit gateway
+	Reserved []*net.IPNet
+	// Nodes is the number of nodes the cluster must be able to grow to
+	Nodes int
+
+	MachineCIDR *net.IPNet
+	ServiceCIDR *net.IPNet
+	PodCIDR     *net.IPNet
+	HostPrefix  int
+}
+
+// Plan is the proposed cluster networking
+type Plan struct {
+	MachineCIDR string `json:"machineCIDR"`
+	ServiceCIDR string `json:"serviceCIDR"`
+	PodCIDR     string `json:"podCIDR"`
+	HostPrefix  int    `json:"hostPrefix"`
+	// MaxNodes is the number of nodes the pod network has room for
+	MaxNodes int `json:"maxNodes"`
+	// PodsPerNode is the number of pod addresses of each node
+	PodsPerNode int `json:"podsPerNode"`
+	// Issues are the overlaps and capacity problems of the networks, empty when the plan can be used
+	Issues []string `json:"issues"`
+}
+
+// Build proposes the networks of the cluster and checks them against the existing ones
+func Build(input *Input) (*Plan, error) {
+	for _, cidr := range append(append([]*net.IPNet{input.MachineCIDR, input.ServiceCIDR, input.PodCIDR},
+		input.VpcCIDRs...), input.Reserved...) {
+		if cidr != nil && cidr.IP.To4() == nil {
+			return nil, fmt.Errorf("Only IPv4 networks are supported, got '%s'", cidr)
+		}
+	}
+	if input.HostPrefix != 0 && (input.HostPrefix < HostPrefixMin || input.HostPrefix > HostPrefixMax) {
+		return nil, fmt.Errorf("Invalid host prefix /%d: it should be between %d and %d",
+			input.HostPrefix, HostPrefixMin, HostPrefixMax)
+	}
+
+	machineCIDR := input.MachineCIDR
+	if machineCIDR == nil {
+		if len(input.VpcCIDRs) > 0 {
+			machineCIDR = Supernet(input.VpcCIDRs)
+		} else if len(input.Subnets) > 0 {
+			subnetCIDRs := []*net.IPNet{}
+			for _, subnet := range input.Subnets {
+				subnetCIDRs = append(subnetCIDRs, subnet.CIDR)
+			}
+			machineCIDR = Supernet(subnetCIDRs)
+		} else {
+			machineCIDR = mustParse(DefaultMachineCIDR)
+		}
+	}
+
+	// Networks the service and pod networks must stay clear of
+	used := append([]*net.IPNet{machineCIDR}, input.VpcCIDRs...)
+	for _, subnet := range input.Subnets {
+		used = append(used, subnet.CIDR)
+	}
+	used = append(used, input.Reserved...)
+
+	hostPrefix := input.HostPrefix
+	podCIDR := input.PodCIDR
+	if podCIDR == nil {
+		var err error
+		podCIDR, hostPrefix, err = proposePodCIDR(used, input.Nodes, hostPrefix)
+		if err != nil {
+			return nil, err
+		}
+	} else if hostPrefix == 0 {
+		hostPrefix = DefaultHostPrefix
+	}
+	used = append(used, podCIDR)
+
+	serviceCIDR := input.ServiceCIDR
+	if serviceCIDR == nil {
+		serviceCIDR = free(used, mustParse(DefaultServiceCIDR), servicePools)
+		if serviceCIDR == nil {
+			return nil, fmt.Errorf("Failed to find a free /16 service network")
+		}
+	}
+
+	podOnes, _ := podCIDR.Mask.Size()
+	plan := &Plan{
+		MachineCIDR: machineCIDR.String(),
+		ServiceCIDR: serviceCIDR.String(),
+		PodCIDR:     podCIDR.String(),
+		HostPrefix:  hostPrefix,
+		MaxNodes:    maxNodes(podOnes, hostPrefix),
+		PodsPerNode: 1<<(32-hostPrefix) - 2,
+		Issues:      []string{},
+	}
+	plan.Issues = issues(input, machineCIDR, serviceCIDR, podCIDR, plan)
+	return plan, nil
+}
+
+// proposePodCIDR returns a free pod network with room for the nodes, and the host prefix that goes
+// with it. The host prefix is only raised when the pod network would otherwise be too large.
+func proposePodCIDR(used []*net.IPNet, nodes int, hostPrefix int) (*net.IPNet, int, error) {
+	hostPrefixes := []int{hostPrefix}
+	if hostPrefix == 0 {
+		hostPrefixes = nil
+		for prefix := HostPrefixMin; prefix <= HostPrefixMax; prefix++ {
+			hostPrefixes = append(hostPrefixes, prefix)
+		}
+	}
+	defaultPodCIDR := mustParse(DefaultPodCIDR)
+	defaultOnes, _ := defaultPodCIDR.Mask.Size()
+	for _, prefix := range hostPrefixes {
+		ones := min(defaultOnes, prefix-nodeBits(nodes))
+		if ones < minPodPrefixLength {
+			continue
+		}
+		preferred := defaultPodCIDR
+		if ones != defaultOnes {
+			preferred = &net.IPNet{IP: defaultPodCIDR.IP.Mask(net.CIDRMask(ones, 32)), Mask: net.CIDRMask(ones, 32)}
+		}
+		podCIDR := free(used, preferred, podPools)
+		if podCIDR != nil {
+			return podCIDR, prefix, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("Failed to find a free pod network with room for %d nodes", nodes)
+}
+
+// free returns the preferred network if it doesn't overlap with the used ones, or else the first free
+// network of the same size in the pools
+func free(used []*net.IPNet, preferred *net.IPNet, pools []string) *net.IPNet {
+	if !overlapsAny(preferred, used) {
+		return preferred
+	}
+	ones, _ := preferred.Mask.Size()
+	for _, pool := range pools {
+		poolNet := mustParse(pool)
+		poolOnes, _ := poolNet.Mask.Size()
+		if poolOnes > ones {
+			continue
+		}
+		start := toUint32(poolNet.IP)
+		for i := uint32(0); i < 1<<(ones-poolOnes); i++ {
+			candidate := &net.IPNet{IP: toIP(start + i<<(32-ones)), Mask: net.CIDRMask(ones, 32)}
+			if !overlapsAny(candidate, used) {
+				return candidate
+			}
+		}
+	}
+	return nil
+}
+
+func issues(input *Input, machineCIDR, serviceCIDR, podCIDR *net.IPNet, plan *Plan) []string {
+	result := []string{}
+	networks := []struct {
+		name string
+		cidr *net.IPNet
+	}{
+		{"Machine CIDR", machineCIDR},
+		{"Service CIDR", serviceCIDR},
+		{"Pod CIDR", podCIDR},
+	}
+	for i, a := range networks {
+		for _, b := range networks[i+1:] {
+			if Overlaps(a.cidr, b.cidr) {
+				result = append(result, fmt.Sprintf("%s '%s' overlaps with %s '%s'", a.name, a.cidr, b.name, b.cidr))
+			}
+		}
+		for _, reserved := range input.Reserved {
+			if Overlaps(a.cidr, reserved) {
+				result = append(result, fmt.Sprintf("%s '%s' overlaps with reserved network '%s'",
+					a.name, a.cidr, reserved))
+			}
+		}
+		if i == 0 {
+			continue
+		}
+		for _, vpcCIDR := range input.VpcCIDRs {
+			if Overlaps(a.cidr, vpcCIDR) {
+				result = append(result, fmt.Sprintf("%s '%s' overlaps with VPC network '%s'", a.name, a.cidr, vpcCIDR))
+			}
+		}
+		for _, subnet := range input.Subnets {
+			if Overlaps(a.cidr, subnet.CIDR) {
+				result = append(result, fmt.Sprintf("%s '%s' overlaps with subnet '%s' (%s)",
+					a.name, a.cidr, subnet.ID, subnet.CIDR))
+			}
+		}
+	}
+	for _, subnet := range input.Subnets {
+		if !Contains(machineCIDR, subnet.CIDR) {
+			result = append(result, fmt.Sprintf("Subnet '%s' (%s) is outside of the machine CIDR '%s'",
+				subnet.ID, subnet.CIDR, machineCIDR))
+		}
+	}
+	if input.Nodes > plan.MaxNodes {
+		result = append(result, fmt.Sprintf("Pod CIDR '%s' with host prefix /%d only has room for %d nodes, "+
+			"%d are wanted", podCIDR, plan.HostPrefix, plan.MaxNodes, input.Nodes))
+	}
+	return result
+}
+
+// maxNodes is the number of host prefix sized node networks in the pod network
+func maxNodes(podOnes int, hostPrefix int) int {
+	if hostPrefix < podOnes {
+		return 0
+	}
+	return 1 << (hostPrefix - podOnes)
+}
+
+// nodeBits is the number of bits needed to number the nodes
+func nodeBits(nodes int) int {
+	if nodes <= 1 {
+		return 0
+	}
+	return bits.Len(uint(nodes - 1))
+}
+
+// Supernet returns the smallest network containing all the given ones
+func Supernet(cidrs []*net.IPNet) *net.IPNet {
+	first := toUint32(cidrs[0].IP)
+	ones, _ := cidrs[0].Mask.Size()
+	for _, cidr := range cidrs[1:] {
+		cidrOnes, _ := cidr.Mask.Size()
+		ones = min(ones, cidrOnes, bits.LeadingZeros32(first^toUint32(cidr.IP)))
+	}
+	mask := net.CIDRMask(ones, 32)
+	return &net.IPNet{IP: toIP(first).Mask(mask), Mask: mask}
+}
+
+// Overlaps checks if the two networks have addresses in common
+func Overlaps(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// Contains checks if the inner network is part of the outer one
+func Contains(outer, inner *net.IPNet) bool {
+	outerOnes, _ := outer.Mask.Size()
+	innerOnes, _ := inner.Mask.Size()
+	return outer.Contains(inner.IP) && innerOnes >= outerOnes
+}
+
+func overlapsAny(cidr *net.IPNet, others []*net.IPNet) bool {
+	for _, other := range others {
+		if Overlaps(cidr, other) {
+			return true
+		}
+	}
+	return false
+}
+
+func toUint32(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
+}
+
+func toIP(value uint32) net.IP {
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, value)
+	return ip
+}
+
+func mustParse(cidr string) *net.IPNet {
+	_, result, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
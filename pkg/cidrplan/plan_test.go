@@ -0,0 +1,94 @@
+package cidrplan
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func cidr(value string) *net.IPNet {
+	_, result, err := net.ParseCIDR(value)
+	Expect(err).ToNot(HaveOccurred())
+	return result
+}
+
+var _ = Describe("Build", func() {
+	It("proposes the default networks when nothing is in the way", func() {
+		plan, err := Build(&Input{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plan).To(Equal(&Plan{
+			MachineCIDR: "10.0.0.0/16",
+			ServiceCIDR: "172.30.0.0/16",
+			PodCIDR:     "10.128.0.0/14",
+			HostPrefix:  23,
+			MaxNodes:    512,
+			PodsPerNode: 510,
+			Issues:      []string{},
+		}))
+	})
+
+	It("moves the pod and service networks away from the VPC and the reserved networks", func() {
+		plan, err := Build(&Input{
+			VpcCIDRs: []*net.IPNet{cidr("10.128.0.0/16")},
+			Reserved: []*net.IPNet{cidr("172.30.0.0/15"), cidr("10.0.0.0/12")},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plan.MachineCIDR).To(Equal("10.128.0.0/16"))
+		Expect(plan.PodCIDR).To(Equal("10.16.0.0/14"))
+		Expect(plan.ServiceCIDR).To(Equal("172.16.0.0/16"))
+		Expect(plan.Issues).To(BeEmpty())
+	})
+
+	It("makes the pod network large enough for the nodes", func() {
+		plan, err := Build(&Input{Nodes: 2000})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plan.PodCIDR).To(Equal("10.128.0.0/12"))
+		Expect(plan.HostPrefix).To(Equal(23))
+		Expect(plan.MaxNodes).To(Equal(2048))
+	})
+
+	It("raises the host prefix when the pod network would be too large", func() {
+		plan, err := Build(&Input{Nodes: 10000})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plan.HostPrefix).To(Equal(24))
+		Expect(plan.PodCIDR).To(Equal("10.128.0.0/10"))
+		Expect(plan.MaxNodes).To(Equal(16384))
+		Expect(plan.PodsPerNode).To(Equal(254))
+	})
+
+	It("reports the problems of the given networks", func() {
+		plan, err := Build(&Input{
+			Subnets: []Subnet{
+				{ID: "subnet-1", CIDR: cidr("10.0.1.0/24")},
+				{ID: "subnet-2", CIDR: cidr("10.1.0.0/24")},
+			},
+			Reserved:    []*net.IPNet{cidr("192.168.0.0/16")},
+			Nodes:       600,
+			MachineCIDR: cidr("10.0.0.0/16"),
+			ServiceCIDR: cidr("10.1.0.0/16"),
+			PodCIDR:     cidr("192.168.0.0/16"),
+			HostPrefix:  23,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plan.MaxNodes).To(Equal(128))
+		Expect(plan.Issues).To(Equal([]string{
+			"Service CIDR '10.1.0.0/16' overlaps with subnet 'subnet-2' (10.1.0.0/24)",
+			"Pod CIDR '192.168.0.0/16' overlaps with reserved network '192.168.0.0/16'",
+			"Subnet 'subnet-2' (10.1.0.0/24) is outside of the machine CIDR '10.0.0.0/16'",
+			"Pod CIDR '192.168.0.0/16' with host prefix /23 only has room for 128 nodes, 600 are wanted",
+		}))
+	})
+
+	It("rejects invalid host prefixes", func() {
+		_, err := Build(&Input{HostPrefix: 28})
+		Expect(err).To(MatchError("Invalid host prefix /28: it should be between 23 and 26"))
+	})
+})
+
+var _ = Describe("Supernet", func() {
+	It("returns the smallest network containing all of them", func() {
+		Expect(Supernet([]*net.IPNet{cidr("10.0.1.0/24"), cidr("10.0.6.0/24")}).String()).To(Equal("10.0.0.0/21"))
+		Expect(Supernet([]*net.IPNet{cidr("10.0.0.0/16")}).String()).To(Equal("10.0.0.0/16"))
+	})
+})
@@ -0,0 +1,303 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterhealth
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// Names of the checks of the health of a cluster
+const (
+	CheckState                = "state"
+	CheckLimitedSupport       = "limited support"
+	CheckInflightChecks       = "inflight checks"
+	CheckUpgrade              = "upgrade"
+	CheckMachinePools         = "machine pools"
+	CheckNodePools            = "node pools"
+	CheckBreakGlassCredential = "break glass credentials"
+	CheckAddOns               = "add-ons"
+)
+
+func checkState(_ Client, cluster *cmv1.Cluster) Check {
+	check := Check{
+		Name:     CheckState,
+		Severity: SeverityInfo,
+		Summary:  fmt.Sprintf("Cluster is %s", cluster.State()),
+	}
+	switch cluster.State() {
+	case cmv1.ClusterStateReady:
+		check.Severity = SeverityOK
+	case cmv1.ClusterStateError:
+		check.Severity = SeverityCritical
+		if message := cluster.Status().ProvisionErrorMessage(); message != "" {
+			check.Details = append(check.Details, message)
+		}
+	case cmv1.ClusterStateUninstalling, cmv1.ClusterStateHibernating:
+		check.Severity = SeverityWarning
+	}
+	if description := cluster.Status().Description(); description != "" {
+		check.Details = append(check.Details, description)
+	}
+	return check
+}
+
+func checkLimitedSupport(client Client, cluster *cmv1.Cluster) Check {
+	reasons, err := client.GetLimitedSupportReasons(cluster.ID())
+	if err != nil {
+		return failedCheck(CheckLimitedSupport, "limited support reasons", err)
+	}
+	if len(reasons) == 0 {
+		return Check{Name: CheckLimitedSupport, Severity: SeverityOK, Summary: "Cluster is fully supported"}
+	}
+	check := Check{
+		Name:     CheckLimitedSupport,
+		Severity: SeverityCritical,
+		Summary:  fmt.Sprintf("Cluster is in limited support for %d reason(s)", len(reasons)),
+	}
+	for _, reason := range reasons {
+		check.Details = append(check.Details, reason.Summary())
+	}
+	return check
+}
+
+func checkInflightChecks(client Client, cluster *cmv1.Cluster) Check {
+	inflightChecks, err := client.GetInflightChecks(cluster.ID())
+	if err != nil {
+		return failedCheck(CheckInflightChecks, "inflight checks", err)
+	}
+	check := Check{Name: CheckInflightChecks, Severity: SeverityOK, Summary: "All inflight checks passed"}
+	failed := 0
+	for _, inflightCheck := range inflightChecks {
+		switch inflightCheck.State() {
+		case cmv1.InflightCheckStateFailed:
+			failed++
+			check.Severity = SeverityWarning
+			check.Details = append(check.Details, fmt.Sprintf("%s failed", inflightCheck.Name()))
+		case cmv1.InflightCheckStatePending, cmv1.InflightCheckStateRunning:
+			if !check.Severity.Worse(SeverityInfo) {
+				check.Severity = SeverityInfo
+				check.Summary = "Inflight checks are running"
+			}
+			check.Details = append(check.Details, fmt.Sprintf("%s is %s", inflightCheck.Name(),
+				inflightCheck.State()))
+		}
+	}
+	if failed > 0 {
+		check.Summary = fmt.Sprintf("%d inflight check(s) failed", failed)
+	} else if len(inflightChecks) == 0 {
+		check.Summary = "No inflight checks"
+	}
+	return check
+}
+
+func checkUpgrade(client Client, cluster *cmv1.Cluster) Check {
+	var version string
+	var nextRun time.Time
+	var state *cmv1.UpgradePolicyState
+	if cluster.Hypershift().Enabled() {
+		upgradePolicy, err := client.GetControlPlaneScheduledUpgrade(cluster.ID())
+		if err != nil {
+			return failedCheck(CheckUpgrade, "scheduled upgrades", err)
+		}
+		if upgradePolicy != nil {
+			version, nextRun, state = upgradePolicy.Version(), upgradePolicy.NextRun(), upgradePolicy.State()
+		}
+	} else {
+		upgradePolicy, upgradeState, err := client.GetScheduledUpgrade(cluster.ID())
+		if err != nil {
+			return failedCheck(CheckUpgrade, "scheduled upgrades", err)
+		}
+		if upgradePolicy != nil {
+			version, nextRun, state = upgradePolicy.Version(), upgradePolicy.NextRun(), upgradeState
+		}
+	}
+
+	if version != "" {
+		check := Check{
+			Name:     CheckUpgrade,
+			Severity: SeverityInfo,
+			Summary: fmt.Sprintf("Upgrade to %s is %s for %s", version, state.Value(),
+				nextRun.Format("2006-01-02 15:04 MST")),
+		}
+		if state.Value() == cmv1.UpgradePolicyStateValueFailed {
+			check.Severity = SeverityWarning
+			check.Summary = fmt.Sprintf("Upgrade to %s failed", version)
+		}
+		if description := state.Description(); description != "" {
+			check.Details = append(check.Details, description)
+		}
+		return check
+	}
+	if upgrades := cluster.Version().AvailableUpgrades(); len(upgrades) > 0 {
+		return Check{
+			Name:     CheckUpgrade,
+			Severity: SeverityInfo,
+			Summary:  fmt.Sprintf("Upgrades available: %s", strings.Join(upgrades, ", ")),
+		}
+	}
+	return Check{Name: CheckUpgrade, Severity: SeverityOK, Summary: "No upgrade available"}
+}
+
+func checkPools(client Client, cluster *cmv1.Cluster) Check {
+	if cluster.Hypershift().Enabled() {
+		return checkNodePools(client, cluster)
+	}
+	return checkMachinePools(client, cluster)
+}
+
+// checkMachinePools compares the compute nodes of the cluster with the replicas wanted by its machine
+// pools
+func checkMachinePools(client Client, cluster *cmv1.Cluster) Check {
+	machinePools, err := client.GetMachinePools(cluster.ID())
+	if err != nil {
+		return failedCheck(CheckMachinePools, "machine pools", err)
+	}
+	wanted := 0
+	for _, machinePool := range machinePools {
+		if machinePool.Autoscaling() != nil {
+			wanted += machinePool.Autoscaling().MinReplicas()
+		} else {
+			wanted += machinePool.Replicas()
+		}
+	}
+	current := cluster.Status().CurrentCompute()
+	check := Check{
+		Name:     CheckMachinePools,
+		Severity: SeverityOK,
+		Summary:  fmt.Sprintf("%d machine pool(s), %d compute node(s)", len(machinePools), current),
+	}
+	if cluster.State() == cmv1.ClusterStateReady && current < wanted {
+		check.Severity = SeverityWarning
+		check.Summary = fmt.Sprintf("%d of %d compute node(s) are running", current, wanted)
+	}
+	return check
+}
+
+// checkNodePools reports the node pools missing replicas or running another version than the control
+// plane
+func checkNodePools(client Client, cluster *cmv1.Cluster) Check {
+	nodePools, err := client.GetNodePools(cluster.ID())
+	if err != nil {
+		return failedCheck(CheckNodePools, "node pools", err)
+	}
+	check := Check{
+		Name:     CheckNodePools,
+		Severity: SeverityOK,
+		Summary:  fmt.Sprintf("%d node pool(s) are healthy", len(nodePools)),
+	}
+	unhealthy := 0
+	for _, nodePool := range nodePools {
+		wanted := nodePool.Replicas()
+		if nodePool.Autoscaling() != nil {
+			wanted = nodePool.Autoscaling().MinReplica()
+		}
+		current := nodePool.Status().CurrentReplicas()
+		if current < wanted {
+			unhealthy++
+			check.Severity = SeverityWarning
+			detail := fmt.Sprintf("Node pool '%s' has %d of %d replicas", nodePool.ID(), current, wanted)
+			if message := nodePool.Status().Message(); message != "" {
+				detail += ": " + message
+			}
+			check.Details = append(check.Details, detail)
+		}
+		version := nodePool.Version().RawID()
+		if version != "" && version != cluster.Version().RawID() {
+			if !check.Severity.Worse(SeverityInfo) {
+				check.Severity = SeverityInfo
+			}
+			check.Details = append(check.Details, fmt.Sprintf("Node pool '%s' runs version %s, the control "+
+				"plane runs %s", nodePool.ID(), version, cluster.Version().RawID()))
+		}
+	}
+	if unhealthy > 0 {
+		check.Summary = fmt.Sprintf("%d of %d node pool(s) are missing replicas", unhealthy, len(nodePools))
+	} else if check.Severity == SeverityInfo {
+		check.Summary = fmt.Sprintf("%d node pool(s), some behind the control plane version", len(nodePools))
+	}
+	return check
+}
+
+func checkBreakGlassCredentials(client Client, cluster *cmv1.Cluster) Check {
+	credentials, err := client.GetBreakGlassCredentials(cluster.ID())
+	if err != nil {
+		return failedCheck(CheckBreakGlassCredential, "break glass credentials", err)
+	}
+	check := Check{Name: CheckBreakGlassCredential}
+	issued := 0
+	for _, credential := range credentials {
+		switch credential.Status() {
+		case cmv1.BreakGlassCredentialStatusIssued:
+			issued++
+		case cmv1.BreakGlassCredentialStatusFailed:
+			check.Details = append(check.Details, fmt.Sprintf("Credential '%s' of user '%s' failed",
+				credential.ID(), credential.Username()))
+		}
+	}
+	switch {
+	case len(check.Details) > 0:
+		check.Severity = SeverityWarning
+		check.Summary = fmt.Sprintf("%d break glass credential(s) failed", len(check.Details))
+	case issued > 0:
+		check.Severity = SeverityOK
+		check.Summary = fmt.Sprintf("%d break glass credential(s) issued", issued)
+	default:
+		check.Severity = SeverityInfo
+		check.Summary = "No break glass credential issued"
+	}
+	return check
+}
+
+func checkAddOns(client Client, cluster *cmv1.Cluster) Check {
+	addOns, err := client.GetAddOnInstallations(cluster.ID())
+	if err != nil {
+		return failedCheck(CheckAddOns, "add-on installations", err)
+	}
+	check := Check{
+		Name:     CheckAddOns,
+		Severity: SeverityOK,
+		Summary:  fmt.Sprintf("%d add-on(s) installed", len(addOns)),
+	}
+	failed := 0
+	for _, addOn := range addOns {
+		detail := fmt.Sprintf("Add-on '%s' is %s", addOn.ID(), addOn.State())
+		if description := addOn.StateDescription(); description != "" {
+			detail += ": " + description
+		}
+		switch addOn.State() {
+		case cmv1.AddOnInstallationStateFailed:
+			failed++
+			check.Severity = SeverityWarning
+			check.Details = append(check.Details, detail)
+		case cmv1.AddOnInstallationStateInstalling, cmv1.AddOnInstallationStatePending,
+			cmv1.AddOnInstallationStateDeleting:
+			if !check.Severity.Worse(SeverityInfo) {
+				check.Severity = SeverityInfo
+				check.Summary = "Add-ons are being installed or removed"
+			}
+			check.Details = append(check.Details, detail)
+		}
+	}
+	if failed > 0 {
+		check.Summary = fmt.Sprintf("%d of %d add-on(s) failed", failed, len(addOns))
+	}
+	return check
+}
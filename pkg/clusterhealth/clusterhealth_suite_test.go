@@ -0,0 +1,13 @@
+package clusterhealth
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestClusterHealth(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cluster health suite")
+}
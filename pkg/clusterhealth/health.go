@@ -0,0 +1,166 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterhealth
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// Severity is how urgently a check needs attention
+type Severity string
+
+const (
+	SeverityOK       Severity = "ok"
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+var severityRanks = map[Severity]int{
+	SeverityOK:       0,
+	SeverityInfo:     1,
+	SeverityWarning:  2,
+	SeverityCritical: 3,
+}
+
+// Worse checks if the severity needs more attention than the other one
+func (s Severity) Worse(other Severity) bool {
+	return severityRanks[s] > severityRanks[other]
+}
+
+// Client is the part of the OCM client the health of clusters is read from
+type Client interface {
+	GetLimitedSupportReasons(clusterID string) ([]*cmv1.LimitedSupportReason, error)
+	GetInflightChecks(clusterID string) ([]*cmv1.InflightCheck, error)
+	GetScheduledUpgrade(clusterID string) (*cmv1.UpgradePolicy, *cmv1.UpgradePolicyState, error)
+	GetControlPlaneScheduledUpgrade(clusterID string) (*cmv1.ControlPlaneUpgradePolicy, error)
+	GetMachinePools(clusterID string) ([]*cmv1.MachinePool, error)
+	GetNodePools(clusterID string) ([]*cmv1.NodePool, error)
+	GetBreakGlassCredentials(clusterID string) ([]*cmv1.BreakGlassCredential, error)
+	GetAddOnInstallations(clusterID string) ([]*cmv1.AddOnInstallation, error)
+}
+
+// Check is the outcome of one aspect of the health of a cluster
+type Check struct {
+	Name     string   `json:"name"`
+	Severity Severity `json:"severity"`
+	Summary  string   `json:"summary"`
+	Details  []string `json:"details,omitempty"`
+}
+
+// Status is the health of a cluster. Its severity is the worst of the severities of its checks.
+type Status struct {
+	ClusterID string   `json:"clusterId"`
+	Name      string   `json:"name"`
+	State     string   `json:"state"`
+	Version   string   `json:"version"`
+	Severity  Severity `json:"severity"`
+	Checks    []Check  `json:"checks"`
+}
+
+// Issues returns the checks that need attention
+func (s *Status) Issues() []Check {
+	issues := []Check{}
+	for _, check := range s.Checks {
+		if check.Severity.Worse(SeverityInfo) {
+			issues = append(issues, check)
+		}
+	}
+	return issues
+}
+
+// Get returns the health of the cluster. The checks are fetched concurrently, a check that can't be
+// fetched is reported as a warning.
+func Get(client Client, cluster *cmv1.Cluster) *Status {
+	checks := []func(Client, *cmv1.Cluster) Check{
+		checkState,
+		checkLimitedSupport,
+		checkInflightChecks,
+		checkUpgrade,
+		checkPools,
+		checkAddOns,
+	}
+	if cluster.ExternalAuthConfig().Enabled() {
+		checks = append(checks, checkBreakGlassCredentials)
+	}
+
+	status := &Status{
+		ClusterID: cluster.ID(),
+		Name:      cluster.Name(),
+		State:     string(cluster.State()),
+		Version:   cluster.Version().RawID(),
+		Severity:  SeverityOK,
+		Checks:    make([]Check, len(checks)),
+	}
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check func(Client, *cmv1.Cluster) Check) {
+			defer wg.Done()
+			status.Checks[i] = check(client, cluster)
+		}(i, check)
+	}
+	wg.Wait()
+	for _, check := range status.Checks {
+		if check.Severity.Worse(status.Severity) {
+			status.Severity = check.Severity
+		}
+	}
+	return status
+}
+
+// GetAll returns the health of the clusters, fetching the given number of clusters at a time. The
+// clusters needing the most attention come first.
+func GetAll(client Client, clusters []*cmv1.Cluster, parallelism int) []*Status {
+	statuses := make([]*Status, len(clusters))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < max(parallelism, 1); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				statuses[i] = Get(client, clusters[i])
+			}
+		}()
+	}
+	for i := range clusters {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if statuses[i].Severity != statuses[j].Severity {
+			return statuses[i].Severity.Worse(statuses[j].Severity)
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+func failedCheck(name string, what string, err error) Check {
+	return Check{
+		Name:     name,
+		Severity: SeverityWarning,
+		Summary:  fmt.Sprintf("Failed to get %s: %v", what, err),
+	}
+}
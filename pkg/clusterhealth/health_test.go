@@ -0,0 +1,184 @@
+package clusterhealth
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// fakeClient returns the same resources for every cluster
+type fakeClient struct {
+	limitedSupportReasons []*cmv1.LimitedSupportReason
+	inflightChecks        []*cmv1.InflightCheck
+	upgradePolicy         *cmv1.UpgradePolicy
+	upgradeState          *cmv1.UpgradePolicyState
+	machinePools          []*cmv1.MachinePool
+	nodePools             []*cmv1.NodePool
+	addOns                []*cmv1.AddOnInstallation
+	err                   error
+}
+
+func (c *fakeClient) GetLimitedSupportReasons(_ string) ([]*cmv1.LimitedSupportReason, error) {
+	return c.limitedSupportReasons, nil
+}
+
+func (c *fakeClient) GetInflightChecks(_ string) ([]*cmv1.InflightCheck, error) {
+	return c.inflightChecks, c.err
+}
+
+func (c *fakeClient) GetScheduledUpgrade(_ string) (*cmv1.UpgradePolicy, *cmv1.UpgradePolicyState, error) {
+	return c.upgradePolicy, c.upgradeState, nil
+}
+
+func (c *fakeClient) GetControlPlaneScheduledUpgrade(_ string) (*cmv1.ControlPlaneUpgradePolicy, error) {
+	return nil, nil
+}
+
+func (c *fakeClient) GetMachinePools(_ string) ([]*cmv1.MachinePool, error) {
+	return c.machinePools, nil
+}
+
+func (c *fakeClient) GetNodePools(_ string) ([]*cmv1.NodePool, error) {
+	return c.nodePools, nil
+}
+
+func (c *fakeClient) GetBreakGlassCredentials(_ string) ([]*cmv1.BreakGlassCredential, error) {
+	return nil, nil
+}
+
+func (c *fakeClient) GetAddOnInstallations(_ string) ([]*cmv1.AddOnInstallation, error) {
+	return c.addOns, nil
+}
+
+func buildCluster(builder *cmv1.ClusterBuilder) *cmv1.Cluster {
+	cluster, err := builder.Build()
+	Expect(err).ToNot(HaveOccurred())
+	return cluster
+}
+
+func readyCluster(name string) *cmv1.ClusterBuilder {
+	return cmv1.NewCluster().ID(name + "-id").Name(name).State(cmv1.ClusterStateReady).
+		Version(cmv1.NewVersion().RawID("4.15.10")).
+		Status(cmv1.NewClusterStatus().CurrentCompute(2))
+}
+
+func findCheck(status *Status, name string) Check {
+	for _, check := range status.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	Fail(fmt.Sprintf("Check '%s' not found", name))
+	return Check{}
+}
+
+var _ = Describe("Cluster health", func() {
+	var client *fakeClient
+
+	BeforeEach(func() {
+		machinePool, err := cmv1.NewMachinePool().ID("worker").Replicas(2).Build()
+		Expect(err).ToNot(HaveOccurred())
+		client = &fakeClient{machinePools: []*cmv1.MachinePool{machinePool}}
+	})
+
+	It("reports a healthy cluster", func() {
+		status := Get(client, buildCluster(readyCluster("foo")))
+		Expect(status.Severity).To(Equal(SeverityOK))
+		Expect(status.Version).To(Equal("4.15.10"))
+		Expect(status.Checks).To(HaveLen(6))
+		Expect(status.Issues()).To(BeEmpty())
+	})
+
+	It("takes the worst severity of the checks", func() {
+		reason, err := cmv1.NewLimitedSupportReason().Summary("Cluster is out of support").Build()
+		Expect(err).ToNot(HaveOccurred())
+		client.limitedSupportReasons = []*cmv1.LimitedSupportReason{reason}
+
+		status := Get(client, buildCluster(readyCluster("foo")))
+		Expect(status.Severity).To(Equal(SeverityCritical))
+		check := findCheck(status, CheckLimitedSupport)
+		Expect(check.Details).To(Equal([]string{"Cluster is out of support"}))
+		Expect(status.Issues()).To(HaveLen(1))
+	})
+
+	It("reports the checks that can't be fetched as warnings", func() {
+		client.err = fmt.Errorf("boom")
+		check := findCheck(Get(client, buildCluster(readyCluster("foo"))), CheckInflightChecks)
+		Expect(check.Severity).To(Equal(SeverityWarning))
+		Expect(check.Summary).To(Equal("Failed to get inflight checks: boom"))
+	})
+
+	It("reports missing compute nodes", func() {
+		cluster := buildCluster(readyCluster("foo").Status(cmv1.NewClusterStatus().CurrentCompute(1)))
+		check := findCheck(Get(client, cluster), CheckMachinePools)
+		Expect(check.Severity).To(Equal(SeverityWarning))
+		Expect(check.Summary).To(Equal("1 of 2 compute node(s) are running"))
+	})
+
+	It("reports failed upgrades and add-ons", func() {
+		var err error
+		client.upgradePolicy, err = cmv1.NewUpgradePolicy().Version("4.15.11").
+			UpgradeType(cmv1.UpgradeTypeOSD).Build()
+		Expect(err).ToNot(HaveOccurred())
+		client.upgradeState, err = cmv1.NewUpgradePolicyState().Value(cmv1.UpgradePolicyStateValueFailed).
+			Description("Nodes failed to drain").Build()
+		Expect(err).ToNot(HaveOccurred())
+		addOn, err := cmv1.NewAddOnInstallation().ID("cluster-logging").
+			State(cmv1.AddOnInstallationStateFailed).Build()
+		Expect(err).ToNot(HaveOccurred())
+		client.addOns = []*cmv1.AddOnInstallation{addOn}
+
+		status := Get(client, buildCluster(readyCluster("foo")))
+		Expect(status.Severity).To(Equal(SeverityWarning))
+		upgrade := findCheck(status, CheckUpgrade)
+		Expect(upgrade.Summary).To(Equal("Upgrade to 4.15.11 failed"))
+		Expect(upgrade.Details).To(Equal([]string{"Nodes failed to drain"}))
+		Expect(findCheck(status, CheckAddOns).Details).To(Equal([]string{"Add-on 'cluster-logging' is failed"}))
+	})
+
+	It("reports node pools missing replicas or behind the control plane", func() {
+		client.nodePools = []*cmv1.NodePool{}
+		for _, builder := range []*cmv1.NodePoolBuilder{
+			cmv1.NewNodePool().ID("workers-a").Replicas(2).Version(cmv1.NewVersion().RawID("4.15.10")).
+				Status(cmv1.NewNodePoolStatus().CurrentReplicas(1).Message("Instance is pending")),
+			cmv1.NewNodePool().ID("workers-b").Replicas(2).Version(cmv1.NewVersion().RawID("4.15.9")).
+				Status(cmv1.NewNodePoolStatus().CurrentReplicas(2)),
+		} {
+			nodePool, err := builder.Build()
+			Expect(err).ToNot(HaveOccurred())
+			client.nodePools = append(client.nodePools, nodePool)
+		}
+
+		cluster := buildCluster(readyCluster("foo").Hypershift(cmv1.NewHypershift().Enabled(true)))
+		check := findCheck(Get(client, cluster), CheckNodePools)
+		Expect(check.Severity).To(Equal(SeverityWarning))
+		Expect(check.Summary).To(Equal("1 of 2 node pool(s) are missing replicas"))
+		Expect(check.Details).To(Equal([]string{
+			"Node pool 'workers-a' has 1 of 2 replicas: Instance is pending",
+			"Node pool 'workers-b' runs version 4.15.9, the control plane runs 4.15.10",
+		}))
+	})
+
+	It("checks break glass credentials of clusters with external authentication", func() {
+		cluster := buildCluster(readyCluster("foo").ExternalAuthConfig(cmv1.NewExternalAuthConfig().Enabled(true)))
+		check := findCheck(Get(client, cluster), CheckBreakGlassCredential)
+		Expect(check.Severity).To(Equal(SeverityInfo))
+		Expect(check.Summary).To(Equal("No break glass credential issued"))
+	})
+
+	It("sorts the fleet by severity and name", func() {
+		clusters := []*cmv1.Cluster{
+			buildCluster(readyCluster("b")),
+			buildCluster(readyCluster("c").State(cmv1.ClusterStateError)),
+			buildCluster(readyCluster("a")),
+		}
+		statuses := GetAll(client, clusters, 2)
+		names := []string{}
+		for _, status := range statuses {
+			names = append(names, status.Name)
+		}
+		Expect(names).To(Equal([]string{"c", "a", "b"}))
+	})
+})
@@ -7,4 +7,7 @@ const (
 	AwsRegion      = "AWS_REGION"       // AWS region to use
 	OcmConfig      = "OCM_CONFIG"       // Path to OCM configuration file
 	OcmTemplateDir = "OCM_TEMPLATE_DIR" // Directory for OCM cloudformation templates
+
+	RosaSchedulesFile   = "ROSA_SCHEDULES_FILE"   // Path to the machine pool schedules file
+	RosaConventionsFile = "ROSA_CONVENTIONS_FILE" // Path to the IAM role conventions file
 )
@@ -0,0 +1,314 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conventions reads the naming conventions an organization enforces on the IAM roles
+// created by ROSA from a local file. The commands creating roles use the conventions as defaults
+// and refuse values that don't follow them.
+//
+// The file sets rules for all the roles and optionally overrides them per kind of role:
+//
+//	prefix: acme
+//	path: /rosa/
+//	permissionsBoundary: arn:aws:iam::123456789012:policy/acme-boundary
+//	tags:
+//	  cost-center: "1234"
+//	operatorRoles:
+//	  path: /rosa/operators/
+//
+// OIDC providers have no prefix, path or permissions boundary, only the tags apply to them.
+package conventions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/constants"
+)
+
+const fileName = "rosa-conventions.yaml"
+
+// Kinds of resources the conventions apply to
+const (
+	AccountRoles  = "accountRoles"
+	OperatorRoles = "operatorRoles"
+	OcmRole       = "ocmRole"
+	UserRole      = "userRole"
+	OidcProvider  = "oidcProvider"
+)
+
+// Rules are the conventions of a kind of resource. Empty fields are not enforced.
+type Rules struct {
+	// Prefix every role prefix must be, or start with followed by '-'
+	Prefix string `json:"prefix,omitempty"`
+	// Path every role and policy path must be, or be nested in
+	Path string `json:"path,omitempty"`
+	// PermissionsBoundary is the ARN of the policy every role must use as permissions boundary
+	PermissionsBoundary string `json:"permissionsBoundary,omitempty"`
+	// Tags set on every role and policy
+	Tags map[string]string `json:"tags,omitempty"`
+
+	source string
+}
+
+// Conventions are the rules of the conventions file.
+type Conventions struct {
+	Rules
+	AccountRoles  *Rules `json:"accountRoles,omitempty"`
+	OperatorRoles *Rules `json:"operatorRoles,omitempty"`
+	OcmRole       *Rules `json:"ocmRole,omitempty"`
+	UserRole      *Rules `json:"userRole,omitempty"`
+	OidcProvider  *Rules `json:"oidcProvider,omitempty"`
+
+	source string
+}
+
+// Location returns the path of the conventions file. It can be overridden with the
+// ROSA_CONVENTIONS_FILE environment variable, otherwise it sits next to the OCM configuration.
+func Location() (string, error) {
+	if path := os.Getenv(constants.RosaConventionsFile); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "ocm", fileName), nil
+}
+
+// Load reads the conventions file. A missing file results in no conventions.
+func Load() (*Conventions, error) {
+	path, err := Location()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFrom(path)
+}
+
+// LoadFrom reads the conventions from the given file. A missing file results in no conventions.
+func LoadFrom(path string) (*Conventions, error) {
+	conventions := &Conventions{source: path}
+	// #nosec G304
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return conventions, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read conventions file '%s': %v", path, err)
+	}
+	err = yaml.UnmarshalStrict(data, conventions)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse conventions file '%s': %v", path, err)
+	}
+	err = conventions.validate()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid conventions file '%s': %v", path, err)
+	}
+	return conventions, nil
+}
+
+// ForKind loads the conventions file and returns the rules of the kind of resource.
+func ForKind(kind string) (*Rules, error) {
+	conventions, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	return conventions.For(kind), nil
+}
+
+func (c *Conventions) overrides() map[string]*Rules {
+	return map[string]*Rules{
+		AccountRoles:  c.AccountRoles,
+		OperatorRoles: c.OperatorRoles,
+		OcmRole:       c.OcmRole,
+		UserRole:      c.UserRole,
+		OidcProvider:  c.OidcProvider,
+	}
+}
+
+func (c *Conventions) validate() error {
+	err := c.Rules.validate()
+	if err != nil {
+		return err
+	}
+	for kind, rules := range c.overrides() {
+		if rules == nil {
+			continue
+		}
+		err = rules.validate()
+		if err != nil {
+			return fmt.Errorf("%s: %v", kind, err)
+		}
+	}
+	return nil
+}
+
+// For returns the rules of the kind of resource, the rules of the kind taking precedence over
+// the rules common to all kinds. Tags of both are set.
+func (c *Conventions) For(kind string) *Rules {
+	result := &Rules{
+		Prefix:              c.Prefix,
+		Path:                c.Path,
+		PermissionsBoundary: c.PermissionsBoundary,
+		Tags:                map[string]string{},
+		source:              c.source,
+	}
+	for key, value := range c.Tags {
+		result.Tags[key] = value
+	}
+	override := c.overrides()[kind]
+	if override == nil {
+		return result
+	}
+	if override.Prefix != "" {
+		result.Prefix = override.Prefix
+	}
+	if override.Path != "" {
+		result.Path = override.Path
+	}
+	if override.PermissionsBoundary != "" {
+		result.PermissionsBoundary = override.PermissionsBoundary
+	}
+	for key, value := range override.Tags {
+		result.Tags[key] = value
+	}
+	return result
+}
+
+func (r *Rules) validate() error {
+	if r.Prefix != "" && !aws.RoleNameRE.MatchString(r.Prefix) {
+		return fmt.Errorf("prefix '%s' must match %s", r.Prefix, aws.RoleNameRE.String())
+	}
+	if r.Path != "" && !aws.ARNPath.MatchString(r.Path) {
+		return fmt.Errorf("path '%s' must begin and end with '/' and contain only alphanumeric "+
+			"characters and/or '/' characters", r.Path)
+	}
+	if r.PermissionsBoundary != "" {
+		err := aws.ARNValidator(r.PermissionsBoundary)
+		if err != nil {
+			return fmt.Errorf("permissions boundary: %v", err)
+		}
+	}
+	for key, value := range r.Tags {
+		if reserved, ok := tags.ReservedPrefix(key); ok {
+			return fmt.Errorf("tag '%s' uses the reserved prefix '%s'", key, reserved)
+		}
+		if !aws.UserTagKeyRE.MatchString(key) {
+			return fmt.Errorf("tag key '%s' must match %s", key, aws.UserTagKeyRE.String())
+		}
+		if value == "" {
+			return fmt.Errorf("tag '%s' must have a value", key)
+		}
+		if !aws.UserTagValueRE.MatchString(value) {
+			return fmt.Errorf("value '%s' of tag '%s' must match %s", value, key, aws.UserTagValueRE.String())
+		}
+	}
+	return nil
+}
+
+// hasPrefix returns true if the prefix is the conventions prefix or starts with it followed by
+// '-', so that 'acme' doesn't accept 'acmecorp'.
+func (r *Rules) hasPrefix(prefix string) bool {
+	return prefix == r.Prefix || strings.HasPrefix(prefix, r.Prefix+"-")
+}
+
+// hasPath returns true if the path is the conventions path or nested in it.
+func (r *Rules) hasPath(path string) bool {
+	parent := strings.TrimSuffix(r.Path, "/")
+	return path == r.Path || strings.HasPrefix(path, parent+"/")
+}
+
+// DefaultPrefix returns the prefix to use when none was chosen, prepending the conventions prefix
+// to the default prefix when it doesn't start with it.
+func (r *Rules) DefaultPrefix(prefix string) string {
+	if r == nil || r.Prefix == "" || r.hasPrefix(prefix) {
+		return prefix
+	}
+	if prefix == "" {
+		return r.Prefix
+	}
+	return fmt.Sprintf("%s-%s", r.Prefix, prefix)
+}
+
+// ValidatePrefix fails when the prefix isn't the conventions prefix or doesn't start with it
+// followed by '-'.
+func (r *Rules) ValidatePrefix(prefix string) error {
+	if r == nil || r.Prefix == "" || r.hasPrefix(prefix) {
+		return nil
+	}
+	return fmt.Errorf("Prefix '%s' does not follow the conventions in '%s': it must be '%s' or start with '%s-'",
+		prefix, r.source, r.Prefix, r.Prefix)
+}
+
+// DefaultPath returns the conventions path when no path was chosen.
+func (r *Rules) DefaultPath(path string) string {
+	if r == nil || path != "" {
+		return path
+	}
+	return r.Path
+}
+
+// ValidatePath fails when the path isn't the conventions path or nested in it.
+func (r *Rules) ValidatePath(path string) error {
+	if r == nil || r.Path == "" || r.hasPath(path) {
+		return nil
+	}
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Errorf("Path '%s' does not follow the conventions in '%s': it must be '%s' or nested in it",
+		path, r.source, r.Path)
+}
+
+// DefaultPermissionsBoundary returns the conventions permissions boundary when none was chosen.
+func (r *Rules) DefaultPermissionsBoundary(permissionsBoundary string) string {
+	if r == nil || permissionsBoundary != "" {
+		return permissionsBoundary
+	}
+	return r.PermissionsBoundary
+}
+
+// ValidatePermissionsBoundary fails when the permissions boundary isn't the conventions one.
+func (r *Rules) ValidatePermissionsBoundary(permissionsBoundary string) error {
+	if r == nil || r.PermissionsBoundary == "" || permissionsBoundary == r.PermissionsBoundary {
+		return nil
+	}
+	if permissionsBoundary == "" {
+		return fmt.Errorf("A permissions boundary is required by the conventions in '%s', use '%s'",
+			r.source, r.PermissionsBoundary)
+	}
+	return fmt.Errorf("Permissions boundary '%s' does not follow the conventions in '%s': it must be '%s'",
+		permissionsBoundary, r.source, r.PermissionsBoundary)
+}
+
+// AddTags sets the conventions tags in the tag list, keeping the tags already set. The values of
+// the conventions tags are validated when the file is loaded.
+func (r *Rules) AddTags(tagList map[string]string) map[string]string {
+	if r == nil {
+		return tagList
+	}
+	for key, value := range r.Tags {
+		if _, ok := tagList[key]; !ok {
+			tagList[key] = value
+		}
+	}
+	return tagList
+}
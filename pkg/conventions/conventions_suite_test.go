@@ -0,0 +1,13 @@
+package conventions
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestConventions(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Conventions suite")
+}
@@ -0,0 +1,119 @@
+package conventions
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Conventions", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	write := func(content string) string {
+		path := filepath.Join(dir, fileName)
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	Context("LoadFrom", func() {
+		It("returns no conventions when the file is missing", func() {
+			conventions, err := LoadFrom(filepath.Join(dir, fileName))
+			Expect(err).NotTo(HaveOccurred())
+			rules := conventions.For(AccountRoles)
+			Expect(rules.DefaultPrefix("ManagedOpenShift")).To(Equal("ManagedOpenShift"))
+			Expect(rules.ValidatePath("")).To(Succeed())
+			Expect(rules.ValidatePermissionsBoundary("")).To(Succeed())
+			Expect(rules.AddTags(map[string]string{})).To(BeEmpty())
+		})
+		It("overrides the common rules per kind", func() {
+			path := write(`
+prefix: acme
+path: /rosa/
+tags:
+  cost-center: "1234"
+  team: platform
+operatorRoles:
+  path: /rosa/operators/
+  tags:
+    team: openshift
+`)
+			conventions, err := LoadFrom(path)
+			Expect(err).NotTo(HaveOccurred())
+			operatorRoles := conventions.For(OperatorRoles)
+			Expect(operatorRoles.Prefix).To(Equal("acme"))
+			Expect(operatorRoles.Path).To(Equal("/rosa/operators/"))
+			Expect(operatorRoles.Tags).To(Equal(map[string]string{"cost-center": "1234", "team": "openshift"}))
+			Expect(conventions.For(AccountRoles).Path).To(Equal("/rosa/"))
+		})
+		It("rejects unknown fields", func() {
+			_, err := LoadFrom(write("prefixes: acme\n"))
+			Expect(err).To(MatchError(ContainSubstring("Failed to parse conventions file")))
+		})
+		It("rejects invalid rules", func() {
+			_, err := LoadFrom(write("path: rosa\n"))
+			Expect(err).To(MatchError(ContainSubstring("path 'rosa' must begin and end with '/'")))
+			_, err = LoadFrom(write("userRole:\n  tags:\n    rosa_role_prefix: acme\n"))
+			Expect(err).To(MatchError(ContainSubstring("userRole: tag 'rosa_role_prefix' uses the reserved prefix")))
+			_, err = LoadFrom(write("tags:\n  owner: \"\"\n"))
+			Expect(err).To(MatchError(ContainSubstring("tag 'owner' must have a value")))
+			_, err = LoadFrom(write("tags:\n  owner: \"a{b}\"\n"))
+			Expect(err).To(MatchError(ContainSubstring("value 'a{b}' of tag 'owner' must match")))
+		})
+	})
+
+	Context("Rules", func() {
+		rules := &Rules{
+			Prefix:              "acme",
+			Path:                "/rosa/",
+			PermissionsBoundary: "arn:aws:iam::123456789012:policy/boundary",
+			Tags:                map[string]string{"team": "platform"},
+			source:              "conventions.yaml",
+		}
+
+		It("fills and validates prefixes", func() {
+			Expect(rules.DefaultPrefix("ManagedOpenShift")).To(Equal("acme-ManagedOpenShift"))
+			Expect(rules.DefaultPrefix("acme-cluster")).To(Equal("acme-cluster"))
+			Expect(rules.DefaultPrefix("")).To(Equal("acme"))
+			Expect(rules.DefaultPrefix("acmecorp")).To(Equal("acme-acmecorp"))
+			Expect(rules.ValidatePrefix("acme")).To(Succeed())
+			Expect(rules.ValidatePrefix("acme-cluster")).To(Succeed())
+			Expect(rules.ValidatePrefix("cluster")).To(MatchError(
+				"Prefix 'cluster' does not follow the conventions in 'conventions.yaml': " +
+					"it must be 'acme' or start with 'acme-'"))
+			Expect(rules.ValidatePrefix("acmecorp")).To(MatchError(ContainSubstring("does not follow the conventions")))
+		})
+		It("fills and validates paths", func() {
+			Expect(rules.DefaultPath("")).To(Equal("/rosa/"))
+			Expect(rules.DefaultPath("/other/")).To(Equal("/other/"))
+			Expect(rules.ValidatePath("/rosa/")).To(Succeed())
+			Expect(rules.ValidatePath("/rosa/operators/")).To(Succeed())
+			Expect(rules.ValidatePath("/rosacorp/")).To(MatchError(ContainSubstring("does not follow the conventions")))
+			Expect(rules.ValidatePath("")).To(MatchError(ContainSubstring("Path '/' does not follow the conventions")))
+		})
+		It("fills and validates permissions boundaries", func() {
+			Expect(rules.DefaultPermissionsBoundary("")).To(Equal(rules.PermissionsBoundary))
+			Expect(rules.ValidatePermissionsBoundary(rules.PermissionsBoundary)).To(Succeed())
+			Expect(rules.ValidatePermissionsBoundary("")).To(MatchError(ContainSubstring("is required")))
+			Expect(rules.ValidatePermissionsBoundary("arn:aws:iam::123456789012:policy/other")).To(
+				MatchError(ContainSubstring("it must be 'arn:aws:iam::123456789012:policy/boundary'")))
+		})
+		It("adds tags without overriding the tags already set", func() {
+			tagList := rules.AddTags(map[string]string{"rosa_role_prefix": "acme", "team": "openshift"})
+			Expect(tagList).To(Equal(map[string]string{"rosa_role_prefix": "acme", "team": "openshift"}))
+			tagList = rules.AddTags(map[string]string{"rosa_role_prefix": "acme"})
+			Expect(tagList).To(HaveKeyWithValue("team", "platform"))
+		})
+		It("enforces nothing without rules", func() {
+			var none *Rules
+			Expect(none.DefaultPrefix("ManagedOpenShift")).To(Equal("ManagedOpenShift"))
+			Expect(none.ValidatePrefix("any")).To(Succeed())
+			Expect(none.AddTags(map[string]string{"key": "value"})).To(HaveLen(1))
+		})
+	})
+})
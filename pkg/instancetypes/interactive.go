@@ -0,0 +1,89 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancetypes
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Number of recommendations shown before an instance type prompt.
+const promptRecommendations = 5
+
+// PromptRecommendation recommends an instance type before an instance type prompt, for the users
+// that asked for it with the '--recommend-instance-type' option. It prints the best fits of the
+// cluster topology for the requirements given by the user and returns the best one, to be used as
+// the default of the prompt. The given default is returned when no instance type fits.
+func PromptRecommendation(machineTypes ocm.MachineTypeList, multiAZ bool, hostedCP bool,
+	defaultID string) (string, error) {
+	cpu, err := interactive.GetInt(interactive.Input{
+		Question: "Minimum CPU cores",
+		Default:  0,
+		Validators: []interactive.Validator{
+			interactive.MinValue(0),
+		},
+	})
+	if err != nil {
+		return defaultID, err
+	}
+	memoryValue, err := interactive.GetString(interactive.Input{
+		Question: "Minimum memory (for example 32Gi)",
+		Validators: []interactive.Validator{
+			func(value interface{}) error {
+				_, err := ParseMemory(fmt.Sprintf("%v", value))
+				return err
+			},
+		},
+	})
+	if err != nil {
+		return defaultID, err
+	}
+	memory, err := ParseMemory(memoryValue)
+	if err != nil {
+		return defaultID, err
+	}
+	gpu, err := interactive.GetBool(interactive.Input{
+		Question: "GPU",
+		Default:  false,
+	})
+	if err != nil {
+		return defaultID, err
+	}
+
+	recommendations := Recommend(machineTypes, Requirements{
+		CPU:       cpu,
+		MemoryGiB: memory,
+		GPU:       gpu,
+		MultiAZ:   multiAZ,
+		HostedCP:  hostedCP,
+	}, nil)
+	if len(recommendations) == 0 || !recommendations[0].HasQuota {
+		fmt.Println("No available instance type with enough quota fits these requirements")
+		return defaultID, nil
+	}
+	if len(recommendations) > promptRecommendations {
+		recommendations = recommendations[:promptRecommendations]
+	}
+	err = Print(os.Stdout, recommendations)
+	if err != nil {
+		return defaultID, err
+	}
+	return recommendations[0].ID(), nil
+}
@@ -0,0 +1,217 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package instancetypes ranks the instance types available to a cluster by how well they fit
+// CPU, memory, GPU and architecture requirements.
+package instancetypes
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+const gibibyte = 1 << 30
+
+// Requirements describe the smallest instance type that fits a workload. Zero CPU or memory means
+// that there is no requirement on that resource.
+type Requirements struct {
+	CPU          int
+	MemoryGiB    float64
+	GPU          bool
+	Architecture string
+	MultiAZ      bool
+	// HostedCP limits the recommendations to the instance types supported by the node pools of
+	// hosted clusters, which live in a single availability zone.
+	HostedCP bool
+}
+
+// Validate checks that the requirements can be matched by an instance type.
+func (r Requirements) Validate() error {
+	if r.CPU < 0 {
+		return fmt.Errorf("CPU must be a non-negative number of cores")
+	}
+	if r.MemoryGiB < 0 {
+		return fmt.Errorf("Memory must be a non-negative amount")
+	}
+	switch cmv1.ProcessorType(r.Architecture) {
+	case "", cmv1.ProcessorTypeAMD64, cmv1.ProcessorTypeARM64:
+	default:
+		return fmt.Errorf("Architecture must be either '%s' or '%s'", cmv1.ProcessorTypeAMD64, cmv1.ProcessorTypeARM64)
+	}
+	if cmv1.ProcessorType(r.Architecture) == cmv1.ProcessorTypeARM64 && !r.HostedCP {
+		return fmt.Errorf("Architecture '%s' is only supported by clusters with Hosted Control Planes",
+			cmv1.ProcessorTypeARM64)
+	}
+	return nil
+}
+
+// Recommendation is an instance type that fits the requirements.
+type Recommendation struct {
+	MachineType *ocm.MachineType
+	HasQuota    bool
+	// Waste is the relative amount of CPU and memory above the requirements, lower is a better fit.
+	Waste float64
+	// Zones lists the availability zones where the instance type is offered, when known.
+	Zones []string
+}
+
+// ID returns the instance type ID.
+func (r *Recommendation) ID() string {
+	return r.MachineType.MachineType.ID()
+}
+
+// ParseMemory parses an amount of memory such as '32Gi', '32GiB' or '512Mi' into GiB. Plain
+// numbers are GiB.
+func ParseMemory(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		return number, nil
+	}
+	value = strings.TrimSuffix(strings.TrimSuffix(value, "B"), "b")
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("Expected a valid amount of memory such as '32Gi', got '%s'", value)
+	}
+	return float64(quantity.Value()) / gibibyte, nil
+}
+
+// MemoryGiB returns the memory of a machine type in GiB.
+func MemoryGiB(machineType *cmv1.MachineType) float64 {
+	memory := machineType.Memory()
+	switch memory.Unit() {
+	case "GiB", "Gi":
+		return memory.Value()
+	case "MiB", "Mi":
+		return memory.Value() / 1024
+	default:
+		return memory.Value() / gibibyte
+	}
+}
+
+// Recommend returns the available instance types that fit the requirements, best fit first.
+// Instance types with enough quota come before those without, then instance types that waste
+// less CPU and memory, then instance types offered in more zones.
+func Recommend(machineTypes ocm.MachineTypeList, requirements Requirements,
+	zones map[string][]string) []*Recommendation {
+	var recommendations []*Recommendation
+	for _, item := range machineTypes.Items {
+		if !item.Available || !fits(item.MachineType, requirements) {
+			continue
+		}
+		recommendations = append(recommendations, &Recommendation{
+			MachineType: item,
+			HasQuota:    item.HasQuota(requirements.MultiAZ && !requirements.HostedCP),
+			Waste:       waste(item.MachineType, requirements),
+			Zones:       zones[item.MachineType.ID()],
+		})
+	}
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.HasQuota != b.HasQuota {
+			return a.HasQuota
+		}
+		if a.Waste != b.Waste {
+			return a.Waste < b.Waste
+		}
+		if len(a.Zones) != len(b.Zones) {
+			return len(a.Zones) > len(b.Zones)
+		}
+		return a.ID() < b.ID()
+	})
+	return recommendations
+}
+
+func fits(machineType *cmv1.MachineType, requirements Requirements) bool {
+	accelerated := machineType.Category() == cmv1.MachineTypeCategoryAcceleratedComputing
+	if accelerated != requirements.GPU {
+		return false
+	}
+	if requirements.Architecture != "" && string(machineType.Architecture()) != requirements.Architecture {
+		return false
+	}
+	// Only the node pools of hosted clusters can run arm64 instances
+	if machineType.Architecture() == cmv1.ProcessorTypeARM64 && !requirements.HostedCP {
+		return false
+	}
+	return machineType.CPU().Value() >= float64(requirements.CPU) && MemoryGiB(machineType) >= requirements.MemoryGiB
+}
+
+func waste(machineType *cmv1.MachineType, requirements Requirements) float64 {
+	result := 0.0
+	if requirements.CPU > 0 {
+		result += (machineType.CPU().Value() - float64(requirements.CPU)) / float64(requirements.CPU)
+	}
+	if requirements.MemoryGiB > 0 {
+		result += (MemoryGiB(machineType) - requirements.MemoryGiB) / requirements.MemoryGiB
+	}
+	return result
+}
+
+// ZoneAvailability inverts lists of instance types available per zone into the zones where each
+// instance type is available.
+func ZoneAvailability(machineTypesPerZone map[string]ocm.MachineTypeList) map[string][]string {
+	result := map[string][]string{}
+	zones := make([]string, 0, len(machineTypesPerZone))
+	for zone := range machineTypesPerZone {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	for _, zone := range zones {
+		for _, item := range machineTypesPerZone[zone].Items {
+			result[item.MachineType.ID()] = append(result[item.MachineType.ID()], zone)
+		}
+	}
+	return result
+}
+
+// Print writes the recommendations as a table.
+func Print(writer io.Writer, recommendations []*Recommendation) error {
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "ID\tCATEGORY\tCPU_CORES\tMEMORY\tARCHITECTURE\tQUOTA\tZONES\n")
+	for _, recommendation := range recommendations {
+		machineType := recommendation.MachineType.MachineType
+		quota := "yes"
+		if !recommendation.HasQuota {
+			quota = "insufficient"
+		}
+		zones := "-"
+		if len(recommendation.Zones) > 0 {
+			zones = strings.Join(recommendation.Zones, ",")
+		}
+		fmt.Fprintf(table, "%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+			machineType.ID(),
+			machineType.Category(),
+			int(machineType.CPU().Value()),
+			strconv.FormatFloat(MemoryGiB(machineType), 'f', -1, 64)+" GiB",
+			machineType.Architecture(),
+			quota,
+			zones,
+		)
+	}
+	return table.Flush()
+}
@@ -0,0 +1,123 @@
+package instancetypes
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+func machineType(id string, category cmv1.MachineTypeCategory, cpu int, memoryGiB int,
+	architecture cmv1.ProcessorType) *ocm.MachineType {
+	item, err := cmv1.NewMachineType().
+		ID(id).
+		Category(category).
+		CPU(cmv1.NewValue().Value(float64(cpu)).Unit("vCPU")).
+		Memory(cmv1.NewValue().Value(float64(memoryGiB) * gibibyte).Unit("B")).
+		Architecture(architecture).
+		Build()
+	Expect(err).ToNot(HaveOccurred())
+	return &ocm.MachineType{MachineType: item, Available: true}
+}
+
+var _ = Describe("Recommend", func() {
+	var machineTypes ocm.MachineTypeList
+
+	BeforeEach(func() {
+		machineTypes = ocm.MachineTypeList{Items: []*ocm.MachineType{
+			machineType("m5.xlarge", cmv1.MachineTypeCategoryGeneralPurpose, 4, 16, cmv1.ProcessorTypeAMD64),
+			machineType("m5.2xlarge", cmv1.MachineTypeCategoryGeneralPurpose, 8, 32, cmv1.ProcessorTypeAMD64),
+			machineType("r5.2xlarge", cmv1.MachineTypeCategoryMemoryOptimized, 8, 64, cmv1.ProcessorTypeAMD64),
+			machineType("m6g.2xlarge", cmv1.MachineTypeCategoryGeneralPurpose, 8, 32, cmv1.ProcessorTypeARM64),
+			machineType("g4dn.2xlarge", cmv1.MachineTypeCategoryAcceleratedComputing, 8, 32, cmv1.ProcessorTypeAMD64),
+		}}
+	})
+
+	ids := func(recommendations []*Recommendation) []string {
+		var result []string
+		for _, recommendation := range recommendations {
+			result = append(result, recommendation.ID())
+		}
+		return result
+	}
+
+	It("OK: ranks the closest fit first", func() {
+		recommendations := Recommend(machineTypes, Requirements{CPU: 8, MemoryGiB: 32, HostedCP: true}, nil)
+		Expect(ids(recommendations)).To(Equal([]string{"m5.2xlarge", "m6g.2xlarge", "r5.2xlarge"}))
+	})
+
+	It("OK: filters by architecture", func() {
+		recommendations := Recommend(machineTypes, Requirements{CPU: 4, Architecture: "arm64", HostedCP: true}, nil)
+		Expect(ids(recommendations)).To(Equal([]string{"m6g.2xlarge"}))
+	})
+
+	It("OK: skips arm64 instance types for classic clusters", func() {
+		recommendations := Recommend(machineTypes, Requirements{CPU: 8, MemoryGiB: 32}, nil)
+		Expect(ids(recommendations)).To(Equal([]string{"m5.2xlarge", "r5.2xlarge"}))
+	})
+
+	It("OK: prefers instance types offered in more zones", func() {
+		zones := ZoneAvailability(map[string]ocm.MachineTypeList{
+			"us-east-1a": {Items: machineTypes.Items[1:2]},
+			"us-east-1b": {Items: machineTypes.Items[1:4]},
+		})
+		recommendations := Recommend(machineTypes, Requirements{CPU: 8, MemoryGiB: 32, HostedCP: true}, zones)
+		Expect(ids(recommendations)).To(Equal([]string{"m5.2xlarge", "m6g.2xlarge", "r5.2xlarge"}))
+		Expect(recommendations[0].Zones).To(Equal([]string{"us-east-1a", "us-east-1b"}))
+		Expect(recommendations[1].Zones).To(Equal([]string{"us-east-1b"}))
+	})
+
+	It("OK: ranks instance types without quota last", func() {
+		recommendations := Recommend(machineTypes, Requirements{CPU: 8, GPU: true}, nil)
+		Expect(ids(recommendations)).To(Equal([]string{"g4dn.2xlarge"}))
+		Expect(recommendations[0].HasQuota).To(BeFalse())
+	})
+
+	It("OK: skips unavailable instance types", func() {
+		machineTypes.Items[1].Available = false
+		recommendations := Recommend(machineTypes, Requirements{CPU: 8, MemoryGiB: 32}, nil)
+		Expect(ids(recommendations)).To(Equal([]string{"r5.2xlarge"}))
+	})
+
+	It("OK: prints a table", func() {
+		var buffer bytes.Buffer
+		recommendations := Recommend(machineTypes, Requirements{CPU: 8, MemoryGiB: 64}, nil)
+		Expect(Print(&buffer, recommendations)).To(Succeed())
+		Expect(buffer.String()).To(Equal(
+			"ID          CATEGORY          CPU_CORES  MEMORY  ARCHITECTURE  QUOTA  ZONES\n" +
+				"r5.2xlarge  memory_optimized  8          64 GiB  amd64         yes    -\n"))
+	})
+
+	It("KO: rejects an unknown architecture", func() {
+		Expect(Requirements{Architecture: "s390x"}.Validate()).To(
+			MatchError(ContainSubstring("Architecture must be either")))
+	})
+
+	It("KO: rejects arm64 for classic clusters", func() {
+		Expect(Requirements{Architecture: "arm64"}.Validate()).To(
+			MatchError(ContainSubstring("only supported by clusters with Hosted Control Planes")))
+	})
+})
+
+var _ = Describe("ParseMemory", func() {
+	DescribeTable("parses amounts of memory",
+		func(value string, expected float64) {
+			memory, err := ParseMemory(value)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(memory).To(Equal(expected))
+		},
+		Entry("empty", "", 0.0),
+		Entry("plain number", "32", 32.0),
+		Entry("Gi", "32Gi", 32.0),
+		Entry("GiB", "32GiB", 32.0),
+		Entry("Mi", "512Mi", 0.5),
+	)
+
+	It("KO: rejects an invalid amount", func() {
+		_, err := ParseMemory("lots")
+		Expect(err).To(MatchError(ContainSubstring("Expected a valid amount of memory")))
+	})
+})
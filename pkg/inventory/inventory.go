@@ -0,0 +1,328 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inventory gathers the IAM roles and OIDC providers created by ROSA in an AWS account,
+// along with their policies and the clusters using them, into a single report for access reviews.
+package inventory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	common "github.com/openshift-online/ocm-common/pkg/aws/validations"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+// Kinds of IAM resources in the inventory
+const (
+	KindAccountRole  = "account-role"
+	KindOperatorRole = "operator-role"
+	KindOcmRole      = "ocm-role"
+	KindUserRole     = "user-role"
+	KindOidcProvider = "oidc-provider"
+)
+
+// OCMClient is the subset of the OCM client used to find the clusters and the OCM organization
+// and user the roles are linked to.
+type OCMClient interface {
+	GetAllClusters(creator *aws.Creator) ([]*cmv1.Cluster, error)
+	GetCurrentOrganization() (string, string, error)
+	GetOrganizationLinkedOCMRoles(orgID string) ([]string, error)
+	GetCurrentAccount() (*amsv1.Account, error)
+	GetAccountLinkedUserRoles(accountID string) ([]string, error)
+}
+
+// Resource is an IAM resource of the inventory
+type Resource struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	ARN  string `json:"arn"`
+	// Type is the account role type, the operator of an operator role or 'Admin' for admin OCM roles
+	Type    string `json:"type,omitempty"`
+	Version string `json:"version,omitempty"`
+	// ManagedPolicies is set when the permission policies are managed by AWS rather than the customer
+	ManagedPolicies     bool     `json:"managedPolicies"`
+	AttachedPolicies    []string `json:"attachedPolicies,omitempty"`
+	PermissionsBoundary string   `json:"permissionsBoundary,omitempty"`
+	// LinkedTo is the OCM organization or user the role is linked to
+	LinkedTo       string     `json:"linkedTo,omitempty"`
+	Clusters       []string   `json:"clusters,omitempty"`
+	CreatedAt      *time.Time `json:"createdAt,omitempty"`
+	LastUsed       *time.Time `json:"lastUsed,omitempty"`
+	LastUsedRegion string     `json:"lastUsedRegion,omitempty"`
+}
+
+// Collector lists the IAM resources created by ROSA in the AWS account
+type Collector struct {
+	AWSClient aws.Client
+	OCMClient OCMClient
+	Creator   *aws.Creator
+
+	// Names of the clusters using a role ARN or an OIDC issuer
+	clustersByRole   map[string][]string
+	clustersByIssuer map[string][]string
+}
+
+// Collect returns the account roles, operator roles, OCM roles, user roles and OIDC providers
+func (c *Collector) Collect() ([]*Resource, error) {
+	err := c.loadClusters()
+	if err != nil {
+		return nil, err
+	}
+	var resources []*Resource
+	for _, collect := range []func() ([]*Resource, error){
+		c.AccountRoles,
+		c.OperatorRoles,
+		c.OcmRoles,
+		c.UserRoles,
+		c.OidcProviders,
+	} {
+		kindResources, err := collect()
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, kindResources...)
+	}
+	return resources, nil
+}
+
+func (c *Collector) loadClusters() error {
+	c.clustersByRole = map[string][]string{}
+	c.clustersByIssuer = map[string][]string{}
+	clusters, err := c.OCMClient.GetAllClusters(c.Creator)
+	if err != nil {
+		return fmt.Errorf("Failed to list clusters: %v", err)
+	}
+	for _, cluster := range clusters {
+		sts := cluster.AWS().STS()
+		roleARNs := []string{}
+		for _, roleARN := range aws.GetAccountRolesArnsMap(cluster) {
+			roleARNs = append(roleARNs, roleARN)
+		}
+		for _, operatorRole := range sts.OperatorIAMRoles() {
+			roleARNs = append(roleARNs, operatorRole.RoleARN())
+		}
+		for _, roleARN := range roleARNs {
+			if roleARN != "" {
+				c.clustersByRole[roleARN] = append(c.clustersByRole[roleARN], cluster.Name())
+			}
+		}
+		if issuer := issuerHost(sts.OIDCEndpointURL()); issuer != "" {
+			c.clustersByIssuer[issuer] = append(c.clustersByIssuer[issuer], cluster.Name())
+		}
+	}
+	return nil
+}
+
+// AccountRoles returns the classic and hosted control plane account roles
+func (c *Collector) AccountRoles() ([]*Resource, error) {
+	accountRoles, err := c.AWSClient.ListAccountRoles("")
+	if err != nil {
+		// Listing account roles fails when there are none
+		if strings.Contains(err.Error(), "no account roles found") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to list account roles: %v", err)
+	}
+	var resources []*Resource
+	for _, accountRole := range accountRoles {
+		resource, err := c.roleResource(KindAccountRole, accountRole.RoleName)
+		if err != nil {
+			return nil, err
+		}
+		resource.Type = accountRole.RoleType
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// OperatorRoles returns the roles tagged with the operator they were created for
+func (c *Collector) OperatorRoles() ([]*Resource, error) {
+	operatorRoles, err := c.AWSClient.ListOperatorRoles("", "", "")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list operator roles: %v", err)
+	}
+	prefixes := make([]string, 0, len(operatorRoles))
+	for prefix := range operatorRoles {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	var resources []*Resource
+	for _, prefix := range prefixes {
+		for _, operatorRole := range operatorRoles[prefix] {
+			if operatorRole.OperatorNamespace == "" {
+				continue
+			}
+			resource, err := c.roleResource(KindOperatorRole, operatorRole.RoleName)
+			if err != nil {
+				return nil, err
+			}
+			resource.Type = fmt.Sprintf("%s/%s", operatorRole.OperatorNamespace, operatorRole.OperatorName)
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+// OcmRoles returns the OCM roles, linked to the current organization when it uses them
+func (c *Collector) OcmRoles() ([]*Resource, error) {
+	ocmRoles, err := c.AWSClient.ListOCMRoles()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list OCM roles: %v", err)
+	}
+	if len(ocmRoles) == 0 {
+		return nil, nil
+	}
+	orgID, externalOrgID, err := c.OCMClient.GetCurrentOrganization()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get organization account: %v", err)
+	}
+	linkedRoles, err := c.OCMClient.GetOrganizationLinkedOCMRoles(orgID)
+	if err != nil {
+		return nil, err
+	}
+	var resources []*Resource
+	for _, ocmRole := range ocmRoles {
+		resource, err := c.roleResource(KindOcmRole, ocmRole.RoleName)
+		if err != nil {
+			return nil, err
+		}
+		if ocmRole.Admin == "Yes" {
+			resource.Type = "Admin"
+		}
+		if helper.Contains(linkedRoles, ocmRole.RoleARN) {
+			resource.LinkedTo = fmt.Sprintf("organization %s", externalOrgID)
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// UserRoles returns the user roles, linked to the current user when they use them
+func (c *Collector) UserRoles() ([]*Resource, error) {
+	userRoles, err := c.AWSClient.ListUserRoles()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list user roles: %v", err)
+	}
+	if len(userRoles) == 0 {
+		return nil, nil
+	}
+	account, err := c.OCMClient.GetCurrentAccount()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get Redhat User Account: %v", err)
+	}
+	linkedRoles, err := c.OCMClient.GetAccountLinkedUserRoles(account.ID())
+	if err != nil {
+		return nil, err
+	}
+	var resources []*Resource
+	for _, userRole := range userRoles {
+		resource, err := c.roleResource(KindUserRole, userRole.RoleName)
+		if err != nil {
+			return nil, err
+		}
+		if helper.Contains(linkedRoles, userRole.RoleARN) {
+			resource.LinkedTo = fmt.Sprintf("user %s", account.Username())
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// OidcProviders returns the OIDC providers created by ROSA
+func (c *Collector) OidcProviders() ([]*Resource, error) {
+	providers, err := c.AWSClient.ListOidcProviders("", nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list OIDC providers: %v", err)
+	}
+	var resources []*Resource
+	for _, provider := range providers {
+		issuer, err := aws.GetResourceIdFromOidcProviderARN(provider.Arn)
+		if err != nil {
+			return nil, err
+		}
+		output, err := c.AWSClient.GetOpenIDConnectProvider(provider.Arn)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get OIDC provider '%s': %v", provider.Arn, err)
+		}
+		resource := &Resource{
+			Kind:      KindOidcProvider,
+			Name:      issuer,
+			ARN:       provider.Arn,
+			Version:   tagValue(output.Tags, common.OpenShiftVersion),
+			Clusters:  c.clustersByIssuer[issuerHost(issuer)],
+			CreatedAt: output.CreateDate,
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// roleResource describes the role with the details only returned when getting a single role
+func (c *Collector) roleResource(kind string, roleName string) (*Resource, error) {
+	role, err := c.AWSClient.GetRoleByName(roleName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get role '%s': %v", roleName, err)
+	}
+	policies, err := c.AWSClient.ListAttachedRolePolicies(roleName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list policies of role '%s': %v", roleName, err)
+	}
+	sort.Strings(policies)
+	roleARN := awssdk.ToString(role.Arn)
+	resource := &Resource{
+		Kind:             kind,
+		Name:             roleName,
+		ARN:              roleARN,
+		Version:          tagValue(role.Tags, common.OpenShiftVersion),
+		ManagedPolicies:  tagValue(role.Tags, common.ManagedPolicies) == tags.True,
+		AttachedPolicies: policies,
+		Clusters:         c.clustersByRole[roleARN],
+		CreatedAt:        role.CreateDate,
+	}
+	if role.PermissionsBoundary != nil {
+		resource.PermissionsBoundary = awssdk.ToString(role.PermissionsBoundary.PermissionsBoundaryArn)
+	}
+	if role.RoleLastUsed != nil {
+		resource.LastUsed = role.RoleLastUsed.LastUsedDate
+		resource.LastUsedRegion = awssdk.ToString(role.RoleLastUsed.Region)
+	}
+	return resource, nil
+}
+
+func tagValue(resourceTags []iamtypes.Tag, key string) string {
+	for _, tag := range resourceTags {
+		if awssdk.ToString(tag.Key) == key {
+			return awssdk.ToString(tag.Value)
+		}
+	}
+	return ""
+}
+
+// issuerHost returns the issuer URL without its scheme and trailing slash, as OIDC providers
+// are named
+func issuerHost(issuerUrl string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(issuerUrl, "https://"), "/")
+}
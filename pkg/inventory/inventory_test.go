@@ -0,0 +1,187 @@
+package inventory
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+type fakeOCMClient struct {
+	clusters        []*cmv1.Cluster
+	linkedOcmRoles  []string
+	linkedUserRoles []string
+}
+
+func (f *fakeOCMClient) GetAllClusters(_ *aws.Creator) ([]*cmv1.Cluster, error) {
+	return f.clusters, nil
+}
+
+func (f *fakeOCMClient) GetCurrentOrganization() (string, string, error) {
+	return "org-id", "org-external-id", nil
+}
+
+func (f *fakeOCMClient) GetOrganizationLinkedOCMRoles(_ string) ([]string, error) {
+	return f.linkedOcmRoles, nil
+}
+
+func (f *fakeOCMClient) GetCurrentAccount() (*amsv1.Account, error) {
+	return amsv1.NewAccount().ID("account-id").Username("jdoe").Build()
+}
+
+func (f *fakeOCMClient) GetAccountLinkedUserRoles(_ string) ([]string, error) {
+	return f.linkedUserRoles, nil
+}
+
+var _ = Describe("Inventory", func() {
+	const accountID = "123456789012"
+	roleARN := func(name string) string {
+		return fmt.Sprintf("arn:aws:iam::%s:role/%s", accountID, name)
+	}
+	lastUsed := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	var (
+		mockCtrl  *gomock.Controller
+		awsClient *aws.MockClient
+		ocmClient *fakeOCMClient
+		collector *Collector
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		awsClient = aws.NewMockClient(mockCtrl)
+		cluster, err := cmv1.NewCluster().Name("mycluster").AWS(cmv1.NewAWS().STS(cmv1.NewSTS().
+			RoleARN(roleARN("acme-Installer-Role")).
+			OperatorIAMRoles(cmv1.NewOperatorIAMRole().RoleARN(roleARN("acme-openshift-ingress"))).
+			OIDCEndpointURL("https://oidc.example.com/abc"))).Build()
+		Expect(err).NotTo(HaveOccurred())
+		ocmClient = &fakeOCMClient{
+			clusters:       []*cmv1.Cluster{cluster},
+			linkedOcmRoles: []string{roleARN("ManagedOpenShift-OCM-Role-1234")},
+		}
+		collector = &Collector{AWSClient: awsClient, OCMClient: ocmClient, Creator: &aws.Creator{}}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	expectRole := func(name string, roleTags ...iamtypes.Tag) {
+		awsClient.EXPECT().GetRoleByName(name).Return(iamtypes.Role{
+			RoleName: awssdk.String(name),
+			Arn:      awssdk.String(roleARN(name)),
+			Tags:     roleTags,
+			PermissionsBoundary: &iamtypes.AttachedPermissionsBoundary{
+				PermissionsBoundaryArn: awssdk.String("arn:aws:iam::123456789012:policy/boundary"),
+			},
+			RoleLastUsed: &iamtypes.RoleLastUsed{LastUsedDate: &lastUsed, Region: awssdk.String("us-east-1")},
+		}, nil)
+		awsClient.EXPECT().ListAttachedRolePolicies(name).Return([]string{"arn:aws:iam::aws:policy/policy"}, nil)
+	}
+
+	It("collects the IAM resources with the clusters using them", func() {
+		awsClient.EXPECT().ListAccountRoles("").Return([]aws.Role{
+			{RoleName: "acme-Installer-Role", RoleType: "Installer"},
+		}, nil)
+		expectRole("acme-Installer-Role",
+			iamtypes.Tag{Key: awssdk.String("rosa_openshift_version"), Value: awssdk.String("4.15")},
+			iamtypes.Tag{Key: awssdk.String("rosa_managed_policies"), Value: awssdk.String("true")})
+		awsClient.EXPECT().ListOperatorRoles("", "", "").Return(map[string][]aws.OperatorRoleDetail{
+			"acme": {
+				{RoleName: "acme-openshift-ingress", OperatorNamespace: "openshift-ingress-operator",
+					OperatorName: "cloud-credentials"},
+				{RoleName: "acme-openshift-other"},
+			},
+		}, nil)
+		expectRole("acme-openshift-ingress")
+		awsClient.EXPECT().ListOCMRoles().Return([]aws.Role{
+			{RoleName: "ManagedOpenShift-OCM-Role-1234", RoleARN: roleARN("ManagedOpenShift-OCM-Role-1234"),
+				Admin: "Yes"},
+		}, nil)
+		expectRole("ManagedOpenShift-OCM-Role-1234")
+		awsClient.EXPECT().ListUserRoles().Return(nil, nil)
+		providerARN := "arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc"
+		awsClient.EXPECT().ListOidcProviders("", nil).Return([]aws.OidcProviderOutput{{Arn: providerARN}}, nil)
+		awsClient.EXPECT().GetOpenIDConnectProvider(providerARN).Return(&iam.GetOpenIDConnectProviderOutput{}, nil)
+
+		resources, err := collector.Collect()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resources).To(HaveLen(4))
+
+		Expect(resources[0].Kind).To(Equal(KindAccountRole))
+		Expect(resources[0].Type).To(Equal("Installer"))
+		Expect(resources[0].Version).To(Equal("4.15"))
+		Expect(resources[0].ManagedPolicies).To(BeTrue())
+		Expect(resources[0].PermissionsBoundary).To(Equal("arn:aws:iam::123456789012:policy/boundary"))
+		Expect(resources[0].Clusters).To(Equal([]string{"mycluster"}))
+		Expect(*resources[0].LastUsed).To(Equal(lastUsed))
+
+		Expect(resources[1].Kind).To(Equal(KindOperatorRole))
+		Expect(resources[1].Type).To(Equal("openshift-ingress-operator/cloud-credentials"))
+		Expect(resources[1].Clusters).To(Equal([]string{"mycluster"}))
+
+		Expect(resources[2].Kind).To(Equal(KindOcmRole))
+		Expect(resources[2].Type).To(Equal("Admin"))
+		Expect(resources[2].LinkedTo).To(Equal("organization org-external-id"))
+		Expect(resources[2].Clusters).To(BeEmpty())
+
+		Expect(resources[3].Kind).To(Equal(KindOidcProvider))
+		Expect(resources[3].Name).To(Equal("oidc.example.com/abc"))
+		Expect(resources[3].Clusters).To(Equal([]string{"mycluster"}))
+	})
+
+	It("reports no account roles when there are none", func() {
+		awsClient.EXPECT().ListAccountRoles("").Return(nil, fmt.Errorf("no account roles found"))
+		resources, err := collector.AccountRoles()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resources).To(BeEmpty())
+	})
+
+	Context("Write", func() {
+		resources := []*Resource{{
+			Kind:             KindAccountRole,
+			Name:             "acme-Installer-Role",
+			ARN:              roleARN("acme-Installer-Role"),
+			AttachedPolicies: []string{"policy-a", "policy-b"},
+			Clusters:         []string{"<mycluster>"},
+			LastUsed:         &lastUsed,
+		}}
+		generatedAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+		It("writes a row per resource in CSV", func() {
+			var output bytes.Buffer
+			Expect(Write(&output, FormatCSV, resources, generatedAt)).To(Succeed())
+			records, err := csv.NewReader(&output).ReadAll()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(2))
+			Expect(records[0]).To(Equal(columns))
+			Expect(records[1][6]).To(Equal("policy-a policy-b"))
+			Expect(records[1][11]).To(Equal("2024-05-01T08:00:00Z"))
+		})
+		It("escapes the values in HTML", func() {
+			var output bytes.Buffer
+			Expect(Write(&output, FormatHTML, resources, generatedAt)).To(Succeed())
+			Expect(output.String()).To(ContainSubstring("<td>&lt;mycluster&gt;</td>"))
+			Expect(output.String()).To(ContainSubstring("Generated at 2024-06-01T00:00:00Z"))
+		})
+		It("writes an empty list in JSON when there are no resources", func() {
+			var output bytes.Buffer
+			Expect(Write(&output, FormatJSON, nil, generatedAt)).To(Succeed())
+			Expect(output.String()).To(Equal("[]\n"))
+		})
+		It("fails for unknown formats", func() {
+			Expect(Write(&bytes.Buffer{}, "xml", resources, generatedAt)).NotTo(Succeed())
+		})
+	})
+})
@@ -0,0 +1,130 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats the inventory can be written in
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+	FormatHTML = "html"
+)
+
+var Formats = []string{FormatJSON, FormatCSV, FormatHTML}
+
+var columns = []string{
+	"Kind", "Name", "ARN", "Type", "Version", "Managed Policies", "Attached Policies",
+	"Permissions Boundary", "Linked To", "Clusters", "Created At", "Last Used", "Last Used Region",
+}
+
+// Write writes the inventory in the format, generated at the given time
+func Write(w io.Writer, format string, resources []*Resource, generatedAt time.Time) error {
+	if resources == nil {
+		resources = []*Resource{}
+	}
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(resources)
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		err := writer.Write(columns)
+		if err != nil {
+			return err
+		}
+		for _, resource := range resources {
+			err = writer.Write(resource.row())
+			if err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	case FormatHTML:
+		rows := make([][]string, 0, len(resources))
+		for _, resource := range resources {
+			rows = append(rows, resource.row())
+		}
+		return htmlTemplate.Execute(w, map[string]interface{}{
+			"GeneratedAt": formatTime(&generatedAt),
+			"Columns":     columns,
+			"Rows":        rows,
+		})
+	}
+	return fmt.Errorf("Invalid format '%s', expected one of: %s", format, strings.Join(Formats, ", "))
+}
+
+func (r *Resource) row() []string {
+	return []string{
+		r.Kind,
+		r.Name,
+		r.ARN,
+		r.Type,
+		r.Version,
+		strconv.FormatBool(r.ManagedPolicies),
+		strings.Join(r.AttachedPolicies, " "),
+		r.PermissionsBoundary,
+		r.LinkedTo,
+		strings.Join(r.Clusters, " "),
+		formatTime(r.CreatedAt),
+		formatTime(r.LastUsed),
+		r.LastUsedRegion,
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+var htmlTemplate = template.Must(template.New("inventory").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>ROSA IAM inventory</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #eee; }
+</style>
+</head>
+<body>
+<h1>ROSA IAM inventory</h1>
+<p>Generated at {{ .GeneratedAt }}</p>
+<table>
+<tr>{{ range .Columns }}<th>{{ . }}</th>{{ end }}</tr>
+{{- range .Rows }}
+<tr>{{ range . }}<td>{{ . }}</td>{{ end }}</tr>
+{{- end }}
+</table>
+</body>
+</html>
+`))
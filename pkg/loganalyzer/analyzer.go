@@ -0,0 +1,168 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loganalyzer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxLineLength is the length the log lines quoted in the analysis are cut to
+const maxLineLength = 300
+
+// Phase is a phase of the installation found in the logs
+type Phase struct {
+	Name    string     `json:"name"`
+	Started *time.Time `json:"started,omitempty"`
+	// Duration is the time until the next phase, or until the last line of the logs
+	Duration string `json:"duration,omitempty"`
+}
+
+// Finding is a known failure found in the logs
+type Finding struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Remediation string `json:"remediation"`
+	// Occurrences is the number of lines matching the failure, Line is the first of them
+	Occurrences int    `json:"occurrences"`
+	Line        string `json:"line"`
+}
+
+// Analysis is the summary of the logs of an installation or uninstallation
+type Analysis struct {
+	Lines    int       `json:"lines"`
+	Phases   []Phase   `json:"phases"`
+	Findings []Finding `json:"findings"`
+}
+
+// timestampPattern matches the timestamp of the lines written by the installer
+var timestampPattern = regexp.MustCompile(`time="([^"]+)"`)
+
+// Analyze finds the phases of the installation and the known failures in the logs
+func Analyze(content string) *Analysis {
+	analysis := &Analysis{
+		Phases:   []Phase{},
+		Findings: []Finding{},
+	}
+	findings := map[string]*Finding{}
+	var lastTime *time.Time
+	nextPhase := 0
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		analysis.Lines++
+		timestamp := lineTime(line)
+		if timestamp != nil {
+			lastTime = timestamp
+		}
+		// Phases are only looked for in order, as the installer repeats some of its messages
+		for i := nextPhase; i < len(phaseMarkers); i++ {
+			if phaseMarkers[i].pattern.MatchString(line) {
+				closePhase(analysis, timestamp)
+				analysis.Phases = append(analysis.Phases, Phase{Name: phaseMarkers[i].name, Started: timestamp})
+				nextPhase = i + 1
+				break
+			}
+		}
+		for _, signature := range Signatures {
+			if !signature.pattern.MatchString(line) {
+				continue
+			}
+			finding := findings[signature.ID]
+			if finding == nil {
+				finding = &Finding{
+					ID:          signature.ID,
+					Title:       signature.Title,
+					Remediation: signature.Remediation,
+					Line:        truncate(strings.TrimSpace(line)),
+				}
+				findings[signature.ID] = finding
+			}
+			finding.Occurrences++
+			// A line is reported for the first signature it matches only
+			break
+		}
+	}
+	if len(analysis.Phases) > 0 && analysis.Phases[len(analysis.Phases)-1].Name != "Complete" {
+		closePhase(analysis, lastTime)
+	}
+	for _, signature := range Signatures {
+		if finding := findings[signature.ID]; finding != nil {
+			analysis.Findings = append(analysis.Findings, *finding)
+		}
+	}
+	return analysis
+}
+
+// closePhase sets the duration of the current phase from the time the next one starts
+func closePhase(analysis *Analysis, end *time.Time) {
+	if len(analysis.Phases) == 0 {
+		return
+	}
+	phase := &analysis.Phases[len(analysis.Phases)-1]
+	if phase.Started != nil && end != nil {
+		phase.Duration = end.Sub(*phase.Started).Round(time.Second).String()
+	}
+}
+
+func lineTime(line string) *time.Time {
+	match := timestampPattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+	timestamp, err := time.Parse(time.RFC3339, match[1])
+	if err != nil {
+		return nil
+	}
+	return &timestamp
+}
+
+func truncate(line string) string {
+	if len(line) <= maxLineLength {
+		return line
+	}
+	return line[:maxLineLength] + "..."
+}
+
+// Describe renders the analysis as text
+func (a *Analysis) Describe() string {
+	var b strings.Builder
+	if len(a.Phases) > 0 {
+		b.WriteString("Phases:\n")
+		for _, phase := range a.Phases {
+			started := ""
+			if phase.Started != nil {
+				started = phase.Started.UTC().Format("15:04:05")
+			}
+			fmt.Fprintf(&b, "  %-24s %-10s %s\n", phase.Name, started, phase.Duration)
+		}
+	}
+	if len(a.Findings) == 0 {
+		fmt.Fprintf(&b, "No known failures found in the last %d lines of the logs\n", a.Lines)
+		return b.String()
+	}
+	b.WriteString("Findings:\n")
+	for _, finding := range a.Findings {
+		fmt.Fprintf(&b, "  - %s (%d lines)\n", finding.Title, finding.Occurrences)
+		fmt.Fprintf(&b, "    %s\n", finding.Line)
+		fmt.Fprintf(&b, "    Hint: %s\n", finding.Remediation)
+	}
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package loganalyzer
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+//nolint:lll
+const failedInstall = `time="2024-05-02T10:00:00Z" level=info msg="Consuming Install Config from target directory"
+time="2024-05-02T10:00:05Z" level=info msg="Creating infrastructure resources..."
+time="2024-05-02T10:04:05Z" level=info msg="Waiting up to 20m0s (until 10:24AM) for the Kubernetes API at https://api.foo.example.com:6443..."
+time="2024-05-02T10:09:05Z" level=info msg="API v1.28.5 up"
+time="2024-05-02T10:09:05Z" level=info msg="Waiting up to 30m0s (until 10:39AM) for bootstrapping to complete..."
+time="2024-05-02T10:39:05Z" level=error msg="Bootstrap failed to complete: timed out waiting for the condition"
+time="2024-05-02T10:39:06Z" level=error msg="Failed to wait for bootstrapping to complete. This error usually happens when there is a problem with control plane hosts that prevents the control plane operators from creating the control plane."
+`
+
+var _ = Describe("Analyze", func() {
+	It("summarizes the phases of the installation", func() {
+		analysis := Analyze(failedInstall)
+		Expect(analysis.Lines).To(Equal(7))
+		names := []string{}
+		durations := []string{}
+		for _, phase := range analysis.Phases {
+			names = append(names, phase.Name)
+			durations = append(durations, phase.Duration)
+		}
+		Expect(names).To(Equal([]string{"Infrastructure", "Kubernetes API", "Bootstrap"}))
+		Expect(durations).To(Equal([]string{"4m0s", "5m0s", "30m1s"}))
+	})
+
+	It("reports each known failure once with its first line", func() {
+		analysis := Analyze(failedInstall)
+		Expect(analysis.Findings).To(HaveLen(1))
+		Expect(analysis.Findings[0].ID).To(Equal("bootstrap-timeout"))
+		Expect(analysis.Findings[0].Occurrences).To(Equal(2))
+		Expect(analysis.Findings[0].Line).To(ContainSubstring("Bootstrap failed to complete"))
+	})
+
+	DescribeTable("detects the known failures",
+		func(line string, id string) {
+			analysis := Analyze(line)
+			Expect(analysis.Findings).To(HaveLen(1))
+			Expect(analysis.Findings[0].ID).To(Equal(id))
+		},
+		Entry("quota", `level=error msg="creating EC2 instance: VcpuLimitExceeded: You have requested more vCPU capacity"`,
+			"quota-exceeded"),
+		Entry("SCP", `level=error msg="AccessDenied: User is not authorized to perform: ec2:RunInstances `+
+			`with an explicit deny in a service control policy"`, "scp-denied"),
+		Entry("permissions", `level=error msg="UnauthorizedOperation: You are not authorized"`, "access-denied"),
+		Entry("DNS", `level=error msg="failed to fetch hosted zone: HostedZoneNotFound"`, "dns"),
+		Entry("subnet", `level=error msg="InvalidSubnetID.NotFound: The subnet ID 'subnet-1' does not exist"`,
+			"subnet"),
+	)
+
+	It("describes the analysis", func() {
+		Expect(Analyze(failedInstall).Describe()).To(Equal(
+			"Phases:\n" +
+				"  Infrastructure           10:00:05   4m0s\n" +
+				"  Kubernetes API           10:04:05   5m0s\n" +
+				"  Bootstrap                10:09:05   30m1s\n" +
+				"Findings:\n" +
+				"  - Bootstrap timed out (2 lines)\n" +
+				"    time=\"2024-05-02T10:39:05Z\" level=error msg=\"Bootstrap failed to complete: " +
+				"timed out waiting for the condition\"\n" +
+				"    Hint: " + Signatures[5].Remediation + "\n"))
+	})
+
+	It("says when no failure is found", func() {
+		Expect(Analyze("foo\nbar\n").Describe()).To(Equal("No known failures found in the last 2 lines of the logs\n"))
+	})
+})
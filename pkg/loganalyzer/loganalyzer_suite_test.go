@@ -0,0 +1,13 @@
+package loganalyzer
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestLogAnalyzer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Log analyzer suite")
+}
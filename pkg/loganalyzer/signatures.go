@@ -0,0 +1,96 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loganalyzer
+
+import "regexp"
+
+// Signature is a known cause of failed installations or uninstallations, detected from the lines of
+// the logs
+type Signature struct {
+	ID          string
+	Title       string
+	Remediation string
+	pattern     *regexp.Regexp
+}
+
+// Signatures are the known failures, in the order they are reported
+var Signatures = []Signature{
+	{
+		ID:    "quota-exceeded",
+		Title: "AWS service quota exceeded",
+		Remediation: "Check the quotas needed by the cluster with 'rosa verify quota', and request the missing " +
+			"ones with 'rosa verify quota --request-increase'.",
+		pattern: regexp.MustCompile(`(?i)(VcpuLimitExceeded|InstanceLimitExceeded|AddressLimitExceeded|` +
+			`VpcLimitExceeded|NatGatewayLimitExceeded|NetworkInterfaceLimitExceeded|TooManyLoadBalancers|` +
+			`ServiceQuotaExceeded|exceeded your .*quota|quota .*exceeded)`),
+	},
+	{
+		ID:    "scp-denied",
+		Title: "AWS request denied by a service control policy",
+		Remediation: "An AWS Organizations service control policy denies an action the installer needs. Ask " +
+			"the administrator of the organization to allow the actions of the ROSA account roles.",
+		pattern: regexp.MustCompile(`(?i)(explicit deny in a service control policy|with an explicit deny)`),
+	},
+	{
+		ID:    "access-denied",
+		Title: "AWS request not authorized",
+		Remediation: "Check the policies of the account and operator roles with 'rosa verify permissions', " +
+			"and update them with 'rosa upgrade roles'.",
+		pattern: regexp.MustCompile(`(?i)(UnauthorizedOperation|AccessDenied|is not authorized to perform)`),
+	},
+	{
+		ID:    "dns",
+		Title: "DNS or Route 53 failure",
+		Remediation: "Check that the base domain resolves and that its Route 53 hosted zone exists and isn't " +
+			"shared with another cluster. For private hosted zones, check the zone is associated with the VPC.",
+		pattern: regexp.MustCompile(`(?i)(HostedZoneNotFound|ConflictingDomainExists|InvalidChangeBatch|` +
+			`no such host|route ?53[^"]*(error|denied|failed|conflict))`),
+	},
+	{
+		ID:    "subnet",
+		Title: "Subnet misconfiguration",
+		Remediation: "Check the subnets with 'rosa verify network': they must exist in the region, have free " +
+			"addresses, and cover the availability zones of the cluster.",
+		pattern: regexp.MustCompile(`(?i)(InvalidSubnet|InsufficientFreeAddressesInSubnet|` +
+			`subnet[^"]*(not found|does not exist|invalid|no available))`),
+	},
+	{
+		ID:    "bootstrap-timeout",
+		Title: "Bootstrap timed out",
+		Remediation: "The bootstrap node couldn't bring up the control plane, usually because it can't reach the " +
+			"registries. Check the egress of the subnets with 'rosa verify network' and compare the firewall " +
+			"rules with 'rosa verify network --allowlist'.",
+		pattern: regexp.MustCompile(`(?i)(Bootstrap failed to complete|failed to wait for bootstrapping|` +
+			`bootstrap[^"]*(timed out|deadline exceeded))`),
+	},
+}
+
+// phaseMarker is a message of the installer starting a phase of the installation
+type phaseMarker struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// phaseMarkers are the phases of the installation, in order
+var phaseMarkers = []phaseMarker{
+	{"Infrastructure", regexp.MustCompile(`Creating infrastructure resources`)},
+	{"Kubernetes API", regexp.MustCompile(`Waiting up to \S+ .*for the Kubernetes API`)},
+	{"Bootstrap", regexp.MustCompile(`Waiting up to \S+ .*for bootstrapping to complete`)},
+	{"Bootstrap removal", regexp.MustCompile(`Destroying the bootstrap resources`)},
+	{"Cluster initialization", regexp.MustCompile(`Waiting up to \S+ .*for the cluster .*to initialize`)},
+	{"Complete", regexp.MustCompile(`Install complete`)},
+}
@@ -0,0 +1,117 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gitlab.com/c0b/go-ordered-json"
+)
+
+// SensitiveFields are the fields whose values are removed from the messages sent to the log by the
+// round tripper, and from the documents gathered for support cases.
+var SensitiveFields = []string{
+	"access_token",
+	"refresh_token",
+	"id_token",
+	"token",
+	"password",
+	"client_secret",
+	"secret_access_key",
+	"pull_secret",
+	"kubeconfig",
+	"authorization",
+}
+
+// sensitiveTextPattern matches the sensitive fields and bearer tokens of plain text, for example
+// 'password: foo' or 'Authorization: Bearer foo'
+var sensitiveTextPattern = regexp.MustCompile(`(?i)((?:"?\b(?:` + strings.Join(SensitiveFields, "|") +
+	`)\b"?\s*[:=]\s*"?(?:bearer\s+)?)|(?:bearer\s+))([^"\s,]+)`)
+
+// RedactJSON replaces the values of the sensitive fields of the JSON document, at any depth, and
+// returns it indented.
+func RedactJSON(data []byte) ([]byte, error) {
+	fields := sensitiveFieldSet()
+	var document interface{}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		var items []json.RawMessage
+		err := json.Unmarshal(data, &items)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse JSON document: %v", err)
+		}
+		objects := []*ordered.OrderedMap{}
+		for _, item := range items {
+			object := ordered.NewOrderedMap()
+			err = json.Unmarshal(item, object)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to parse JSON document: %v", err)
+			}
+			redactValue(object, fields)
+			objects = append(objects, object)
+		}
+		document = objects
+	} else {
+		object := ordered.NewOrderedMap()
+		err := json.Unmarshal(data, object)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse JSON document: %v", err)
+		}
+		redactValue(object, fields)
+		document = object
+	}
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// RedactText replaces the values of the sensitive fields and the bearer tokens of the text
+func RedactText(text string) string {
+	return sensitiveTextPattern.ReplaceAllString(text, "${1}"+redactedReplacement)
+}
+
+func sensitiveFieldSet() map[string]bool {
+	fields := make(map[string]bool)
+	for _, field := range SensitiveFields {
+		fields[field] = true
+	}
+	return fields
+}
+
+// redactValue replaces the values of the sensitive fields of the objects within the value
+func redactValue(value interface{}, fields map[string]bool) {
+	switch typed := value.(type) {
+	case *ordered.OrderedMap:
+		iterator := typed.EntriesIter()
+		for {
+			pair, ok := iterator()
+			if !ok {
+				break
+			}
+			if fields[pair.Key] || fields[strings.ToLower(pair.Key)] {
+				typed.Set(pair.Key, redactedReplacement)
+			} else {
+				redactValue(pair.Value, fields)
+			}
+		}
+	case []interface{}:
+		for _, item := range typed {
+			redactValue(item, fields)
+		}
+	}
+}
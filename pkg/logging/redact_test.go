@@ -0,0 +1,39 @@
+package logging
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redaction", func() {
+	It("redacts the sensitive fields of nested objects and lists", func() {
+		redacted, err := RedactJSON([]byte(`{"id":"foo","token":"abc",` +
+			`"idps":[{"name":"htpasswd","password":"secret"}],"aws":{"Client_Secret":"xyz"}}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(redacted)).To(MatchJSON(`{"id":"foo","token":"***",` +
+			`"idps":[{"name":"htpasswd","password":"***"}],"aws":{"Client_Secret":"***"}}`))
+	})
+
+	It("redacts the objects of a list", func() {
+		redacted, err := RedactJSON([]byte(`[{"access_token":"abc"},{"name":"bar"}]`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(redacted)).To(MatchJSON(`[{"access_token":"***"},{"name":"bar"}]`))
+	})
+
+	It("fails for invalid documents", func() {
+		_, err := RedactJSON([]byte(`{"token"`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("redacts sensitive fields and bearer tokens of text", func() {
+		Expect(RedactText("level=info password=hunter2 user=admin\n" +
+			"Authorization: Bearer eyJhbGci.payload\n" +
+			`{"pull_secret": "abc"}`)).To(Equal("level=info password=*** user=admin\n" +
+			"Authorization: Bearer ***\n" +
+			`{"pull_secret": "***"}`))
+	})
+
+	It("redacts the sensitive fields of the round tripper by default", func() {
+		Expect(NewRoundTripper().redact).To(HaveKey("refresh_token"))
+	})
+})
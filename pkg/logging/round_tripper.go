@@ -56,9 +56,12 @@ type RoundTripper struct {
 var _ http.RoundTripper = &RoundTripper{}
 
 // NewRoundTripper creates a builder that can then be used to create a round tripper that sends to
-// the log the details of the requests sent and the responses received.
+// the log the details of the requests sent and the responses received. The sensitive fields are
+// redacted by default.
 func NewRoundTripper() *RoundTripperBuilder {
-	return &RoundTripperBuilder{}
+	return &RoundTripperBuilder{
+		redact: sensitiveFieldSet(),
+	}
 }
 
 // Logger sets the logger that the round tripper will use to send the details of request and
@@ -325,16 +328,7 @@ func (d *RoundTripper) dumpBytes(what string, data []byte) {
 
 // redactSensitive replaces sensitive fields within a response with redactionStr.
 func (d *RoundTripper) redactSensitive(body *ordered.OrderedMap) {
-	iterator := body.EntriesIter()
-	for {
-		pair, ok := iterator()
-		if !ok {
-			break
-		}
-		if d.redact[pair.Key] {
-			body.Set(pair.Key, redactedReplacement)
-		}
-	}
+	redactValue(body, d.redact)
 }
 
 // String that replaces redactedReplacement fields in messages sent to the log:
@@ -26,6 +26,10 @@ func NewMachinepoolArgsFunction(flagSource bool) func(cmd *cobra.Command, argv [
 				return err
 			}
 		} else {
+			// Bulk operations select machine pools by label and validate their own arguments
+			if isBulkCommand(cmd) {
+				return nil
+			}
 			if len(argv) != 1 {
 				return ErrMissingMachinePoolIdentifier
 			}
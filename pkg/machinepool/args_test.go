@@ -71,6 +71,28 @@ var _ = Describe("NewMachinepoolArgsFunction test", func() {
 
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("Skips the identifier for bulk commands", func() {
+			AddBulkFlags(cmd.Flags(), &BulkOptions{})
+			cmd.Flags().Set(SelectorFlag, "pool-type=gpu")
+
+			validateArgs := NewMachinepoolArgsFunction(false)
+			err := validateArgs(cmd, []string{})
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Requires the identifier when the bulk flags are set to their defaults", func() {
+			AddBulkFlags(cmd.Flags(), &BulkOptions{})
+			cmd.Flags().Set(SelectorFlag, "")
+			cmd.Flags().Set(AllFlag, "false")
+
+			validateArgs := NewMachinepoolArgsFunction(false)
+			err := validateArgs(cmd, []string{})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(ErrMissingMachinePoolIdentifier.Error()))
+		})
 	})
 
 })
@@ -0,0 +1,607 @@
+package machinepool
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	SelectorFlag    = "selector"
+	AllFlag         = "all"
+	ParallelismFlag = "parallelism"
+
+	defaultParallelism = 5
+
+	BulkStatusSucceeded = "succeeded"
+	BulkStatusSkipped   = "skipped"
+	BulkStatusFailed    = "failed"
+)
+
+// BulkOptions holds the flags used to run a machine pool command against every machine pool
+// matching a label selector instead of a single machine pool.
+type BulkOptions struct {
+	Selector    string
+	All         bool
+	Parallelism int
+}
+
+// AddBulkFlags adds the '--selector', '--all' and '--parallelism' flags to the given flag set.
+func AddBulkFlags(flags *pflag.FlagSet, options *BulkOptions) {
+	flags.StringVar(
+		&options.Selector,
+		SelectorFlag,
+		"",
+		"Label selector used to target every matching machine pool instead of a single one. "+
+			"Format should be a comma-separated list of 'key=value', 'key!=value', 'key' or '!key'.",
+	)
+	flags.BoolVar(
+		&options.All,
+		AllFlag,
+		false,
+		"Target every machine pool of the cluster instead of a single one.",
+	)
+	flags.IntVar(
+		&options.Parallelism,
+		ParallelismFlag,
+		defaultParallelism,
+		"Maximum number of machine pools changed at the same time when using '--selector' or '--all'.",
+	)
+}
+
+// IsBulk returns true when the user asked to target several machine pools at once.
+func (o *BulkOptions) IsBulk() bool {
+	return o.Selector != "" || o.All
+}
+
+// Validate checks that the bulk flags are consistent with each other and with the positional
+// arguments of the command.
+func (o *BulkOptions) Validate(argv []string) error {
+	if !o.IsBulk() {
+		return nil
+	}
+	if o.Selector != "" && o.All {
+		return fmt.Errorf("The '--%s' and '--%s' options are mutually exclusive", SelectorFlag, AllFlag)
+	}
+	if len(argv) > 0 {
+		return fmt.Errorf("A machine pool name can't be used together with '--%s' or '--%s'",
+			SelectorFlag, AllFlag)
+	}
+	if o.Parallelism < 1 {
+		return fmt.Errorf("The '--%s' option must be a number greater than 0", ParallelismFlag)
+	}
+	if o.Selector != "" {
+		if _, err := ParseSelector(o.Selector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isBulkCommand returns true if the command was invoked with a non-empty selector or with '--all'.
+// The values are checked rather than whether the flags were set, as '--selector ""' and '--all=false'
+// target a single machine pool.
+func isBulkCommand(cmd *cobra.Command) bool {
+	if selector, err := cmd.Flags().GetString(SelectorFlag); err == nil && selector != "" {
+		return true
+	}
+	if all, err := cmd.Flags().GetBool(AllFlag); err == nil && all {
+		return true
+	}
+	return false
+}
+
+type selectorRequirement struct {
+	key    string
+	value  string
+	negate bool
+	exists bool
+}
+
+// Selector is a simplified Kubernetes equality-based label selector.
+type Selector []selectorRequirement
+
+// ParseSelector parses a comma-separated list of 'key=value', 'key==value', 'key!=value',
+// 'key' and '!key' requirements. All requirements must match for a machine pool to be selected.
+func ParseSelector(selector string) (Selector, error) {
+	var result Selector
+	for _, raw := range strings.Split(selector, ",") {
+		requirement := strings.TrimSpace(raw)
+		if requirement == "" {
+			return nil, fmt.Errorf("Invalid selector '%s': empty requirement", selector)
+		}
+		var req selectorRequirement
+		switch {
+		case strings.Contains(requirement, "!="):
+			parts := strings.SplitN(requirement, "!=", 2)
+			req = selectorRequirement{key: parts[0], value: parts[1], negate: true}
+		case strings.Contains(requirement, "=="):
+			parts := strings.SplitN(requirement, "==", 2)
+			req = selectorRequirement{key: parts[0], value: parts[1]}
+		case strings.Contains(requirement, "="):
+			parts := strings.SplitN(requirement, "=", 2)
+			req = selectorRequirement{key: parts[0], value: parts[1]}
+		case strings.HasPrefix(requirement, "!"):
+			req = selectorRequirement{key: requirement[1:], exists: true, negate: true}
+		default:
+			req = selectorRequirement{key: requirement, exists: true}
+		}
+		req.key = strings.TrimSpace(req.key)
+		req.value = strings.TrimSpace(req.value)
+		if req.key == "" || strings.ContainsAny(req.key, "=! ") {
+			return nil, fmt.Errorf("Invalid selector '%s': expected a label key in '%s'", selector, requirement)
+		}
+		result = append(result, req)
+	}
+	return result, nil
+}
+
+// Matches returns true if the given labels satisfy every requirement of the selector.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, req := range s {
+		value, ok := labels[req.key]
+		if req.exists {
+			if ok == req.negate {
+				return false
+			}
+			continue
+		}
+		if (ok && value == req.value) == req.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// defaultMachinePoolID is the ID of the machine pool created along with classic clusters
+const defaultMachinePoolID = "worker"
+
+// BulkTarget is a machine pool selected by a bulk operation. Exactly one of MachinePool and
+// NodePool is set depending on the cluster topology.
+type BulkTarget struct {
+	ID          string
+	MachinePool *cmv1.MachinePool
+	NodePool    *cmv1.NodePool
+}
+
+func (t *BulkTarget) labels() map[string]string {
+	if t.NodePool != nil {
+		return t.NodePool.Labels()
+	}
+	return t.MachinePool.Labels()
+}
+
+// BulkResult is the outcome of a bulk operation on a single machine pool.
+type BulkResult struct {
+	ID      string
+	Status  string
+	Message string
+}
+
+// SelectMachinePools returns the machine pools, or node pools for hosted clusters, that match the
+// bulk options, sorted by ID.
+func SelectMachinePools(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string,
+	options *BulkOptions) ([]*BulkTarget, error) {
+	_, selected, err := selectBulkTargets(r, cluster, clusterKey, options)
+	return selected, err
+}
+
+// SelectMachinePoolsToDelete returns the machine pools matching the bulk options like
+// SelectMachinePools, and fails before anything is deleted when the selection would remove the
+// default machine pool or every machine pool of the cluster.
+func SelectMachinePoolsToDelete(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string,
+	options *BulkOptions) ([]*BulkTarget, error) {
+	targets, selected, err := selectBulkTargets(r, cluster, clusterKey, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(selected) > 0 && len(selected) == len(targets) {
+		return nil, fmt.Errorf("The selection matches every machine pool of cluster '%s', "+
+			"at least one machine pool must be kept", clusterKey)
+	}
+	if !cluster.Hypershift().Enabled() {
+		for _, target := range selected {
+			if target.ID == defaultMachinePoolID {
+				return nil, fmt.Errorf("The selection matches the default machine pool '%s' of cluster '%s', "+
+					"it can't be deleted with a selector", defaultMachinePoolID, clusterKey)
+			}
+		}
+	}
+	return selected, nil
+}
+
+// selectBulkTargets returns every machine pool of the cluster and the ones matching the bulk
+// options, sorted by ID.
+func selectBulkTargets(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string,
+	options *BulkOptions) ([]*BulkTarget, []*BulkTarget, error) {
+	selector := Selector{}
+	if options.Selector != "" {
+		var err error
+		selector, err = ParseSelector(options.Selector)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var targets []*BulkTarget
+	if cluster.Hypershift().Enabled() {
+		r.Reporter.Debugf("Loading machine pools for hosted cluster '%s'", clusterKey)
+		nodePools, err := r.OCMClient.GetNodePools(cluster.ID())
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to get machine pools for hosted cluster '%s': %v", clusterKey, err)
+		}
+		for _, nodePool := range nodePools {
+			targets = append(targets, &BulkTarget{ID: nodePool.ID(), NodePool: nodePool})
+		}
+	} else {
+		r.Reporter.Debugf("Loading machine pools for cluster '%s'", clusterKey)
+		machinePools, err := r.OCMClient.GetMachinePools(cluster.ID())
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
+		}
+		for _, machinePool := range machinePools {
+			targets = append(targets, &BulkTarget{ID: machinePool.ID(), MachinePool: machinePool})
+		}
+	}
+
+	var selected []*BulkTarget
+	for _, target := range targets {
+		if selector.Matches(target.labels()) {
+			selected = append(selected, target)
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].ID < selected[j].ID
+	})
+	return targets, selected, nil
+}
+
+// BulkTargetIDs returns the IDs of the given targets.
+func BulkTargetIDs(targets []*BulkTarget) []string {
+	ids := make([]string, 0, len(targets))
+	for _, target := range targets {
+		ids = append(ids, target.ID)
+	}
+	return ids
+}
+
+// RunBulkOperation applies the operation to every target, running at most 'parallelism'
+// operations at the same time. Results are returned in the same order as the targets.
+func RunBulkOperation(targets []*BulkTarget, parallelism int,
+	operation func(target *BulkTarget) BulkResult) []BulkResult {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	results := make([]BulkResult, len(targets))
+	semaphore := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, target *BulkTarget) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			results[i] = operation(target)
+			results[i].ID = target.ID
+		}(i, target)
+	}
+	wg.Wait()
+	return results
+}
+
+// PrintBulkResults prints one row per machine pool and returns an error if any of the
+// operations failed.
+func PrintBulkResults(results []BulkResult) error {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "MACHINE POOL\tRESULT\tDETAILS\n")
+	failed := 0
+	for _, result := range results {
+		if result.Status == BulkStatusFailed {
+			failed++
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", result.ID, result.Status, result.Message)
+	}
+	writer.Flush()
+	if failed > 0 {
+		return fmt.Errorf("The operation failed on %d out of %d machine pools", failed, len(results))
+	}
+	return nil
+}
+
+// BulkScaling describes the replica and autoscaling settings applied by a bulk edit. Only the
+// settings whose flag was set are changed, the others keep the value of each machine pool.
+type BulkScaling struct {
+	ReplicasSet    bool
+	Replicas       int
+	AutoscalingSet bool
+	Autoscaling    bool
+	MinReplicasSet bool
+	MinReplicas    int
+	MaxReplicasSet bool
+	MaxReplicas    int
+}
+
+// BulkScalingFromCommand reads the scaling flags of the 'edit machinepool' command.
+func BulkScalingFromCommand(cmd *cobra.Command) (BulkScaling, error) {
+	var scaling BulkScaling
+	var err error
+	flags := cmd.Flags()
+
+	if scaling.ReplicasSet = flags.Changed("replicas"); scaling.ReplicasSet {
+		if scaling.Replicas, err = flags.GetInt("replicas"); err != nil {
+			return scaling, err
+		}
+	}
+	if scaling.AutoscalingSet = flags.Changed("enable-autoscaling"); scaling.AutoscalingSet {
+		if scaling.Autoscaling, err = flags.GetBool("enable-autoscaling"); err != nil {
+			return scaling, err
+		}
+	}
+	if scaling.MinReplicasSet = flags.Changed("min-replicas"); scaling.MinReplicasSet {
+		if scaling.MinReplicas, err = flags.GetInt("min-replicas"); err != nil {
+			return scaling, err
+		}
+	}
+	if scaling.MaxReplicasSet = flags.Changed("max-replicas"); scaling.MaxReplicasSet {
+		if scaling.MaxReplicas, err = flags.GetInt("max-replicas"); err != nil {
+			return scaling, err
+		}
+	}
+
+	if !scaling.ReplicasSet && !scaling.AutoscalingSet && !scaling.MinReplicasSet && !scaling.MaxReplicasSet {
+		return scaling, fmt.Errorf("At least one of '--replicas', '--enable-autoscaling', '--min-replicas' " +
+			"or '--max-replicas' is required when editing several machine pools")
+	}
+	for _, name := range []string{"labels", "taints", "autorepair", "tuning-configs", "kubelet-configs",
+		"node-drain-grace-period", "max-surge", "max-unavailable"} {
+		if flags.Changed(name) {
+			return scaling, fmt.Errorf("The '--%s' option can't be used when editing several machine pools, "+
+				"only replicas and autoscaling settings can be changed", name)
+		}
+	}
+	if scaling.ReplicasSet && (scaling.MinReplicasSet || scaling.MaxReplicasSet) {
+		return scaling, fmt.Errorf("The '--replicas' option is mutually exclusive with '--min-replicas' " +
+			"and '--max-replicas'")
+	}
+	if scaling.ReplicasSet && scaling.AutoscalingSet && scaling.Autoscaling {
+		return scaling, fmt.Errorf("The '--replicas' option can't be used with '--enable-autoscaling', " +
+			"use '--min-replicas' and '--max-replicas' instead")
+	}
+	if scaling.ReplicasSet && scaling.Replicas < 0 {
+		return scaling, fmt.Errorf("The number of machine pool replicas needs to be a non-negative integer")
+	}
+	return scaling, nil
+}
+
+// desiredScaling computes the scaling of a machine pool after applying the bulk settings.
+func (s BulkScaling) desiredScaling(autoscaling bool, replicas int, minReplicas int,
+	maxReplicas int) (bool, int, int, int, error) {
+	if s.AutoscalingSet {
+		autoscaling = s.Autoscaling
+	} else if s.ReplicasSet {
+		// Replacing the autoscaling bounds by a fixed size must be asked for, like when editing a
+		// single machine pool
+		if autoscaling {
+			return true, 0, 0, 0, fmt.Errorf("Autoscaling is enabled, use '--enable-autoscaling=false' " +
+				"along with '--replicas' to set a fixed number of replicas")
+		}
+	} else if s.MinReplicasSet || s.MaxReplicasSet {
+		autoscaling = true
+	}
+
+	if !autoscaling {
+		if !s.ReplicasSet {
+			return false, 0, 0, 0, fmt.Errorf("'--replicas' is required to disable autoscaling")
+		}
+		return false, s.Replicas, 0, 0, nil
+	}
+
+	if s.MinReplicasSet {
+		minReplicas = s.MinReplicas
+	}
+	if s.MaxReplicasSet {
+		maxReplicas = s.MaxReplicas
+	}
+	if !s.MinReplicasSet && !s.MaxReplicasSet && minReplicas == 0 && maxReplicas == 0 {
+		return true, 0, 0, 0, fmt.Errorf("'--min-replicas' and '--max-replicas' are required to " +
+			"enable autoscaling")
+	}
+	if minReplicas > maxReplicas {
+		return true, 0, 0, 0, fmt.Errorf("min-replicas (%d) must be lower than or equal to max-replicas (%d)",
+			minReplicas, maxReplicas)
+	}
+	return true, 0, minReplicas, maxReplicas, nil
+}
+
+// ValidateBulkScaling checks that the scaling settings can be applied to every target, so that
+// nothing is edited when one of them would be rejected.
+func ValidateBulkScaling(cluster *cmv1.Cluster, targets []*BulkTarget, scaling BulkScaling) error {
+	var failures []string
+	for _, target := range targets {
+		var err error
+		if target.NodePool != nil {
+			_, _, err = nodePoolScalingPatch(target.NodePool, scaling)
+		} else {
+			_, _, err = machinePoolScalingPatch(cluster, target.MachinePool, scaling)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("'%s': %v", target.ID, err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("The scaling settings can't be applied to every machine pool, nothing was changed:\n%s",
+			strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// BulkEditScaling applies the scaling settings to every target.
+func BulkEditScaling(r *rosa.Runtime, cluster *cmv1.Cluster, targets []*BulkTarget, scaling BulkScaling,
+	parallelism int) []BulkResult {
+	return RunBulkOperation(targets, parallelism, func(target *BulkTarget) BulkResult {
+		message, err := ApplyScaling(r, cluster, target, scaling)
+		if err != nil {
+			return BulkResult{Status: BulkStatusFailed, Message: err.Error()}
+		}
+		if message == "" {
+			return BulkResult{Status: BulkStatusSkipped, Message: "already at the requested size"}
+		}
+		return BulkResult{Status: BulkStatusSucceeded, Message: message}
+	})
+}
+
+// ApplyScaling applies the scaling settings to a single machine pool. It returns a description of
+// the new scaling, or an empty string if the machine pool already had the requested size.
+func ApplyScaling(r *rosa.Runtime, cluster *cmv1.Cluster, target *BulkTarget, scaling BulkScaling) (string, error) {
+	var message string
+	var err error
+	if target.NodePool != nil {
+		var patch *cmv1.NodePool
+		patch, message, err = nodePoolScalingPatch(target.NodePool, scaling)
+		if err == nil && patch != nil {
+			_, err = r.OCMClient.UpdateNodePool(cluster.ID(), patch)
+		}
+	} else {
+		var patch *cmv1.MachinePool
+		patch, message, err = machinePoolScalingPatch(cluster, target.MachinePool, scaling)
+		if err == nil && patch != nil {
+			_, err = r.OCMClient.UpdateMachinePool(cluster.ID(), patch)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	return message, nil
+}
+
+// GetBulkTarget loads a single machine pool, or node pool for hosted clusters, by ID.
+func GetBulkTarget(r *rosa.Runtime, cluster *cmv1.Cluster, machinePoolID string) (*BulkTarget, error) {
+	if cluster.Hypershift().Enabled() {
+		nodePool, exists, err := r.OCMClient.GetNodePool(cluster.ID(), machinePoolID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("Machine pool '%s' does not exist for hosted cluster '%s'",
+				machinePoolID, cluster.ID())
+		}
+		return &BulkTarget{ID: machinePoolID, NodePool: nodePool}, nil
+	}
+	machinePool, exists, err := r.OCMClient.GetMachinePool(cluster.ID(), machinePoolID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("Machine pool '%s' does not exist for cluster '%s'", machinePoolID, cluster.ID())
+	}
+	return &BulkTarget{ID: machinePoolID, MachinePool: machinePool}, nil
+}
+
+// nodePoolScalingPatch returns the update of the node pool applying the scaling settings, along with
+// a description of the new scaling. The update is nil when the node pool already has that size.
+func nodePoolScalingPatch(nodePool *cmv1.NodePool, scaling BulkScaling) (*cmv1.NodePool, string, error) {
+	current := nodePool.Autoscaling()
+	autoscaling, replicas, minReplicas, maxReplicas, err := scaling.desiredScaling(
+		current != nil, nodePool.Replicas(), current.MinReplica(), current.MaxReplica())
+	if err != nil {
+		return nil, "", err
+	}
+
+	npBuilder := cmv1.NewNodePool().ID(nodePool.ID())
+	var message string
+	if autoscaling {
+		if minReplicas < 1 {
+			return nil, "", fmt.Errorf("min-replicas must be greater than zero")
+		}
+		if current != nil && current.MinReplica() == minReplicas && current.MaxReplica() == maxReplicas {
+			return nil, "", nil
+		}
+		npBuilder.Autoscaling(cmv1.NewNodePoolAutoscaling().MinReplica(minReplicas).MaxReplica(maxReplicas))
+		message = fmt.Sprintf("autoscaling %d-%d", minReplicas, maxReplicas)
+	} else {
+		if current == nil && nodePool.Replicas() == replicas {
+			return nil, "", nil
+		}
+		// Sending only the replicas replaces the autoscaling bounds, as when editing a single node pool
+		npBuilder.Replicas(replicas)
+		message = fmt.Sprintf("%d replicas", replicas)
+		if current != nil {
+			message += ", autoscaling disabled"
+		}
+	}
+
+	patch, err := npBuilder.Build()
+	if err != nil {
+		return nil, "", err
+	}
+	return patch, message, nil
+}
+
+// machinePoolScalingPatch is the machine pool version of nodePoolScalingPatch
+func machinePoolScalingPatch(cluster *cmv1.Cluster, machinePool *cmv1.MachinePool,
+	scaling BulkScaling) (*cmv1.MachinePool, string, error) {
+	current := machinePool.Autoscaling()
+	autoscaling, replicas, minReplicas, maxReplicas, err := scaling.desiredScaling(
+		current != nil, machinePool.Replicas(), current.MinReplicas(), current.MaxReplicas())
+	if err != nil {
+		return nil, "", err
+	}
+
+	multiAZ := cluster.MultiAZ() && isMultiAZMachinePool(machinePool)
+	mpBuilder := cmv1.NewMachinePool().ID(machinePool.ID())
+	var message string
+	if autoscaling {
+		if multiAZ && (minReplicas%3 != 0 || maxReplicas%3 != 0) {
+			return nil, "", fmt.Errorf("multi-AZ machine pools require replicas to be a multiple of 3")
+		}
+		if current != nil && current.MinReplicas() == minReplicas && current.MaxReplicas() == maxReplicas {
+			return nil, "", nil
+		}
+		mpBuilder.Autoscaling(cmv1.NewMachinePoolAutoscaling().MinReplicas(minReplicas).MaxReplicas(maxReplicas))
+		message = fmt.Sprintf("autoscaling %d-%d", minReplicas, maxReplicas)
+	} else {
+		if multiAZ && replicas%3 != 0 {
+			return nil, "", fmt.Errorf("multi-AZ machine pools require replicas to be a multiple of 3")
+		}
+		if current == nil && machinePool.Replicas() == replicas {
+			return nil, "", nil
+		}
+		// Sending only the replicas replaces the autoscaling bounds, as when editing a single machine pool
+		mpBuilder.Replicas(replicas)
+		message = fmt.Sprintf("%d replicas", replicas)
+		if current != nil {
+			message += ", autoscaling disabled"
+		}
+	}
+
+	patch, err := mpBuilder.Build()
+	if err != nil {
+		return nil, "", err
+	}
+	return patch, message, nil
+}
+
+// BulkDelete deletes every target.
+func BulkDelete(r *rosa.Runtime, cluster *cmv1.Cluster, targets []*BulkTarget, parallelism int) []BulkResult {
+	return RunBulkOperation(targets, parallelism, func(target *BulkTarget) BulkResult {
+		var err error
+		if target.NodePool != nil {
+			err = r.OCMClient.DeleteNodePool(cluster.ID(), target.ID)
+		} else {
+			err = r.OCMClient.DeleteMachinePool(cluster.ID(), target.ID)
+		}
+		if err != nil {
+			return BulkResult{Status: BulkStatusFailed, Message: err.Error()}
+		}
+		return BulkResult{Status: BulkStatusSucceeded, Message: "deleted"}
+	})
+}
@@ -0,0 +1,177 @@
+package machinepool
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Bulk machine pool operations", func() {
+	Context("ParseSelector", func() {
+		labels := map[string]string{"pool-type": "gpu", "team": "ml"}
+
+		DescribeTable("matches labels",
+			func(selector string, expected bool) {
+				parsed, err := ParseSelector(selector)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(parsed.Matches(labels)).To(Equal(expected))
+			},
+			Entry("equality", "pool-type=gpu", true),
+			Entry("double equality", "pool-type==gpu", true),
+			Entry("equality mismatch", "pool-type=cpu", false),
+			Entry("inequality", "pool-type!=cpu", true),
+			Entry("inequality mismatch", "pool-type!=gpu", false),
+			Entry("inequality on missing label", "zone!=a", true),
+			Entry("existence", "team", true),
+			Entry("existence mismatch", "zone", false),
+			Entry("non existence", "!zone", true),
+			Entry("non existence mismatch", "!team", false),
+			Entry("all requirements", "pool-type=gpu, team=ml", true),
+			Entry("one requirement fails", "pool-type=gpu,team=web", false),
+		)
+
+		It("KO: fails on an empty requirement", func() {
+			_, err := ParseSelector("pool-type=gpu,")
+			Expect(err).To(MatchError("Invalid selector 'pool-type=gpu,': empty requirement"))
+		})
+
+		It("KO: fails on a missing key", func() {
+			_, err := ParseSelector("=gpu")
+			Expect(err).To(MatchError(ContainSubstring("expected a label key in '=gpu'")))
+		})
+	})
+
+	Context("BulkOptions", func() {
+		It("KO: rejects both --selector and --all", func() {
+			options := &BulkOptions{Selector: "a=b", All: true, Parallelism: 1}
+			Expect(options.Validate(nil)).To(MatchError(ContainSubstring("mutually exclusive")))
+		})
+		It("KO: rejects a machine pool name", func() {
+			options := &BulkOptions{All: true, Parallelism: 1}
+			Expect(options.Validate([]string{"mp1"})).To(MatchError(ContainSubstring("can't be used together")))
+		})
+		It("KO: rejects a parallelism lower than 1", func() {
+			options := &BulkOptions{All: true}
+			Expect(options.Validate(nil)).To(MatchError(ContainSubstring("greater than 0")))
+		})
+		It("OK: ignores non bulk invocations", func() {
+			options := &BulkOptions{}
+			Expect(options.IsBulk()).To(BeFalse())
+			Expect(options.Validate([]string{"mp1"})).To(Succeed())
+		})
+	})
+
+	Context("RunBulkOperation", func() {
+		It("OK: keeps the order of the targets and limits parallelism", func() {
+			var targets []*BulkTarget
+			for i := 0; i < 10; i++ {
+				targets = append(targets, &BulkTarget{ID: fmt.Sprintf("mp%d", i)})
+			}
+			var lock sync.Mutex
+			running, maxRunning := 0, 0
+			results := RunBulkOperation(targets, 3, func(target *BulkTarget) BulkResult {
+				lock.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				lock.Unlock()
+				time.Sleep(5 * time.Millisecond)
+				lock.Lock()
+				running--
+				lock.Unlock()
+				return BulkResult{Status: BulkStatusSucceeded, Message: target.ID}
+			})
+			Expect(maxRunning).To(BeNumerically("<=", 3))
+			Expect(results).To(HaveLen(10))
+			for i, result := range results {
+				Expect(result.ID).To(Equal(fmt.Sprintf("mp%d", i)))
+				Expect(result.Message).To(Equal(result.ID))
+			}
+		})
+
+		It("KO: reports failures", func() {
+			err := PrintBulkResults([]BulkResult{
+				{ID: "mp1", Status: BulkStatusSucceeded},
+				{ID: "mp2", Status: BulkStatusFailed, Message: "boom"},
+			})
+			Expect(err).To(MatchError("The operation failed on 1 out of 2 machine pools"))
+		})
+	})
+
+	Context("BulkScaling", func() {
+		It("OK: sets replicas and disables autoscaling", func() {
+			scaling := BulkScaling{AutoscalingSet: true, Autoscaling: false, ReplicasSet: true, Replicas: 0}
+			autoscaling, replicas, _, _, err := scaling.desiredScaling(true, 0, 1, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(autoscaling).To(BeFalse())
+			Expect(replicas).To(Equal(0))
+		})
+		It("OK: keeps the current bounds that were not set", func() {
+			scaling := BulkScaling{MaxReplicasSet: true, MaxReplicas: 6}
+			autoscaling, _, minReplicas, maxReplicas, err := scaling.desiredScaling(true, 0, 2, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(autoscaling).To(BeTrue())
+			Expect(minReplicas).To(Equal(2))
+			Expect(maxReplicas).To(Equal(6))
+		})
+		It("KO: requires autoscaling to be disabled to set replicas", func() {
+			scaling := BulkScaling{ReplicasSet: true, Replicas: 2}
+			_, _, _, _, err := scaling.desiredScaling(true, 0, 2, 3)
+			Expect(err).To(MatchError(ContainSubstring("use '--enable-autoscaling=false' along with '--replicas'")))
+		})
+		It("KO: requires replicas to disable autoscaling", func() {
+			scaling := BulkScaling{AutoscalingSet: true, Autoscaling: false}
+			_, _, _, _, err := scaling.desiredScaling(true, 0, 2, 3)
+			Expect(err).To(MatchError("'--replicas' is required to disable autoscaling"))
+		})
+		It("KO: rejects min greater than max", func() {
+			scaling := BulkScaling{MinReplicasSet: true, MinReplicas: 5}
+			_, _, _, _, err := scaling.desiredScaling(true, 0, 2, 3)
+			Expect(err).To(MatchError(ContainSubstring("must be lower than or equal to max-replicas")))
+		})
+		It("KO: rejects multi-AZ replicas that are not a multiple of 3", func() {
+			cluster, err := cmv1.NewCluster().MultiAZ(true).Build()
+			Expect(err).ToNot(HaveOccurred())
+			machinePool, err := cmv1.NewMachinePool().ID("mp1").Replicas(3).
+				AvailabilityZones("us-east-1a", "us-east-1b", "us-east-1c").Build()
+			Expect(err).ToNot(HaveOccurred())
+			_, _, err = machinePoolScalingPatch(cluster, machinePool, BulkScaling{ReplicasSet: true, Replicas: 4})
+			Expect(err).To(MatchError(ContainSubstring("multiple of 3")))
+		})
+		It("KO: validates every target before anything is changed", func() {
+			cluster, err := cmv1.NewCluster().Build()
+			Expect(err).ToNot(HaveOccurred())
+			fixed, err := cmv1.NewMachinePool().ID("mp1").Replicas(2).Build()
+			Expect(err).ToNot(HaveOccurred())
+			autoscaled, err := cmv1.NewMachinePool().ID("mp2").
+				Autoscaling(cmv1.NewMachinePoolAutoscaling().MinReplicas(1).MaxReplicas(3)).Build()
+			Expect(err).ToNot(HaveOccurred())
+			targets := []*BulkTarget{{ID: "mp1", MachinePool: fixed}, {ID: "mp2", MachinePool: autoscaled}}
+
+			err = ValidateBulkScaling(cluster, targets, BulkScaling{ReplicasSet: true, Replicas: 4})
+			Expect(err).To(MatchError(ContainSubstring("nothing was changed:\n'mp2': Autoscaling is enabled")))
+			err = ValidateBulkScaling(cluster, targets,
+				BulkScaling{AutoscalingSet: true, Autoscaling: false, ReplicasSet: true, Replicas: 4})
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("OK: replaces the autoscaling bounds by a fixed size", func() {
+			cluster, err := cmv1.NewCluster().Build()
+			Expect(err).ToNot(HaveOccurred())
+			machinePool, err := cmv1.NewMachinePool().ID("mp1").
+				Autoscaling(cmv1.NewMachinePoolAutoscaling().MinReplicas(1).MaxReplicas(3)).Build()
+			Expect(err).ToNot(HaveOccurred())
+			patch, message, err := machinePoolScalingPatch(cluster, machinePool,
+				BulkScaling{AutoscalingSet: true, Autoscaling: false, ReplicasSet: true, Replicas: 4})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(message).To(Equal("4 replicas, autoscaling disabled"))
+			Expect(patch.Replicas()).To(Equal(4))
+			_, ok := patch.GetAutoscaling()
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -23,6 +23,7 @@ import (
 	"github.com/openshift/rosa/pkg/helper/machinepools"
 	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
 	"github.com/openshift/rosa/pkg/helper/versions"
+	"github.com/openshift/rosa/pkg/instancetypes"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/interactive/securitygroups"
@@ -136,6 +137,21 @@ func (m *machinePool) CreateMachinePool(r *rosa.Runtime, cmd *cobra.Command, clu
 	mpHelpers.HostedClusterOnlyFlag(r, cmd, "kubelet-configs")
 	mpHelpers.HostedClusterOnlyFlag(r, cmd, "ec2-metadata-http-tokens")
 
+	// Mixed instances strategy
+	var mixedPolicy *MixedInstancesPolicy
+	if IsMixedInstancesCommand(cmd) {
+		if interactive.Enabled() {
+			return fmt.Errorf("Interactive mode is not supported with a mixed instances strategy")
+		}
+		if strings.TrimSpace(args.Name) == "" {
+			return fmt.Errorf("The `name` flag is required with a mixed instances strategy")
+		}
+		mixedPolicy, err = MixedInstancesPolicyFromOptions(cmd, args)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Machine pool name:
 	name := strings.Trim(args.Name, " \t")
 	if name == "" && !interactive.Enabled() {
@@ -233,6 +249,10 @@ func (m *machinePool) CreateMachinePool(r *rosa.Runtime, cmd *cobra.Command, clu
 	if err != nil {
 		return err
 	}
+	if mixedPolicy != nil && autoscaling {
+		return fmt.Errorf("Autoscaling is not supported with a mixed instances strategy, " +
+			"set a fixed number of replicas instead")
+	}
 
 	securityGroupIds := args.SecurityGroupIds
 	if interactive.Enabled() && isVersionCompatibleComputeSgIds &&
@@ -251,6 +271,9 @@ func (m *machinePool) CreateMachinePool(r *rosa.Runtime, cmd *cobra.Command, clu
 	if instanceType == "" && !interactive.Enabled() {
 		return fmt.Errorf("You must supply a valid instance type")
 	}
+	if args.RecommendInstanceType && !interactive.Enabled() {
+		return fmt.Errorf("The '--recommend-instance-type' option is only supported in interactive mode")
+	}
 
 	var spin *spinner.Spinner
 	if r.Reporter.IsTerminal() && !output.HasFlag() {
@@ -283,10 +306,27 @@ func (m *machinePool) CreateMachinePool(r *rosa.Runtime, cmd *cobra.Command, clu
 		spin.Stop()
 	}
 
+	if mixedPolicy != nil {
+		for _, instanceTypeWeight := range mixedPolicy.InstanceTypes {
+			err = instanceTypeList.ValidateMachineType(instanceTypeWeight.InstanceType, cluster.MultiAZ())
+			if err != nil {
+				return fmt.Errorf("Expected a valid instance type: %s", err)
+			}
+		}
+		instanceType = mixedPolicy.InstanceTypes[0].InstanceType
+	}
+
 	if interactive.Enabled() {
 		if instanceType == "" {
 			instanceType = instanceTypeList.Items[0].MachineType.ID()
 		}
+		if args.RecommendInstanceType {
+			instanceType, err = instancetypes.PromptRecommendation(instanceTypeList, cluster.MultiAZ(), false,
+				instanceType)
+			if err != nil {
+				return fmt.Errorf("Expected a valid instance type: %s", err)
+			}
+		}
 		instanceType, err = interactive.GetOption(interactive.Input{
 			Question: "Instance type",
 			Help:     cmd.Flags().Lookup("instance-type").Usage,
@@ -328,7 +368,7 @@ func (m *machinePool) CreateMachinePool(r *rosa.Runtime, cmd *cobra.Command, clu
 			return err
 		}
 	}
-	if isLocalZone && useSpotInstances {
+	if isLocalZone && (useSpotInstances || (mixedPolicy != nil && mixedPolicy.SpotPercentage > 0)) {
 		return fmt.Errorf("Spot instances are not supported for local zones")
 	}
 
@@ -388,11 +428,11 @@ func (m *machinePool) CreateMachinePool(r *rosa.Runtime, cmd *cobra.Command, clu
 	}
 
 	awsMpBuilder := cmv1.NewAWSMachinePool()
+	spotBuilder := cmv1.NewAWSSpotMarketOptions()
+	if maxPrice != nil {
+		spotBuilder = spotBuilder.MaxPrice(*maxPrice)
+	}
 	if useSpotInstances {
-		spotBuilder := cmv1.NewAWSSpotMarketOptions()
-		if maxPrice != nil {
-			spotBuilder = spotBuilder.MaxPrice(*maxPrice)
-		}
 		awsMpBuilder.SpotMarketOptions(spotBuilder)
 	}
 	if len(securityGroupIds) > 0 {
@@ -462,6 +502,11 @@ func (m *machinePool) CreateMachinePool(r *rosa.Runtime, cmd *cobra.Command, clu
 		}
 	}
 
+	if mixedPolicy != nil {
+		return createMixedInstancesGroup(r, clusterKey, cluster, name, mixedPolicy, replicas,
+			cluster.MultiAZ() && multiAZMachinePool, labelMap, mpBuilder, awsMpBuilder, spotBuilder)
+	}
+
 	machinePool, err := mpBuilder.Build()
 	if err != nil {
 		return fmt.Errorf("Failed to create machine pool for cluster '%s': %v", clusterKey, err)
@@ -494,6 +539,10 @@ func (m *machinePool) CreateNodePools(r *rosa.Runtime, cmd *cobra.Command, clust
 	if isMultiAvailabilityZoneSet {
 		return fmt.Errorf("Setting `multi-availability-zone` flag is not supported for HCP clusters.")
 	}
+	err = rejectMixedInstancesFlags(cmd)
+	if err != nil {
+		return err
+	}
 
 	isAvailabilityZoneSet := cmd.Flags().Changed("availability-zone")
 	isSubnetSet := cmd.Flags().Changed("subnet")
@@ -643,6 +692,9 @@ func (m *machinePool) CreateNodePools(r *rosa.Runtime, cmd *cobra.Command, clust
 	if instanceType == "" && !interactive.Enabled() {
 		return fmt.Errorf("You must supply a valid instance type")
 	}
+	if args.RecommendInstanceType && !interactive.Enabled() {
+		return fmt.Errorf("The '--recommend-instance-type' option is only supported in interactive mode")
+	}
 
 	var spin *spinner.Spinner
 	if r.Reporter.IsTerminal() && !output.HasFlag() {
@@ -679,6 +731,13 @@ func (m *machinePool) CreateNodePools(r *rosa.Runtime, cmd *cobra.Command, clust
 		if instanceType == "" {
 			instanceType = instanceTypeList.Items[0].MachineType.ID()
 		}
+		if args.RecommendInstanceType {
+			instanceType, err = instancetypes.PromptRecommendation(instanceTypeList, cluster.MultiAZ(), true,
+				instanceType)
+			if err != nil {
+				return fmt.Errorf("Expected a valid instance type: %s", err)
+			}
+		}
 		instanceType, err = interactive.GetOption(interactive.Input{
 			Question: "Instance type",
 			Help:     cmd.Flags().Lookup("instance-type").Usage,
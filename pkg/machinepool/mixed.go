@@ -0,0 +1,375 @@
+package machinepool
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	mpOpts "github.com/openshift/rosa/pkg/options/machinepool"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	InstanceTypesFlag        = "instance-types"
+	OnDemandBaseCapacityFlag = "on-demand-base-capacity"
+	SpotPercentageFlag       = "spot-percentage"
+	SpotFallbackFlag         = "spot-fallback"
+
+	// Labels added to every machine pool created by a mixed instances strategy, so that the pools
+	// can be targeted together with '--selector'.
+	InstanceGroupLabel = "rosa.openshift.io/instance-group"
+	CapacityTypeLabel  = "rosa.openshift.io/capacity-type"
+
+	CapacityTypeSpot     = "spot"
+	CapacityTypeOnDemand = "on-demand"
+)
+
+// maxMachinePoolIDLength is the longest machine pool ID accepted by OCM
+const maxMachinePoolIDLength = 30
+
+// spotCapacityErrorRE matches the errors returned when spot instances are unavailable or not
+// supported, the only errors a spot machine pool falls back to on-demand capacity for.
+var spotCapacityErrorRE = regexp.MustCompile(`(?i)insufficient\s*(instance\s*)?capacity|` +
+	`unfulfillable\s*capacity|max\s*spot\s*instance\s*count|spot\s*max\s*price|capacity[- ]not[- ]available|` +
+	`spot[^.]*\bnot\s+(be\s+)?(supported|available|allowed)|(not|n't)\s+support\w*\s+spot`)
+
+var mixedInstancesFlags = []string{
+	InstanceTypesFlag,
+	OnDemandBaseCapacityFlag,
+	SpotPercentageFlag,
+	SpotFallbackFlag,
+}
+
+// InstanceTypeWeight is an instance type of a mixed instances strategy. Replicas are spread
+// across instance types proportionally to their weight.
+type InstanceTypeWeight struct {
+	InstanceType string
+	Weight       int
+}
+
+// MixedInstancesPolicy splits the replicas of a machine pool between on-demand and spot capacity
+// over several instance types. OCM machine pools have a single instance type and purchase option,
+// so the policy is implemented by creating one machine pool per instance type and capacity type.
+type MixedInstancesPolicy struct {
+	InstanceTypes        []InstanceTypeWeight
+	OnDemandBaseCapacity int
+	SpotPercentage       int
+	SpotFallback         bool
+}
+
+// MixedPoolPlan is one of the machine pools created for a mixed instances strategy.
+type MixedPoolPlan struct {
+	ID           string
+	InstanceType string
+	Replicas     int
+	Spot         bool
+}
+
+// FallbackID is the ID of the on-demand machine pool created when the spot machine pool is rejected.
+func (p MixedPoolPlan) FallbackID() string {
+	return fmt.Sprintf("%s-fallback", p.ID)
+}
+
+// IsMixedInstancesCommand returns true when any of the mixed instances strategy flags is set.
+func IsMixedInstancesCommand(cmd *cobra.Command) bool {
+	for _, flag := range mixedInstancesFlags {
+		if cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseInstanceTypeWeights parses a comma-separated list of 'type[:weight]' entries. The weight
+// defaults to 1.
+func ParseInstanceTypeWeights(value string) ([]InstanceTypeWeight, error) {
+	var result []InstanceTypeWeight
+	seen := map[string]bool{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		instanceType, weightValue, hasWeight := strings.Cut(entry, ":")
+		instanceType = strings.TrimSpace(instanceType)
+		if instanceType == "" {
+			return nil, fmt.Errorf("Expected a valid instance type in '%s'", entry)
+		}
+		if seen[instanceType] {
+			return nil, fmt.Errorf("Instance type '%s' is listed more than once", instanceType)
+		}
+		seen[instanceType] = true
+		weight := 1
+		if hasWeight {
+			var err error
+			weight, err = strconv.Atoi(strings.TrimSpace(weightValue))
+			if err != nil || weight < 1 {
+				return nil, fmt.Errorf("Expected a positive integer weight for instance type '%s', got '%s'",
+					instanceType, weightValue)
+			}
+		}
+		result = append(result, InstanceTypeWeight{InstanceType: instanceType, Weight: weight})
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("Expected at least one instance type")
+	}
+	return result, nil
+}
+
+// MixedInstancesPolicyFromOptions builds the mixed instances strategy from the create machine
+// pool flags. A single '--instance-type' is used when '--instance-types' isn't set.
+func MixedInstancesPolicyFromOptions(cmd *cobra.Command,
+	args *mpOpts.CreateMachinepoolUserOptions) (*MixedInstancesPolicy, error) {
+	if cmd.Flags().Changed(InstanceTypesFlag) && cmd.Flags().Changed("instance-type") {
+		return nil, fmt.Errorf("Setting both `instance-type` and `%s` flags is not supported", InstanceTypesFlag)
+	}
+	if cmd.Flags().Changed("use-spot-instances") {
+		return nil, fmt.Errorf("Setting the `use-spot-instances` flag is not supported with a mixed instances "+
+			"strategy, use `%s` instead", SpotPercentageFlag)
+	}
+	instanceTypes := args.InstanceType
+	if cmd.Flags().Changed(InstanceTypesFlag) {
+		instanceTypes = args.InstanceTypes
+	}
+	weights, err := ParseInstanceTypeWeights(instanceTypes)
+	if err != nil {
+		return nil, err
+	}
+	policy := &MixedInstancesPolicy{
+		InstanceTypes:        weights,
+		OnDemandBaseCapacity: args.OnDemandBaseCapacity,
+		SpotPercentage:       args.SpotPercentage,
+		SpotFallback:         args.SpotFallback,
+	}
+	return policy, policy.Validate()
+}
+
+// Validate checks the capacity settings of the policy.
+func (p *MixedInstancesPolicy) Validate() error {
+	if p.OnDemandBaseCapacity < 0 {
+		return fmt.Errorf("On-demand base capacity must be a non-negative integer")
+	}
+	if p.SpotPercentage < 0 || p.SpotPercentage > 100 {
+		return fmt.Errorf("Spot percentage must be between 0 and 100")
+	}
+	return nil
+}
+
+// Plan computes the machine pools needed to run the given number of replicas. The on-demand base
+// capacity is filled first, then the remaining replicas are split between on-demand and spot
+// according to the spot percentage. Each part is spread across instance types by weight.
+// Replicas are allocated in units of 'unit' machines, which is 3 for multi-AZ machine pools.
+func (p *MixedInstancesPolicy) Plan(name string, replicas int, unit int) ([]MixedPoolPlan, error) {
+	if unit < 1 {
+		unit = 1
+	}
+	if replicas%unit != 0 {
+		return nil, fmt.Errorf("Replicas must be a multiple of %d, got %d", unit, replicas)
+	}
+	if p.OnDemandBaseCapacity%unit != 0 {
+		return nil, fmt.Errorf("On-demand base capacity must be a multiple of %d, got %d", unit,
+			p.OnDemandBaseCapacity)
+	}
+	if p.OnDemandBaseCapacity > replicas {
+		return nil, fmt.Errorf("On-demand base capacity (%d) can't exceed the number of replicas (%d)",
+			p.OnDemandBaseCapacity, replicas)
+	}
+
+	units := replicas / unit
+	baseUnits := p.OnDemandBaseCapacity / unit
+	spotUnits := (units - baseUnits) * p.SpotPercentage / 100
+	onDemandUnits := units - spotUnits
+
+	var plan []MixedPoolPlan
+	for i, count := range spreadByWeight(onDemandUnits, p.InstanceTypes) {
+		if count > 0 {
+			plan = append(plan, MixedPoolPlan{
+				ID:           fmt.Sprintf("%s-od-%d", name, i+1),
+				InstanceType: p.InstanceTypes[i].InstanceType,
+				Replicas:     count * unit,
+			})
+		}
+	}
+	for i, count := range spreadByWeight(spotUnits, p.InstanceTypes) {
+		if count > 0 {
+			plan = append(plan, MixedPoolPlan{
+				ID:           fmt.Sprintf("%s-spot-%d", name, i+1),
+				InstanceType: p.InstanceTypes[i].InstanceType,
+				Replicas:     count * unit,
+				Spot:         true,
+			})
+		}
+	}
+	if len(plan) == 0 {
+		return nil, fmt.Errorf("A mixed instances strategy requires at least %d replicas", unit)
+	}
+	for _, pool := range plan {
+		ids := []string{pool.ID}
+		if pool.Spot && p.SpotFallback {
+			ids = append(ids, pool.FallbackID())
+		}
+		for _, id := range ids {
+			if len(id) > maxMachinePoolIDLength {
+				return nil, fmt.Errorf("Machine pool ID '%s' is longer than %d characters, use a shorter name",
+					id, maxMachinePoolIDLength)
+			}
+		}
+	}
+	return plan, nil
+}
+
+// spreadByWeight splits count between the instance types proportionally to their weight. The
+// replicas left after rounding down go to the instance types with the largest remainders, and to
+// the instance types listed first on ties.
+func spreadByWeight(count int, weights []InstanceTypeWeight) []int {
+	result := make([]int, len(weights))
+	remainders := make([]int, len(weights))
+	total := 0
+	for _, weight := range weights {
+		total += weight.Weight
+	}
+	assigned := 0
+	for i, weight := range weights {
+		result[i] = count * weight.Weight / total
+		remainders[i] = count * weight.Weight % total
+		assigned += result[i]
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]] > remainders[order[j]]
+	})
+	for i := 0; assigned < count; i++ {
+		result[order[i%len(order)]]++
+		assigned++
+	}
+	return result
+}
+
+func rejectMixedInstancesFlags(cmd *cobra.Command) error {
+	for _, flag := range mixedInstancesFlags {
+		if cmd.Flags().Changed(flag) {
+			return fmt.Errorf("Setting the `%s` flag is only supported for classic clusters", flag)
+		}
+	}
+	return nil
+}
+
+// createMixedInstancesGroup creates and reports the machine pools of a mixed instances strategy.
+func createMixedInstancesGroup(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster, name string,
+	policy *MixedInstancesPolicy, replicas int, multiAZ bool, labels map[string]string,
+	mpBuilder *cmv1.MachinePoolBuilder, awsMpBuilder *cmv1.AWSMachinePoolBuilder,
+	spotBuilder *cmv1.AWSSpotMarketOptionsBuilder) error {
+	unit := 1
+	if multiAZ {
+		unit = len(cluster.Nodes().AvailabilityZones())
+	}
+	plan, err := policy.Plan(name, replicas, unit)
+	if err != nil {
+		return err
+	}
+
+	createdMachinePools, err := createMixedMachinePools(r, clusterKey, cluster, name, policy, plan, labels,
+		mpBuilder, awsMpBuilder, spotBuilder)
+	if err != nil {
+		if len(createdMachinePools) > 0 {
+			var ids []string
+			for _, machinePool := range createdMachinePools {
+				ids = append(ids, machinePool.ID())
+			}
+			r.Reporter.Warnf("Machine pools created before the failure: %s", strings.Join(ids, ", "))
+		}
+		return err
+	}
+
+	if output.HasFlag() {
+		if err = output.Print(createdMachinePools); err != nil {
+			return fmt.Errorf("Unable to print machine pools: %v", err)
+		}
+		return nil
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "MACHINE POOL\tINSTANCE TYPE\tREPLICAS\tCAPACITY TYPE\n")
+	for _, machinePool := range createdMachinePools {
+		fmt.Fprintf(writer, "%s\t%s\t%d\t%s\n", machinePool.ID(), machinePool.InstanceType(),
+			machinePool.Replicas(), machinePool.Labels()[CapacityTypeLabel])
+	}
+	writer.Flush()
+	r.Reporter.Infof("Machine pools of instance group '%s' created successfully on cluster '%s'", name, clusterKey)
+	r.Reporter.Infof("To view all machine pools, run 'rosa list machinepools --cluster %s'", clusterKey)
+	r.Reporter.Infof("To edit them together, run 'rosa edit machinepool --cluster %s --selector %s=%s'",
+		clusterKey, InstanceGroupLabel, name)
+	return nil
+}
+
+// createMixedMachinePools creates the machine pools of a mixed instances strategy from a builder
+// holding the settings shared by every pool. When a spot machine pool is rejected for lack of spot
+// capacity or support and fallback is enabled, an on-demand machine pool with the same instance type
+// and replicas is created instead. Other errors are returned as is.
+func createMixedMachinePools(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster, name string,
+	policy *MixedInstancesPolicy, plan []MixedPoolPlan, labels map[string]string,
+	mpBuilder *cmv1.MachinePoolBuilder, awsMpBuilder *cmv1.AWSMachinePoolBuilder,
+	spotBuilder *cmv1.AWSSpotMarketOptionsBuilder) ([]*cmv1.MachinePool, error) {
+	var created []*cmv1.MachinePool
+	for _, pool := range plan {
+		machinePool, err := createMixedMachinePool(r, cluster, name, pool, labels, mpBuilder, awsMpBuilder,
+			spotBuilder)
+		if err != nil && pool.Spot && policy.SpotFallback && isSpotCapacityError(err) {
+			r.Reporter.Warnf("Failed to create spot machine pool '%s': %v", pool.ID, err)
+			pool.ID = pool.FallbackID()
+			pool.Spot = false
+			r.Reporter.Infof("Creating on-demand machine pool '%s' with %d replicas of '%s' instead",
+				pool.ID, pool.Replicas, pool.InstanceType)
+			machinePool, err = createMixedMachinePool(r, cluster, name, pool, labels, mpBuilder, awsMpBuilder,
+				spotBuilder)
+		}
+		if err != nil {
+			return created, fmt.Errorf("Failed to add machine pool '%s' to cluster '%s': %v", pool.ID, clusterKey, err)
+		}
+		created = append(created, machinePool)
+	}
+	return created, nil
+}
+
+// isSpotCapacityError checks if the machine pool was rejected because spot instances are unavailable
+// or not supported
+func isSpotCapacityError(err error) bool {
+	return spotCapacityErrorRE.MatchString(err.Error())
+}
+
+func createMixedMachinePool(r *rosa.Runtime, cluster *cmv1.Cluster, name string, pool MixedPoolPlan,
+	labels map[string]string, mpBuilder *cmv1.MachinePoolBuilder, awsMpBuilder *cmv1.AWSMachinePoolBuilder,
+	spotBuilder *cmv1.AWSSpotMarketOptionsBuilder) (*cmv1.MachinePool, error) {
+	poolLabels := map[string]string{}
+	for key, value := range labels {
+		poolLabels[key] = value
+	}
+	poolLabels[InstanceGroupLabel] = name
+	poolLabels[CapacityTypeLabel] = CapacityTypeOnDemand
+	awsMpBuilder.SpotMarketOptions(nil)
+	if pool.Spot {
+		poolLabels[CapacityTypeLabel] = CapacityTypeSpot
+		awsMpBuilder.SpotMarketOptions(spotBuilder)
+	}
+	machinePool, err := mpBuilder.
+		ID(pool.ID).
+		InstanceType(pool.InstanceType).
+		Replicas(pool.Replicas).
+		Labels(poolLabels).
+		AWS(awsMpBuilder).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return r.OCMClient.CreateMachinePool(cluster.ID(), machinePool)
+}
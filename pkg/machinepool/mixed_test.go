@@ -0,0 +1,166 @@
+package machinepool
+
+import (
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+// formatOCMError simulates the body of an OCM error response
+func formatOCMError(status int, reason string) string {
+	return fmt.Sprintf(`{"kind": "Error", "id": "%d", "href": "/api/clusters_mgmt/v1/errors/%d", `+
+		`"code": "CLUSTERS-MGMT-%d", "reason": "%s"}`, status, status, status, reason)
+}
+
+var _ = Describe("Mixed instances strategy", func() {
+	Context("ParseInstanceTypeWeights", func() {
+		It("OK: parses types with and without weights", func() {
+			weights, err := ParseInstanceTypeWeights("m5.xlarge:2, m5a.xlarge")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(weights).To(Equal([]InstanceTypeWeight{
+				{InstanceType: "m5.xlarge", Weight: 2},
+				{InstanceType: "m5a.xlarge", Weight: 1},
+			}))
+		})
+
+		DescribeTable("KO: rejects invalid lists",
+			func(value string, message string) {
+				_, err := ParseInstanceTypeWeights(value)
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("empty list", "", "at least one instance type"),
+			Entry("missing type", ":2", "valid instance type"),
+			Entry("zero weight", "m5.xlarge:0", "positive integer weight"),
+			Entry("invalid weight", "m5.xlarge:a", "positive integer weight"),
+			Entry("duplicate type", "m5.xlarge,m5.xlarge:2", "listed more than once"),
+		)
+	})
+
+	Context("Plan", func() {
+		policy := &MixedInstancesPolicy{
+			InstanceTypes: []InstanceTypeWeight{
+				{InstanceType: "m5.xlarge", Weight: 2},
+				{InstanceType: "m5a.xlarge", Weight: 1},
+			},
+			OnDemandBaseCapacity: 2,
+			SpotPercentage:       50,
+		}
+
+		It("OK: splits replicas between on-demand and spot by weight", func() {
+			plan, err := policy.Plan("mp", 8, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan).To(Equal([]MixedPoolPlan{
+				{ID: "mp-od-1", InstanceType: "m5.xlarge", Replicas: 3},
+				{ID: "mp-od-2", InstanceType: "m5a.xlarge", Replicas: 2},
+				{ID: "mp-spot-1", InstanceType: "m5.xlarge", Replicas: 2, Spot: true},
+				{ID: "mp-spot-2", InstanceType: "m5a.xlarge", Replicas: 1, Spot: true},
+			}))
+		})
+
+		It("OK: allocates multi-AZ replicas in multiples of 3", func() {
+			multiAZPolicy := &MixedInstancesPolicy{
+				InstanceTypes:  policy.InstanceTypes,
+				SpotPercentage: 100,
+			}
+			plan, err := multiAZPolicy.Plan("mp", 6, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan).To(Equal([]MixedPoolPlan{
+				{ID: "mp-spot-1", InstanceType: "m5.xlarge", Replicas: 3, Spot: true},
+				{ID: "mp-spot-2", InstanceType: "m5a.xlarge", Replicas: 3, Spot: true},
+			}))
+		})
+
+		It("KO: fails when the base capacity exceeds the replicas", func() {
+			_, err := policy.Plan("mp", 1, 1)
+			Expect(err).To(MatchError(ContainSubstring("can't exceed the number of replicas")))
+		})
+
+		It("KO: fails when multi-AZ replicas are not a multiple of 3", func() {
+			_, err := policy.Plan("mp", 4, 3)
+			Expect(err).To(MatchError(ContainSubstring("must be a multiple of 3")))
+		})
+	})
+
+	It("KO: rejects fallback machine pool IDs that are too long", func() {
+		policy := &MixedInstancesPolicy{
+			InstanceTypes:  []InstanceTypeWeight{{InstanceType: "m5.xlarge", Weight: 1}},
+			SpotPercentage: 100,
+		}
+		_, err := policy.Plan("a-twenty-char-name-x", 2, 1)
+		Expect(err).ToNot(HaveOccurred())
+		policy.SpotFallback = true
+		_, err = policy.Plan("a-twenty-char-name-x", 2, 1)
+		Expect(err).To(MatchError("Machine pool ID 'a-twenty-char-name-x-spot-1-fallback' is longer than 30 " +
+			"characters, use a shorter name"))
+	})
+
+	DescribeTable("falls back to on-demand capacity only for spot errors",
+		func(message string, expected bool) {
+			Expect(isSpotCapacityError(fmt.Errorf("%s", message))).To(Equal(expected))
+		},
+		Entry("insufficient capacity", "InsufficientInstanceCapacity: no capacity in us-east-1a", true),
+		Entry("spot unsupported", "Spot instances are not supported in region 'us-gov-west-1'", true),
+		Entry("price too low", "SpotMaxPriceTooLow: the max price is lower than the spot price", true),
+		Entry("name conflict", "Machine pool with name 'gpu-spot-1' already exists", false),
+		Entry("validation", "Invalid instance type 'm5.huge'", false),
+		Entry("authorization", "Account is not authorized to perform this action", false),
+	)
+
+	Context("createMixedMachinePools", func() {
+		var (
+			t       *test.TestingRuntime
+			cluster *cmv1.Cluster
+			plan    []MixedPoolPlan
+		)
+
+		BeforeEach(func() {
+			t = test.NewTestRuntime()
+			var err error
+			cluster, err = cmv1.NewCluster().ID(test.MockClusterID).Build()
+			Expect(err).ToNot(HaveOccurred())
+			plan = []MixedPoolPlan{{ID: "gpu-spot-1", InstanceType: "m5.xlarge", Replicas: 2, Spot: true}}
+		})
+
+		create := func() ([]*cmv1.MachinePool, error) {
+			return createMixedMachinePools(t.RosaRuntime, test.MockClusterName, cluster, "gpu",
+				&MixedInstancesPolicy{SpotFallback: true}, plan, nil, cmv1.NewMachinePool(),
+				cmv1.NewAWSMachinePool(), cmv1.NewAWSSpotMarketOptions())
+		}
+
+		It("OK: creates an on-demand machine pool when spot capacity is unavailable", func() {
+			fallback, err := cmv1.NewMachinePool().ID("gpu-spot-1-fallback").Build()
+			Expect(err).ToNot(HaveOccurred())
+			t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusBadRequest,
+				formatOCMError(http.StatusBadRequest, "InsufficientInstanceCapacity for instance type m5.xlarge")))
+			t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusCreated, test.FormatResource(fallback)))
+
+			created, err := create()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(HaveLen(1))
+			Expect(created[0].ID()).To(Equal("gpu-spot-1-fallback"))
+			requests := t.ApiServer.ReceivedRequests()
+			Expect(requests).To(HaveLen(2))
+		})
+
+		It("KO: doesn't fall back for other errors", func() {
+			t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusConflict,
+				formatOCMError(http.StatusConflict, "Machine pool with name 'gpu-spot-1' already exists")))
+
+			created, err := create()
+			Expect(err).To(MatchError(ContainSubstring("already exists")))
+			Expect(created).To(BeEmpty())
+			Expect(t.ApiServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	It("KO: rejects an invalid spot percentage", func() {
+		policy := &MixedInstancesPolicy{SpotPercentage: 120}
+		Expect(policy.Validate()).To(MatchError(ContainSubstring("between 0 and 100")))
+	})
+})
@@ -0,0 +1,211 @@
+package network
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+// Cluster topologies with their own egress requirements
+const (
+	TopologyClassic     = "classic"
+	TopologyHostedCP    = "hcp"
+	TopologyPrivateLink = "privatelink"
+)
+
+var Topologies = []string{TopologyClassic, TopologyHostedCP, TopologyPrivateLink}
+
+// ClusterTopology returns the topology of the cluster
+func ClusterTopology(cluster *cmv1.Cluster) string {
+	if cluster.Hypershift().Enabled() {
+		return TopologyHostedCP
+	}
+	if cluster.AWS().PrivateLink() {
+		return TopologyPrivateLink
+	}
+	return TopologyClassic
+}
+
+// Categories of the egress endpoints
+const (
+	CategoryRegistry   = "registry"
+	CategoryTelemetry  = "telemetry"
+	CategoryManagement = "cluster management"
+	CategoryAWSAPI     = "AWS API"
+	CategoryOther      = "other"
+)
+
+// Endpoint is a destination the cluster must be able to reach
+type Endpoint struct {
+	Domain   string `json:"domain"`
+	Port     int    `json:"port"`
+	Category string `json:"category"`
+	Rule     string `json:"rule"`
+}
+
+// egressEndpoint is an entry of the allowlist. The region placeholder of the domain is replaced by the
+// region of the cluster.
+type egressEndpoint struct {
+	domain   string
+	port     int
+	category string
+	// classicOnly endpoints are only needed by clusters whose control plane runs in the account
+	classicOnly bool
+}
+
+const regionPlaceholder = "{region}"
+
+// egressEndpoints mirrors the documented firewall prerequisites of ROSA clusters and must be kept in sync
+// with them:
+// https://docs.openshift.com/rosa/rosa_planning/rosa-sts-aws-prereqs.html
+// https://docs.openshift.com/rosa/rosa_hcp/rosa-hcp-aws-prereqs.html
+var egressEndpoints = []egressEndpoint{
+	{domain: "registry.redhat.io", port: 443, category: CategoryRegistry},
+	{domain: "quay.io", port: 443, category: CategoryRegistry},
+	{domain: "cdn01.quay.io", port: 443, category: CategoryRegistry},
+	{domain: "cdn02.quay.io", port: 443, category: CategoryRegistry},
+	{domain: "cdn03.quay.io", port: 443, category: CategoryRegistry},
+	{domain: "sso.redhat.com", port: 443, category: CategoryRegistry},
+	{domain: "quayio-production-s3.s3.amazonaws.com", port: 443, category: CategoryRegistry},
+	{domain: "registry.access.redhat.com", port: 443, category: CategoryRegistry},
+	{domain: "access.redhat.com", port: 443, category: CategoryRegistry},
+	{domain: "pull.q1w2.quay.rhcloud.com", port: 443, category: CategoryRegistry},
+	{domain: "cert-api.access.redhat.com", port: 443, category: CategoryTelemetry},
+	{domain: "api.access.redhat.com", port: 443, category: CategoryTelemetry},
+	{domain: "infogw.api.openshift.com", port: 443, category: CategoryTelemetry},
+	{domain: "console.redhat.com", port: 443, category: CategoryTelemetry},
+	{domain: "observatorium-mst.api.openshift.com", port: 443, category: CategoryTelemetry},
+	{domain: "observatorium.api.openshift.com", port: 443, category: CategoryTelemetry, classicOnly: true},
+	{domain: "api.openshift.com", port: 443, category: CategoryManagement},
+	{domain: "mirror.openshift.com", port: 443, category: CategoryManagement},
+	{domain: "api.pagerduty.com", port: 443, category: CategoryManagement, classicOnly: true},
+	{domain: "events.pagerduty.com", port: 443, category: CategoryManagement, classicOnly: true},
+	{domain: "api.deadmanssnitch.com", port: 443, category: CategoryManagement, classicOnly: true},
+	{domain: "nosnch.in", port: 443, category: CategoryManagement, classicOnly: true},
+	{domain: "http-inputs-osdsecuritylogs.splunkcloud.com", port: 443, category: CategoryManagement,
+		classicOnly: true},
+	{domain: "sftp.access.redhat.com", port: 22, category: CategoryManagement, classicOnly: true},
+	{domain: "sts.amazonaws.com", port: 443, category: CategoryAWSAPI},
+	{domain: "sts." + regionPlaceholder + ".amazonaws.com", port: 443, category: CategoryAWSAPI},
+	{domain: "ec2." + regionPlaceholder + ".amazonaws.com", port: 443, category: CategoryAWSAPI},
+	{domain: "elasticloadbalancing." + regionPlaceholder + ".amazonaws.com", port: 443, category: CategoryAWSAPI},
+	{domain: "iam.amazonaws.com", port: 443, category: CategoryAWSAPI, classicOnly: true},
+	{domain: "route53.amazonaws.com", port: 443, category: CategoryAWSAPI, classicOnly: true},
+	{domain: "tagging.us-east-1.amazonaws.com", port: 443, category: CategoryAWSAPI, classicOnly: true},
+}
+
+// Allowlist returns the endpoints a cluster of the topology must reach in the region, sorted by
+// category and domain
+func Allowlist(topology string, region string) ([]Endpoint, error) {
+	if !helper.Contains(Topologies, topology) {
+		return nil, fmt.Errorf("Invalid topology '%s', expected one of: %s", topology,
+			strings.Join(Topologies, ", "))
+	}
+	if region == "" {
+		return nil, fmt.Errorf("Region is required to build the allowlist")
+	}
+	endpoints := []Endpoint{}
+	for _, endpoint := range egressEndpoints {
+		if endpoint.classicOnly && topology == TopologyHostedCP {
+			continue
+		}
+		domain := strings.ReplaceAll(endpoint.domain, regionPlaceholder, region)
+		endpoints = append(endpoints, Endpoint{
+			Domain:   domain,
+			Port:     endpoint.port,
+			Category: endpoint.category,
+			Rule:     firewallRule(domain, endpoint.port, endpoint.category, topology),
+		})
+	}
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		if endpoints[i].Category != endpoints[j].Category {
+			return endpoints[i].Category < endpoints[j].Category
+		}
+		return endpoints[i].Domain < endpoints[j].Domain
+	})
+	return endpoints, nil
+}
+
+// egressDetailPattern matches the destination reported by the network verifier, for example
+// 'egressURL error: https://registry.redhat.io:443 (connection timed out)'
+var egressDetailPattern = regexp.MustCompile(`(?:[a-z]+://)?((?:[a-zA-Z0-9-]+\.)+[a-zA-Z]{2,})(?::(\d+))?`)
+
+// EgressFailure is an endpoint the network verifier failed to reach
+type EgressFailure struct {
+	Endpoint
+	Detail string `json:"detail"`
+}
+
+// ParseEgressFailure returns the endpoint of a failure reported by the network verifier, with the
+// category and firewall rule of the matching allowlist entry
+func ParseEgressFailure(detail string, topology string) EgressFailure {
+	failure := EgressFailure{
+		Endpoint: Endpoint{Category: CategoryOther},
+		Detail:   detail,
+	}
+	match := egressDetailPattern.FindStringSubmatch(detail)
+	if match == nil {
+		failure.Rule = "Check the detail reported by the network verifier"
+		return failure
+	}
+	failure.Domain = match[1]
+	failure.Port = 443
+	if match[2] != "" {
+		failure.Port, _ = strconv.Atoi(match[2])
+	}
+	for _, endpoint := range egressEndpoints {
+		pattern := "^" + strings.ReplaceAll(regexp.QuoteMeta(endpoint.domain),
+			regexp.QuoteMeta(regionPlaceholder), "[a-z0-9-]+") + "$"
+		if regexp.MustCompile(pattern).MatchString(failure.Domain) {
+			failure.Category = endpoint.category
+			break
+		}
+	}
+	if failure.Category == CategoryOther && strings.HasSuffix(failure.Domain, ".amazonaws.com") {
+		failure.Category = CategoryAWSAPI
+	}
+	failure.Rule = firewallRule(failure.Domain, failure.Port, failure.Category, topology)
+	return failure
+}
+
+// VerificationResult is the outcome of the network verification of a subnet
+type VerificationResult struct {
+	Subnet   string            `json:"subnet"`
+	State    string            `json:"state,omitempty"`
+	Platform string            `json:"platform,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	Failures []EgressFailure   `json:"failures,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// NewVerificationResult builds the result of the subnet from the status returned by the network
+// verifier, or from the error getting it
+func NewVerificationResult(subnet string, status *cmv1.SubnetNetworkVerification, err error,
+	topology string) *VerificationResult {
+	result := &VerificationResult{Subnet: subnet}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.State = status.State()
+	result.Platform = string(status.Platform())
+	result.Tags = status.Tags()
+	for _, detail := range status.Details() {
+		result.Failures = append(result.Failures, ParseEgressFailure(detail, topology))
+	}
+	return result
+}
+
+func firewallRule(domain string, port int, category string, topology string) string {
+	rule := fmt.Sprintf("Allow TCP egress to '%s' on port %d", domain, port)
+	if category == CategoryAWSAPI && topology == TopologyPrivateLink {
+		rule += ", or reach it through a VPC endpoint"
+	}
+	return rule
+}
@@ -0,0 +1,77 @@
+package network
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Egress", func() {
+	Context("Allowlist", func() {
+		It("fills the region of the AWS endpoints", func() {
+			endpoints, err := Allowlist(TopologyClassic, "eu-west-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(endpoints).To(ContainElement(Endpoint{
+				Domain:   "ec2.eu-west-1.amazonaws.com",
+				Port:     443,
+				Category: CategoryAWSAPI,
+				Rule:     "Allow TCP egress to 'ec2.eu-west-1.amazonaws.com' on port 443",
+			}))
+			Expect(endpoints[0].Category).To(Equal(CategoryAWSAPI))
+		})
+
+		It("leaves out the endpoints of the classic control plane for hosted clusters", func() {
+			classic, err := Allowlist(TopologyClassic, "us-east-1")
+			Expect(err).ToNot(HaveOccurred())
+			hosted, err := Allowlist(TopologyHostedCP, "us-east-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(hosted)).To(BeNumerically("<", len(classic)))
+			for _, endpoint := range hosted {
+				Expect(endpoint.Domain).ToNot(Equal("api.pagerduty.com"))
+			}
+		})
+
+		It("suggests VPC endpoints for the AWS APIs of PrivateLink clusters", func() {
+			endpoints, err := Allowlist(TopologyPrivateLink, "us-east-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(endpoints[0].Rule).To(HaveSuffix(", or reach it through a VPC endpoint"))
+		})
+
+		It("fails for an unknown topology", func() {
+			_, err := Allowlist("foo", "us-east-1")
+			Expect(err).To(MatchError("Invalid topology 'foo', expected one of: classic, hcp, privatelink"))
+		})
+	})
+
+	Context("ParseEgressFailure", func() {
+		It("finds the endpoint and its category", func() {
+			failure := ParseEgressFailure("egressURL error: https://registry.redhat.io:443 (timeout)", TopologyClassic)
+			Expect(failure.Domain).To(Equal("registry.redhat.io"))
+			Expect(failure.Port).To(Equal(443))
+			Expect(failure.Category).To(Equal(CategoryRegistry))
+			Expect(failure.Rule).To(Equal("Allow TCP egress to 'registry.redhat.io' on port 443"))
+		})
+
+		It("matches the regional AWS endpoints", func() {
+			failure := ParseEgressFailure("egressURL error: sts.ap-south-1.amazonaws.com:443", TopologyClassic)
+			Expect(failure.Category).To(Equal(CategoryAWSAPI))
+		})
+
+		It("keeps details without an endpoint", func() {
+			failure := ParseEgressFailure("unexpected error", TopologyClassic)
+			Expect(failure.Domain).To(BeEmpty())
+			Expect(failure.Category).To(Equal(CategoryOther))
+			Expect(failure.Detail).To(Equal("unexpected error"))
+		})
+	})
+
+	It("builds the verification result of a failed subnet", func() {
+		status, err := cmv1.NewSubnetNetworkVerification().ID("subnet-a").State("failed").
+			Platform(cmv1.PlatformAwsClassic).Details("egressURL error: api.openshift.com:443").Build()
+		Expect(err).ToNot(HaveOccurred())
+		result := NewVerificationResult("subnet-a", status, nil, TopologyClassic)
+		Expect(result.State).To(Equal("failed"))
+		Expect(result.Failures).To(HaveLen(1))
+		Expect(result.Failures[0].Category).To(Equal(CategoryManagement))
+	})
+})
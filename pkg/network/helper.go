@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	cfTypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -109,6 +110,41 @@ func SelectTemplate(templateDir, command string) string {
 	return fmt.Sprintf("%s/%s/cloudformation.yaml", templateDir, command)
 }
 
+// TemplateParameter is a parameter of a network template
+type TemplateParameter struct {
+	Name        string `yaml:"-"`
+	Description string `yaml:"Description"`
+	Default     string `yaml:"Default"`
+}
+
+// TemplateParameters returns the description of the template and its parameters, in the order they
+// are declared
+func TemplateParameters(templateBody []byte) (string, []TemplateParameter, error) {
+	var template struct {
+		Description string    `yaml:"Description"`
+		Parameters  yaml.Node `yaml:"Parameters"`
+	}
+	err := yaml.Unmarshal(templateBody, &template)
+	if err != nil {
+		return "", nil, err
+	}
+	if template.Parameters.Kind != yaml.MappingNode {
+		return template.Description, nil, nil
+	}
+	var params []TemplateParameter
+	content := template.Parameters.Content
+	for i := 0; i+1 < len(content); i += 2 {
+		var param TemplateParameter
+		err = content[i+1].Decode(&param)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid parameter '%s': %v", content[i].Value, err)
+		}
+		param.Name = content[i].Value
+		params = append(params, param)
+	}
+	return template.Description, params, nil
+}
+
 func formatParams(params map[string]string) string {
 	var paramStr string
 	keys := make([]string, 0, len(params))
@@ -138,7 +174,7 @@ func formatTags(tags map[string]string) string {
 func deleteHelperMessage(logger *logrus.Logger, params map[string]string, err error) {
 	logger.Errorf("Failed to create CloudFormation stack: %v", err)
 	logger.Infof("To delete all created resource stacks, run "+
-		"`aws cloudformation delete-stack --stack-name %s --region %s`",
+		"`rosa delete network %s --region %s`",
 		params["Name"], params["Region"])
 }
 
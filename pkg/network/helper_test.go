@@ -131,6 +131,40 @@ var _ = Describe("Helper Functions", func() {
 		})
 	})
 
+	Describe("TemplateParameters", func() {
+		It("should return the parameters in the order they are declared", func() {
+			templateBody := []byte(`
+Description: Test template
+Parameters:
+  Name:
+    Type: String
+    Description: "Name prefix for resources"
+  AvailabilityZoneCount:
+    Type: Number
+    Description: "Number of Availability Zones to use"
+    Default: 1
+Resources:
+  VPC:
+    Type: AWS::EC2::VPC
+    Properties:
+      CidrBlock: !Ref VpcCidr
+`)
+			description, params, err := TemplateParameters(templateBody)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(description).To(Equal("Test template"))
+			Expect(params).To(Equal([]TemplateParameter{
+				{Name: "Name", Description: "Name prefix for resources"},
+				{Name: "AvailabilityZoneCount", Description: "Number of Availability Zones to use", Default: "1"},
+			}))
+		})
+
+		It("should return no parameters when the template has none", func() {
+			_, params, err := TemplateParameters([]byte("Description: Test template\n"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(params).To(BeEmpty())
+		})
+	})
+
 	var _ = Describe("getStatusColor", func() {
 		It("should return green for create complete and update complete statuses", func() {
 			Expect(getStatusColor(cfTypes.ResourceStatusCreateComplete)).To(Equal(ColorGreen))
@@ -125,5 +125,7 @@ func (s *network) CreateStack(templateFile string, params map[string]string, tag
 	}
 
 	logger.Infof("Stack %s created", params["Name"])
+	logger.Infof("To install a cluster in the stack, run "+
+		"`rosa create cluster --network-stack %s --region %s`", params["Name"], params["Region"])
 	return nil
 }
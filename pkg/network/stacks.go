@@ -0,0 +1,249 @@
+package network
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	cfTypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Outputs of the network templates describing the resources of the stack
+const (
+	OutputVpcID          = "VPCId"
+	OutputPublicSubnets  = "PublicSubnets"
+	OutputPrivateSubnets = "PrivateSubnets"
+	OutputNatGateways    = "NatGatewayId"
+)
+
+// ParameterVpcCidr is the parameter of the network templates holding the CIDR block of the VPC
+const ParameterVpcCidr = "VpcCidr"
+
+// Subnet is a subnet created by a network stack
+type Subnet struct {
+	ID               string `json:"id"`
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+}
+
+// Stack is a CloudFormation stack created by 'rosa create network'
+type Stack struct {
+	Name           string     `json:"name"`
+	Status         string     `json:"status"`
+	StatusReason   string     `json:"statusReason,omitempty"`
+	Template       string     `json:"template"`
+	CreatedAt      *time.Time `json:"createdAt,omitempty"`
+	VpcID          string     `json:"vpcId,omitempty"`
+	VpcCidr        string     `json:"vpcCidr,omitempty"`
+	PublicSubnets  []Subnet   `json:"publicSubnets,omitempty"`
+	PrivateSubnets []Subnet   `json:"privateSubnets,omitempty"`
+	NatGateways    []string   `json:"natGateways,omitempty"`
+}
+
+// ListStacks returns the network stacks of the region, sorted by name
+func ListStacks(awsClient aws.Client) ([]*Stack, error) {
+	cfStacks, err := awsClient.ListStacksWithTag(tags.NetworkTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list network stacks: %v", err)
+	}
+	stacks := make([]*Stack, 0, len(cfStacks))
+	for i := range cfStacks {
+		stacks = append(stacks, newStack(&cfStacks[i]))
+	}
+	sort.Slice(stacks, func(i, j int) bool {
+		return stacks[i].Name < stacks[j].Name
+	})
+	return stacks, nil
+}
+
+// GetStack returns the network stack with the availability zones of its subnets. It fails for
+// stacks that weren't created by 'rosa create network'.
+func GetStack(awsClient aws.Client, name string) (*Stack, error) {
+	cfStack, err := awsClient.DescribeStack(name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to describe stack '%s': %v", name, err)
+	}
+	if cfStack == nil {
+		return nil, fmt.Errorf("Network stack '%s' not found in region '%s'", name, awsClient.GetRegion())
+	}
+	if stackTag(cfStack, tags.NetworkTemplate) == "" {
+		return nil, fmt.Errorf("Stack '%s' wasn't created by 'rosa create network'", name)
+	}
+	stack := newStack(cfStack)
+	subnetIDs := stack.SubnetIDs()
+	if len(subnetIDs) == 0 {
+		return stack, nil
+	}
+	subnets, err := awsClient.ListSubnets(subnetIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get the subnets of stack '%s': %v", name, err)
+	}
+	zones := map[string]string{}
+	for _, subnet := range subnets {
+		zones[awssdk.ToString(subnet.SubnetId)] = awssdk.ToString(subnet.AvailabilityZone)
+	}
+	for _, stackSubnets := range [][]Subnet{stack.PublicSubnets, stack.PrivateSubnets} {
+		for i := range stackSubnets {
+			stackSubnets[i].AvailabilityZone = zones[stackSubnets[i].ID]
+		}
+	}
+	return stack, nil
+}
+
+func newStack(cfStack *cfTypes.Stack) *Stack {
+	outputs := map[string]string{}
+	for _, output := range cfStack.Outputs {
+		outputs[awssdk.ToString(output.OutputKey)] = awssdk.ToString(output.OutputValue)
+	}
+	stack := &Stack{
+		Name:         awssdk.ToString(cfStack.StackName),
+		Status:       string(cfStack.StackStatus),
+		StatusReason: awssdk.ToString(cfStack.StackStatusReason),
+		Template:     stackTag(cfStack, tags.NetworkTemplate),
+		CreatedAt:    cfStack.CreationTime,
+		VpcID:        outputs[OutputVpcID],
+		VpcCidr:      stackParameter(cfStack, ParameterVpcCidr),
+		NatGateways:  splitOutput(outputs[OutputNatGateways]),
+	}
+	for _, id := range splitOutput(outputs[OutputPublicSubnets]) {
+		stack.PublicSubnets = append(stack.PublicSubnets, Subnet{ID: id})
+	}
+	for _, id := range splitOutput(outputs[OutputPrivateSubnets]) {
+		stack.PrivateSubnets = append(stack.PrivateSubnets, Subnet{ID: id})
+	}
+	return stack
+}
+
+// SubnetIDs returns the identifiers of the public and private subnets of the stack
+func (s *Stack) SubnetIDs() []string {
+	ids := []string{}
+	for _, subnet := range append(append([]Subnet{}, s.PublicSubnets...), s.PrivateSubnets...) {
+		ids = append(ids, subnet.ID)
+	}
+	return ids
+}
+
+// ClustersUsingStack returns the names of the clusters installed in the subnets of the stack
+func ClustersUsingStack(clusters []*cmv1.Cluster, stack *Stack) []string {
+	subnetIDs := stack.SubnetIDs()
+	names := []string{}
+	for _, cluster := range clusters {
+		for _, subnetID := range cluster.AWS().SubnetIDs() {
+			if helper.Contains(subnetIDs, subnetID) {
+				names = append(names, cluster.Name())
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ClusterSubnets returns the subnets of the stack to install a cluster in: a private subnet per
+// availability zone, along with a public one unless the cluster uses PrivateLink. Classic clusters get
+// one or three availability zones depending on multiAZ, hosted clusters get all of them.
+func ClusterSubnets(awsClient aws.Client, stack *Stack, multiAZ bool, privateLink bool,
+	hostedCP bool) ([]string, error) {
+	subnetIDs := stack.SubnetIDs()
+	if len(subnetIDs) == 0 {
+		return nil, fmt.Errorf("Network stack '%s' has no subnets", stack.Name)
+	}
+	subnets, err := awsClient.ListSubnets(subnetIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get the subnets of stack '%s': %v", stack.Name, err)
+	}
+	publicSubnets, err := awsClient.FetchPublicSubnetMap(subnets)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to check which subnets of stack '%s' are public: %v", stack.Name, err)
+	}
+
+	// The first private and public subnets of each availability zone, in the order of the outputs
+	type zoneSubnets struct {
+		private string
+		public  string
+	}
+	zones := map[string]*zoneSubnets{}
+	for _, subnetID := range subnetIDs {
+		for _, subnet := range subnets {
+			if awssdk.ToString(subnet.SubnetId) != subnetID {
+				continue
+			}
+			zone := awssdk.ToString(subnet.AvailabilityZone)
+			if zones[zone] == nil {
+				zones[zone] = &zoneSubnets{}
+			}
+			if publicSubnets[subnetID] && zones[zone].public == "" {
+				zones[zone].public = subnetID
+			} else if !publicSubnets[subnetID] && zones[zone].private == "" {
+				zones[zone].private = subnetID
+			}
+		}
+	}
+
+	zoneCount := len(zones)
+	if !hostedCP {
+		zoneCount = 1
+		if multiAZ {
+			zoneCount = 3
+		}
+	}
+	zoneNames := helper.MapKeys(zones)
+	sort.Strings(zoneNames)
+	selected := []string{}
+	for _, zone := range zoneNames {
+		if zoneCount == 0 {
+			break
+		}
+		if zones[zone].private == "" || (!privateLink && zones[zone].public == "") {
+			continue
+		}
+		selected = append(selected, zones[zone].private)
+		if !privateLink {
+			selected = append(selected, zones[zone].public)
+		}
+		zoneCount--
+	}
+	if !hostedCP {
+		err = ocm.ValidateSubnetsCount(multiAZ, privateLink, len(selected))
+		if err != nil {
+			return nil, fmt.Errorf("Network stack '%s' doesn't have enough subnets: %v", stack.Name, err)
+		}
+	}
+	return selected, nil
+}
+
+func stackParameter(cfStack *cfTypes.Stack, key string) string {
+	for _, parameter := range cfStack.Parameters {
+		if awssdk.ToString(parameter.ParameterKey) == key {
+			return awssdk.ToString(parameter.ParameterValue)
+		}
+	}
+	return ""
+}
+
+func stackTag(cfStack *cfTypes.Stack, key string) string {
+	for _, tag := range cfStack.Tags {
+		if awssdk.ToString(tag.Key) == key {
+			return awssdk.ToString(tag.Value)
+		}
+	}
+	return ""
+}
+
+// splitOutput splits the comma separated identifiers of a stack output
+func splitOutput(value string) []string {
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
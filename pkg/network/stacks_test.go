@@ -0,0 +1,171 @@
+package network
+
+import (
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	cfTypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+func networkStack(name string) cfTypes.Stack {
+	return cfTypes.Stack{
+		StackName:   awssdk.String(name),
+		StackStatus: cfTypes.StackStatusCreateComplete,
+		Tags: []cfTypes.Tag{
+			{Key: awssdk.String(tags.NetworkTemplate), Value: awssdk.String("rosa-quickstart-default-vpc")},
+		},
+		Outputs: []cfTypes.Output{
+			{OutputKey: awssdk.String(OutputVpcID), OutputValue: awssdk.String("vpc-1")},
+			{OutputKey: awssdk.String(OutputPublicSubnets), OutputValue: awssdk.String("subnet-pub-a,subnet-pub-b")},
+			{OutputKey: awssdk.String(OutputPrivateSubnets), OutputValue: awssdk.String("subnet-priv-a, subnet-priv-b")},
+			{OutputKey: awssdk.String(OutputNatGateways), OutputValue: awssdk.String("nat-a,nat-b")},
+		},
+	}
+}
+
+var _ = Describe("Network stacks", func() {
+	var (
+		ctrl      *gomock.Controller
+		awsClient *aws.MockClient
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		awsClient = aws.NewMockClient(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Context("ListStacks", func() {
+		It("returns the tagged stacks sorted by name", func() {
+			awsClient.EXPECT().ListStacksWithTag(tags.NetworkTemplate).Return(
+				[]cfTypes.Stack{networkStack("stack-b"), networkStack("stack-a")}, nil)
+
+			stacks, err := ListStacks(awsClient)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stacks).To(HaveLen(2))
+			Expect(stacks[0].Name).To(Equal("stack-a"))
+			Expect(stacks[1].Name).To(Equal("stack-b"))
+			Expect(stacks[0].Template).To(Equal("rosa-quickstart-default-vpc"))
+			Expect(stacks[0].VpcID).To(Equal("vpc-1"))
+			Expect(stacks[0].NatGateways).To(Equal([]string{"nat-a", "nat-b"}))
+		})
+	})
+
+	Context("GetStack", func() {
+		It("fills the availability zones of the subnets", func() {
+			stack := networkStack("stack-a")
+			awsClient.EXPECT().DescribeStack("stack-a").Return(&stack, nil)
+			awsClient.EXPECT().ListSubnets("subnet-pub-a", "subnet-pub-b", "subnet-priv-a", "subnet-priv-b").
+				Return([]ec2types.Subnet{
+					{SubnetId: awssdk.String("subnet-pub-a"), AvailabilityZone: awssdk.String("us-east-1a")},
+					{SubnetId: awssdk.String("subnet-pub-b"), AvailabilityZone: awssdk.String("us-east-1b")},
+					{SubnetId: awssdk.String("subnet-priv-a"), AvailabilityZone: awssdk.String("us-east-1a")},
+					{SubnetId: awssdk.String("subnet-priv-b"), AvailabilityZone: awssdk.String("us-east-1b")},
+				}, nil)
+
+			result, err := GetStack(awsClient, "stack-a")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.PublicSubnets).To(Equal([]Subnet{
+				{ID: "subnet-pub-a", AvailabilityZone: "us-east-1a"},
+				{ID: "subnet-pub-b", AvailabilityZone: "us-east-1b"},
+			}))
+			Expect(result.PrivateSubnets).To(Equal([]Subnet{
+				{ID: "subnet-priv-a", AvailabilityZone: "us-east-1a"},
+				{ID: "subnet-priv-b", AvailabilityZone: "us-east-1b"},
+			}))
+		})
+
+		It("fails when the stack doesn't exist", func() {
+			awsClient.EXPECT().DescribeStack("stack-a").Return(nil, nil)
+			awsClient.EXPECT().GetRegion().Return("us-east-1")
+
+			_, err := GetStack(awsClient, "stack-a")
+			Expect(err).To(MatchError("Network stack 'stack-a' not found in region 'us-east-1'"))
+		})
+
+		It("fails when the stack wasn't created by rosa", func() {
+			stack := networkStack("stack-a")
+			stack.Tags = nil
+			awsClient.EXPECT().DescribeStack("stack-a").Return(&stack, nil)
+
+			_, err := GetStack(awsClient, "stack-a")
+			Expect(err).To(MatchError("Stack 'stack-a' wasn't created by 'rosa create network'"))
+		})
+	})
+
+	Context("ClustersUsingStack", func() {
+		It("returns the clusters installed in the subnets of the stack", func() {
+			cfStack := networkStack("stack-a")
+			stack := newStack(&cfStack)
+			clusters := []*cmv1.Cluster{}
+			for name, subnets := range map[string][]string{
+				"foo": {"subnet-priv-a"},
+				"bar": {"subnet-other"},
+				"baz": {"subnet-pub-b", "subnet-priv-b"},
+			} {
+				cluster, err := cmv1.NewCluster().Name(name).AWS(cmv1.NewAWS().SubnetIDs(subnets...)).Build()
+				Expect(err).ToNot(HaveOccurred())
+				clusters = append(clusters, cluster)
+			}
+
+			Expect(ClustersUsingStack(clusters, stack)).To(Equal([]string{"baz", "foo"}))
+		})
+	})
+
+	Context("ClusterSubnets", func() {
+		var stack *Stack
+
+		BeforeEach(func() {
+			stack = &Stack{
+				Name: "stack-a",
+				PublicSubnets: []Subnet{
+					{ID: "subnet-pub-a"}, {ID: "subnet-pub-b"}, {ID: "subnet-pub-c"},
+				},
+				PrivateSubnets: []Subnet{
+					{ID: "subnet-priv-a"}, {ID: "subnet-priv-b"}, {ID: "subnet-priv-c"},
+				},
+			}
+			subnets := []ec2types.Subnet{}
+			for _, zone := range []string{"c", "b", "a"} {
+				for _, kind := range []string{"pub", "priv"} {
+					subnets = append(subnets, ec2types.Subnet{
+						SubnetId:         awssdk.String("subnet-" + kind + "-" + zone),
+						AvailabilityZone: awssdk.String("us-east-1" + zone),
+					})
+				}
+			}
+			awsClient.EXPECT().ListSubnets(gomock.Any()).Return(subnets, nil)
+			awsClient.EXPECT().FetchPublicSubnetMap(subnets).Return(map[string]bool{
+				"subnet-pub-a": true, "subnet-pub-b": true, "subnet-pub-c": true,
+			}, nil)
+		})
+
+		It("picks a private and a public subnet in the first zone for single AZ clusters", func() {
+			subnetIDs, err := ClusterSubnets(awsClient, stack, false, false, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(subnetIDs).To(Equal([]string{"subnet-priv-a", "subnet-pub-a"}))
+		})
+
+		It("picks only private subnets for multi AZ PrivateLink clusters", func() {
+			subnetIDs, err := ClusterSubnets(awsClient, stack, true, true, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(subnetIDs).To(Equal([]string{"subnet-priv-a", "subnet-priv-b", "subnet-priv-c"}))
+		})
+
+		It("fails when the stack doesn't span enough zones", func() {
+			stack.PublicSubnets = stack.PublicSubnets[:1]
+			_, err := ClusterSubnets(awsClient, stack, true, false, false)
+			Expect(err).To(MatchError(ContainSubstring("The number of subnets for a 'multi-AZ' 'cluster' " +
+				"should be '6', instead received: '2'")))
+		})
+	})
+})
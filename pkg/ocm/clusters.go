@@ -499,6 +499,16 @@ func (c *Client) HasAClusterUsingOidcEndpointUrl(issuerUrl string) (bool, error)
 	return false, nil
 }
 
+// GetClustersUsingOidcEndpointUrl lists the clusters whose service account tokens are validated
+// against the given OIDC issuer
+func (c *Client) GetClustersUsingOidcEndpointUrl(issuerUrl string) ([]*cmv1.Cluster, error) {
+	query := fmt.Sprintf(
+		"aws.sts.oidc_endpoint_url = '%s'", issuerUrl,
+	)
+	// Fetch in pages of 100 clusters until a page isn't full
+	return c.queryClusters(query, 100)
+}
+
 func (c *Client) IsSTSClusterExists(creator *aws.Creator, count int, roleARN string) (exists bool, err error) {
 	if count < 1 {
 		err = errors.Errorf("Cannot fetch fewer than 1 cluster")
@@ -0,0 +1,210 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidcconfig
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// BackendS3 hosts the documents in a public S3 bucket
+	BackendS3 = "s3"
+	// BackendCloudFront hosts the documents in a private S3 bucket served by CloudFront
+	BackendCloudFront = "cloudfront"
+	// BackendRawFiles writes the documents in a directory to be uploaded to any static web host
+	BackendRawFiles = "raw-files"
+)
+
+// Backends lists the supported issuer backends of unmanaged OIDC configurations.
+var Backends = []string{BackendS3, BackendCloudFront, BackendRawFiles}
+
+const (
+	// CloudFrontIssuerUrlOutput is the stack output holding the issuer URL served by CloudFront
+	CloudFrontIssuerUrlOutput = "IssuerUrl"
+	// CloudFrontStackTimeout is how long to wait for the distribution to be deployed
+	CloudFrontStackTimeout = 30 * time.Minute
+
+	prefixForPrivateKeySecret = "rosa-private-key-"
+)
+
+// CloudFrontTemplate is a CloudFormation template creating a private bucket and a CloudFront
+// distribution reading from it through an origin access control. The bucket is retained when
+// the stack is deleted so that it is emptied and removed along the secret, like the buckets of
+// the S3 backend.
+const CloudFrontTemplate = `AWSTemplateFormatVersion: "2010-09-09"
+Description: Private S3 bucket served by CloudFront hosting the documents of a ROSA OIDC configuration
+Parameters:
+  BucketName:
+    Type: String
+    Description: Name of the S3 bucket holding the discovery document and the JSON Web Key Set
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    DeletionPolicy: Retain
+    Properties:
+      BucketName: !Ref BucketName
+      PublicAccessBlockConfiguration:
+        BlockPublicAcls: true
+        BlockPublicPolicy: true
+        IgnorePublicAcls: true
+        RestrictPublicBuckets: true
+      Tags:
+        - Key: red-hat-managed
+          Value: "true"
+  OriginAccessControl:
+    Type: AWS::CloudFront::OriginAccessControl
+    Properties:
+      OriginAccessControlConfig:
+        Name: !Ref BucketName
+        OriginAccessControlOriginType: s3
+        SigningBehavior: always
+        SigningProtocol: sigv4
+  Distribution:
+    Type: AWS::CloudFront::Distribution
+    Properties:
+      DistributionConfig:
+        Enabled: true
+        Comment: !Sub "ROSA OIDC configuration ${BucketName}"
+        HttpVersion: http2
+        PriceClass: PriceClass_100
+        Origins:
+          - Id: oidc
+            DomainName: !GetAtt Bucket.RegionalDomainName
+            OriginAccessControlId: !GetAtt OriginAccessControl.Id
+            S3OriginConfig:
+              OriginAccessIdentity: ""
+        DefaultCacheBehavior:
+          TargetOriginId: oidc
+          ViewerProtocolPolicy: https-only
+          AllowedMethods:
+            - GET
+            - HEAD
+          # Managed CachingDisabled policy, so that rotated keys are served right away
+          CachePolicyId: 4135ea2d-6df8-44a3-9df3-4b5a84be39ad
+  BucketPolicy:
+    Type: AWS::S3::BucketPolicy
+    DeletionPolicy: Retain
+    Properties:
+      Bucket: !Ref Bucket
+      PolicyDocument:
+        Version: "2012-10-17"
+        Statement:
+          - Sid: AllowCloudFrontRead
+            Effect: Allow
+            Principal:
+              Service: cloudfront.amazonaws.com
+            Action: s3:GetObject
+            Resource: !Sub "arn:${AWS::Partition}:s3:::${BucketName}/*"
+            Condition:
+              StringEquals:
+                AWS:SourceArn: !Sub "arn:${AWS::Partition}:cloudfront::${AWS::AccountId}:distribution/${Distribution}"
+Outputs:
+  IssuerUrl:
+    Value: !Sub "https://${Distribution.DomainName}"
+  DistributionId:
+    Value: !Ref Distribution
+`
+
+// ValidateBackend checks that the backend is one of the supported issuer backends.
+func ValidateBackend(backend string) error {
+	for _, supported := range Backends {
+		if backend == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid issuer backend '%s', expected one of: %s", backend, strings.Join(Backends, ", "))
+}
+
+// CloudFrontStackName returns the name of the CloudFormation stack serving the bucket.
+func CloudFrontStackName(bucketName string) string {
+	return fmt.Sprintf("%s-cloudfront", bucketName)
+}
+
+// IsS3IssuerUrl returns true if the issuer is served directly by a S3 bucket.
+func IsS3IssuerUrl(issuerUrl string) bool {
+	_, err := BucketNameFromIssuerUrl(issuerUrl)
+	return err == nil
+}
+
+// BackendFromIssuerUrl returns the issuer backend of an unmanaged OIDC configuration given its issuer
+// URL. Issuers that are neither a S3 bucket nor a CloudFront distribution are hosted by the user from
+// the raw files, ROSA created no bucket nor stack for them.
+func BackendFromIssuerUrl(issuerUrl string) string {
+	if IsS3IssuerUrl(issuerUrl) {
+		return BackendS3
+	}
+	parsed, err := url.Parse(issuerUrl)
+	if err == nil && strings.HasSuffix(parsed.Hostname(), ".cloudfront.net") {
+		return BackendCloudFront
+	}
+	return BackendRawFiles
+}
+
+// BucketNameFromSecretName returns the name of the bucket of an OIDC configuration created by
+// ROSA given the name of its secret, which is 'rosa-private-key-<bucket>-<aws-suffix>'.
+func BucketNameFromSecretName(secretName string) string {
+	bucketName := strings.TrimPrefix(secretName, prefixForPrivateKeySecret)
+	index := strings.LastIndex(bucketName, "-")
+	if index != -1 {
+		bucketName = bucketName[:index]
+	}
+	return bucketName
+}
+
+// ValidateIssuerUrl checks that an issuer URL provided by the user can be used by AWS STS.
+func ValidateIssuerUrl(issuerUrl string) error {
+	parsed, err := url.ParseRequestURI(issuerUrl)
+	if err != nil {
+		return fmt.Errorf("Invalid issuer URL '%s': %v", issuerUrl, err)
+	}
+	if parsed.Scheme != "https" || parsed.Host == "" {
+		return fmt.Errorf("Issuer URL '%s' must be an 'https' URL", issuerUrl)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return fmt.Errorf("Issuer URL '%s' must not have a query or a fragment", issuerUrl)
+	}
+	if strings.HasSuffix(parsed.Path, "/") {
+		return fmt.Errorf("Issuer URL '%s' must not end with a '/'", issuerUrl)
+	}
+	return nil
+}
+
+// SaveRawFilesBundle writes the discovery document and the JSON Web Key Set in the directory,
+// laid out as they must be served under the issuer URL.
+func SaveRawFilesBundle(directory string, discoveryDocument string, jwks []byte) error {
+	documents := map[string][]byte{
+		DiscoveryDocumentKey: []byte(discoveryDocument),
+		JwksKey:              jwks,
+	}
+	for key, document := range documents {
+		path := filepath.Join(directory, filepath.FromSlash(key))
+		err := os.MkdirAll(filepath.Dir(path), os.FileMode(0755))
+		if err != nil {
+			return fmt.Errorf("Failed to create directory %s: %v", filepath.Dir(path), err)
+		}
+		err = os.WriteFile(path, document, 0600)
+		if err != nil {
+			return fmt.Errorf("Failed to write file '%s': %v", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package oidcconfig
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backends", func() {
+	It("OK: validates backends", func() {
+		Expect(ValidateBackend(BackendCloudFront)).To(Succeed())
+		Expect(ValidateBackend("gcs")).To(MatchError(
+			"Invalid issuer backend 'gcs', expected one of: s3, cloudfront, raw-files"))
+	})
+
+	It("OK: tells S3 issuers from other issuers", func() {
+		Expect(IsS3IssuerUrl("https://prefix-oidc-a1b2.s3.us-east-1.amazonaws.com")).To(BeTrue())
+		Expect(IsS3IssuerUrl("https://d1234.cloudfront.net")).To(BeFalse())
+	})
+
+	It("OK: tells the backend of an issuer", func() {
+		Expect(BackendFromIssuerUrl("https://prefix-oidc-a1b2.s3.us-east-1.amazonaws.com")).To(Equal(BackendS3))
+		Expect(BackendFromIssuerUrl("https://d1234.cloudfront.net")).To(Equal(BackendCloudFront))
+		Expect(BackendFromIssuerUrl("https://oidc.example.com/cluster")).To(Equal(BackendRawFiles))
+	})
+
+	It("OK: returns the bucket of a secret created by ROSA", func() {
+		Expect(BucketNameFromSecretName("rosa-private-key-prefix-oidc-a1b2-Xy9z8w")).To(Equal("prefix-oidc-a1b2"))
+	})
+
+	DescribeTable("ValidateIssuerUrl",
+		func(issuerUrl string, expected string) {
+			err := ValidateIssuerUrl(issuerUrl)
+			if expected == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expected)))
+			}
+		},
+		Entry("https host", "https://oidc.example.com", ""),
+		Entry("https path", "https://example.com/oidc", ""),
+		Entry("http", "http://oidc.example.com", "must be an 'https' URL"),
+		Entry("trailing slash", "https://oidc.example.com/", "must not end with a '/'"),
+		Entry("query", "https://oidc.example.com?a=b", "must not have a query or a fragment"),
+	)
+
+	It("OK: saves the raw files bundle as served by the issuer", func() {
+		directory := GinkgoT().TempDir()
+		Expect(SaveRawFilesBundle(directory, "{}", []byte(`{"keys":[]}`))).To(Succeed())
+		discoveryDocument, err := os.ReadFile(filepath.Join(directory, ".well-known", "openid-configuration"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(discoveryDocument)).To(Equal("{}"))
+		jwks, err := os.ReadFile(filepath.Join(directory, "keys.json"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(jwks)).To(Equal(`{"keys":[]}`))
+	})
+})
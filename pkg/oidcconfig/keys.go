@@ -0,0 +1,117 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package oidcconfig contains helpers to manage the signing keys of unmanaged OIDC
+// configurations, whose documents are hosted in a S3 bucket of the customer account.
+package oidcconfig
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/openshift-online/ocm-common/pkg/rosa/oidcconfigs"
+)
+
+const (
+	// DiscoveryDocumentKey is the S3 key of the OpenID discovery document
+	DiscoveryDocumentKey = ".well-known/openid-configuration"
+	// JwksKey is the S3 key of the JSON Web Key Set
+	JwksKey = "keys.json"
+)
+
+// PublicKeyFromPrivateKey returns the PEM encoded public key of a PEM encoded RSA private key,
+// in the same format as the keys generated by 'rosa create oidc-config'.
+func PublicKeyFromPrivateKey(privateKey []byte) ([]byte, error) {
+	block, _ := pem.Decode(privateKey)
+	if block == nil {
+		return nil, fmt.Errorf("Failed to decode private key PEM")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse private key: %v", err)
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to generate public key from private: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: publicKey,
+	}), nil
+}
+
+// BuildJSONWebKeySet builds a JSON Web Key Set containing the public keys of the given PEM
+// encoded private keys, in order. Keys are identified by their key ID so that the same key
+// is never published twice.
+func BuildJSONWebKeySet(privateKeys ...[]byte) ([]byte, error) {
+	var sets [][]byte
+	for _, privateKey := range privateKeys {
+		publicKey, err := PublicKeyFromPrivateKey(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		jwks, err := oidcconfigs.BuildJSONWebKeySet(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to build JSON Web Key Set: %v", err)
+		}
+		sets = append(sets, jwks)
+	}
+	return MergeJSONWebKeySets(sets...)
+}
+
+// MergeJSONWebKeySets combines several JSON Web Key Sets into one, dropping keys whose key
+// ID was already seen.
+func MergeJSONWebKeySets(sets ...[]byte) ([]byte, error) {
+	keys := []map[string]interface{}{}
+	seen := map[string]bool{}
+	for _, set := range sets {
+		var jwks struct {
+			Keys []map[string]interface{} `json:"keys"`
+		}
+		err := json.Unmarshal(set, &jwks)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse JSON Web Key Set: %v", err)
+		}
+		for _, key := range jwks.Keys {
+			kid, _ := key["kid"].(string)
+			if kid != "" && seen[kid] {
+				continue
+			}
+			seen[kid] = true
+			keys = append(keys, key)
+		}
+	}
+	return json.MarshalIndent(map[string]interface{}{"keys": keys}, "", "    ")
+}
+
+// BucketNameFromIssuerUrl returns the name of the S3 bucket hosting the documents of an
+// unmanaged OIDC configuration, given an issuer URL such as
+// 'https://<bucket>.s3.<region>.amazonaws.com'.
+func BucketNameFromIssuerUrl(issuerUrl string) (string, error) {
+	parsed, err := url.Parse(issuerUrl)
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse issuer URL '%s': %v", issuerUrl, err)
+	}
+	index := strings.Index(parsed.Hostname(), ".s3.")
+	if index <= 0 || !strings.HasSuffix(parsed.Hostname(), ".amazonaws.com") {
+		return "", fmt.Errorf("Issuer URL '%s' is not a S3 bucket URL", issuerUrl)
+	}
+	return parsed.Hostname()[:index], nil
+}
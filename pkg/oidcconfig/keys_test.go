@@ -0,0 +1,95 @@
+package oidcconfig
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openshift-online/ocm-common/pkg/rosa/oidcconfigs"
+)
+
+func keyIds(jwks []byte) []string {
+	var set struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+		} `json:"keys"`
+	}
+	Expect(json.Unmarshal(jwks, &set)).To(Succeed())
+	var ids []string
+	for _, key := range set.Keys {
+		ids = append(ids, key.Kid)
+	}
+	return ids
+}
+
+var _ = Describe("Signing keys", Ordered, func() {
+	var oldPrivateKey, oldPublicKey, newPrivateKey []byte
+
+	BeforeAll(func() {
+		var err error
+		oldPrivateKey, oldPublicKey, err = oidcconfigs.CreateKeyPair()
+		Expect(err).ToNot(HaveOccurred())
+		newPrivateKey, _, err = oidcconfigs.CreateKeyPair()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Context("PublicKeyFromPrivateKey", func() {
+		It("OK: returns the public key generated along the private key", func() {
+			publicKey, err := PublicKeyFromPrivateKey(oldPrivateKey)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(publicKey).To(Equal(oldPublicKey))
+		})
+
+		It("KO: fails on a document that is not a PEM", func() {
+			_, err := PublicKeyFromPrivateKey([]byte("not a key"))
+			Expect(err).To(MatchError("Failed to decode private key PEM"))
+		})
+	})
+
+	Context("BuildJSONWebKeySet", func() {
+		It("OK: publishes the old and new keys in order", func() {
+			oldJwks, err := BuildJSONWebKeySet(oldPrivateKey)
+			Expect(err).ToNot(HaveOccurred())
+			newJwks, err := BuildJSONWebKeySet(newPrivateKey)
+			Expect(err).ToNot(HaveOccurred())
+			jwks, err := BuildJSONWebKeySet(oldPrivateKey, newPrivateKey)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keyIds(jwks)).To(Equal(append(keyIds(oldJwks), keyIds(newJwks)...)))
+		})
+
+		It("OK: does not publish the same key twice", func() {
+			jwks, err := BuildJSONWebKeySet(oldPrivateKey, oldPrivateKey)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keyIds(jwks)).To(HaveLen(1))
+		})
+	})
+})
+
+var _ = Describe("MergeJSONWebKeySets", func() {
+	It("OK: keeps keys in order and drops duplicated key IDs", func() {
+		jwks, err := MergeJSONWebKeySets(
+			[]byte(`{"keys":[{"kid":"a","kty":"RSA"}]}`),
+			[]byte(`{"keys":[{"kid":"b","kty":"RSA"},{"kid":"a","kty":"RSA"}]}`),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(keyIds(jwks)).To(Equal([]string{"a", "b"}))
+	})
+
+	It("KO: fails on invalid JSON", func() {
+		_, err := MergeJSONWebKeySets([]byte(`{`))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("BucketNameFromIssuerUrl", func() {
+	It("OK: returns the bucket of a S3 issuer URL", func() {
+		bucket, err := BucketNameFromIssuerUrl("https://prefix-oidc-a1b2.s3.us-east-1.amazonaws.com")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bucket).To(Equal("prefix-oidc-a1b2"))
+	})
+
+	It("KO: fails on an issuer that is not hosted in S3", func() {
+		_, err := BucketNameFromIssuerUrl("https://d1234.cloudfront.net")
+		Expect(err).To(MatchError("Issuer URL 'https://d1234.cloudfront.net' is not a S3 bucket URL"))
+	})
+})
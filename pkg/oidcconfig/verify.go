@@ -0,0 +1,222 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidcconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+const signingAlgorithm = "RS256"
+
+// DiscoveryDocument is the subset of the OpenID discovery document used by AWS STS.
+type DiscoveryDocument struct {
+	Issuer                           string   `json:"issuer"`
+	JwksUri                          string   `json:"jwks_uri"`
+	ResponseTypesSupported           []string `json:"response_types_supported"`
+	IdTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// Verification is the result of checking the documents served under an issuer URL. Problems
+// prevent AWS STS from validating service account tokens, warnings do not.
+type Verification struct {
+	IssuerUrl         string
+	DiscoveryDocument *DiscoveryDocument
+	KeyIds            []string
+	Problems          []string
+	Warnings          []string
+}
+
+func (v *Verification) problemf(format string, a ...interface{}) {
+	v.Problems = append(v.Problems, fmt.Sprintf(format, a...))
+}
+
+func (v *Verification) warnf(format string, a ...interface{}) {
+	v.Warnings = append(v.Warnings, fmt.Sprintf(format, a...))
+}
+
+// Verify fetches the discovery document and the JSON Web Key Set served under the issuer URL
+// and checks that they are consistent with the issuer.
+func Verify(client *http.Client, issuerUrl string) *Verification {
+	issuerUrl = strings.TrimSuffix(issuerUrl, "/")
+	v := &Verification{IssuerUrl: issuerUrl}
+
+	discoveryUrl := fmt.Sprintf("%s/%s", issuerUrl, DiscoveryDocumentKey)
+	body, err := fetch(client, discoveryUrl)
+	if err != nil {
+		v.problemf("%v", err)
+		return v
+	}
+	document := &DiscoveryDocument{}
+	err = json.Unmarshal(body, document)
+	if err != nil {
+		v.problemf("Discovery document '%s' is not valid JSON: %v", discoveryUrl, err)
+		return v
+	}
+	v.DiscoveryDocument = document
+	if strings.TrimSuffix(document.Issuer, "/") != issuerUrl {
+		v.problemf("Discovery document issuer '%s' does not match issuer URL '%s'", document.Issuer, issuerUrl)
+	}
+	if !helper.Contains(document.ResponseTypesSupported, "id_token") {
+		v.warnf("Discovery document does not list 'id_token' in 'response_types_supported'")
+	}
+	if !helper.Contains(document.IdTokenSigningAlgValuesSupported, signingAlgorithm) {
+		v.problemf("Discovery document does not list '%s' in 'id_token_signing_alg_values_supported'",
+			signingAlgorithm)
+	}
+	if document.JwksUri == "" {
+		v.problemf("Discovery document has no 'jwks_uri'")
+		return v
+	}
+	if !strings.HasPrefix(document.JwksUri, "https://") {
+		v.problemf("JWKS URI '%s' must be an 'https' URL", document.JwksUri)
+		return v
+	}
+
+	body, err = fetch(client, document.JwksUri)
+	if err != nil {
+		v.problemf("%v", err)
+		return v
+	}
+	v.verifyKeys(document.JwksUri, body)
+	return v
+}
+
+func (v *Verification) verifyKeys(jwksUri string, body []byte) {
+	var jwks struct {
+		Keys []map[string]interface{} `json:"keys"`
+	}
+	err := json.Unmarshal(body, &jwks)
+	if err != nil {
+		v.problemf("JSON Web Key Set '%s' is not valid JSON: %v", jwksUri, err)
+		return
+	}
+	if len(jwks.Keys) == 0 {
+		v.problemf("JSON Web Key Set '%s' has no keys", jwksUri)
+		return
+	}
+	seen := map[string]bool{}
+	for i, key := range jwks.Keys {
+		kid, _ := key["kid"].(string)
+		name := fmt.Sprintf("key %d", i)
+		if kid == "" {
+			v.problemf("JSON Web Key Set %s has no 'kid'", name)
+		} else {
+			name = fmt.Sprintf("key '%s'", kid)
+			if seen[kid] {
+				v.problemf("JSON Web Key Set has duplicated %s", name)
+			}
+			seen[kid] = true
+			v.KeyIds = append(v.KeyIds, kid)
+		}
+		if kty, _ := key["kty"].(string); kty != "RSA" {
+			v.problemf("JSON Web Key Set %s has type '%s', expected 'RSA'", name, kty)
+		}
+		if use, ok := key["use"].(string); ok && use != "sig" {
+			v.problemf("JSON Web Key Set %s has use '%s', expected 'sig'", name, use)
+		}
+		if alg, ok := key["alg"].(string); ok && alg != signingAlgorithm {
+			v.problemf("JSON Web Key Set %s has algorithm '%s', expected '%s'", name, alg, signingAlgorithm)
+		}
+		if _, ok := key["n"].(string); !ok {
+			v.problemf("JSON Web Key Set %s has no modulus 'n'", name)
+		}
+		if _, ok := key["e"].(string); !ok {
+			v.problemf("JSON Web Key Set %s has no exponent 'e'", name)
+		}
+	}
+}
+
+// VerifyPrivateKey checks that the public key of the private key used to sign service account
+// tokens is published in the JSON Web Key Set.
+func (v *Verification) VerifyPrivateKey(privateKey []byte) {
+	jwks, err := BuildJSONWebKeySet(privateKey)
+	if err != nil {
+		v.problemf("Private key is not valid: %v", err)
+		return
+	}
+	var set struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+		} `json:"keys"`
+	}
+	err = json.Unmarshal(jwks, &set)
+	if err != nil || len(set.Keys) == 0 {
+		v.problemf("Failed to compute the key ID of the private key")
+		return
+	}
+	if !helper.Contains(v.KeyIds, set.Keys[0].Kid) {
+		v.problemf("The public key of the private key stored in Secrets Manager, with key ID '%s', "+
+			"is not published in the JSON Web Key Set", set.Keys[0].Kid)
+	}
+}
+
+// VerifyThumbprint checks that the thumbprint of the issuer is registered on the IAM OIDC provider.
+func (v *Verification) VerifyThumbprint(thumbprint string, registered []string) {
+	for _, value := range registered {
+		if strings.EqualFold(value, thumbprint) {
+			return
+		}
+	}
+	v.problemf("Thumbprint '%s' of the issuer is not registered on the OIDC provider, which has: %s",
+		thumbprint, strings.Join(registered, ", "))
+}
+
+// PublishedKeyIds returns the IDs of the keys of the JSON Web Key Set served under the issuer URL.
+func PublishedKeyIds(client *http.Client, issuerUrl string) ([]string, error) {
+	jwksUrl := fmt.Sprintf("%s/%s", strings.TrimSuffix(issuerUrl, "/"), JwksKey)
+	body, err := fetch(client, jwksUrl)
+	if err != nil {
+		return nil, err
+	}
+	var jwks struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+		} `json:"keys"`
+	}
+	err = json.Unmarshal(body, &jwks)
+	if err != nil {
+		return nil, fmt.Errorf("JSON Web Key Set '%s' is not valid JSON: %v", jwksUrl, err)
+	}
+	keyIds := make([]string, 0, len(jwks.Keys))
+	for _, key := range jwks.Keys {
+		keyIds = append(keyIds, key.Kid)
+	}
+	return keyIds, nil
+}
+
+func fetch(client *http.Client, url string) ([]byte, error) {
+	// #nosec G107
+	response, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch '%s': %v", url, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed to fetch '%s': status %s", url, response.Status)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read '%s': %v", url, err)
+	}
+	return body, nil
+}
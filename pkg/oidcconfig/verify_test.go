@@ -0,0 +1,106 @@
+package oidcconfig
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openshift-online/ocm-common/pkg/rosa/oidcconfigs"
+)
+
+var _ = Describe("Verify", Ordered, func() {
+	var server *httptest.Server
+	var documents map[string]string
+	var privateKey, jwks []byte
+
+	BeforeAll(func() {
+		var err error
+		privateKey, _, err = oidcconfigs.CreateKeyPair()
+		Expect(err).ToNot(HaveOccurred())
+		jwks, err = BuildJSONWebKeySet(privateKey)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	BeforeEach(func() {
+		documents = map[string]string{}
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			document, ok := documents[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprint(w, document)
+		}))
+		documents["/"+DiscoveryDocumentKey] = oidcconfigs.GenerateDiscoveryDocument(server.URL)
+		documents["/"+JwksKey] = string(jwks)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("OK: accepts documents generated by ROSA", func() {
+		v := Verify(server.Client(), server.URL)
+		Expect(v.Problems).To(BeEmpty())
+		Expect(v.Warnings).To(BeEmpty())
+		Expect(v.KeyIds).To(HaveLen(1))
+		v.VerifyPrivateKey(privateKey)
+		Expect(v.Problems).To(BeEmpty())
+	})
+
+	It("KO: reports a missing discovery document", func() {
+		delete(documents, "/"+DiscoveryDocumentKey)
+		v := Verify(server.Client(), server.URL)
+		Expect(v.Problems).To(ConsistOf(ContainSubstring("status 404")))
+	})
+
+	It("KO: reports a discovery document for another issuer", func() {
+		documents["/"+DiscoveryDocumentKey] = oidcconfigs.GenerateDiscoveryDocument(server.URL + "/other")
+		v := Verify(server.Client(), server.URL)
+		Expect(v.Problems).To(ContainElement(ContainSubstring("does not match issuer URL")))
+	})
+
+	It("KO: reports keys that cannot be used to verify tokens", func() {
+		documents["/"+JwksKey] = `{"keys":[{"kid":"a","kty":"EC","use":"enc"},{"kid":"a","kty":"RSA","n":"x","e":"y"}]}`
+		v := Verify(server.Client(), server.URL)
+		Expect(v.Problems).To(ConsistOf(
+			"JSON Web Key Set key 'a' has type 'EC', expected 'RSA'",
+			"JSON Web Key Set key 'a' has use 'enc', expected 'sig'",
+			"JSON Web Key Set key 'a' has no modulus 'n'",
+			"JSON Web Key Set key 'a' has no exponent 'e'",
+			"JSON Web Key Set has duplicated key 'a'",
+		))
+	})
+
+	It("KO: reports a private key that is not published", func() {
+		documents["/"+JwksKey] = `{"keys":[{"kid":"a","kty":"RSA","n":"x","e":"y"}]}`
+		v := Verify(server.Client(), server.URL)
+		Expect(v.Problems).To(BeEmpty())
+		v.VerifyPrivateKey(privateKey)
+		Expect(v.Problems).To(ConsistOf(ContainSubstring("is not published in the JSON Web Key Set")))
+	})
+
+	It("OK: returns the IDs of the published keys", func() {
+		documents["/"+JwksKey] = `{"keys":[{"kid":"a"},{"kid":"b"}]}`
+		keyIds, err := PublishedKeyIds(server.Client(), server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(keyIds).To(Equal([]string{"a", "b"}))
+	})
+})
+
+var _ = Describe("VerifyThumbprint", func() {
+	It("OK: ignores the case of the thumbprints", func() {
+		v := &Verification{}
+		v.VerifyThumbprint("ABCDEF", []string{"012345", "abcdef"})
+		Expect(v.Problems).To(BeEmpty())
+	})
+
+	It("KO: reports a thumbprint that is not registered", func() {
+		v := &Verification{}
+		v.VerifyThumbprint("abcdef", []string{"012345"})
+		Expect(v.Problems).To(ConsistOf(
+			"Thumbprint 'abcdef' of the issuer is not registered on the OIDC provider, which has: 012345"))
+	})
+})
@@ -13,6 +13,7 @@ import (
 type CreateMachinepoolUserOptions struct {
 	Name                  string
 	InstanceType          string
+	RecommendInstanceType bool
 	Replicas              int
 	AutoscalingEnabled    bool
 	MinReplicas           int
@@ -21,6 +22,10 @@ type CreateMachinepoolUserOptions struct {
 	Taints                string
 	UseSpotInstances      bool
 	SpotMaxPrice          string
+	InstanceTypes         string
+	OnDemandBaseCapacity  int
+	SpotPercentage        int
+	SpotFallback          bool
 	MultiAvailabilityZone bool
 	AvailabilityZone      string
 	Subnet                string
@@ -53,6 +58,9 @@ const (
   # Add a machine pool with spot instances to a cluster
   rosa create machinepool -c mycluster --name=mp-1 --replicas=2 --instance-type=r5.2xlarge --use-spot-instances \
     --spot-max-price=0.5
+  # Add 6 machines split between on-demand and spot capacity over two instance types
+  rosa create machinepool -c mycluster --name=mp-1 --replicas=6 --instance-types=m5.xlarge:2,m5a.xlarge:1 \
+    --on-demand-base-capacity=2 --spot-percentage=50 --spot-fallback
   # Add a machine pool to a cluster and set the node drain grace period
   rosa create machinepool -c mycluster --name=mp-1 --node-drain-grace-period="90 minutes"`
 )
@@ -141,6 +149,13 @@ func BuildMachinePoolCreateCommandWithOptions() (*cobra.Command, *CreateMachinep
 		"Instance type that should be used.",
 	)
 
+	flags.BoolVar(
+		&options.RecommendInstanceType,
+		"recommend-instance-type",
+		false,
+		"Recommend an instance type from CPU and memory requirements. Only supported in interactive mode.",
+	)
+
 	flags.StringVar(
 		&options.Labels,
 		"labels",
@@ -171,6 +186,37 @@ func BuildMachinePoolCreateCommandWithOptions() (*cobra.Command, *CreateMachinep
 		"Max price for spot instance. If empty use the on-demand price.",
 	)
 
+	flags.StringVar(
+		&options.InstanceTypes,
+		"instance-types",
+		"",
+		"Instance types of a mixed instances strategy. Format should be a comma-separated list of "+
+			"'type:weight', replicas are spread across instance types proportionally to their weight. "+
+			"A machine pool is created for each instance type and capacity type.",
+	)
+
+	flags.IntVar(
+		&options.OnDemandBaseCapacity,
+		"on-demand-base-capacity",
+		0,
+		"Number of replicas of a mixed instances strategy that always run on on-demand instances.",
+	)
+
+	flags.IntVar(
+		&options.SpotPercentage,
+		"spot-percentage",
+		0,
+		"Percentage of the replicas above the on-demand base capacity that run on spot instances.",
+	)
+
+	flags.BoolVar(
+		&options.SpotFallback,
+		"spot-fallback",
+		false,
+		"Create an on-demand machine pool instead of a spot machine pool when the spot machine pool "+
+			"can't be created.",
+	)
+
 	flags.BoolVar(
 		&options.MultiAvailabilityZone,
 		"multi-availability-zone",
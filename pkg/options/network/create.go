@@ -19,8 +19,18 @@ const (
 		"\n\n" + `  # ROSA quick start HCP VPC example` +
 		"\n" + `  rosa create network rosa-quickstart-default-vpc --param Region=us-west-2` +
 		` --param Name=quickstart-stack --param AvailabilityZoneCount=1 --param VpcCidr=10.0.0.0/16` +
+		"\n\n" + `  # PrivateLink-only VPC with VPC endpoints for STS, ECR, S3 and EC2` +
+		"\n" + `  rosa create network rosa-privatelink-vpc --param Region=us-west-2 --param Name=private-stack` +
+		"\n\n" + `  # VPC whose egress is restricted by an AWS Network Firewall to the ROSA allowlist` +
+		"\n" + `  rosa create network rosa-egress-restricted-vpc --param Region=us-west-2 --param Name=egress-stack` +
+		"\n\n" + `  # VPC attached to an existing transit gateway` +
+		"\n" + `  rosa create network rosa-transit-gateway-vpc --param Region=us-west-2 --param Name=tgw-stack` +
+		` --param TransitGatewayId=tgw-0123456789abcdef0` +
+		"\n\n" + `  # Multi-AZ VPC with custom CIDR blocks and subnet sizes` +
+		"\n" + `  rosa create network rosa-multi-az-vpc --param Region=us-west-2 --param Name=multi-az-stack` +
+		` --param VpcCidr=10.10.0.0/16 --param PrivateSubnetsCidr=10.10.0.0/17 --param PublicSubnetsCidr=10.10.128.0/20` +
 		"\n\n" + `  # To delete the AWS cloudformation stack` +
-		"\n" + `  aws cloudformation delete-stack --stack-name <name> --region <region>`
+		"\n" + `  rosa delete network <name> --region <region>`
 	defaultTemplateDir = "cmd/create/network/templates"
 )
 
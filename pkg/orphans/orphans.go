@@ -0,0 +1,447 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package orphans finds the IAM and OIDC resources created by ROSA that are no longer used by
+// any cluster, such as the operator roles and OIDC providers left behind by failed or deleted
+// clusters, so that 'rosa cleanup orphans' can report and delete them.
+package orphans
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/oidcconfig"
+)
+
+const (
+	KindOperatorRoles = "operator-roles"
+	KindOidcProvider  = "oidc-provider"
+	KindOidcConfig    = "oidc-config"
+	KindAccountRole   = "account-role"
+)
+
+const (
+	// StatusOrphaned resources are not used by any cluster and can be deleted
+	StatusOrphaned = "orphaned"
+	// StatusInUse resources are used by at least one cluster
+	StatusInUse = "in use"
+	// StatusProtected resources carry the protect tag
+	StatusProtected = "protected"
+	// StatusRecent resources are unused but younger than the age threshold
+	StatusRecent = "recent"
+	// StatusOtherRegion resources can only be deleted from another region
+	StatusOtherRegion = "other region"
+)
+
+// DefaultProtectTag is the tag that keeps a resource from being deleted, whatever its value.
+const DefaultProtectTag = tags.CleanupProtect
+
+// Page size used when looking for clusters using an account role
+const clusterPageSize = 100
+
+// ClusterFinder is the subset of the OCM client used to find the clusters using a resource.
+type ClusterFinder interface {
+	HasAClusterUsingOperatorRolesPrefix(prefix string) (bool, error)
+	HasAClusterUsingOidcProvider(issuerUrl string, curAccountId string) (bool, error)
+	HasAClusterUsingOidcEndpointUrl(issuerUrl string) (bool, error)
+	GetClustersUsingAccountRole(aws *aws.Creator, role aws.Role, count int) ([]*cmv1.Cluster, error)
+	ListOidcConfigs(awsAccountId string) ([]*cmv1.OidcConfig, error)
+	DeleteOidcConfig(id string) error
+}
+
+// Resource is a set of AWS resources that are created and deleted together, like the operator
+// roles sharing a prefix, along with whether a cluster still uses it.
+type Resource struct {
+	Kind      string
+	Name      string
+	Details   string
+	CreatedAt time.Time
+	InUse     bool
+	Protected bool
+	// Region where the resource must be deleted from, when it isn't the current one
+	Region string
+	Status string
+
+	remove func() error
+}
+
+// Delete removes the resource from AWS, and from OCM for OIDC configurations.
+func (r *Resource) Delete() error {
+	if r.remove == nil {
+		return fmt.Errorf("Deleting %s '%s' is not supported", r.Kind, r.Name)
+	}
+	return r.remove()
+}
+
+// Evaluate sets the status of the resource. Unused resources are only orphaned once they are
+// older than the threshold, so that resources created ahead of a cluster are not deleted.
+func (r *Resource) Evaluate(olderThan time.Duration, now time.Time) {
+	switch {
+	case r.InUse:
+		r.Status = StatusInUse
+	case r.Protected:
+		r.Status = StatusProtected
+	case !r.CreatedAt.IsZero() && now.Sub(r.CreatedAt) < olderThan:
+		r.Status = StatusRecent
+	case r.Region != "":
+		r.Status = StatusOtherRegion
+	default:
+		r.Status = StatusOrphaned
+	}
+}
+
+// ProtectTag matches the tags that protect a resource from deletion. An empty value matches
+// any value of the key.
+type ProtectTag struct {
+	Key   string
+	Value string
+}
+
+// ParseProtectTag parses a protect tag given as 'key' or 'key=value'.
+func ParseProtectTag(tag string) (ProtectTag, error) {
+	key, value, _ := strings.Cut(tag, "=")
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return ProtectTag{}, fmt.Errorf("Invalid protect tag '%s', expected 'key' or 'key=value'", tag)
+	}
+	return ProtectTag{Key: key, Value: strings.TrimSpace(value)}, nil
+}
+
+// Matches returns true if one of the tags matches the protect tag.
+func (p ProtectTag) Matches(resourceTags []iamtypes.Tag) bool {
+	for _, tag := range resourceTags {
+		if awssdk.ToString(tag.Key) != p.Key {
+			continue
+		}
+		if p.Value == "" || awssdk.ToString(tag.Value) == p.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// Collector lists the resources created by ROSA in the AWS account and checks which of them
+// are used by a cluster.
+type Collector struct {
+	AWSClient  aws.Client
+	OCMClient  ClusterFinder
+	Creator    *aws.Creator
+	Region     string
+	ProtectTag ProtectTag
+	// IncludeIAM also collects the operator roles, OIDC providers and account roles. They are only
+	// checked against the clusters of the current organization, while an AWS account often serves
+	// several of them.
+	IncludeIAM bool
+}
+
+// Collect returns every resource in the order they must be deleted in: operator roles and OIDC
+// providers first, as they reference the OIDC configurations, and account roles last. The IAM
+// resources are only returned when included, the OIDC providers are still listed to protect the
+// OIDC configurations of protected providers.
+func (c *Collector) Collect() ([]*Resource, error) {
+	var resources []*Resource
+	if c.IncludeIAM {
+		operatorRoles, err := c.OperatorRoles()
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, operatorRoles...)
+	}
+	providers, err := c.OidcProviders()
+	if err != nil {
+		return nil, err
+	}
+	if c.IncludeIAM {
+		resources = append(resources, providers...)
+	}
+	configs, err := c.OidcConfigs(providers)
+	if err != nil {
+		return nil, err
+	}
+	resources = append(resources, configs...)
+	if !c.IncludeIAM {
+		return resources, nil
+	}
+	accountRoles, err := c.AccountRoles()
+	if err != nil {
+		return nil, err
+	}
+	resources = append(resources, accountRoles...)
+	return resources, nil
+}
+
+// OperatorRoles returns a resource per operator roles prefix. The prefix is as old as its most
+// recent role, and protected if any of its roles is.
+func (c *Collector) OperatorRoles() ([]*Resource, error) {
+	operatorRoles, err := c.AWSClient.ListOperatorRoles("", "", "")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list operator roles: %v", err)
+	}
+	prefixes := make([]string, 0, len(operatorRoles))
+	for prefix := range operatorRoles {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	var resources []*Resource
+	for _, prefix := range prefixes {
+		// Only roles tagged with their operator are operator roles created by ROSA
+		var roles []aws.OperatorRoleDetail
+		for _, role := range operatorRoles[prefix] {
+			if role.OperatorNamespace != "" {
+				roles = append(roles, role)
+			}
+		}
+		if len(roles) == 0 {
+			continue
+		}
+		// Prefixes are listed in lower case, while clusters reference roles by their actual name
+		if name := roles[0].RoleName; len(name) >= len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+			prefix = name[:len(prefix)]
+		}
+		inUse, err := c.OCMClient.HasAClusterUsingOperatorRolesPrefix(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to check clusters using operator roles prefix '%s': %v", prefix, err)
+		}
+		resource := &Resource{
+			Kind:    KindOperatorRoles,
+			Name:    prefix,
+			Details: fmt.Sprintf("%d roles", len(roles)),
+			InUse:   inUse,
+		}
+		for _, operatorRole := range roles {
+			role, err := c.AWSClient.GetRoleByName(operatorRole.RoleName)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get operator role '%s': %v", operatorRole.RoleName, err)
+			}
+			if role.CreateDate != nil && role.CreateDate.After(resource.CreatedAt) {
+				resource.CreatedAt = *role.CreateDate
+			}
+			if c.ProtectTag.Matches(role.Tags) {
+				resource.Protected = true
+			}
+		}
+		resource.remove = func() error {
+			for _, role := range roles {
+				err := c.AWSClient.DeleteOperatorRole(role.RoleName, role.ManagedPolicy)
+				if err != nil {
+					return fmt.Errorf("Failed to delete operator role '%s': %v", role.RoleName, err)
+				}
+			}
+			return nil
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// OidcProviders returns a resource per OIDC provider created by ROSA.
+func (c *Collector) OidcProviders() ([]*Resource, error) {
+	providers, err := c.AWSClient.ListOidcProviders("", nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list OIDC providers: %v", err)
+	}
+	var resources []*Resource
+	for _, provider := range providers {
+		providerArn := provider.Arn
+		resourceId, err := aws.GetResourceIdFromOidcProviderARN(providerArn)
+		if err != nil {
+			return nil, err
+		}
+		issuerUrl := fmt.Sprintf("https://%s", resourceId)
+		inUse, err := c.OCMClient.HasAClusterUsingOidcProvider(issuerUrl, c.Creator.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to check clusters using OIDC provider '%s': %v", providerArn, err)
+		}
+		output, err := c.AWSClient.GetOpenIDConnectProvider(providerArn)
+		if err != nil {
+			return nil, err
+		}
+		resource := &Resource{
+			Kind:    KindOidcProvider,
+			Name:    providerArn,
+			Details: issuerUrl,
+			InUse:   inUse,
+		}
+		if output.CreateDate != nil {
+			resource.CreatedAt = *output.CreateDate
+		}
+		resource.Protected = c.ProtectTag.Matches(output.Tags)
+		resource.remove = func() error {
+			return c.AWSClient.DeleteOpenIDConnectProvider(providerArn)
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// OidcConfigs returns a resource per unmanaged OIDC configuration registered for the account.
+// An OIDC configuration is protected if the OIDC provider of its issuer is.
+func (c *Collector) OidcConfigs(providers []*Resource) ([]*Resource, error) {
+	configs, err := c.OCMClient.ListOidcConfigs(c.Creator.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list OIDC configurations: %v", err)
+	}
+	protectedIssuers := map[string]bool{}
+	for _, provider := range providers {
+		if provider.Protected {
+			protectedIssuers[provider.Details] = true
+		}
+	}
+	var resources []*Resource
+	for _, config := range configs {
+		if config.Managed() {
+			continue
+		}
+		issuerUrl := strings.TrimSuffix(config.IssuerUrl(), "/")
+		inUse, err := c.OCMClient.HasAClusterUsingOidcEndpointUrl(config.IssuerUrl())
+		if err != nil {
+			return nil, fmt.Errorf("Failed to check clusters using OIDC configuration '%s': %v", config.ID(), err)
+		}
+		resource := &Resource{
+			Kind:      KindOidcConfig,
+			Name:      config.ID(),
+			Details:   issuerUrl,
+			CreatedAt: config.CreationTimestamp(),
+			InUse:     inUse,
+			Protected: protectedIssuers[issuerUrl],
+		}
+		secretArn := config.SecretArn()
+		parsedSecretArn, err := arn.Parse(secretArn)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse secret ARN '%s' of OIDC configuration '%s': %v",
+				secretArn, config.ID(), err)
+		}
+		if parsedSecretArn.Region != c.Region {
+			resource.Region = parsedSecretArn.Region
+		}
+		secretName, err := aws.GetResourceIdFromSecretArn(secretArn)
+		if err != nil {
+			return nil, err
+		}
+		backend := oidcconfig.BackendFromIssuerUrl(issuerUrl)
+		bucketName := oidcconfig.BucketNameFromSecretName(secretName)
+		configID := config.ID()
+		// The registration is deleted first, so that a failure leaves the AWS resources in place
+		// instead of a registered configuration without documents.
+		resource.remove = func() error {
+			err := c.OCMClient.DeleteOidcConfig(configID)
+			if err != nil {
+				return fmt.Errorf("Failed to delete OIDC configuration '%s': %v", configID, err)
+			}
+			err = c.AWSClient.DeleteSecretInSecretsManager(secretArn)
+			if err != nil {
+				return fmt.Errorf("Failed to delete secret '%s': %v", secretArn, err)
+			}
+			// The documents of raw files configurations are served by the user's own web host.
+			if backend == oidcconfig.BackendRawFiles {
+				return nil
+			}
+			err = c.AWSClient.DeleteS3Bucket(bucketName)
+			if err != nil {
+				return fmt.Errorf("Failed to delete S3 bucket '%s': %v", bucketName, err)
+			}
+			if backend == oidcconfig.BackendCloudFront {
+				stackName := oidcconfig.CloudFrontStackName(bucketName)
+				err = c.AWSClient.DeleteStack(stackName)
+				if err != nil {
+					return fmt.Errorf("Failed to delete CloudFormation stack '%s': %v", stackName, err)
+				}
+			}
+			return nil
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// AccountRoles returns a resource per account role.
+func (c *Collector) AccountRoles() ([]*Resource, error) {
+	accountRoles, err := c.AWSClient.ListAccountRoles("")
+	if err != nil {
+		// Listing account roles fails when there are none
+		if strings.Contains(err.Error(), "no account roles found") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to list account roles: %v", err)
+	}
+	var resources []*Resource
+	for _, accountRole := range accountRoles {
+		accountRole := accountRole
+		clusters, err := c.OCMClient.GetClustersUsingAccountRole(c.Creator, accountRole, clusterPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to check clusters using account role '%s': %v",
+				accountRole.RoleName, err)
+		}
+		role, err := c.AWSClient.GetRoleByName(accountRole.RoleName)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get account role '%s': %v", accountRole.RoleName, err)
+		}
+		resource := &Resource{
+			Kind:    KindAccountRole,
+			Name:    accountRole.RoleName,
+			Details: accountRole.RoleType,
+			InUse:   len(clusters) > 0,
+		}
+		if role.CreateDate != nil {
+			resource.CreatedAt = *role.CreateDate
+		}
+		resource.Protected = c.ProtectTag.Matches(role.Tags)
+		prefix := AccountRolePrefix(accountRole.RoleName)
+		for _, tag := range role.Tags {
+			if awssdk.ToString(tag.Key) == tags.RolePrefix && awssdk.ToString(tag.Value) != "" {
+				prefix = awssdk.ToString(tag.Value)
+			}
+		}
+		resource.remove = func() error {
+			return c.AWSClient.DeleteAccountRole(accountRole.RoleName, prefix, accountRole.ManagedPolicy)
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// AccountRolePrefix returns the prefix of an account role named '<prefix>-<name>-Role', for
+// roles that don't carry the role prefix tag.
+func AccountRolePrefix(roleName string) string {
+	var names []string
+	for _, role := range aws.HCPAccountRoles {
+		names = append(names, role.Name)
+	}
+	for _, role := range aws.AccountRoles {
+		names = append(names, role.Name)
+	}
+	// HCP roles are checked first, as their names end with the classic ones
+	sort.SliceStable(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j])
+	})
+	for _, name := range names {
+		suffix := fmt.Sprintf("-%s-Role", name)
+		if strings.HasSuffix(roleName, suffix) {
+			return strings.TrimSuffix(roleName, suffix)
+		}
+	}
+	return roleName
+}
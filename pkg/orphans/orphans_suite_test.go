@@ -0,0 +1,13 @@
+package orphans
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestOrphans(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Orphans suite")
+}
@@ -0,0 +1,303 @@
+package orphans
+
+import (
+	"fmt"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+type fakeClusterFinder struct {
+	usedPrefixes  map[string]bool
+	usedIssuers   map[string]bool
+	usedRoles     map[string]bool
+	oidcConfigs   []*cmv1.OidcConfig
+	deletedConfig string
+	deleteErr     error
+}
+
+func (f *fakeClusterFinder) HasAClusterUsingOperatorRolesPrefix(prefix string) (bool, error) {
+	return f.usedPrefixes[prefix], nil
+}
+
+func (f *fakeClusterFinder) HasAClusterUsingOidcProvider(issuerUrl string, _ string) (bool, error) {
+	return f.usedIssuers[issuerUrl], nil
+}
+
+func (f *fakeClusterFinder) HasAClusterUsingOidcEndpointUrl(issuerUrl string) (bool, error) {
+	return f.usedIssuers[issuerUrl], nil
+}
+
+func (f *fakeClusterFinder) GetClustersUsingAccountRole(_ *aws.Creator, role aws.Role,
+	_ int) ([]*cmv1.Cluster, error) {
+	if f.usedRoles[role.RoleName] {
+		cluster, err := cmv1.NewCluster().ID("cluster").Build()
+		return []*cmv1.Cluster{cluster}, err
+	}
+	return nil, nil
+}
+
+func (f *fakeClusterFinder) ListOidcConfigs(_ string) ([]*cmv1.OidcConfig, error) {
+	return f.oidcConfigs, nil
+}
+
+func (f *fakeClusterFinder) DeleteOidcConfig(id string) error {
+	if f.deleteErr != nil {
+		return f.deleteErr
+	}
+	f.deletedConfig = id
+	return nil
+}
+
+var _ = Describe("Orphans", func() {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	Context("Evaluate", func() {
+		It("marks unused old resources as orphaned", func() {
+			resource := &Resource{CreatedAt: now.Add(-48 * time.Hour)}
+			resource.Evaluate(24*time.Hour, now)
+			Expect(resource.Status).To(Equal(StatusOrphaned))
+		})
+		It("keeps resources used by a cluster", func() {
+			resource := &Resource{InUse: true, Protected: true, CreatedAt: now.Add(-48 * time.Hour)}
+			resource.Evaluate(24*time.Hour, now)
+			Expect(resource.Status).To(Equal(StatusInUse))
+		})
+		It("keeps protected resources", func() {
+			resource := &Resource{Protected: true, CreatedAt: now.Add(-48 * time.Hour)}
+			resource.Evaluate(24*time.Hour, now)
+			Expect(resource.Status).To(Equal(StatusProtected))
+		})
+		It("keeps resources younger than the threshold", func() {
+			resource := &Resource{CreatedAt: now.Add(-time.Hour)}
+			resource.Evaluate(24*time.Hour, now)
+			Expect(resource.Status).To(Equal(StatusRecent))
+		})
+		It("keeps resources that must be deleted from another region", func() {
+			resource := &Resource{Region: "us-west-2", CreatedAt: now.Add(-48 * time.Hour)}
+			resource.Evaluate(24*time.Hour, now)
+			Expect(resource.Status).To(Equal(StatusOtherRegion))
+		})
+	})
+
+	Context("ProtectTag", func() {
+		It("parses keys and values", func() {
+			tag, err := ParseProtectTag("keep=yes")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tag).To(Equal(ProtectTag{Key: "keep", Value: "yes"}))
+			tag, err = ParseProtectTag("keep")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tag).To(Equal(ProtectTag{Key: "keep"}))
+			_, err = ParseProtectTag("=yes")
+			Expect(err).To(HaveOccurred())
+		})
+		It("matches tags", func() {
+			resourceTags := []iamtypes.Tag{{Key: awssdk.String("keep"), Value: awssdk.String("yes")}}
+			Expect(ProtectTag{Key: "keep"}.Matches(resourceTags)).To(BeTrue())
+			Expect(ProtectTag{Key: "keep", Value: "yes"}.Matches(resourceTags)).To(BeTrue())
+			Expect(ProtectTag{Key: "keep", Value: "no"}.Matches(resourceTags)).To(BeFalse())
+			Expect(ProtectTag{Key: "other"}.Matches(resourceTags)).To(BeFalse())
+		})
+	})
+
+	Context("AccountRolePrefix", func() {
+		It("trims the account role names", func() {
+			Expect(AccountRolePrefix("my-Installer-Role")).To(Equal("my"))
+			Expect(AccountRolePrefix("my-HCP-ROSA-Worker-Role")).To(Equal("my"))
+			Expect(AccountRolePrefix("other")).To(Equal("other"))
+		})
+	})
+
+	Context("Collector", func() {
+		var (
+			mockCtrl   *gomock.Controller
+			mockClient *aws.MockClient
+			finder     *fakeClusterFinder
+			collector  *Collector
+			createDate = now.Add(-72 * time.Hour)
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockClient = aws.NewMockClient(mockCtrl)
+			finder = &fakeClusterFinder{}
+			collector = &Collector{
+				AWSClient:  mockClient,
+				OCMClient:  finder,
+				Creator:    &aws.Creator{AccountID: "123456789012"},
+				Region:     "us-east-1",
+				ProtectTag: ProtectTag{Key: DefaultProtectTag},
+			}
+		})
+
+		It("groups operator roles by prefix and deletes them", func() {
+			mockClient.EXPECT().ListOperatorRoles("", "", "").Return(map[string][]aws.OperatorRoleDetail{
+				"used": {{RoleName: "Used-openshift-ingress", OperatorNamespace: "openshift-ingress-operator"}},
+				"old": {
+					{RoleName: "Old-openshift-ingress", OperatorNamespace: "openshift-ingress-operator"},
+					{RoleName: "Old-kube-system-capa", OperatorNamespace: "kube-system", ManagedPolicy: true},
+				},
+				"other": {{RoleName: "other-openshift-role"}},
+			}, nil)
+			finder.usedPrefixes = map[string]bool{"Used": true}
+			mockClient.EXPECT().GetRoleByName(gomock.Any()).Return(iamtypes.Role{CreateDate: &createDate}, nil).
+				Times(3)
+
+			resources, err := collector.OperatorRoles()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(HaveLen(2))
+			Expect(resources[0].Name).To(Equal("Old"))
+			Expect(resources[0].InUse).To(BeFalse())
+			Expect(resources[0].CreatedAt).To(Equal(createDate))
+			Expect(resources[1].Name).To(Equal("Used"))
+			Expect(resources[1].InUse).To(BeTrue())
+
+			mockClient.EXPECT().DeleteOperatorRole("Old-openshift-ingress", false).Return(nil)
+			mockClient.EXPECT().DeleteOperatorRole("Old-kube-system-capa", true).Return(nil)
+			Expect(resources[0].Delete()).To(Succeed())
+		})
+
+		It("protects OIDC configurations whose provider is protected", func() {
+			providerArn := "arn:aws:iam::123456789012:oidc-provider/bucket.s3.us-east-1.amazonaws.com"
+			issuerUrl := "https://bucket.s3.us-east-1.amazonaws.com"
+			mockClient.EXPECT().ListOidcProviders("", nil).Return([]aws.OidcProviderOutput{{Arn: providerArn}}, nil)
+			mockClient.EXPECT().GetOpenIDConnectProvider(providerArn).Return(&iam.GetOpenIDConnectProviderOutput{
+				CreateDate: &createDate,
+				Tags:       []iamtypes.Tag{{Key: awssdk.String(DefaultProtectTag), Value: awssdk.String("")}},
+			}, nil)
+			config, err := cmv1.NewOidcConfig().ID("config").IssuerUrl(issuerUrl).Managed(false).
+				SecretArn("arn:aws:secretsmanager:us-east-1:123456789012:secret:rosa-private-key-bucket-abc").
+				CreationTimestamp(createDate).Build()
+			Expect(err).NotTo(HaveOccurred())
+			finder.oidcConfigs = []*cmv1.OidcConfig{config}
+
+			providers, err := collector.OidcProviders()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providers).To(HaveLen(1))
+			Expect(providers[0].Protected).To(BeTrue())
+			configs, err := collector.OidcConfigs(providers)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configs).To(HaveLen(1))
+			Expect(configs[0].Protected).To(BeTrue())
+			Expect(configs[0].Region).To(BeEmpty())
+		})
+
+		It("deletes the documents of unmanaged OIDC configurations", func() {
+			config, err := cmv1.NewOidcConfig().ID("config").
+				IssuerUrl("https://d111111abcdef8.cloudfront.net").Managed(false).
+				SecretArn("arn:aws:secretsmanager:us-west-2:123456789012:secret:rosa-private-key-bucket-abc").
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+			finder.oidcConfigs = []*cmv1.OidcConfig{config}
+
+			configs, err := collector.OidcConfigs(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configs).To(HaveLen(1))
+			Expect(configs[0].Region).To(Equal("us-west-2"))
+
+			mockClient.EXPECT().DeleteSecretInSecretsManager(config.SecretArn()).Return(nil)
+			mockClient.EXPECT().DeleteS3Bucket("bucket").Return(nil)
+			mockClient.EXPECT().DeleteStack("bucket-cloudfront").Return(nil)
+			Expect(configs[0].Delete()).To(Succeed())
+			Expect(finder.deletedConfig).To(Equal("config"))
+		})
+
+		It("only deletes the secret of raw files OIDC configurations", func() {
+			config, err := cmv1.NewOidcConfig().ID("config").
+				IssuerUrl("https://oidc.example.com").Managed(false).
+				SecretArn("arn:aws:secretsmanager:us-east-1:123456789012:secret:rosa-private-key-bucket-abc").
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+			finder.oidcConfigs = []*cmv1.OidcConfig{config}
+
+			configs, err := collector.OidcConfigs(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configs).To(HaveLen(1))
+
+			mockClient.EXPECT().DeleteSecretInSecretsManager(config.SecretArn()).Return(nil)
+			Expect(configs[0].Delete()).To(Succeed())
+			Expect(finder.deletedConfig).To(Equal("config"))
+		})
+
+		It("keeps the AWS resources when the OIDC configuration cannot be unregistered", func() {
+			config, err := cmv1.NewOidcConfig().ID("config").
+				IssuerUrl("https://bucket.s3.us-east-1.amazonaws.com").Managed(false).
+				SecretArn("arn:aws:secretsmanager:us-east-1:123456789012:secret:rosa-private-key-bucket-abc").
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+			finder.oidcConfigs = []*cmv1.OidcConfig{config}
+			finder.deleteErr = fmt.Errorf("forbidden")
+
+			configs, err := collector.OidcConfigs(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configs).To(HaveLen(1))
+			err = configs[0].Delete()
+			Expect(err).To(MatchError("Failed to delete OIDC configuration 'config': forbidden"))
+		})
+
+		It("uses the prefix tag to delete account roles", func() {
+			mockClient.EXPECT().ListAccountRoles("").Return([]aws.Role{
+				{RoleName: "custom-Installer-Role", RoleType: aws.InstallerAccountRoleType},
+				{RoleName: "used-Support-Role", RoleType: aws.SupportAccountRoleType},
+			}, nil)
+			finder.usedRoles = map[string]bool{"used-Support-Role": true}
+			mockClient.EXPECT().GetRoleByName("custom-Installer-Role").Return(iamtypes.Role{
+				CreateDate: &createDate,
+				Tags:       []iamtypes.Tag{{Key: awssdk.String("rosa_role_prefix"), Value: awssdk.String("real")}},
+			}, nil)
+			mockClient.EXPECT().GetRoleByName("used-Support-Role").Return(iamtypes.Role{}, nil)
+
+			resources, err := collector.AccountRoles()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(HaveLen(2))
+			Expect(resources[0].InUse).To(BeFalse())
+			Expect(resources[1].InUse).To(BeTrue())
+
+			mockClient.EXPECT().DeleteAccountRole("custom-Installer-Role", "real", false).Return(nil)
+			Expect(resources[0].Delete()).To(Succeed())
+		})
+
+		It("ignores accounts without account roles", func() {
+			mockClient.EXPECT().ListAccountRoles("").Return(nil, fmt.Errorf("no account roles found"))
+			resources, err := collector.AccountRoles()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(BeEmpty())
+		})
+
+		It("only collects IAM resources when they are included", func() {
+			providerArn := "arn:aws:iam::123456789012:oidc-provider/bucket.s3.us-east-1.amazonaws.com"
+			mockClient.EXPECT().ListOidcProviders("", nil).Return([]aws.OidcProviderOutput{{Arn: providerArn}}, nil).
+				Times(2)
+			mockClient.EXPECT().GetOpenIDConnectProvider(providerArn).Return(&iam.GetOpenIDConnectProviderOutput{
+				CreateDate: &createDate,
+			}, nil).Times(2)
+			resources, err := collector.Collect()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(BeEmpty())
+
+			collector.IncludeIAM = true
+			mockClient.EXPECT().ListOperatorRoles("", "", "").Return(map[string][]aws.OperatorRoleDetail{
+				"old": {{RoleName: "old-openshift-ingress", OperatorNamespace: "openshift-ingress-operator"}},
+			}, nil)
+			mockClient.EXPECT().GetRoleByName("old-openshift-ingress").Return(iamtypes.Role{}, nil)
+			mockClient.EXPECT().ListAccountRoles("").Return([]aws.Role{
+				{RoleName: "old-Installer-Role", RoleType: aws.InstallerAccountRoleType},
+			}, nil)
+			mockClient.EXPECT().GetRoleByName("old-Installer-Role").Return(iamtypes.Role{}, nil)
+			resources, err = collector.Collect()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(HaveLen(3))
+			Expect(resources[0].Kind).To(Equal(KindOperatorRoles))
+			Expect(resources[1].Kind).To(Equal(KindOidcProvider))
+			Expect(resources[2].Kind).To(Equal(KindAccountRole))
+		})
+	})
+})
@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quotaplan
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+// Check returns the requirements of the cluster along with the current usage and the service quotas of
+// the region
+func Check(awsClient aws.Client, shape *Shape) ([]Requirement, error) {
+	err := shape.Validate()
+	if err != nil {
+		return nil, err
+	}
+	vcpus, err := awsClient.GetInstanceTypeVCPUs(shape.InstanceTypes())
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get the vCPUs of the instance types: %v", err)
+	}
+	requirements, err := Requirements(shape, vcpus)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := awsClient.GetQuotaUsage()
+	if err != nil {
+		return nil, err
+	}
+	usages := map[string]float64{
+		aws.QuotaCodeStandardVCPUs:     float64(usage.StandardVCPUs),
+		aws.QuotaCodeElasticIPs:        float64(usage.ElasticIPs),
+		aws.QuotaCodeGp3Storage:        gibToTiB(usage.Gp3StorageGiB),
+		aws.QuotaCodeNetworkInterfaces: float64(usage.NetworkInterfaces),
+	}
+	for i := range requirements {
+		requirements[i].Usage = usages[requirements[i].QuotaCode]
+	}
+	err = compareQuotas(awsClient, requirements)
+	if err != nil {
+		return nil, err
+	}
+	return requirements, nil
+}
+
+// CheckStatic returns the static minimums of the service quotas along with the service quotas of the
+// region. Unlike Check it neither needs a cluster shape nor the permissions to count the usage.
+func CheckStatic(awsClient aws.Client) ([]Requirement, error) {
+	requirements := StaticRequirements()
+	err := compareQuotas(awsClient, requirements)
+	if err != nil {
+		return nil, err
+	}
+	return requirements, nil
+}
+
+func compareQuotas(awsClient aws.Client, requirements []Requirement) error {
+	var err error
+	for i := range requirements {
+		requirement := &requirements[i]
+		requirement.Quota, err = awsClient.GetServiceQuotaValue(requirement.ServiceCode, requirement.QuotaCode)
+		if err != nil {
+			return fmt.Errorf("Error getting AWS service quota: %s %s %v", requirement.ServiceCode,
+				requirement.QuotaCode, err)
+		}
+		requirement.Missing = math.Max(0, requirement.Usage+requirement.Required-requirement.Quota)
+	}
+	return nil
+}
+
+// Insufficient returns the requirements the service quotas are too low for
+func Insufficient(requirements []Requirement) []Requirement {
+	result := []Requirement{}
+	for _, requirement := range requirements {
+		if requirement.Missing > 0 {
+			result = append(result, requirement)
+		}
+	}
+	return result
+}
+
+// RequestIncrease requests the service quota to be raised for the current usage and the cluster to fit,
+// and returns the identifier of the request
+func RequestIncrease(awsClient aws.Client, requirement Requirement) (string, error) {
+	desiredValue := math.Ceil(requirement.Usage + requirement.Required)
+	id, err := awsClient.RequestServiceQuotaIncrease(requirement.ServiceCode, requirement.QuotaCode, desiredValue)
+	if err != nil {
+		return "", fmt.Errorf("Failed to request an increase of service %s quota code %s to %g: %v",
+			requirement.ServiceCode, requirement.QuotaCode, desiredValue, err)
+	}
+	return id, nil
+}
@@ -0,0 +1,97 @@
+package quotaplan
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("Check", func() {
+	var (
+		ctrl      *gomock.Controller
+		awsClient *aws.MockClient
+		shape     *Shape
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		awsClient = aws.NewMockClient(ctrl)
+		shape = &Shape{
+			HostedCP:            true,
+			ComputeInstanceType: "m5.xlarge",
+			ComputeNodes:        2,
+			ComputeVolumeSize:   300,
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("compares the requirements with the usage and the quotas", func() {
+		awsClient.EXPECT().GetInstanceTypeVCPUs([]string{"m5.xlarge"}).Return(map[string]int{"m5.xlarge": 4}, nil)
+		awsClient.EXPECT().GetQuotaUsage().Return(&aws.QuotaUsage{
+			StandardVCPUs:     60,
+			ElasticIPs:        1,
+			Gp3StorageGiB:     2048,
+			NetworkInterfaces: 100,
+		}, nil)
+		awsClient.EXPECT().GetServiceQuotaValue("ec2", aws.QuotaCodeStandardVCPUs).Return(64.0, nil)
+		awsClient.EXPECT().GetServiceQuotaValue("ec2", aws.QuotaCodeElasticIPs).Return(5.0, nil)
+		awsClient.EXPECT().GetServiceQuotaValue("ebs", aws.QuotaCodeGp3Storage).Return(50.0, nil)
+		awsClient.EXPECT().GetServiceQuotaValue("vpc", aws.QuotaCodeNetworkInterfaces).Return(5000.0, nil)
+		awsClient.EXPECT().GetServiceQuotaValue("vpc", "L-F678F1CE").Return(3.0, nil)
+		awsClient.EXPECT().GetServiceQuotaValue(gomock.Any(), gomock.Any()).Return(300000.0, nil).Times(6)
+
+		requirements, err := Check(awsClient, shape)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requirements[0]).To(Equal(Requirement{
+			ServiceCode: "ec2",
+			QuotaCode:   aws.QuotaCodeStandardVCPUs,
+			QuotaName:   "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances",
+			Required:    8,
+			Usage:       60,
+			Quota:       64,
+			Missing:     4,
+		}))
+		Expect(requirements[2].Usage).To(Equal(2.0))
+		// The static requirements are compared with the quota only
+		Expect(requirements[4].Missing).To(Equal(2.0))
+		Expect(Insufficient(requirements)).To(Equal([]Requirement{requirements[0], requirements[4]}))
+	})
+
+	It("compares the static minimums with the quotas only", func() {
+		awsClient.EXPECT().GetServiceQuotaValue("ec2", aws.QuotaCodeStandardVCPUs).Return(64.0, nil)
+		awsClient.EXPECT().GetServiceQuotaValue(gomock.Any(), gomock.Any()).Return(300000.0, nil).Times(10)
+
+		requirements, err := CheckStatic(awsClient)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requirements).To(HaveLen(11))
+		Expect(Insufficient(requirements)).To(Equal([]Requirement{{
+			ServiceCode: "ec2",
+			QuotaCode:   aws.QuotaCodeStandardVCPUs,
+			QuotaName:   "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances",
+			Required:    100,
+			Quota:       64,
+			Missing:     36,
+			Static:      true,
+		}}))
+	})
+
+	It("requests an increase for the usage and the requirement", func() {
+		requirement := Requirement{
+			ServiceCode: "ebs",
+			QuotaCode:   aws.QuotaCodeGp3Storage,
+			Required:    0.59,
+			Usage:       49.8,
+			Quota:       50,
+		}
+		awsClient.EXPECT().RequestServiceQuotaIncrease("ebs", aws.QuotaCodeGp3Storage, 51.0).Return("req-1", nil)
+
+		id, err := RequestIncrease(awsClient, requirement)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(id).To(Equal("req-1"))
+	})
+})
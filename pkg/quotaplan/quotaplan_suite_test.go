@@ -0,0 +1,13 @@
+package quotaplan
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestQuotaPlan(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Quota plan suite")
+}
@@ -0,0 +1,319 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quotaplan
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+// Defaults of the cluster shape, matching the defaults of 'rosa create cluster'
+const (
+	DefaultComputeInstanceType = "m5.xlarge"
+	DefaultComputeNodes        = 2
+	DefaultComputeVolumeSize   = 300
+
+	bootstrapInstanceType    = "m5.xlarge"
+	bootstrapVolumeSize      = 120
+	controlPlaneNodes        = 3
+	controlPlaneVolumeSize   = 350
+	infraVolumeSize          = 300
+	classicLoadBalancers     = 3
+	hostedCPLoadBalancers    = 1
+	hostedCPEndpointsPerZone = 1
+)
+
+// Shape describes the cluster whose AWS resources are counted
+type Shape struct {
+	HostedCP bool
+	MultiAZ  bool
+	// ComputeInstanceType, ComputeNodes and MaxComputeNodes describe the compute nodes. MaxComputeNodes is
+	// the maximum of the autoscaler, zero when the nodes aren't autoscaled.
+	ComputeInstanceType string
+	ComputeNodes        int
+	MaxComputeNodes     int
+	// ComputeVolumeSize is the size of the root volume of the compute nodes in GiB
+	ComputeVolumeSize int
+}
+
+// NodeGroup is a set of nodes of the same instance type created in the account of the cluster
+type NodeGroup struct {
+	Role         string `json:"role"`
+	InstanceType string `json:"instanceType"`
+	Count        int    `json:"count"`
+	// VolumeSize is the size of the root volume of each node in GiB
+	VolumeSize int `json:"volumeSize"`
+}
+
+// Zones returns the number of availability zones of the cluster
+func (s *Shape) Zones() int {
+	if s.MultiAZ {
+		return 3
+	}
+	return 1
+}
+
+// NodeGroups returns the nodes created in the account of the cluster when it is at its largest. Classic
+// clusters have control plane and infra nodes along with the compute ones, sized on the number of
+// compute nodes. Their bootstrap node is removed once the cluster is installed, before the autoscaler
+// adds compute nodes, so it is only counted when the compute nodes aren't autoscaled above their
+// initial number.
+func (s *Shape) NodeGroups() []NodeGroup {
+	computeNodes := max(s.ComputeNodes, s.MaxComputeNodes)
+	groups := []NodeGroup{}
+	if !s.HostedCP {
+		infraNodes := 2
+		if s.MultiAZ {
+			infraNodes = 3
+		}
+		groups = append(groups,
+			NodeGroup{"control plane", controlPlaneInstanceType(computeNodes), controlPlaneNodes, controlPlaneVolumeSize},
+			NodeGroup{"infra", infraInstanceType(computeNodes), infraNodes, infraVolumeSize},
+		)
+		if computeNodes == s.ComputeNodes {
+			groups = append(groups, NodeGroup{"bootstrap", bootstrapInstanceType, 1, bootstrapVolumeSize})
+		}
+	}
+	groups = append(groups, NodeGroup{"compute", s.ComputeInstanceType, computeNodes, s.ComputeVolumeSize})
+	return groups
+}
+
+// InstanceTypes returns the instance types of the nodes, sorted
+func (s *Shape) InstanceTypes() []string {
+	seen := map[string]bool{}
+	instanceTypes := []string{}
+	for _, group := range s.NodeGroups() {
+		if !seen[group.InstanceType] {
+			seen[group.InstanceType] = true
+			instanceTypes = append(instanceTypes, group.InstanceType)
+		}
+	}
+	sort.Strings(instanceTypes)
+	return instanceTypes
+}
+
+// Validate checks that the shape describes a cluster that can be created
+func (s *Shape) Validate() error {
+	if s.ComputeInstanceType == "" {
+		return fmt.Errorf("Compute instance type is required")
+	}
+	if s.ComputeNodes < 0 || s.MaxComputeNodes < 0 {
+		return fmt.Errorf("The number of compute nodes can't be negative")
+	}
+	if s.MaxComputeNodes != 0 && s.MaxComputeNodes < s.ComputeNodes {
+		return fmt.Errorf("The maximum number of compute nodes %d is lower than the number of compute nodes %d",
+			s.MaxComputeNodes, s.ComputeNodes)
+	}
+	if s.ComputeVolumeSize <= 0 {
+		return fmt.Errorf("The size of the compute volumes should be positive")
+	}
+	return nil
+}
+
+// Requirement is the capacity a cluster needs from a service quota, in the unit of the quota
+type Requirement struct {
+	ServiceCode string  `json:"serviceCode"`
+	QuotaCode   string  `json:"quotaCode"`
+	QuotaName   string  `json:"quotaName"`
+	Required    float64 `json:"required"`
+	Usage       float64 `json:"usage"`
+	Quota       float64 `json:"quota"`
+	// Missing is how much the quota should be raised for the current usage and the cluster to fit
+	Missing float64 `json:"missing"`
+	// Static requirements are minimums of the quota that don't depend on the shape of the cluster, their
+	// usage isn't counted
+	Static bool `json:"static"`
+}
+
+// staticRequirements are the minimums of the service quotas of the resources that aren't derived from the
+// shape of the cluster
+var staticRequirements = []Requirement{
+	{
+		ServiceCode: "vpc",
+		QuotaCode:   "L-F678F1CE",
+		QuotaName:   "VPCs per Region",
+		Required:    5,
+	},
+	{
+		ServiceCode: "vpc",
+		QuotaCode:   "L-A4707A72",
+		QuotaName:   "Internet gateways per Region",
+		Required:    5,
+	},
+	{
+		ServiceCode: "ebs",
+		QuotaCode:   "L-309BACF6",
+		QuotaName:   "Number of EBS snapshots",
+		Required:    300,
+	},
+	{
+		ServiceCode: "ebs",
+		QuotaCode:   "L-B3A130E6",
+		QuotaName:   "Provisioned IOPS",
+		Required:    300000,
+	},
+	{
+		ServiceCode: "ebs",
+		QuotaCode:   "L-FD252861",
+		QuotaName:   "Provisioned IOPS SSD (io1) volume storage",
+		Required:    50,
+	},
+	{
+		ServiceCode: "elasticloadbalancing",
+		QuotaCode:   "L-53DA6B97",
+		QuotaName:   "Application Load Balancers per Region",
+		Required:    50,
+	},
+	{
+		ServiceCode: "elasticloadbalancing",
+		QuotaCode:   "L-E9E9831D",
+		QuotaName:   "Classic Load Balancers per Region",
+		Required:    20,
+	},
+}
+
+// staticComputeRequirements are the minimums of the service quotas derived from the shape of the cluster,
+// used when the quotas are verified without a shape and without the usage of the region
+var staticComputeRequirements = []Requirement{
+	{
+		ServiceCode: "ec2",
+		QuotaCode:   aws.QuotaCodeStandardVCPUs,
+		QuotaName:   "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances",
+		Required:    100,
+	},
+	{
+		ServiceCode: "ec2",
+		QuotaCode:   aws.QuotaCodeElasticIPs,
+		QuotaName:   "EC2-VPC Elastic IPs",
+		Required:    5,
+	},
+	{
+		ServiceCode: "ebs",
+		QuotaCode:   aws.QuotaCodeGp3Storage,
+		QuotaName:   "Storage for General Purpose SSD (gp3) volumes, in TiB",
+		Required:    50,
+	},
+	{
+		ServiceCode: "vpc",
+		QuotaCode:   aws.QuotaCodeNetworkInterfaces,
+		QuotaName:   "Network interfaces per Region",
+		Required:    5000,
+	},
+}
+
+// StaticRequirements returns the static minimums of every service quota verified for a cluster
+func StaticRequirements() []Requirement {
+	var requirements []Requirement
+	for _, requirement := range append(staticComputeRequirements, staticRequirements...) {
+		requirement.Static = true
+		requirements = append(requirements, requirement)
+	}
+	return requirements
+}
+
+// Requirements returns the capacity the cluster needs from each service quota, given the number of vCPUs
+// of its instance types, followed by the static requirements. Instance types outside of the standard
+// families count against other quotas and are left out.
+func Requirements(shape *Shape, vcpus map[string]int) ([]Requirement, error) {
+	var standardVCPUs, storageGiB, nodes int
+	for _, group := range shape.NodeGroups() {
+		if aws.IsStandardInstanceType(group.InstanceType) {
+			count, ok := vcpus[group.InstanceType]
+			if !ok {
+				return nil, fmt.Errorf("Unknown number of vCPUs for instance type '%s'", group.InstanceType)
+			}
+			standardVCPUs += count * group.Count
+		}
+		storageGiB += group.VolumeSize * group.Count
+		nodes += group.Count
+	}
+
+	zones := shape.Zones()
+	// Each node has a network interface, as have the load balancers in each zone. Hosted clusters also
+	// reach their control plane through a VPC endpoint in each zone.
+	networkInterfaces := nodes + classicLoadBalancers*zones
+	if shape.HostedCP {
+		networkInterfaces = nodes + (hostedCPLoadBalancers+hostedCPEndpointsPerZone)*zones
+	}
+
+	requirements := []Requirement{
+		{
+			ServiceCode: "ec2",
+			QuotaCode:   aws.QuotaCodeStandardVCPUs,
+			QuotaName:   "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances",
+			Required:    float64(standardVCPUs),
+		},
+		{
+			// A NAT gateway with an Elastic IP in each zone
+			ServiceCode: "ec2",
+			QuotaCode:   aws.QuotaCodeElasticIPs,
+			QuotaName:   "EC2-VPC Elastic IPs",
+			Required:    float64(zones),
+		},
+		{
+			ServiceCode: "ebs",
+			QuotaCode:   aws.QuotaCodeGp3Storage,
+			QuotaName:   "Storage for General Purpose SSD (gp3) volumes, in TiB",
+			Required:    gibToTiB(storageGiB),
+		},
+		{
+			ServiceCode: "vpc",
+			QuotaCode:   aws.QuotaCodeNetworkInterfaces,
+			QuotaName:   "Network interfaces per Region",
+			Required:    float64(networkInterfaces),
+		},
+	}
+	for _, requirement := range staticRequirements {
+		requirement.Static = true
+		requirements = append(requirements, requirement)
+	}
+	return requirements, nil
+}
+
+// controlPlaneInstanceType is the instance type of the control plane nodes of classic clusters, which
+// grows with the number of compute nodes
+func controlPlaneInstanceType(computeNodes int) string {
+	switch {
+	case computeNodes <= 25:
+		return "m5.2xlarge"
+	case computeNodes <= 100:
+		return "m5.4xlarge"
+	default:
+		return "m5.8xlarge"
+	}
+}
+
+// infraInstanceType is the instance type of the infra nodes of classic clusters, which grows with the
+// number of compute nodes
+func infraInstanceType(computeNodes int) string {
+	switch {
+	case computeNodes <= 25:
+		return "r5.xlarge"
+	case computeNodes <= 100:
+		return "r5.2xlarge"
+	default:
+		return "r5.4xlarge"
+	}
+}
+
+// gibToTiB converts the size to TiB, rounded up to two decimals
+func gibToTiB(gib int) float64 {
+	return math.Ceil(float64(gib)/1024*100) / 100
+}
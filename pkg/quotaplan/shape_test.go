@@ -0,0 +1,107 @@
+package quotaplan
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("Shape", func() {
+	vcpus := map[string]int{
+		"m5.xlarge":  4,
+		"m5.2xlarge": 8,
+		"m5.4xlarge": 16,
+		"r5.xlarge":  4,
+		"r5.2xlarge": 8,
+	}
+
+	It("counts the control plane, infra and bootstrap nodes of classic clusters", func() {
+		shape := &Shape{
+			ComputeInstanceType: "m5.xlarge",
+			ComputeNodes:        2,
+			ComputeVolumeSize:   300,
+		}
+		Expect(shape.NodeGroups()).To(Equal([]NodeGroup{
+			{"control plane", "m5.2xlarge", 3, 350},
+			{"infra", "r5.xlarge", 2, 300},
+			{"bootstrap", "m5.xlarge", 1, 120},
+			{"compute", "m5.xlarge", 2, 300},
+		}))
+		Expect(shape.InstanceTypes()).To(Equal([]string{"m5.2xlarge", "m5.xlarge", "r5.xlarge"}))
+
+		requirements, err := Requirements(shape, vcpus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requirements).To(HaveLen(11))
+		// 3x8 + 2x4 + 4 + 2x4
+		Expect(requirements[0].QuotaCode).To(Equal(aws.QuotaCodeStandardVCPUs))
+		Expect(requirements[0].Required).To(Equal(44.0))
+		Expect(requirements[1].Required).To(Equal(1.0))
+		// 1050 + 600 + 120 + 600 GiB
+		Expect(requirements[2].Required).To(Equal(2.32))
+		// 8 nodes and 3 load balancers
+		Expect(requirements[3].Required).To(Equal(11.0))
+		Expect(requirements[3].Static).To(BeFalse())
+		Expect(requirements[4]).To(Equal(Requirement{
+			ServiceCode: "vpc",
+			QuotaCode:   "L-F678F1CE",
+			QuotaName:   "VPCs per Region",
+			Required:    5,
+			Static:      true,
+		}))
+	})
+
+	It("sizes the control plane on the maximum number of compute nodes without the bootstrap node", func() {
+		shape := &Shape{
+			MultiAZ:             true,
+			ComputeInstanceType: "m5.xlarge",
+			ComputeNodes:        3,
+			MaxComputeNodes:     60,
+			ComputeVolumeSize:   300,
+		}
+		Expect(shape.NodeGroups()).To(Equal([]NodeGroup{
+			{"control plane", "m5.4xlarge", 3, 350},
+			{"infra", "r5.2xlarge", 3, 300},
+			{"compute", "m5.xlarge", 60, 300},
+		}))
+	})
+
+	It("only counts the compute nodes of hosted clusters", func() {
+		shape := &Shape{
+			HostedCP:            true,
+			MultiAZ:             true,
+			ComputeInstanceType: "m5.xlarge",
+			ComputeNodes:        3,
+			ComputeVolumeSize:   300,
+		}
+		requirements, err := Requirements(shape, vcpus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requirements[0].Required).To(Equal(12.0))
+		Expect(requirements[1].Required).To(Equal(3.0))
+		// 3 nodes, and a load balancer and a VPC endpoint in each zone
+		Expect(requirements[3].Required).To(Equal(9.0))
+	})
+
+	It("leaves out the instance types that aren't in the standard families", func() {
+		shape := &Shape{
+			HostedCP:            true,
+			ComputeInstanceType: "g4dn.xlarge",
+			ComputeNodes:        2,
+			ComputeVolumeSize:   300,
+		}
+		requirements, err := Requirements(shape, map[string]int{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requirements[0].Required).To(Equal(0.0))
+	})
+
+	It("fails when the maximum number of nodes is lower than the number of nodes", func() {
+		shape := &Shape{
+			ComputeInstanceType: "m5.xlarge",
+			ComputeNodes:        4,
+			MaxComputeNodes:     3,
+			ComputeVolumeSize:   300,
+		}
+		Expect(shape.Validate()).To(MatchError(
+			"The maximum number of compute nodes 3 is lower than the number of compute nodes 4"))
+	})
+})
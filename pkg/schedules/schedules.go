@@ -0,0 +1,324 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schedules stores scaling schedules for machine pools in a local file and decides which
+// of them are due. Schedules are evaluated by 'rosa schedules run-due', which is meant to be run
+// periodically, for example from cron or a CI pipeline.
+package schedules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/openshift/rosa/pkg/constants"
+	"github.com/openshift/rosa/pkg/machinepool"
+)
+
+const fileName = "rosa-schedules.json"
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// Schedule sets the size of a machine pool at every occurrence of a cron expression. Either
+// Replicas, or MinReplicas and MaxReplicas for autoscaling machine pools, are set.
+type Schedule struct {
+	Name        string     `json:"name"`
+	ClusterID   string     `json:"cluster_id"`
+	ClusterName string     `json:"cluster_name"`
+	MachinePool string     `json:"machine_pool"`
+	Cron        string     `json:"cron"`
+	Replicas    *int       `json:"replicas,omitempty"`
+	MinReplicas *int       `json:"min_replicas,omitempty"`
+	MaxReplicas *int       `json:"max_replicas,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastRun     *time.Time `json:"last_run,omitempty"`
+}
+
+// ValidateCron checks that the expression is a standard five fields cron expression.
+func ValidateCron(expression string) error {
+	_, err := parseCron(expression)
+	return err
+}
+
+func parseCron(expression string) (cron.Schedule, error) {
+	schedule, err := cronParser.Parse(fmt.Sprintf("CRON_TZ=UTC %s", expression))
+	if err != nil {
+		return nil, fmt.Errorf("Schedule '%s' is not a valid cron expression: %v", expression, err)
+	}
+	return schedule, nil
+}
+
+// Validate checks that the schedule has a valid cron expression and a consistent size.
+func (s *Schedule) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("Schedule name is required")
+	}
+	if s.MachinePool == "" {
+		return fmt.Errorf("Machine pool is required")
+	}
+	if err := ValidateCron(s.Cron); err != nil {
+		return err
+	}
+	isAutoscaling := s.MinReplicas != nil || s.MaxReplicas != nil
+	switch {
+	case s.Replicas != nil && isAutoscaling:
+		return fmt.Errorf("Replicas are mutually exclusive with min and max replicas")
+	case s.Replicas == nil && !isAutoscaling:
+		return fmt.Errorf("Either replicas, or min and max replicas, are required")
+	case s.Replicas != nil && *s.Replicas < 0:
+		return fmt.Errorf("Replicas must be a non-negative integer")
+	case isAutoscaling && (s.MinReplicas == nil || s.MaxReplicas == nil):
+		return fmt.Errorf("Both min and max replicas are required to set autoscaling bounds")
+	case isAutoscaling && *s.MinReplicas > *s.MaxReplicas:
+		return fmt.Errorf("Min replicas must be lower than or equal to max replicas")
+	}
+	return nil
+}
+
+// Scaling returns the machine pool scaling settings applied by the schedule.
+func (s *Schedule) Scaling() machinepool.BulkScaling {
+	if s.Replicas != nil {
+		return machinepool.BulkScaling{
+			AutoscalingSet: true,
+			Autoscaling:    false,
+			ReplicasSet:    true,
+			Replicas:       *s.Replicas,
+		}
+	}
+	return machinepool.BulkScaling{
+		AutoscalingSet: true,
+		Autoscaling:    true,
+		MinReplicasSet: true,
+		MinReplicas:    *s.MinReplicas,
+		MaxReplicasSet: true,
+		MaxReplicas:    *s.MaxReplicas,
+	}
+}
+
+// Size describes the size the schedule sets the machine pool to.
+func (s *Schedule) Size() string {
+	if s.Replicas != nil {
+		return fmt.Sprintf("%d replicas", *s.Replicas)
+	}
+	if s.MinReplicas != nil && s.MaxReplicas != nil {
+		return fmt.Sprintf("autoscaling %d-%d", *s.MinReplicas, *s.MaxReplicas)
+	}
+	return ""
+}
+
+// Next returns the next occurrence of the schedule after the given time.
+func (s *Schedule) Next(after time.Time) (time.Time, error) {
+	schedule, err := parseCron(s.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(after), nil
+}
+
+// Due returns true and the latest missed occurrence if the schedule has an occurrence between
+// its last run, or its creation when it never ran, and now.
+func (s *Schedule) Due(now time.Time) (bool, time.Time, error) {
+	schedule, err := parseCron(s.Cron)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	from := s.CreatedAt
+	if s.LastRun != nil {
+		from = *s.LastRun
+	}
+	occursBy := func(after time.Time) bool {
+		next := schedule.Next(after)
+		return !next.IsZero() && !next.After(now)
+	}
+	if !occursBy(from) {
+		return false, time.Time{}, nil
+	}
+	// The latest occurrence is found by bisecting the time whose next occurrence is the last one
+	// before now, instead of walking through every occurrence since the last run. Occurrences are
+	// at least a minute apart, so a window shorter than a minute holds at most one of them.
+	low, high := from, now
+	for high.Sub(low) >= time.Minute {
+		middle := low.Add(high.Sub(low) / 2)
+		if occursBy(middle) {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+	return true, schedule.Next(low), nil
+}
+
+// Store is the set of schedules saved in the local schedules file.
+type Store struct {
+	path      string
+	Schedules []*Schedule `json:"schedules"`
+}
+
+// Location returns the path of the schedules file. It can be overridden with the
+// ROSA_SCHEDULES_FILE environment variable, otherwise it sits next to the OCM configuration.
+func Location() (string, error) {
+	if path := os.Getenv(constants.RosaSchedulesFile); path != "" {
+		return path, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "ocm", fileName), nil
+}
+
+// Load reads the schedules file. A missing file results in an empty store.
+func Load() (*Store, error) {
+	path, err := Location()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFrom(path)
+}
+
+// LoadFrom reads the schedules from the given file. A missing file results in an empty store.
+func LoadFrom(path string) (*Store, error) {
+	store := &Store{path: path}
+	// #nosec G304
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read schedules file '%s': %v", path, err)
+	}
+	err = json.Unmarshal(data, store)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse schedules file '%s': %v", path, err)
+	}
+	return store, nil
+}
+
+// Save writes the schedules back to the file they were loaded from.
+func (s *Store) Save() error {
+	sort.Slice(s.Schedules, func(i, j int) bool {
+		return s.Schedules[i].Name < s.Schedules[j].Name
+	})
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed to marshal schedules: %v", err)
+	}
+	dir := filepath.Dir(s.path)
+	err = os.MkdirAll(dir, os.FileMode(0755))
+	if err != nil {
+		return fmt.Errorf("Failed to create directory %s: %v", dir, err)
+	}
+	err = os.WriteFile(s.path, data, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to write file '%s': %v", s.path, err)
+	}
+	return nil
+}
+
+// Find returns the schedule with the given name, or nil if there is none.
+func (s *Store) Find(name string) *Schedule {
+	for _, schedule := range s.Schedules {
+		if schedule.Name == name {
+			return schedule
+		}
+	}
+	return nil
+}
+
+// Add validates and adds a schedule. Schedule names must be unique.
+func (s *Store) Add(schedule *Schedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	if s.Find(schedule.Name) != nil {
+		return fmt.Errorf("A schedule named '%s' already exists", schedule.Name)
+	}
+	s.Schedules = append(s.Schedules, schedule)
+	return nil
+}
+
+// Remove deletes the schedule with the given name.
+func (s *Store) Remove(name string) error {
+	for i, schedule := range s.Schedules {
+		if schedule.Name == name {
+			s.Schedules = append(s.Schedules[:i], s.Schedules[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("There is no schedule named '%s'", name)
+}
+
+// ForCluster returns the schedules of the cluster with the given ID or name. An empty key
+// returns every schedule.
+func (s *Store) ForCluster(clusterKey string) []*Schedule {
+	var result []*Schedule
+	for _, schedule := range s.Schedules {
+		if clusterKey == "" || schedule.ClusterID == clusterKey || schedule.ClusterName == clusterKey {
+			result = append(result, schedule)
+		}
+	}
+	return result
+}
+
+// Occurrence is the latest missed occurrence of a due schedule.
+type Occurrence struct {
+	Schedule *Schedule
+	Time     time.Time
+	// SupersededBy is the schedule of the same machine pool with a more recent occurrence, which is
+	// applied instead of this one.
+	SupersededBy *Schedule
+	// Err is set when the cron expression of the schedule can't be evaluated.
+	Err error
+}
+
+// DueOccurrences returns the occurrences of the schedules that are due, in the order of the store.
+// When several schedules of the same machine pool are due only the one with the most recent
+// occurrence is applied, the others are superseded by it.
+func (s *Store) DueOccurrences(now time.Time) []*Occurrence {
+	var occurrences []*Occurrence
+	latest := map[string]*Occurrence{}
+	for _, schedule := range s.Schedules {
+		due, occurrenceTime, err := schedule.Due(now)
+		if err != nil {
+			occurrences = append(occurrences, &Occurrence{Schedule: schedule, Err: err})
+			continue
+		}
+		if !due {
+			continue
+		}
+		occurrence := &Occurrence{Schedule: schedule, Time: occurrenceTime}
+		occurrences = append(occurrences, occurrence)
+		key := schedule.ClusterID + "/" + schedule.MachinePool
+		if current, ok := latest[key]; !ok || occurrence.Time.After(current.Time) {
+			latest[key] = occurrence
+		}
+	}
+	for _, occurrence := range occurrences {
+		if occurrence.Err != nil {
+			continue
+		}
+		winner := latest[occurrence.Schedule.ClusterID+"/"+occurrence.Schedule.MachinePool]
+		if winner != occurrence {
+			occurrence.SupersededBy = winner.Schedule
+		}
+	}
+	return occurrences
+}
@@ -0,0 +1,13 @@
+package schedules
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSchedules(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Schedules suite")
+}
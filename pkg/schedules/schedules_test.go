@@ -0,0 +1,207 @@
+package schedules
+
+import (
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func intPtr(value int) *int {
+	return &value
+}
+
+var _ = Describe("Schedule", func() {
+	var schedule *Schedule
+
+	BeforeEach(func() {
+		schedule = &Schedule{
+			Name:        "gpu-night",
+			ClusterID:   "24vf9iitg3p6tlml88iml6j6mu095mh8",
+			ClusterName: "cluster",
+			MachinePool: "gpu",
+			Cron:        "0 19 * * 1-5",
+			Replicas:    intPtr(0),
+			CreatedAt:   time.Date(2024, 6, 3, 8, 0, 0, 0, time.UTC),
+		}
+	})
+
+	Context("Validate", func() {
+		It("OK: accepts replicas", func() {
+			Expect(schedule.Validate()).To(Succeed())
+			Expect(schedule.Size()).To(Equal("0 replicas"))
+		})
+
+		It("OK: accepts autoscaling bounds", func() {
+			schedule.Replicas = nil
+			schedule.MinReplicas = intPtr(1)
+			schedule.MaxReplicas = intPtr(3)
+			Expect(schedule.Validate()).To(Succeed())
+			Expect(schedule.Size()).To(Equal("autoscaling 1-3"))
+			scaling := schedule.Scaling()
+			Expect(scaling.Autoscaling).To(BeTrue())
+			Expect(scaling.MinReplicas).To(Equal(1))
+			Expect(scaling.MaxReplicas).To(Equal(3))
+		})
+
+		It("KO: fails with an invalid cron expression", func() {
+			schedule.Cron = "every night"
+			Expect(schedule.Validate()).To(MatchError(ContainSubstring("is not a valid cron expression")))
+		})
+
+		It("KO: fails with both replicas and autoscaling bounds", func() {
+			schedule.MinReplicas = intPtr(1)
+			schedule.MaxReplicas = intPtr(3)
+			Expect(schedule.Validate()).To(MatchError(ContainSubstring("mutually exclusive")))
+		})
+
+		It("KO: fails without a size", func() {
+			schedule.Replicas = nil
+			Expect(schedule.Validate()).To(MatchError(ContainSubstring("are required")))
+		})
+
+		It("KO: fails with a single autoscaling bound", func() {
+			schedule.Replicas = nil
+			schedule.MinReplicas = intPtr(1)
+			Expect(schedule.Validate()).To(MatchError(ContainSubstring("Both min and max replicas")))
+		})
+
+		It("KO: fails when min replicas are greater than max replicas", func() {
+			schedule.Replicas = nil
+			schedule.MinReplicas = intPtr(3)
+			schedule.MaxReplicas = intPtr(1)
+			Expect(schedule.Validate()).To(MatchError(ContainSubstring("lower than or equal")))
+		})
+	})
+
+	Context("Due", func() {
+		It("OK: is not due before the first occurrence", func() {
+			due, _, err := schedule.Due(time.Date(2024, 6, 3, 18, 59, 0, 0, time.UTC))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(due).To(BeFalse())
+		})
+
+		It("OK: is due after the first occurrence", func() {
+			due, occurrence, err := schedule.Due(time.Date(2024, 6, 3, 19, 5, 0, 0, time.UTC))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(due).To(BeTrue())
+			Expect(occurrence).To(Equal(time.Date(2024, 6, 3, 19, 0, 0, 0, time.UTC)))
+		})
+
+		It("OK: returns the latest missed occurrence", func() {
+			due, occurrence, err := schedule.Due(time.Date(2024, 6, 6, 8, 0, 0, 0, time.UTC))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(due).To(BeTrue())
+			Expect(occurrence).To(Equal(time.Date(2024, 6, 5, 19, 0, 0, 0, time.UTC)))
+		})
+
+		It("OK: returns the latest occurrence of a frequent schedule idle for a long time", func() {
+			schedule.Cron = "* * * * *"
+			due, occurrence, err := schedule.Due(time.Date(2024, 10, 1, 8, 30, 15, 0, time.UTC))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(due).To(BeTrue())
+			Expect(occurrence).To(Equal(time.Date(2024, 10, 1, 8, 30, 0, 0, time.UTC)))
+		})
+
+		It("OK: returns an occurrence right after the last run", func() {
+			lastRun := time.Date(2024, 6, 3, 19, 5, 0, 0, time.UTC)
+			schedule.LastRun = &lastRun
+			schedule.Cron = "*/10 * * * *"
+			due, occurrence, err := schedule.Due(time.Date(2024, 6, 3, 19, 10, 0, 0, time.UTC))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(due).To(BeTrue())
+			Expect(occurrence).To(Equal(time.Date(2024, 6, 3, 19, 10, 0, 0, time.UTC)))
+		})
+
+		It("OK: is not due again after running", func() {
+			lastRun := time.Date(2024, 6, 3, 19, 5, 0, 0, time.UTC)
+			schedule.LastRun = &lastRun
+			due, _, err := schedule.Due(time.Date(2024, 6, 4, 8, 0, 0, 0, time.UTC))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(due).To(BeFalse())
+		})
+	})
+
+	Context("DueOccurrences", func() {
+		It("OK: applies only the most recent occurrence of each machine pool", func() {
+			morning := *schedule
+			morning.Name = "gpu-morning"
+			morning.Cron = "0 7 * * 1-5"
+			morning.Replicas = intPtr(2)
+			other := *schedule
+			other.Name = "infra-night"
+			other.MachinePool = "infra"
+			broken := *schedule
+			broken.Name = "broken"
+			broken.Cron = "every night"
+			store := &Store{Schedules: []*Schedule{&broken, schedule, &morning, &other}}
+
+			// The night schedule last occurred on Wednesday evening, after the morning one
+			occurrences := store.DueOccurrences(time.Date(2024, 6, 6, 6, 0, 0, 0, time.UTC))
+			Expect(occurrences).To(HaveLen(4))
+			Expect(occurrences[0].Err).To(HaveOccurred())
+			Expect(occurrences[1].Schedule).To(Equal(schedule))
+			Expect(occurrences[1].SupersededBy).To(BeNil())
+			Expect(occurrences[2].Schedule).To(Equal(&morning))
+			Expect(occurrences[2].SupersededBy).To(Equal(schedule))
+			Expect(occurrences[3].SupersededBy).To(BeNil())
+
+			occurrences = store.DueOccurrences(time.Date(2024, 6, 6, 8, 0, 0, 0, time.UTC))
+			Expect(occurrences[1].SupersededBy).To(Equal(&morning))
+			Expect(occurrences[2].SupersededBy).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("Store", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "ocm", "rosa-schedules.json")
+	})
+
+	It("OK: loads an empty store when the file does not exist", func() {
+		store, err := LoadFrom(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(store.Schedules).To(BeEmpty())
+	})
+
+	It("OK: saves and loads schedules", func() {
+		store, err := LoadFrom(path)
+		Expect(err).ToNot(HaveOccurred())
+		for _, name := range []string{"b", "a"} {
+			err = store.Add(&Schedule{
+				Name:        name,
+				ClusterID:   "24vf9iitg3p6tlml88iml6j6mu095mh8",
+				ClusterName: "cluster",
+				MachinePool: "gpu",
+				Cron:        "0 7 * * *",
+				MinReplicas: intPtr(1),
+				MaxReplicas: intPtr(2),
+			})
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(store.Save()).To(Succeed())
+
+		loaded, err := LoadFrom(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(loaded.Schedules).To(HaveLen(2))
+		Expect(loaded.Schedules[0].Name).To(Equal("a"))
+		Expect(*loaded.Schedules[0].MaxReplicas).To(Equal(2))
+		Expect(loaded.ForCluster("cluster")).To(HaveLen(2))
+		Expect(loaded.ForCluster("other")).To(BeEmpty())
+
+		Expect(loaded.Remove("a")).To(Succeed())
+		Expect(loaded.Find("a")).To(BeNil())
+		Expect(loaded.Remove("a")).To(MatchError(ContainSubstring("There is no schedule named 'a'")))
+	})
+
+	It("KO: fails to add a schedule with a duplicate name", func() {
+		store, err := LoadFrom(path)
+		Expect(err).ToNot(HaveOccurred())
+		schedule := &Schedule{Name: "a", MachinePool: "gpu", Cron: "0 7 * * *", Replicas: intPtr(1)}
+		Expect(store.Add(schedule)).To(Succeed())
+		Expect(store.Add(schedule)).To(MatchError(ContainSubstring("already exists")))
+	})
+})
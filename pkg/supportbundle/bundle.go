@@ -0,0 +1,393 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supportbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/network"
+)
+
+// ManifestPath is the file of the bundle describing its content
+const ManifestPath = "manifest.json"
+
+// logTail is the number of lines of the install and uninstall logs added to the bundle
+const logTail = 10000
+
+// OCMClient is the part of the OCM client the documents of the bundle are read from
+type OCMClient interface {
+	GetInstallLogs(clusterID string, tail int) (*cmv1.Log, error)
+	GetUninstallLogs(clusterID string, tail int) (*cmv1.Log, error)
+	GetUpgradePolicies(clusterID string) ([]*cmv1.UpgradePolicy, error)
+	GetControlPlaneUpgradePolicies(clusterID string) ([]*cmv1.ControlPlaneUpgradePolicy, error)
+	GetLimitedSupportReasons(clusterID string) ([]*cmv1.LimitedSupportReason, error)
+	GetInflightChecks(clusterID string) ([]*cmv1.InflightCheck, error)
+	GetOidcConfig(id string) (*cmv1.OidcConfig, error)
+	GetVerifyNetworkSubnet(id string) (*cmv1.SubnetNetworkVerification, error)
+}
+
+// Document is a file of the bundle. Documents that can't be collected are listed with the error.
+type Document struct {
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	Size        int    `json:"size,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Manifest describes the bundle
+type Manifest struct {
+	ClusterID   string     `json:"clusterId"`
+	ClusterName string     `json:"clusterName"`
+	RosaVersion string     `json:"rosaVersion"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	Documents   []Document `json:"documents"`
+}
+
+// Bundle holds the redacted documents gathered for a support case
+type Bundle struct {
+	Manifest Manifest
+	files    map[string][]byte
+}
+
+// FileName returns the name of the tarball of the bundle of the cluster created at the given time
+func FileName(cluster *cmv1.Cluster, createdAt time.Time) string {
+	return fmt.Sprintf("rosa-support-bundle-%s-%s.tar.gz", cluster.Name(), createdAt.UTC().Format("20060102T150405Z"))
+}
+
+// Collect gathers the documents of the cluster. Secrets and tokens are redacted from all of them.
+func Collect(ocmClient OCMClient, awsClient aws.Client, cluster *cmv1.Cluster, rosaVersion string,
+	createdAt time.Time) *Bundle {
+	b := &Bundle{
+		Manifest: Manifest{
+			ClusterID:   cluster.ID(),
+			ClusterName: cluster.Name(),
+			RosaVersion: rosaVersion,
+			CreatedAt:   createdAt.UTC(),
+			Documents:   []Document{},
+		},
+		files: map[string][]byte{},
+	}
+	b.addCluster(cluster)
+	b.addLogs(ocmClient, cluster)
+	b.addUpgradePolicies(ocmClient, cluster)
+	b.addJSON("limited-support-reasons.json", "Limited support reasons", func() ([]byte, error) {
+		reasons, err := ocmClient.GetLimitedSupportReasons(cluster.ID())
+		return marshal(reasons, err, cmv1.MarshalLimitedSupportReasonList)
+	})
+	b.addJSON("inflight-checks.json", "Inflight checks", func() ([]byte, error) {
+		checks, err := ocmClient.GetInflightChecks(cluster.ID())
+		return marshal(checks, err, cmv1.MarshalInflightCheckList)
+	})
+	b.addRoles(awsClient, cluster)
+	b.addOidcConfig(ocmClient, awsClient, cluster)
+	b.addNetworkVerification(ocmClient, cluster)
+	return b
+}
+
+// Failed returns the documents that couldn't be collected
+func (b *Bundle) Failed() []Document {
+	failed := []Document{}
+	for _, document := range b.Manifest.Documents {
+		if document.Error != "" {
+			failed = append(failed, document)
+		}
+	}
+	return failed
+}
+
+// Write writes the bundle as a gzipped tarball, starting with the manifest
+func (b *Bundle) Write(writer io.Writer) error {
+	manifest, err := json.MarshalIndent(b.Manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed to marshal the manifest: %v", err)
+	}
+	gzipWriter := gzip.NewWriter(writer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	err = writeFile(tarWriter, ManifestPath, manifest, b.Manifest.CreatedAt)
+	if err != nil {
+		return err
+	}
+	for _, document := range b.Manifest.Documents {
+		if document.Error != "" {
+			continue
+		}
+		err = writeFile(tarWriter, document.Path, b.files[document.Path], b.Manifest.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	err = tarWriter.Close()
+	if err != nil {
+		return fmt.Errorf("Failed to write the bundle: %v", err)
+	}
+	err = gzipWriter.Close()
+	if err != nil {
+		return fmt.Errorf("Failed to write the bundle: %v", err)
+	}
+	return nil
+}
+
+func writeFile(tarWriter *tar.Writer, path string, content []byte, modTime time.Time) error {
+	err := tarWriter.WriteHeader(&tar.Header{
+		Name:    path,
+		Mode:    0600,
+		Size:    int64(len(content)),
+		ModTime: modTime,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to write '%s' to the bundle: %v", path, err)
+	}
+	_, err = tarWriter.Write(content)
+	if err != nil {
+		return fmt.Errorf("Failed to write '%s' to the bundle: %v", path, err)
+	}
+	return nil
+}
+
+func (b *Bundle) add(path string, description string, content []byte, err error) {
+	document := Document{Path: path, Description: description}
+	if err != nil {
+		document.Error = err.Error()
+	} else {
+		document.Size = len(content)
+		b.files[path] = content
+	}
+	b.Manifest.Documents = append(b.Manifest.Documents, document)
+}
+
+// addJSON adds the JSON document returned by the function, with the sensitive fields redacted
+func (b *Bundle) addJSON(path string, description string, get func() ([]byte, error)) {
+	data, err := get()
+	if err == nil {
+		data, err = logging.RedactJSON(data)
+	}
+	b.add(path, description, data, err)
+}
+
+// addText adds the text returned by the function, with the sensitive fields and tokens redacted
+func (b *Bundle) addText(path string, description string, get func() (string, error)) {
+	text, err := get()
+	b.add(path, description, []byte(logging.RedactText(text)), err)
+}
+
+func marshal[T any](value T, err error, marshaller func(T, io.Writer) error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	err = marshaller(value, &buffer)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (b *Bundle) addCluster(cluster *cmv1.Cluster) {
+	b.addJSON("cluster.json", "Description of the cluster", func() ([]byte, error) {
+		return marshal(cluster, nil, cmv1.MarshalCluster)
+	})
+}
+
+func (b *Bundle) addLogs(ocmClient OCMClient, cluster *cmv1.Cluster) {
+	b.addText("logs/install.log", "Install logs", func() (string, error) {
+		logs, err := ocmClient.GetInstallLogs(cluster.ID(), logTail)
+		if err != nil {
+			return "", err
+		}
+		return logs.Content(), nil
+	})
+	if cluster.State() != cmv1.ClusterStateUninstalling {
+		return
+	}
+	b.addText("logs/uninstall.log", "Uninstall logs", func() (string, error) {
+		logs, err := ocmClient.GetUninstallLogs(cluster.ID(), logTail)
+		if err != nil {
+			return "", err
+		}
+		return logs.Content(), nil
+	})
+}
+
+func (b *Bundle) addUpgradePolicies(ocmClient OCMClient, cluster *cmv1.Cluster) {
+	b.addJSON("upgrade-policies.json", "Upgrade policies", func() ([]byte, error) {
+		if cluster.Hypershift().Enabled() {
+			policies, err := ocmClient.GetControlPlaneUpgradePolicies(cluster.ID())
+			return marshal(policies, err, cmv1.MarshalControlPlaneUpgradePolicyList)
+		}
+		policies, err := ocmClient.GetUpgradePolicies(cluster.ID())
+		return marshal(policies, err, cmv1.MarshalUpgradePolicyList)
+	})
+}
+
+// RolePolicy is a policy of a role, with its document
+type RolePolicy struct {
+	Name     string      `json:"name"`
+	Arn      string      `json:"arn,omitempty"`
+	Type     string      `json:"type"`
+	Document interface{} `json:"document"`
+}
+
+// Role is an IAM role of the cluster with its trust policy and permission policies
+type Role struct {
+	Arn                      string       `json:"arn"`
+	AssumeRolePolicyDocument interface{}  `json:"assumeRolePolicyDocument"`
+	Policies                 []RolePolicy `json:"policies"`
+}
+
+// roleARNs returns the account and operator roles of the cluster
+func roleARNs(cluster *cmv1.Cluster) []string {
+	sts := cluster.AWS().STS()
+	arns := []string{}
+	for _, roleARN := range []string{
+		sts.RoleARN(),
+		sts.SupportRoleARN(),
+		sts.InstanceIAMRoles().MasterRoleARN(),
+		sts.InstanceIAMRoles().WorkerRoleARN(),
+	} {
+		if roleARN != "" {
+			arns = append(arns, roleARN)
+		}
+	}
+	for _, operatorRole := range sts.OperatorIAMRoles() {
+		arns = append(arns, operatorRole.RoleARN())
+	}
+	return arns
+}
+
+func (b *Bundle) addRoles(awsClient aws.Client, cluster *cmv1.Cluster) {
+	for _, roleARN := range roleARNs(cluster) {
+		roleName, err := aws.GetResourceIdFromARN(roleARN)
+		if err != nil {
+			b.add("iam/"+roleARN+".json", "IAM role "+roleARN, nil, err)
+			continue
+		}
+		b.addJSON("iam/"+roleName+".json", "IAM role "+roleARN, func() ([]byte, error) {
+			role, err := getRole(awsClient, roleARN, roleName)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(role)
+		})
+	}
+}
+
+func getRole(awsClient aws.Client, roleARN string, roleName string) (*Role, error) {
+	iamRole, err := awsClient.GetRoleByARN(roleARN)
+	if err != nil {
+		return nil, err
+	}
+	role := &Role{
+		Arn:                      roleARN,
+		AssumeRolePolicyDocument: policyDocument(awssdk.ToString(iamRole.AssumeRolePolicyDocument)),
+		Policies:                 []RolePolicy{},
+	}
+	policies, err := awsClient.GetAttachedPolicy(awssdk.String(roleName))
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		rolePolicy := RolePolicy{Name: policy.PolicyName, Arn: policy.PolicyArn, Type: policy.PolicyType}
+		if policy.PolicyType == aws.Inline {
+			output, err := awsClient.IsRolePolicyExists(roleName, policy.PolicyName)
+			if err != nil {
+				return nil, err
+			}
+			rolePolicy.Document = policyDocument(awssdk.ToString(output.PolicyDocument))
+		} else {
+			document, err := awsClient.GetDefaultPolicyDocument(policy.PolicyArn)
+			if err != nil {
+				return nil, err
+			}
+			rolePolicy.Document = policyDocument(document)
+		}
+		role.Policies = append(role.Policies, rolePolicy)
+	}
+	return role, nil
+}
+
+// policyDocument decodes the URL encoded policy document returned by IAM, it is kept as text when it
+// isn't valid JSON
+func policyDocument(document string) interface{} {
+	if decoded, err := url.QueryUnescape(document); err == nil {
+		document = decoded
+	}
+	if json.Valid([]byte(document)) {
+		return json.RawMessage(document)
+	}
+	return document
+}
+
+func (b *Bundle) addOidcConfig(ocmClient OCMClient, awsClient aws.Client, cluster *cmv1.Cluster) {
+	sts := cluster.AWS().STS()
+	if oidcConfigID := sts.OidcConfig().ID(); oidcConfigID != "" {
+		b.addJSON("oidc-config.json", "OIDC configuration", func() ([]byte, error) {
+			oidcConfig, err := ocmClient.GetOidcConfig(oidcConfigID)
+			return marshal(oidcConfig, err, cmv1.MarshalOidcConfig)
+		})
+	}
+	if sts.OIDCEndpointURL() == "" {
+		return
+	}
+	b.addJSON("oidc-provider.json", "OIDC provider of the AWS account", func() ([]byte, error) {
+		providerARN, err := awsClient.GetOpenIDConnectProviderByOidcEndpointUrl(sts.OIDCEndpointURL())
+		if err != nil {
+			return nil, err
+		}
+		if providerARN == "" {
+			return nil, fmt.Errorf("No OIDC provider found for '%s'", sts.OIDCEndpointURL())
+		}
+		provider, err := awsClient.GetOpenIDConnectProvider(providerARN)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(map[string]interface{}{
+			"arn":            providerARN,
+			"url":            awssdk.ToString(provider.Url),
+			"clientIds":      provider.ClientIDList,
+			"thumbprints":    provider.ThumbprintList,
+			"createDateTime": provider.CreateDate,
+		})
+	})
+}
+
+func (b *Bundle) addNetworkVerification(ocmClient OCMClient, cluster *cmv1.Cluster) {
+	subnetIDs := cluster.AWS().SubnetIDs()
+	if len(subnetIDs) == 0 {
+		return
+	}
+	topology := network.ClusterTopology(cluster)
+	b.addJSON("network-verification.json", "Network verification of the subnets", func() ([]byte, error) {
+		results := []*network.VerificationResult{}
+		for _, subnetID := range subnetIDs {
+			status, err := ocmClient.GetVerifyNetworkSubnet(subnetID)
+			results = append(results, network.NewVerificationResult(subnetID, status, err, topology))
+		}
+		return json.Marshal(results)
+	})
+}
@@ -0,0 +1,167 @@
+package supportbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+// fakeOCMClient returns the same documents for every cluster
+type fakeOCMClient struct {
+	installLogs string
+}
+
+func (c *fakeOCMClient) GetInstallLogs(_ string, _ int) (*cmv1.Log, error) {
+	return cmv1.NewLog().Content(c.installLogs).Build()
+}
+
+func (c *fakeOCMClient) GetUninstallLogs(_ string, _ int) (*cmv1.Log, error) {
+	return nil, fmt.Errorf("Failed to get logs")
+}
+
+func (c *fakeOCMClient) GetUpgradePolicies(_ string) ([]*cmv1.UpgradePolicy, error) {
+	policy, err := cmv1.NewUpgradePolicy().ID("policy-1").Version("4.15.11").Build()
+	return []*cmv1.UpgradePolicy{policy}, err
+}
+
+func (c *fakeOCMClient) GetControlPlaneUpgradePolicies(_ string) ([]*cmv1.ControlPlaneUpgradePolicy, error) {
+	return nil, nil
+}
+
+func (c *fakeOCMClient) GetLimitedSupportReasons(_ string) ([]*cmv1.LimitedSupportReason, error) {
+	return nil, fmt.Errorf("Forbidden")
+}
+
+func (c *fakeOCMClient) GetInflightChecks(_ string) ([]*cmv1.InflightCheck, error) {
+	return nil, nil
+}
+
+func (c *fakeOCMClient) GetOidcConfig(id string) (*cmv1.OidcConfig, error) {
+	return cmv1.NewOidcConfig().ID(id).Managed(true).Build()
+}
+
+func (c *fakeOCMClient) GetVerifyNetworkSubnet(id string) (*cmv1.SubnetNetworkVerification, error) {
+	return cmv1.NewSubnetNetworkVerification().ID(id).State("passed").Build()
+}
+
+// readBundle returns the files of the tarball in order
+func readBundle(data []byte) ([]string, map[string]string) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	Expect(err).ToNot(HaveOccurred())
+	tarReader := tar.NewReader(gzipReader)
+	paths := []string{}
+	files := map[string]string{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		Expect(err).ToNot(HaveOccurred())
+		content, err := io.ReadAll(tarReader)
+		Expect(err).ToNot(HaveOccurred())
+		paths = append(paths, header.Name)
+		files[header.Name] = string(content)
+	}
+	return paths, files
+}
+
+var _ = Describe("Support bundle", func() {
+	var (
+		ctrl      *gomock.Controller
+		awsClient *aws.MockClient
+		ocmClient *fakeOCMClient
+		cluster   *cmv1.Cluster
+		createdAt = time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		awsClient = aws.NewMockClient(ctrl)
+		ocmClient = &fakeOCMClient{installLogs: "level=info msg=installing\ntoken=abc\n"}
+		var err error
+		cluster, err = cmv1.NewCluster().ID("123").Name("foo").State(cmv1.ClusterStateReady).
+			AWS(cmv1.NewAWS().SubnetIDs("subnet-a").STS(cmv1.NewSTS().
+				RoleARN("arn:aws:iam::123456789012:role/foo-Installer-Role").
+				OIDCEndpointURL("https://oidc.example.com/abc").
+				OidcConfig(cmv1.NewOidcConfig().ID("abc")))).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("names the tarball after the cluster and the time", func() {
+		Expect(FileName(cluster, createdAt)).To(Equal("rosa-support-bundle-foo-20240506T070809Z.tar.gz"))
+	})
+
+	It("collects the redacted documents and lists the failures in the manifest", func() {
+		trustPolicy := `{"Version":"2012-10-17","Statement":[]}`
+		awsClient.EXPECT().GetRoleByARN("arn:aws:iam::123456789012:role/foo-Installer-Role").Return(
+			iamtypes.Role{AssumeRolePolicyDocument: awssdk.String(url.QueryEscape(trustPolicy))}, nil)
+		awsClient.EXPECT().GetAttachedPolicy(awssdk.String("foo-Installer-Role")).Return([]aws.PolicyDetail{
+			{PolicyName: "installer", PolicyArn: "arn:aws:iam::123456789012:policy/installer", PolicyType: aws.Attached},
+			{PolicyName: "inline", PolicyType: aws.Inline},
+		}, nil)
+		awsClient.EXPECT().GetDefaultPolicyDocument("arn:aws:iam::123456789012:policy/installer").
+			Return(`{"Statement":[{"Action":"ec2:*"}]}`, nil)
+		awsClient.EXPECT().IsRolePolicyExists("foo-Installer-Role", "inline").Return(
+			&iam.GetRolePolicyOutput{PolicyDocument: awssdk.String(url.QueryEscape(`{"Statement":[]}`))}, nil)
+		awsClient.EXPECT().GetOpenIDConnectProviderByOidcEndpointUrl("https://oidc.example.com/abc").Return("", nil)
+
+		bundle := Collect(ocmClient, awsClient, cluster, "1.2.40", createdAt)
+		paths := []string{}
+		for _, entry := range bundle.Manifest.Documents {
+			paths = append(paths, entry.Path)
+		}
+		Expect(paths).To(Equal([]string{
+			"cluster.json",
+			"logs/install.log",
+			"upgrade-policies.json",
+			"limited-support-reasons.json",
+			"inflight-checks.json",
+			"iam/foo-Installer-Role.json",
+			"oidc-config.json",
+			"oidc-provider.json",
+			"network-verification.json",
+		}))
+		Expect(bundle.Failed()).To(Equal([]Document{
+			{Path: "limited-support-reasons.json", Description: "Limited support reasons", Error: "Forbidden"},
+			{Path: "oidc-provider.json", Description: "OIDC provider of the AWS account",
+				Error: "No OIDC provider found for 'https://oidc.example.com/abc'"},
+		}))
+
+		var buffer bytes.Buffer
+		Expect(bundle.Write(&buffer)).To(Succeed())
+		tarPaths, files := readBundle(buffer.Bytes())
+		Expect(tarPaths).To(HaveLen(8))
+		Expect(tarPaths[0]).To(Equal(ManifestPath))
+		Expect(files[ManifestPath]).To(ContainSubstring(`"rosaVersion": "1.2.40"`))
+		Expect(files["logs/install.log"]).To(Equal("level=info msg=installing\ntoken=***\n"))
+		Expect(files["iam/foo-Installer-Role.json"]).To(MatchJSON(`{
+			"arn": "arn:aws:iam::123456789012:role/foo-Installer-Role",
+			"assumeRolePolicyDocument": {"Version": "2012-10-17", "Statement": []},
+			"policies": [
+				{"name": "installer", "arn": "arn:aws:iam::123456789012:policy/installer", "type": "attached",
+					"document": {"Statement": [{"Action": "ec2:*"}]}},
+				{"name": "inline", "type": "inline", "document": {"Statement": []}}
+			]
+		}`))
+		Expect(files["network-verification.json"]).To(MatchJSON(`[{"subnet": "subnet-a", "state": "passed"}]`))
+	})
+})
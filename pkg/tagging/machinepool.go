@@ -0,0 +1,114 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tagging
+
+import (
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// TagMachinePool sets the cost allocation tags of the machine pool through OCM, which applies them to
+// the instances of the pool. OCM refuses the update for the machine pools whose tags can't change.
+func TagMachinePool(r *rosa.Runtime, cluster *cmv1.Cluster, machinePoolID string,
+	tagList map[string]string) error {
+	if len(tagList) == 0 {
+		return fmt.Errorf("No tags to set, use '--%s'", TagsFlag)
+	}
+	return updateMachinePoolTags(r, cluster, machinePoolID, func(poolTags map[string]string) {
+		for key, value := range tagList {
+			poolTags[key] = value
+		}
+	})
+}
+
+// UntagMachinePool removes the tags of the keys from the machine pool through OCM
+func UntagMachinePool(r *rosa.Runtime, cluster *cmv1.Cluster, machinePoolID string, keys []string) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("No tags to remove, use '--%s'", TagKeysFlag)
+	}
+	err := ValidateKeys(keys)
+	if err != nil {
+		return err
+	}
+	return updateMachinePoolTags(r, cluster, machinePoolID, func(poolTags map[string]string) {
+		for _, key := range keys {
+			delete(poolTags, key)
+		}
+	})
+}
+
+func updateMachinePoolTags(r *rosa.Runtime, cluster *cmv1.Cluster, machinePoolID string,
+	change func(map[string]string)) error {
+	poolTags := map[string]string{}
+	if cluster.Hypershift().Enabled() {
+		nodePool, exists, err := r.OCMClient.GetNodePool(cluster.ID(), machinePoolID)
+		if err != nil {
+			return fmt.Errorf("Failed to get machine pool '%s' of cluster '%s': %v", machinePoolID,
+				cluster.Name(), err)
+		}
+		if !exists {
+			return fmt.Errorf("Machine pool '%s' does not exist on cluster '%s'", machinePoolID, cluster.Name())
+		}
+		for key, value := range nodePool.AWSNodePool().Tags() {
+			poolTags[key] = value
+		}
+		change(poolTags)
+		update, err := cmv1.NewNodePool().ID(machinePoolID).
+			AWSNodePool(cmv1.NewAWSNodePool().Tags(poolTags)).
+			Build()
+		if err != nil {
+			return err
+		}
+		r.Reporter.Debugf("Updating tags of machine pool '%s' on hosted cluster '%s'", machinePoolID,
+			cluster.Name())
+		_, err = r.OCMClient.UpdateNodePool(cluster.ID(), update)
+		return machinePoolUpdated(r, cluster, machinePoolID, err)
+	}
+	machinePool, exists, err := r.OCMClient.GetMachinePool(cluster.ID(), machinePoolID)
+	if err != nil {
+		return fmt.Errorf("Failed to get machine pool '%s' of cluster '%s': %v", machinePoolID,
+			cluster.Name(), err)
+	}
+	if !exists {
+		return fmt.Errorf("Machine pool '%s' does not exist on cluster '%s'", machinePoolID, cluster.Name())
+	}
+	for key, value := range machinePool.AWS().Tags() {
+		poolTags[key] = value
+	}
+	change(poolTags)
+	update, err := cmv1.NewMachinePool().ID(machinePoolID).
+		AWS(cmv1.NewAWSMachinePool().Tags(poolTags)).
+		Build()
+	if err != nil {
+		return err
+	}
+	r.Reporter.Debugf("Updating tags of machine pool '%s' on cluster '%s'", machinePoolID, cluster.Name())
+	_, err = r.OCMClient.UpdateMachinePool(cluster.ID(), update)
+	return machinePoolUpdated(r, cluster, machinePoolID, err)
+}
+
+func machinePoolUpdated(r *rosa.Runtime, cluster *cmv1.Cluster, machinePoolID string, err error) error {
+	if err != nil {
+		return fmt.Errorf("Failed to update tags of machine pool '%s' on cluster '%s': %v", machinePoolID,
+			cluster.Name(), err)
+	}
+	r.Reporter.Infof("Updated tags of machine pool '%s' on cluster '%s'", machinePoolID, cluster.Name())
+	return nil
+}
@@ -0,0 +1,369 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tagging finds the AWS resources managed by ROSA and changes their tags after they were
+// created. It backs the 'rosa tag' and 'rosa untag' commands.
+package tagging
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/oidcconfig"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// Kinds of resources that can be tagged
+const (
+	KindRole         = "IAM role"
+	KindOidcProvider = "OIDC provider"
+	KindS3Bucket     = "S3 bucket"
+	KindSecret       = "secret"
+)
+
+const (
+	TagsFlag    = "tags"
+	TagKeysFlag = "tag-keys"
+)
+
+// Resource is an AWS resource identified by its name, or its ARN when it has no name
+type Resource struct {
+	Kind string
+	ID   string
+}
+
+func (r Resource) String() string {
+	return fmt.Sprintf("%s '%s'", r.Kind, r.ID)
+}
+
+// AddTagsFlag adds the flag of the tags to set
+func AddTagsFlag(flags *pflag.FlagSet, value *[]string) {
+	flags.StringSliceVar(
+		value,
+		TagsFlag,
+		nil,
+		"Tags to set on the resources, overriding the values of the tags already set. "+
+			"Tags are comma separated, for example: 'key value, foo bar'",
+	)
+}
+
+// AddTagKeysFlag adds the flag of the keys of the tags to remove
+func AddTagKeysFlag(flags *pflag.FlagSet, value *[]string) {
+	flags.StringSliceVar(
+		value,
+		TagKeysFlag,
+		nil,
+		"Keys of the tags to remove from the resources, comma separated (required).",
+	)
+}
+
+// ParseTags returns the tags of the '--tags' flag. Tags used by ROSA and AWS can't be set.
+func ParseTags(input []string) (map[string]string, error) {
+	tagList := map[string]string{}
+	if len(input) == 0 {
+		return tagList, nil
+	}
+	err := aws.UserTagValidator(input)
+	if err != nil {
+		return nil, err
+	}
+	delim := aws.GetTagsDelimiter(input)
+	for _, tag := range input {
+		t := strings.Split(tag, delim)
+		tagList[t[0]] = strings.TrimSpace(t[1])
+	}
+	err = ValidateKeys(keysOf(tagList))
+	if err != nil {
+		return nil, err
+	}
+	return tagList, nil
+}
+
+// ValidateKeys fails when a key is used by ROSA or AWS, as changing them would break ROSA
+func ValidateKeys(keys []string) error {
+	for _, key := range keys {
+		if key == "" {
+			return fmt.Errorf("Tag keys can't be empty")
+		}
+		if reserved, ok := tags.ReservedPrefix(key); ok {
+			return fmt.Errorf("Tag '%s' uses the prefix '%s' reserved for ROSA and AWS", key, reserved)
+		}
+	}
+	return nil
+}
+
+// ClusterTags returns the user tags of the cluster, which are set on the resources ROSA created
+// for it. Tags used by ROSA are left out.
+func ClusterTags(cluster *cmv1.Cluster) map[string]string {
+	tagList := map[string]string{}
+	for key, value := range cluster.AWS().Tags() {
+		if _, ok := tags.ReservedPrefix(key); ok {
+			continue
+		}
+		tagList[key] = value
+	}
+	return tagList
+}
+
+// Tag sets the tags on the resource
+func Tag(awsClient aws.Client, resource Resource, tagList map[string]string) error {
+	switch resource.Kind {
+	case KindRole:
+		return awsClient.TagRole(resource.ID, tagList)
+	case KindOidcProvider:
+		return awsClient.TagOpenIDConnectProvider(resource.ID, tagList)
+	case KindS3Bucket:
+		return awsClient.TagS3Bucket(resource.ID, tagList)
+	case KindSecret:
+		return awsClient.TagSecret(resource.ID, tagList)
+	}
+	return fmt.Errorf("Unsupported kind of resource '%s'", resource.Kind)
+}
+
+// Untag removes the tags of the keys from the resource
+func Untag(awsClient aws.Client, resource Resource, keys []string) error {
+	switch resource.Kind {
+	case KindRole:
+		return awsClient.UntagRole(resource.ID, keys)
+	case KindOidcProvider:
+		return awsClient.UntagOpenIDConnectProvider(resource.ID, keys)
+	case KindS3Bucket:
+		return awsClient.UntagS3Bucket(resource.ID, keys)
+	case KindSecret:
+		return awsClient.UntagSecret(resource.ID, keys)
+	}
+	return fmt.Errorf("Unsupported kind of resource '%s'", resource.Kind)
+}
+
+// TagAll asks for confirmation and sets the tags on all the resources. Resources failing to be
+// tagged don't stop the others from being tagged.
+func TagAll(r *rosa.Runtime, resources []Resource, tagList map[string]string) error {
+	if len(tagList) == 0 {
+		return fmt.Errorf("No tags to set, use '--%s'", TagsFlag)
+	}
+	return apply(r, resources, "tag", func(resource Resource) error {
+		return Tag(r.AWSClient, resource, tagList)
+	})
+}
+
+// UntagAll asks for confirmation and removes the tags of the keys from all the resources.
+// Resources failing to be untagged don't stop the others from being untagged.
+func UntagAll(r *rosa.Runtime, resources []Resource, keys []string) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("No tags to remove, use '--%s'", TagKeysFlag)
+	}
+	err := ValidateKeys(keys)
+	if err != nil {
+		return err
+	}
+	return apply(r, resources, "untag", func(resource Resource) error {
+		return Untag(r.AWSClient, resource, keys)
+	})
+}
+
+func apply(r *rosa.Runtime, resources []Resource, verb string, change func(Resource) error) error {
+	if len(resources) == 0 {
+		return fmt.Errorf("No resources found to %s", verb)
+	}
+	r.Reporter.Infof("Resources to %s:", verb)
+	for _, resource := range resources {
+		fmt.Printf("  - %s\n", resource)
+	}
+	if !confirm.Confirm("%s %d resources", verb, len(resources)) {
+		return nil
+	}
+	failed := 0
+	for _, resource := range resources {
+		r.Reporter.Debugf("Changing tags of %s", resource)
+		err := change(resource)
+		if err != nil {
+			r.Reporter.Errorf("Failed to %s %s: %v", verb, resource, err)
+			failed++
+			continue
+		}
+		r.Reporter.Infof("Updated tags of %s", resource)
+	}
+	if failed > 0 {
+		return fmt.Errorf("Failed to %s %d of %d resources", verb, failed, len(resources))
+	}
+	return nil
+}
+
+// AccountRolesByPrefix returns the classic and hosted control plane account roles of the prefix
+func AccountRolesByPrefix(awsClient aws.Client, prefix string) ([]Resource, error) {
+	var names []string
+	for _, accountRole := range aws.AccountRoles {
+		names = append(names, fmt.Sprintf("%s-%s-Role", prefix, accountRole.Name))
+	}
+	for _, accountRole := range aws.HCPAccountRoles {
+		names = append(names, fmt.Sprintf("%s-%s-Role", prefix, accountRole.Name))
+	}
+	sort.Strings(names)
+	resources := []Resource{}
+	for _, name := range names {
+		exists, _, err := awsClient.CheckRoleExists(name)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get role '%s': %v", name, err)
+		}
+		if exists {
+			resources = append(resources, Resource{Kind: KindRole, ID: name})
+		}
+	}
+	return resources, nil
+}
+
+// ClusterAccountRoles returns the account roles used by the cluster
+func ClusterAccountRoles(cluster *cmv1.Cluster) ([]Resource, error) {
+	roleARNs := []string{}
+	for _, roleARN := range aws.GetAccountRolesArnsMap(cluster) {
+		roleARNs = append(roleARNs, roleARN)
+	}
+	return rolesOf(roleARNs)
+}
+
+// OperatorRolesByPrefix returns the operator roles of the prefix
+func OperatorRolesByPrefix(awsClient aws.Client, prefix string,
+	credRequests map[string]*cmv1.STSOperator) ([]Resource, error) {
+	names, err := awsClient.GetOperatorRolesFromAccountByPrefix(prefix, credRequests)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get operator roles of prefix '%s': %v", prefix, err)
+	}
+	sort.Strings(names)
+	resources := []Resource{}
+	for _, name := range names {
+		resources = append(resources, Resource{Kind: KindRole, ID: name})
+	}
+	return resources, nil
+}
+
+// ClusterOperatorRoles returns the operator roles used by the cluster
+func ClusterOperatorRoles(cluster *cmv1.Cluster) ([]Resource, error) {
+	roleARNs := []string{}
+	for _, operatorRole := range cluster.AWS().STS().OperatorIAMRoles() {
+		roleARNs = append(roleARNs, operatorRole.RoleARN())
+	}
+	return rolesOf(roleARNs)
+}
+
+func rolesOf(roleARNs []string) ([]Resource, error) {
+	resources := []Resource{}
+	seen := map[string]bool{}
+	for _, roleARN := range roleARNs {
+		if roleARN == "" {
+			continue
+		}
+		name, err := aws.GetResourceIdFromARN(roleARN)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse role ARN '%s': %v", roleARN, err)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		resources = append(resources, Resource{Kind: KindRole, ID: name})
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].ID < resources[j].ID
+	})
+	return resources, nil
+}
+
+// OidcProvider returns the OIDC provider of the issuer
+func OidcProvider(awsClient aws.Client, issuerUrl string) ([]Resource, error) {
+	providerARN, err := awsClient.GetOpenIDConnectProviderByOidcEndpointUrl(issuerUrl)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get OIDC provider of issuer '%s': %v", issuerUrl, err)
+	}
+	if providerARN == "" {
+		return nil, fmt.Errorf("There is no OIDC provider for issuer '%s'", issuerUrl)
+	}
+	return []Resource{{Kind: KindOidcProvider, ID: providerARN}}, nil
+}
+
+// OidcConfigStorage returns the S3 bucket serving the discovery documents and the secret holding
+// the private key of an unmanaged OIDC config. Managed OIDC configs are stored by Red Hat.
+func OidcConfigStorage(oidcConfig *cmv1.OidcConfig, region string) ([]Resource, error) {
+	if oidcConfig.Managed() {
+		return nil, fmt.Errorf("OIDC config '%s' is managed by Red Hat, it has no resources in the AWS account",
+			oidcConfig.ID())
+	}
+	secretArn := oidcConfig.SecretArn()
+	parsedArn, err := arn.Parse(secretArn)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse secret ARN '%s' of OIDC config '%s': %v",
+			secretArn, oidcConfig.ID(), err)
+	}
+	if parsedArn.Region != region {
+		return nil, fmt.Errorf("The private key of OIDC config '%s' is stored in region '%s', "+
+			"run the command with '--region %s'", oidcConfig.ID(), parsedArn.Region, parsedArn.Region)
+	}
+	// The documents of raw files configurations are hosted by the user, only the secret is in the account
+	if oidcconfig.BackendFromIssuerUrl(oidcConfig.IssuerUrl()) == oidcconfig.BackendRawFiles {
+		return []Resource{{Kind: KindSecret, ID: secretArn}}, nil
+	}
+	bucketName, err := oidcconfig.BucketNameFromIssuerUrl(oidcConfig.IssuerUrl())
+	if err != nil {
+		// Issuers served by CloudFront read from the bucket named after the secret
+		secretName, err := aws.GetResourceIdFromSecretArn(secretArn)
+		if err != nil {
+			return nil, fmt.Errorf("There was a problem parsing secret ARN '%s': %v", secretArn, err)
+		}
+		bucketName = oidcconfig.BucketNameFromSecretName(secretName)
+	}
+	return []Resource{
+		{Kind: KindS3Bucket, ID: bucketName},
+		{Kind: KindSecret, ID: secretArn},
+	}, nil
+}
+
+// ClusterIAMResources returns the account roles, operator roles and OIDC provider of the cluster
+func ClusterIAMResources(awsClient aws.Client, cluster *cmv1.Cluster) ([]Resource, error) {
+	if cluster.AWS().STS().RoleARN() == "" {
+		return nil, fmt.Errorf("Cluster '%s' is not an STS cluster, it has no IAM resources created by ROSA",
+			cluster.Name())
+	}
+	accountRoles, err := ClusterAccountRoles(cluster)
+	if err != nil {
+		return nil, err
+	}
+	operatorRoles, err := ClusterOperatorRoles(cluster)
+	if err != nil {
+		return nil, err
+	}
+	oidcProvider, err := OidcProvider(awsClient, cluster.AWS().STS().OIDCEndpointURL())
+	if err != nil {
+		return nil, err
+	}
+	resources := append(accountRoles, operatorRoles...)
+	return append(resources, oidcProvider...), nil
+}
+
+func keysOf(tagList map[string]string) []string {
+	keys := make([]string, 0, len(tagList))
+	for key := range tagList {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
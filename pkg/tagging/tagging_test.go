@@ -0,0 +1,93 @@
+package tagging
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("Tagging", func() {
+	Context("ParseTags", func() {
+		It("parses the tags of the flag", func() {
+			tagList, err := ParseTags([]string{"cost-center:1234", "team:platform"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tagList).To(Equal(map[string]string{"cost-center": "1234", "team": "platform"}))
+		})
+		It("rejects the tags reserved for ROSA and AWS", func() {
+			_, err := ParseTags([]string{"red-hat-managed:false"})
+			Expect(err).To(MatchError("Tag 'red-hat-managed' uses the prefix 'red-hat-' reserved for ROSA and AWS"))
+			Expect(ValidateKeys([]string{"rosa_cluster_id"})).NotTo(Succeed())
+		})
+	})
+
+	Context("Cluster resources", func() {
+		cluster, err := cmv1.NewCluster().Name("mycluster").AWS(cmv1.NewAWS().
+			Tags(map[string]string{"team": "platform", "red-hat-managed": "true"}).
+			STS(cmv1.NewSTS().
+				RoleARN("arn:aws:iam::123456789012:role/acme-Installer-Role").
+				SupportRoleARN("arn:aws:iam::123456789012:role/acme-Support-Role").
+				InstanceIAMRoles(cmv1.NewInstanceIAMRoles().
+					MasterRoleARN("arn:aws:iam::123456789012:role/acme-ControlPlane-Role").
+					WorkerRoleARN("arn:aws:iam::123456789012:role/acme-Worker-Role")).
+				OperatorIAMRoles(cmv1.NewOperatorIAMRole().
+					RoleARN("arn:aws:iam::123456789012:role/mycluster-openshift-ingress-operator-cloud-credentials")).
+				OIDCEndpointURL("https://oidc.example.com/abc"))).Build()
+		Expect(err).NotTo(HaveOccurred())
+
+		It("propagates the user tags of the cluster", func() {
+			Expect(ClusterTags(cluster)).To(Equal(map[string]string{"team": "platform"}))
+		})
+		It("finds the IAM resources of the cluster", func() {
+			mockCtrl := gomock.NewController(GinkgoT())
+			awsClient := aws.NewMockClient(mockCtrl)
+			providerARN := "arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc"
+			awsClient.EXPECT().GetOpenIDConnectProviderByOidcEndpointUrl("https://oidc.example.com/abc").
+				Return(providerARN, nil)
+			resources, err := ClusterIAMResources(awsClient, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(Equal([]Resource{
+				{Kind: KindRole, ID: "acme-ControlPlane-Role"},
+				{Kind: KindRole, ID: "acme-Installer-Role"},
+				{Kind: KindRole, ID: "acme-Support-Role"},
+				{Kind: KindRole, ID: "acme-Worker-Role"},
+				{Kind: KindRole, ID: "mycluster-openshift-ingress-operator-cloud-credentials"},
+				{Kind: KindOidcProvider, ID: providerARN},
+			}))
+		})
+	})
+
+	Context("OidcConfigStorage", func() {
+		It("returns the bucket and the secret of unmanaged configs", func() {
+			secretArn := "arn:aws:secretsmanager:us-east-1:123456789012:secret:rosa-private-key-acme-oidc-abcd-Xy12Zw"
+			oidcConfig, err := cmv1.NewOidcConfig().ID("config").Managed(false).
+				IssuerUrl("https://acme-oidc-abcd.s3.us-east-1.amazonaws.com").SecretArn(secretArn).Build()
+			Expect(err).NotTo(HaveOccurred())
+			resources, err := OidcConfigStorage(oidcConfig, "us-east-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(Equal([]Resource{
+				{Kind: KindS3Bucket, ID: "acme-oidc-abcd"},
+				{Kind: KindSecret, ID: secretArn},
+			}))
+			_, err = OidcConfigStorage(oidcConfig, "eu-west-1")
+			Expect(err).To(MatchError(ContainSubstring("run the command with '--region us-east-1'")))
+		})
+		It("returns only the secret of raw files configs", func() {
+			secretArn := "arn:aws:secretsmanager:us-east-1:123456789012:secret:rosa-private-key-acme-oidc-abcd-Xy12Zw"
+			oidcConfig, err := cmv1.NewOidcConfig().ID("config").Managed(false).
+				IssuerUrl("https://oidc.example.com/acme").SecretArn(secretArn).Build()
+			Expect(err).NotTo(HaveOccurred())
+			resources, err := OidcConfigStorage(oidcConfig, "us-east-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(Equal([]Resource{{Kind: KindSecret, ID: secretArn}}))
+		})
+		It("fails for managed configs", func() {
+			oidcConfig, err := cmv1.NewOidcConfig().ID("config").Managed(true).Build()
+			Expect(err).NotTo(HaveOccurred())
+			_, err = OidcConfigStorage(oidcConfig, "us-east-1")
+			Expect(err).To(MatchError(ContainSubstring("is managed by Red Hat")))
+		})
+	})
+})
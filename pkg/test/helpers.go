@@ -285,6 +285,10 @@ func FormatResource(resource interface{}) string {
 		if res, ok := resource.(*v1.BreakGlassCredential); ok {
 			err = v1.MarshalBreakGlassCredential(res, &outputJson)
 		}
+	case "*v1.OidcConfig":
+		if res, ok := resource.(*v1.OidcConfig); ok {
+			err = v1.MarshalOidcConfig(res, &outputJson)
+		}
 	case "*v1.Account":
 		if res, ok := resource.(*amsv1.Account); ok {
 			err = amsv1.MarshalAccount(res, &outputJson)
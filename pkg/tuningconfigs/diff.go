@@ -0,0 +1,185 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tuningconfigs
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DiffSpecs compares two tuning config specs and describes, one line per change, which
+// profiles and recommendations were added, removed or modified. Profile data is
+// compared option by option so that the user sees which TuneD settings change rather
+// than two opaque blobs of text.
+func DiffSpecs(oldSpec interface{}, newSpec interface{}) ([]string, error) {
+	oldMap, err := normalizeSpec(oldSpec)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read current spec: %v", err)
+	}
+	newMap, err := normalizeSpec(newSpec)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read new spec: %v", err)
+	}
+
+	var changes []string
+	changes = append(changes, diffProfiles(
+		entriesByKey(oldMap[profileKey], "name"), entriesByKey(newMap[profileKey], "name"))...)
+	changes = append(changes, diffRecommends(
+		entriesByKey(oldMap[recommendKey], "profile"), entriesByKey(newMap[recommendKey], "profile"))...)
+	return changes, nil
+}
+
+// normalizeSpec round-trips the spec through JSON so that specs coming from OCM and
+// from a local file have the same shape.
+func normalizeSpec(spec interface{}) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	if spec == nil {
+		return result, nil
+	}
+	body, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(body, &result)
+	return result, err
+}
+
+func entriesByKey(raw interface{}, key string) map[string]map[string]interface{} {
+	result := map[string]map[string]interface{}{}
+	items, _ := raw.([]interface{})
+	for _, item := range items {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := entry[key].(string)
+		result[name] = entry
+	}
+	return result
+}
+
+func sortedKeys[T any](values ...map[string]T) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, value := range values {
+		for key := range value {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func diffProfiles(oldProfiles, newProfiles map[string]map[string]interface{}) []string {
+	var changes []string
+	for _, name := range sortedKeys(oldProfiles, newProfiles) {
+		oldProfile, inOld := oldProfiles[name]
+		newProfile, inNew := newProfiles[name]
+		switch {
+		case !inOld:
+			changes = append(changes, fmt.Sprintf("+ profile '%s' added", name))
+		case !inNew:
+			changes = append(changes, fmt.Sprintf("- profile '%s' removed", name))
+		default:
+			oldData, _ := oldProfile["data"].(string)
+			newData, _ := newProfile["data"].(string)
+			optionChanges := diffProfileData(oldData, newData)
+			if len(optionChanges) == 0 {
+				continue
+			}
+			changes = append(changes, fmt.Sprintf("~ profile '%s' changed:", name))
+			for _, change := range optionChanges {
+				changes = append(changes, "    "+change)
+			}
+		}
+	}
+	return changes
+}
+
+func diffProfileData(oldData, newData string) []string {
+	oldOptions := parseProfileData(oldData)
+	newOptions := parseProfileData(newData)
+	var changes []string
+	for _, option := range sortedKeys(oldOptions, newOptions) {
+		oldValue, inOld := oldOptions[option]
+		newValue, inNew := newOptions[option]
+		switch {
+		case !inOld:
+			changes = append(changes, fmt.Sprintf("+ %s=%s", option, newValue))
+		case !inNew:
+			changes = append(changes, fmt.Sprintf("- %s=%s", option, oldValue))
+		case oldValue != newValue:
+			changes = append(changes, fmt.Sprintf("~ %s: %s -> %s", option, oldValue, newValue))
+		}
+	}
+	return changes
+}
+
+// parseProfileData flattens TuneD profile data into '[section] key' -> value pairs.
+func parseProfileData(data string) map[string]string {
+	options := map[string]string{}
+	section := ""
+	for _, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "", strings.HasPrefix(trimmed, "#"), strings.HasPrefix(trimmed, ";"):
+			continue
+		case strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]"):
+			section = trimmed
+		case strings.Contains(trimmed, "="):
+			parts := strings.SplitN(trimmed, "=", 2)
+			options[fmt.Sprintf("%s %s", section, strings.TrimSpace(parts[0]))] = strings.TrimSpace(parts[1])
+		}
+	}
+	return options
+}
+
+func diffRecommends(oldRecommends, newRecommends map[string]map[string]interface{}) []string {
+	var changes []string
+	for _, profile := range sortedKeys(oldRecommends, newRecommends) {
+		oldRecommend, inOld := oldRecommends[profile]
+		newRecommend, inNew := newRecommends[profile]
+		switch {
+		case !inOld:
+			changes = append(changes, fmt.Sprintf("+ recommendation for profile '%s' added with priority %v",
+				profile, newRecommend["priority"]))
+		case !inNew:
+			changes = append(changes, fmt.Sprintf("- recommendation for profile '%s' removed", profile))
+		case !reflect.DeepEqual(oldRecommend, newRecommend):
+			var details []string
+			if !reflect.DeepEqual(oldRecommend["priority"], newRecommend["priority"]) {
+				details = append(details, fmt.Sprintf("priority %v -> %v",
+					oldRecommend["priority"], newRecommend["priority"]))
+			}
+			if !reflect.DeepEqual(oldRecommend["match"], newRecommend["match"]) {
+				details = append(details, "match rules changed")
+			}
+			if len(details) == 0 {
+				details = append(details, "options changed")
+			}
+			changes = append(changes, fmt.Sprintf("~ recommendation for profile '%s' changed: %s",
+				profile, strings.Join(details, ", ")))
+		}
+	}
+	return changes
+}
@@ -0,0 +1,56 @@
+package tuningconfigs
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiffSpecs", func() {
+	spec := func(profiles map[string]string, priorities map[string]float64) map[string]interface{} {
+		var profileList, recommendList []interface{}
+		for name, data := range profiles {
+			profileList = append(profileList, map[string]interface{}{"name": name, "data": data})
+		}
+		for name, priority := range priorities {
+			recommendList = append(recommendList, map[string]interface{}{"profile": name, "priority": priority})
+		}
+		return map[string]interface{}{"profile": profileList, "recommend": recommendList}
+	}
+
+	It("OK: reports no changes for identical specs", func() {
+		old := spec(map[string]string{"a": "[sysctl]\nvm.swappiness=10"}, map[string]float64{"a": 20})
+		changes, err := DiffSpecs(old, old)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("OK: describes profile and recommendation changes", func() {
+		old := spec(
+			map[string]string{
+				"a": "[main]\ninclude=openshift-node\n[sysctl]\nvm.swappiness=10\nvm.dirty_ratio=10",
+				"b": "[sysctl]\nvm.max_map_count=262144",
+			},
+			map[string]float64{"a": 20, "b": 20},
+		)
+		updated := spec(
+			map[string]string{
+				"a": "[main]\ninclude=openshift-node\n[sysctl]\nvm.swappiness=30\nkernel.numa_balancing=0",
+				"c": "[sysctl]\nvm.max_map_count=262144",
+			},
+			map[string]float64{"a": 10, "c": 20},
+		)
+		changes, err := DiffSpecs(old, updated)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(Equal([]string{
+			"~ profile 'a' changed:",
+			"    + [sysctl] kernel.numa_balancing=0",
+			"    - [sysctl] vm.dirty_ratio=10",
+			"    ~ [sysctl] vm.swappiness: 10 -> 30",
+			"- profile 'b' removed",
+			"+ profile 'c' added",
+			"~ recommendation for profile 'a' changed: priority 20 -> 10",
+			"- recommendation for profile 'b' removed",
+			"+ recommendation for profile 'c' added with priority 20",
+		}))
+	})
+})
@@ -0,0 +1,267 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tuningconfigs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+const (
+	profileKey   = "profile"
+	recommendKey = "recommend"
+
+	matchTypeNode = "node"
+	matchTypePod  = "pod"
+)
+
+var allowedSpecKeys = []string{profileKey, recommendKey}
+var allowedProfileKeys = []string{"name", "data"}
+var allowedRecommendKeys = []string{"profile", "priority", "match", "machineConfigLabels", "operand"}
+var allowedMatchKeys = []string{"label", "value", "type", "match"}
+
+// ValidationError collects every problem found in a TuneD spec so that the user can
+// fix them all at once instead of one upload attempt at a time.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("Invalid TuneD spec:\n  - %s", strings.Join(e.Problems, "\n  - "))
+}
+
+type validator struct {
+	problems []string
+	warnings []string
+}
+
+func (v *validator) problemf(format string, a ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf(format, a...))
+}
+
+func (v *validator) warnf(format string, a ...interface{}) {
+	v.warnings = append(v.warnings, fmt.Sprintf(format, a...))
+}
+
+// ValidateSpec checks the spec section of a tuning config against the TuneD schema
+// without contacting the cluster. It returns a ValidationError listing every schema
+// violation, and a list of warnings for constructs that are valid but probably not
+// what the user intended.
+func ValidateSpec(spec map[string]interface{}) ([]string, error) {
+	v := &validator{}
+	if len(spec) == 0 {
+		v.problemf("spec is empty, at least one of '%s' or '%s' is required", profileKey, recommendKey)
+		return nil, &ValidationError{Problems: v.problems}
+	}
+	v.checkUnknownKeys("spec", spec, allowedSpecKeys)
+
+	profileNames := v.validateProfiles(spec[profileKey])
+	v.validateRecommends(spec[recommendKey], profileNames)
+
+	if len(v.problems) > 0 {
+		return v.warnings, &ValidationError{Problems: v.problems}
+	}
+	return v.warnings, nil
+}
+
+func (v *validator) checkUnknownKeys(path string, value map[string]interface{}, allowed []string) {
+	var unknown []string
+	for key := range value {
+		if !helper.Contains(allowed, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		v.problemf("%s: unknown field '%s', expected one of: %s", path, key, strings.Join(allowed, ", "))
+	}
+}
+
+func (v *validator) validateProfiles(raw interface{}) map[string]bool {
+	names := map[string]bool{}
+	if raw == nil {
+		return names
+	}
+	profiles, ok := raw.([]interface{})
+	if !ok {
+		v.problemf("%s: expected a list of profiles", profileKey)
+		return names
+	}
+	for i, item := range profiles {
+		path := fmt.Sprintf("%s[%d]", profileKey, i)
+		profile, ok := item.(map[string]interface{})
+		if !ok {
+			v.problemf("%s: expected an object with 'name' and 'data'", path)
+			continue
+		}
+		v.checkUnknownKeys(path, profile, allowedProfileKeys)
+
+		name, ok := profile["name"].(string)
+		if !ok || strings.TrimSpace(name) == "" {
+			v.problemf("%s: 'name' is required and must be a non-empty string", path)
+		} else if names[name] {
+			v.problemf("%s: duplicate profile name '%s'", path, name)
+		} else {
+			names[name] = true
+		}
+
+		data, ok := profile["data"].(string)
+		if !ok || strings.TrimSpace(data) == "" {
+			v.problemf("%s: 'data' is required and must be a non-empty string", path)
+			continue
+		}
+		for _, problem := range lintProfileData(data) {
+			v.problemf("%s: %s", path, problem)
+		}
+	}
+	return names
+}
+
+func (v *validator) validateRecommends(raw interface{}, profileNames map[string]bool) {
+	if raw == nil {
+		if len(profileNames) > 0 {
+			v.warnf("no '%s' entries: the profiles in this spec will not be applied to any node", recommendKey)
+		}
+		return
+	}
+	recommends, ok := raw.([]interface{})
+	if !ok {
+		v.problemf("%s: expected a list of recommendations", recommendKey)
+		return
+	}
+	for i, item := range recommends {
+		path := fmt.Sprintf("%s[%d]", recommendKey, i)
+		recommend, ok := item.(map[string]interface{})
+		if !ok {
+			v.problemf("%s: expected an object with 'profile' and 'priority'", path)
+			continue
+		}
+		v.checkUnknownKeys(path, recommend, allowedRecommendKeys)
+
+		profile, ok := recommend["profile"].(string)
+		if !ok || strings.TrimSpace(profile) == "" {
+			v.problemf("%s: 'profile' is required and must be a non-empty string", path)
+		} else if !profileNames[profile] {
+			v.warnf("%s: profile '%s' is not defined in this spec, it must already exist on the cluster",
+				path, profile)
+		}
+
+		priority, ok := recommend["priority"]
+		if !ok {
+			v.problemf("%s: 'priority' is required", path)
+		} else if _, err := toPriority(priority); err != nil {
+			v.problemf("%s: %v", path, err)
+		}
+
+		if match, ok := recommend["match"]; ok {
+			v.validateMatchRules(path+".match", match)
+		}
+	}
+}
+
+func (v *validator) validateMatchRules(path string, raw interface{}) {
+	rules, ok := raw.([]interface{})
+	if !ok {
+		v.problemf("%s: expected a list of match rules", path)
+		return
+	}
+	for i, item := range rules {
+		rulePath := fmt.Sprintf("%s[%d]", path, i)
+		rule, ok := item.(map[string]interface{})
+		if !ok {
+			v.problemf("%s: expected an object with a 'label'", rulePath)
+			continue
+		}
+		v.checkUnknownKeys(rulePath, rule, allowedMatchKeys)
+
+		label, ok := rule["label"].(string)
+		if !ok || strings.TrimSpace(label) == "" {
+			v.problemf("%s: 'label' is required and must be a non-empty string", rulePath)
+		}
+		if value, ok := rule["value"]; ok {
+			if _, ok := value.(string); !ok {
+				v.problemf("%s: 'value' must be a string", rulePath)
+			}
+		}
+		if matchType, ok := rule["type"]; ok {
+			if matchType != matchTypeNode && matchType != matchTypePod {
+				v.problemf("%s: 'type' must be either '%s' or '%s'", rulePath, matchTypeNode, matchTypePod)
+			}
+		}
+		if nested, ok := rule["match"]; ok {
+			v.validateMatchRules(rulePath+".match", nested)
+		}
+	}
+}
+
+// toPriority converts a decoded priority into an integer. Specs are decoded through
+// JSON so numbers arrive as float64.
+func toPriority(value interface{}) (int64, error) {
+	var priority int64
+	switch typed := value.(type) {
+	case float64:
+		if typed != float64(int64(typed)) {
+			return 0, fmt.Errorf("'priority' must be an integer, got '%v'", typed)
+		}
+		priority = int64(typed)
+	case int:
+		priority = int64(typed)
+	case int64:
+		priority = typed
+	default:
+		return 0, fmt.Errorf("'priority' must be an integer, got '%v'", value)
+	}
+	if priority < 0 {
+		return 0, fmt.Errorf("'priority' must be greater than or equal to 0, got '%d'", priority)
+	}
+	return priority, nil
+}
+
+// lintProfileData checks that the TuneD profile data is made of sections, 'key=value'
+// options, comments and blank lines, and that every option belongs to a section.
+func lintProfileData(data string) []string {
+	var problems []string
+	section := ""
+	for i, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "", strings.HasPrefix(trimmed, "#"), strings.HasPrefix(trimmed, ";"):
+			continue
+		case strings.HasPrefix(trimmed, "["):
+			if !strings.HasSuffix(trimmed, "]") || len(trimmed) < 3 {
+				problems = append(problems, fmt.Sprintf("line %d: malformed section header '%s'", i+1, trimmed))
+				continue
+			}
+			section = trimmed[1 : len(trimmed)-1]
+		case strings.Contains(trimmed, "="):
+			if section == "" {
+				problems = append(problems,
+					fmt.Sprintf("line %d: option '%s' must be inside a section such as [main]", i+1, trimmed))
+			}
+			if strings.TrimSpace(strings.SplitN(trimmed, "=", 2)[0]) == "" {
+				problems = append(problems, fmt.Sprintf("line %d: option '%s' has no name", i+1, trimmed))
+			}
+		default:
+			problems = append(problems,
+				fmt.Sprintf("line %d: expected a '[section]' or 'key=value' line, got '%s'", i+1, trimmed))
+		}
+	}
+	return problems
+}
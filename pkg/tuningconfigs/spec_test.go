@@ -0,0 +1,89 @@
+package tuningconfigs
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateSpec", func() {
+	validSpec := func() map[string]interface{} {
+		return map[string]interface{}{
+			"profile": []interface{}{
+				map[string]interface{}{
+					"name": "tuned-1-profile",
+					"data": "[main]\nsummary=Custom OpenShift profile\ninclude=openshift-node\n" +
+						"[sysctl]\nvm.dirty_ratio=\"55\"\n",
+				},
+			},
+			"recommend": []interface{}{
+				map[string]interface{}{
+					"profile":  "tuned-1-profile",
+					"priority": float64(20),
+					"match": []interface{}{
+						map[string]interface{}{"label": "node-role.kubernetes.io/worker", "type": "node"},
+					},
+				},
+			},
+		}
+	}
+
+	It("OK: accepts a valid spec", func() {
+		warnings, err := ValidateSpec(validSpec())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("KO: rejects an empty spec", func() {
+		_, err := ValidateSpec(map[string]interface{}{})
+		Expect(err).To(MatchError(ContainSubstring("spec is empty")))
+	})
+
+	It("KO: reports every problem at once", func() {
+		spec := validSpec()
+		spec["extra"] = true
+		spec["profile"] = append(spec["profile"].([]interface{}), map[string]interface{}{
+			"name": "tuned-1-profile",
+			"data": "summary=no section",
+		})
+		recommend := spec["recommend"].([]interface{})[0].(map[string]interface{})
+		recommend["priority"] = float64(-1)
+		recommend["match"] = []interface{}{map[string]interface{}{"type": "machine"}}
+
+		_, err := ValidateSpec(spec)
+		Expect(err).To(HaveOccurred())
+		problems := err.(*ValidationError).Problems
+		Expect(problems).To(ConsistOf(
+			"spec: unknown field 'extra', expected one of: profile, recommend",
+			"profile[1]: duplicate profile name 'tuned-1-profile'",
+			"profile[1]: line 1: option 'summary=no section' must be inside a section such as [main]",
+			"recommend[0]: 'priority' must be greater than or equal to 0, got '-1'",
+			"recommend[0].match[0]: 'label' is required and must be a non-empty string",
+			"recommend[0].match[0]: 'type' must be either 'node' or 'pod'",
+		))
+	})
+
+	It("KO: requires an integer priority", func() {
+		spec := validSpec()
+		spec["recommend"].([]interface{})[0].(map[string]interface{})["priority"] = "high"
+		_, err := ValidateSpec(spec)
+		Expect(err).To(MatchError(ContainSubstring("'priority' must be an integer, got 'high'")))
+	})
+
+	It("OK: warns about profiles that are not defined in the spec", func() {
+		spec := validSpec()
+		spec["recommend"].([]interface{})[0].(map[string]interface{})["profile"] = "openshift-node"
+		warnings, err := ValidateSpec(spec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(ConsistOf(
+			"recommend[0]: profile 'openshift-node' is not defined in this spec, it must already exist on the cluster",
+		))
+	})
+
+	It("OK: warns when profiles are never recommended", func() {
+		spec := validSpec()
+		delete(spec, "recommend")
+		warnings, err := ValidateSpec(spec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,145 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tuningconfigs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const defaultTemplatePriority = 20
+
+// Template is a built-in TuneD profile that can be used to create a tuning config
+// without writing a spec file.
+type Template struct {
+	Name        string
+	Description string
+	data        string
+}
+
+var templates = map[string]Template{
+	"hugepages": {
+		Name:        "hugepages",
+		Description: "Reserve 50 2MiB hugepages at boot time",
+		data: `[main]
+summary=Boot time configuration for hugepages
+include=openshift-node
+[bootloader]
+cmdline_openshift_node_hugepages=hugepagesz=2M hugepages=50
+`,
+	},
+	"max-map-count": {
+		Name:        "max-map-count",
+		Description: "Raise vm.max_map_count for memory-mapped workloads such as Elasticsearch",
+		data: `[main]
+summary=Raise the maximum number of memory map areas
+include=openshift-node
+[sysctl]
+vm.max_map_count=262144
+`,
+	},
+	"network-latency": {
+		Name:        "network-latency",
+		Description: "Favor low network latency over throughput and power savings",
+		data: `[main]
+summary=Optimize for low network latency
+include=openshift-node
+[sysctl]
+net.core.busy_read=50
+net.core.busy_poll=50
+net.ipv4.tcp_fastopen=3
+kernel.numa_balancing=0
+`,
+	},
+	"network-throughput": {
+		Name:        "network-throughput",
+		Description: "Increase kernel network buffers for high throughput workloads",
+		data: `[main]
+summary=Optimize for high network throughput
+include=openshift-node
+[sysctl]
+net.core.rmem_max=16777216
+net.core.wmem_max=16777216
+net.ipv4.tcp_rmem=4096 87380 16777216
+net.ipv4.tcp_wmem=4096 65536 16777216
+`,
+	},
+	"virtual-memory": {
+		Name:        "virtual-memory",
+		Description: "Flush dirty pages earlier and reduce swapping",
+		data: `[main]
+summary=Tune virtual memory writeback and swapping
+include=openshift-node
+[sysctl]
+vm.dirty_ratio=10
+vm.dirty_background_ratio=3
+vm.swappiness=10
+`,
+	},
+}
+
+// TemplateNames returns the sorted names of the built-in templates.
+func TemplateNames() []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TemplatesHelp returns an indented description of every built-in template, suitable for
+// the long help of commands.
+func TemplatesHelp() string {
+	var lines []string
+	for _, name := range TemplateNames() {
+		lines = append(lines, fmt.Sprintf("  %s: %s", name, templates[name].Description))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// GetTemplate returns the built-in template with the given name.
+func GetTemplate(name string) (Template, error) {
+	template, ok := templates[name]
+	if !ok {
+		return Template{}, fmt.Errorf("Unknown tuning config template '%s', expected one of: %s",
+			name, strings.Join(TemplateNames(), ", "))
+	}
+	return template, nil
+}
+
+// Spec builds the spec section of a tuning config from the template. The TuneD profile
+// is named after the tuning config so that several configs created from the same
+// template do not clash on the cluster.
+func (t Template) Spec(tuningConfigName string) map[string]interface{} {
+	profileName := fmt.Sprintf("%s-%s", tuningConfigName, t.Name)
+	return map[string]interface{}{
+		profileKey: []interface{}{
+			map[string]interface{}{
+				"name": profileName,
+				"data": t.data,
+			},
+		},
+		recommendKey: []interface{}{
+			map[string]interface{}{
+				"profile":  profileName,
+				"priority": float64(defaultTemplatePriority),
+			},
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package tuningconfigs
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Templates", func() {
+	It("OK: every template produces a valid spec", func() {
+		for _, name := range TemplateNames() {
+			template, err := GetTemplate(name)
+			Expect(err).ToNot(HaveOccurred())
+			warnings, err := ValidateSpec(template.Spec("tuned"))
+			Expect(err).ToNot(HaveOccurred(), name)
+			Expect(warnings).To(BeEmpty(), name)
+		}
+	})
+
+	It("OK: names the profile after the tuning config", func() {
+		template, err := GetTemplate("hugepages")
+		Expect(err).ToNot(HaveOccurred())
+		spec := template.Spec("tuned1")
+		Expect(spec["profile"].([]interface{})[0].(map[string]interface{})["name"]).To(Equal("tuned1-hugepages"))
+		Expect(spec["recommend"].([]interface{})[0].(map[string]interface{})["profile"]).To(Equal("tuned1-hugepages"))
+	})
+
+	It("OK: describes every template in the help", func() {
+		help := TemplatesHelp()
+		for _, name := range TemplateNames() {
+			Expect(help).To(ContainSubstring("  " + name + ": "))
+		}
+	})
+
+	It("KO: fails for an unknown template", func() {
+		_, err := GetTemplate("foo")
+		Expect(err).To(MatchError(ContainSubstring("Unknown tuning config template 'foo'")))
+	})
+})
@@ -0,0 +1,145 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradeplan
+
+import (
+	"fmt"
+	"net/url"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	awserr "github.com/openshift-online/ocm-common/pkg/aws/errors"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/reporter"
+)
+
+// Apply makes the changes of the plan. Nothing is changed unless every resource is still in the
+// state it was in when the plan was created, so that only the reviewed changes are made.
+func Apply(awsClient aws.Client, r *reporter.Object, plan *Plan) error {
+	for i, change := range plan.Changes {
+		err := checkUnchanged(awsClient, change)
+		if err != nil {
+			return fmt.Errorf("Change %d of the plan can't be applied: %v. Create a new plan", i+1, err)
+		}
+	}
+	for _, change := range plan.Changes {
+		err := apply(awsClient, r, plan, change)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkUnchanged(awsClient aws.Client, change *Change) error {
+	switch change.Type {
+	case ChangePermissionPolicy:
+		_, err := awsClient.IsPolicyExists(change.PolicyARN)
+		if err != nil {
+			if !awserr.IsNoSuchEntityException(err) {
+				return fmt.Errorf("failed to get policy '%s': %v", change.PolicyARN, err)
+			}
+			if change.Current != "" {
+				return fmt.Errorf("policy '%s' has been deleted", change.PolicyARN)
+			}
+			return nil
+		}
+		if change.Current == "" {
+			return fmt.Errorf("policy '%s' has been created", change.PolicyARN)
+		}
+		current, err := awsClient.GetDefaultPolicyDocument(change.PolicyARN)
+		if err != nil {
+			return fmt.Errorf("failed to get the document of policy '%s': %v", change.PolicyARN, err)
+		}
+		if normalize(current) != change.Current {
+			return fmt.Errorf("policy '%s' has changed", change.PolicyARN)
+		}
+	case ChangeTrustPolicy, ChangeRoleTag:
+		role, err := awsClient.GetRoleByName(change.RoleName)
+		if err != nil {
+			return fmt.Errorf("failed to get role '%s': %v", change.RoleName, err)
+		}
+		if change.Type == ChangeRoleTag {
+			if tagValue(role.Tags, change.TagKey) != change.Current {
+				return fmt.Errorf("tag '%s' of role '%s' has changed", change.TagKey, change.RoleName)
+			}
+			return nil
+		}
+		current, err := url.QueryUnescape(awssdk.ToString(role.AssumeRolePolicyDocument))
+		if err != nil {
+			return err
+		}
+		if normalize(current) != change.Current {
+			return fmt.Errorf("trust policy of role '%s' has changed", change.RoleName)
+		}
+	case ChangeOperatorRole:
+		exists, _, err := awsClient.CheckRoleExists(change.RoleName)
+		if err != nil {
+			return fmt.Errorf("failed to check if role '%s' exists: %v", change.RoleName, err)
+		}
+		if exists {
+			return fmt.Errorf("role '%s' has been created", change.RoleName)
+		}
+	default:
+		return fmt.Errorf("unknown type '%s'", change.Type)
+	}
+	return nil
+}
+
+func apply(awsClient aws.Client, r *reporter.Object, plan *Plan, change *Change) error {
+	switch change.Type {
+	case ChangePermissionPolicy:
+		// The document is known to differ, so a new version is created whatever the version tag
+		policyARN, err := awsClient.ForceEnsurePolicy(change.PolicyARN, change.Desired, plan.PolicyVersion,
+			change.Tags, change.Path)
+		if err != nil {
+			return fmt.Errorf("Failed to upgrade policy '%s': %v", change.PolicyARN, err)
+		}
+		if change.RoleName != "" {
+			err = awsClient.AttachRolePolicy(r, change.RoleName, policyARN)
+			if err != nil {
+				return fmt.Errorf("Failed to attach policy '%s' to role '%s': %v", policyARN, change.RoleName, err)
+			}
+		}
+		r.Infof("Upgraded policy with ARN '%s' to version '%s'", policyARN, plan.PolicyVersion)
+	case ChangeTrustPolicy:
+		err := awsClient.UpdateAssumeRolePolicy(change.RoleName, change.Desired)
+		if err != nil {
+			return fmt.Errorf("Failed to update trust policy of role '%s': %v", change.RoleName, err)
+		}
+		r.Infof("Updated trust policy of role '%s'", change.RoleName)
+	case ChangeRoleTag:
+		err := awsClient.AddRoleTag(change.RoleName, change.TagKey, change.Desired)
+		if err != nil {
+			return fmt.Errorf("Failed to tag role '%s': %v", change.RoleName, err)
+		}
+		r.Infof("Set tag '%s' of role '%s' to '%s'", change.TagKey, change.RoleName, change.Desired)
+	case ChangeOperatorRole:
+		roleARN, err := awsClient.EnsureRole(r, change.RoleName, change.Desired, "", "", change.Tags,
+			change.Path, false)
+		if err != nil {
+			return fmt.Errorf("Failed to create role '%s': %v", change.RoleName, err)
+		}
+		r.Infof("Created role '%s' with ARN '%s'", change.RoleName, roleARN)
+		err = awsClient.AttachRolePolicy(r, change.RoleName, change.PolicyARN)
+		if err != nil {
+			return fmt.Errorf("Failed to attach role policy. Check your prefix or run "+
+				"'rosa create operator-roles' to create the necessary policies: %s", err)
+		}
+	}
+	return nil
+}
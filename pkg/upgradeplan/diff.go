@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradeplan
+
+import (
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around the changed ones
+const diffContext = 3
+
+// Diff compares the two documents line by line and returns the removed lines prefixed with '-',
+// the added ones with '+' and a few unchanged lines around them, with '...' between distant
+// changes.
+func Diff(current string, desired string) []string {
+	oldLines := splitLines(current)
+	newLines := splitLines(desired)
+
+	// lengths[i][j] is the length of the longest common subsequence of oldLines[i:] and newLines[j:]
+	lengths := make([][]int, len(oldLines)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			lines = append(lines, "  "+oldLines[i])
+			i++
+			j++
+		case i < len(oldLines) && (j == len(newLines) || lengths[i+1][j] >= lengths[i][j+1]):
+			lines = append(lines, "- "+oldLines[i])
+			i++
+		default:
+			lines = append(lines, "+ "+newLines[j])
+			j++
+		}
+	}
+	return trimContext(lines)
+}
+
+// trimContext drops the unchanged lines that are far from any change
+func trimContext(lines []string) []string {
+	keep := make([]bool, len(lines))
+	for i, line := range lines {
+		if strings.HasPrefix(line, "  ") {
+			continue
+		}
+		for k := max(0, i-diffContext); k <= min(len(lines)-1, i+diffContext); k++ {
+			keep[k] = true
+		}
+	}
+	var result []string
+	skipped := false
+	for i, line := range lines {
+		if !keep[i] {
+			skipped = true
+			continue
+		}
+		if skipped && len(result) > 0 {
+			result = append(result, "...")
+		}
+		skipped = false
+		result = append(result, line)
+	}
+	return result
+}
+
+func splitLines(document string) []string {
+	if document == "" {
+		return nil
+	}
+	return strings.Split(document, "\n")
+}
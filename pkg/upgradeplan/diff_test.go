@@ -0,0 +1,38 @@
+package upgradeplan
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	It("marks the removed and added lines", func() {
+		Expect(Diff("a\nb\nc", "a\nd\nc")).To(Equal([]string{"  a", "- b", "+ d", "  c"}))
+	})
+
+	It("adds every line of a new document", func() {
+		Expect(Diff("", "a\nb")).To(Equal([]string{"+ a", "+ b"}))
+	})
+
+	It("returns nothing for equal documents", func() {
+		Expect(Diff("a\nb", "a\nb")).To(BeEmpty())
+	})
+
+	It("only keeps the unchanged lines around the changes", func() {
+		lines := []string{}
+		for _, c := range "abcdefghijklmnop" {
+			lines = append(lines, string(c))
+		}
+		current := strings.Join(lines, "\n")
+		lines[1] = "B"
+		lines[14] = "O"
+		desired := strings.Join(lines, "\n")
+		Expect(Diff(current, desired)).To(Equal([]string{
+			"  a", "- b", "+ B", "  c", "  d", "  e",
+			"...",
+			"  l", "  m", "  n", "- o", "+ O", "  p",
+		}))
+	})
+})
@@ -0,0 +1,583 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package upgradeplan computes, before changing anything, the policy documents, trust policies
+// and tags that 'rosa upgrade roles' would change for a cluster upgrade, so that the plan can be
+// reviewed, saved to a file and applied later exactly as reviewed.
+package upgradeplan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	awserr "github.com/openshift-online/ocm-common/pkg/aws/errors"
+	common "github.com/openshift-online/ocm-common/pkg/aws/validations"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/helper/roles"
+)
+
+// Types of changes in a plan
+const (
+	// ChangePermissionPolicy creates or adds a version to a permission policy and attaches it to the role
+	ChangePermissionPolicy = "permission-policy"
+	// ChangeTrustPolicy replaces the trust policy of a role
+	ChangeTrustPolicy = "trust-policy"
+	// ChangeRoleTag sets a tag of a role
+	ChangeRoleTag = "role-tag"
+	// ChangeOperatorRole creates an operator role required by the new cluster version
+	ChangeOperatorRole = "operator-role"
+)
+
+const operatorRoleTrustPolicy = "operator_iam_role_policy"
+
+// Plan lists the changes to the roles of a cluster needed to upgrade it to a version
+type Plan struct {
+	ClusterID      string    `json:"clusterId"`
+	ClusterVersion string    `json:"clusterVersion"`
+	PolicyVersion  string    `json:"policyVersion"`
+	AccountID      string    `json:"accountId"`
+	CreatedAt      time.Time `json:"createdAt"`
+	Changes        []*Change `json:"changes"`
+}
+
+// Change is a single change to a role or policy
+type Change struct {
+	Type      string `json:"type"`
+	RoleName  string `json:"roleName,omitempty"`
+	PolicyARN string `json:"policyArn,omitempty"`
+	// Path is the IAM path of the policy or role created by the change
+	Path   string `json:"path,omitempty"`
+	TagKey string `json:"tagKey,omitempty"`
+	// Current and Desired are the documents or tag values before and after the change. Current
+	// is empty when the policy or role doesn't exist yet.
+	Current string `json:"current,omitempty"`
+	Desired string `json:"desired"`
+	// CurrentVersion is the OpenShift version the current permission policy is tagged with
+	CurrentVersion string `json:"currentVersion,omitempty"`
+	// Tags are set on the policy or role when the change is applied
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// Input is what OCM expects of the roles of the cluster for the upgrade
+type Input struct {
+	Cluster              *cmv1.Cluster
+	ClusterVersion       string
+	PolicyVersion        string
+	Env                  string
+	AccountRolePolicies  map[string]*cmv1.AWSSTSPolicy
+	OperatorRolePolicies map[string]*cmv1.AWSSTSPolicy
+	CredRequests         map[string]*cmv1.STSOperator
+	// MissingOperators are the operators of the new version without a role in the cluster
+	MissingOperators map[string]*cmv1.STSOperator
+}
+
+type planner struct {
+	awsClient aws.Client
+	creator   *aws.Creator
+	input     *Input
+	plan      *Plan
+	// operatorPath is the IAM path of the operator roles of the cluster and of their policies
+	operatorPath string
+}
+
+// Build compares the roles and policies of the cluster in the AWS account with the ones expected
+// for the upgrade. It only reads from AWS.
+func Build(awsClient aws.Client, creator *aws.Creator, input *Input) (*Plan, error) {
+	p := &planner{
+		awsClient: awsClient,
+		creator:   creator,
+		input:     input,
+		plan: &Plan{
+			ClusterID:      input.Cluster.ID(),
+			ClusterVersion: input.ClusterVersion,
+			PolicyVersion:  input.PolicyVersion,
+			AccountID:      creator.AccountID,
+			CreatedAt:      time.Now().UTC(),
+			Changes:        []*Change{},
+		},
+	}
+	operatorRoles := input.Cluster.AWS().STS().OperatorIAMRoles()
+	if len(operatorRoles) == 0 {
+		return nil, fmt.Errorf("Cluster '%s' doesn't have any operator roles associated with it",
+			input.Cluster.ID())
+	}
+	operatorPath, err := aws.GetPathFromARN(operatorRoles[0].RoleARN())
+	if err != nil {
+		return nil, err
+	}
+	p.operatorPath = operatorPath
+	// Account role policies must be upgraded before operator role policies
+	err = p.accountRoles()
+	if err != nil {
+		return nil, err
+	}
+	err = p.operatorRoles()
+	if err != nil {
+		return nil, err
+	}
+	err = p.missingOperatorRoles()
+	if err != nil {
+		return nil, err
+	}
+	return p.plan, nil
+}
+
+func (p *planner) add(change *Change) {
+	p.plan.Changes = append(p.plan.Changes, change)
+}
+
+func (p *planner) managedPolicies() bool {
+	return p.input.Cluster.AWS().STS().ManagedPolicies()
+}
+
+// accountRoles plans the upgrade of the permission policies of the account roles, like 'rosa
+// upgrade roles' does. Their trust policies are left untouched.
+func (p *planner) accountRoles() error {
+	// Policies managed by AWS are upgraded by AWS
+	if p.managedPolicies() {
+		return nil
+	}
+	cluster := p.input.Cluster
+	files := make([]string, 0, len(aws.AccountRoles))
+	for file := range aws.AccountRoles {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		roleName, err := aws.GetAccountRoleName(cluster, aws.AccountRoles[file].Name)
+		if err != nil {
+			return err
+		}
+		if roleName == "" {
+			continue
+		}
+		role, err := p.awsClient.GetRoleByName(roleName)
+		if err != nil {
+			return fmt.Errorf("Failed to get account role '%s': %v", roleName, err)
+		}
+
+		prefix, err := aws.GetPrefixFromAccountRole(cluster, aws.AccountRoles[file].Name)
+		if err != nil {
+			return err
+		}
+		rolePath, err := aws.GetPathFromAccountRole(cluster, aws.AccountRoles[file].Name)
+		if err != nil {
+			return err
+		}
+		policyARN, err := AccountRolePolicyARN(p.awsClient, roleName, prefix, rolePath,
+			p.creator.Partition, p.creator.AccountID, nil)
+		if err != nil {
+			return err
+		}
+		err = p.permissionPolicy(roleName, policyARN,
+			aws.GetPolicyDetails(p.input.AccountRolePolicies, fmt.Sprintf("sts_%s_permission_policy", file)),
+			map[string]string{
+				common.OpenShiftVersion: p.input.PolicyVersion,
+				tags.RolePrefix:         prefix,
+				tags.RoleType:           file,
+				tags.RedHatManaged:      tags.True,
+			})
+		if err != nil {
+			return err
+		}
+
+		currentVersion := tagValue(role.Tags, common.OpenShiftVersion)
+		if currentVersion != p.input.PolicyVersion {
+			p.add(&Change{
+				Type:     ChangeRoleTag,
+				RoleName: roleName,
+				TagKey:   common.OpenShiftVersion,
+				Current:  currentVersion,
+				Desired:  p.input.PolicyVersion,
+			})
+		}
+	}
+	return nil
+}
+
+func (p *planner) operatorRoles() error {
+	cluster := p.input.Cluster
+	operatorRoles := cluster.AWS().STS().OperatorIAMRoles()
+	policyPrefix, err := aws.GetOperatorRolePolicyPrefixFromCluster(cluster, p.awsClient)
+	if err != nil {
+		return fmt.Errorf("Error getting operator role policy prefix: %v", err)
+	}
+	isSharedVpc := cluster.AWS().PrivateHostedZoneRoleARN() != ""
+	trustPolicyDetails := aws.GetPolicyDetails(p.input.OperatorRolePolicies, operatorRoleTrustPolicy)
+
+	for _, credRequest := range sortedKeys(p.input.CredRequests) {
+		operator := p.input.CredRequests[credRequest]
+		operatorRoleARN := aws.FindOperatorRoleBySTSOperator(operatorRoles, operator)
+		roleName := ""
+		policyARN := aws.GetOperatorPolicyARN(p.creator.Partition, p.creator.AccountID, policyPrefix,
+			operator.Namespace(), operator.Name(), p.operatorPath)
+		if operatorRoleARN != "" {
+			roleName, err = aws.GetResourceIdFromARN(operatorRoleARN)
+			if err != nil {
+				return err
+			}
+			role, err := p.awsClient.GetRoleByName(roleName)
+			if err != nil {
+				return fmt.Errorf("Failed to get operator role '%s': %v", roleName, err)
+			}
+			trustPolicy, err := aws.GenerateOperatorRolePolicyDoc(p.creator.Partition, cluster,
+				p.creator.AccountID, operator, trustPolicyDetails)
+			if err != nil {
+				return err
+			}
+			err = p.trustPolicy(role, trustPolicy)
+			if err != nil {
+				return err
+			}
+			if p.managedPolicies() {
+				continue
+			}
+			policyARN, err = OperatorRolePolicyARN(p.awsClient, roleName, policyPrefix, p.operatorPath, operator,
+				p.creator.Partition, p.creator.AccountID, nil)
+			if err != nil {
+				return err
+			}
+		} else if p.managedPolicies() {
+			continue
+		}
+
+		policy := aws.GetPolicyDetails(p.input.OperatorRolePolicies,
+			aws.GetOperatorPolicyKey(credRequest, cluster.Hypershift().Enabled(), isSharedVpc))
+		if isSharedVpc {
+			policy = aws.InterpolatePolicyDocument(p.creator.Partition, policy, map[string]string{
+				"shared_vpc_role_arn": cluster.AWS().PrivateHostedZoneRoleARN(),
+			})
+		}
+		err = p.permissionPolicy(roleName, policyARN, policy, map[string]string{
+			common.OpenShiftVersion: p.input.PolicyVersion,
+			tags.RolePrefix:         policyPrefix,
+			tags.OperatorNamespace:  operator.Namespace(),
+			tags.OperatorName:       operator.Name(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *planner) missingOperatorRoles() error {
+	cluster := p.input.Cluster
+	if len(p.input.MissingOperators) == 0 {
+		return nil
+	}
+	policyPrefix, err := aws.GetOperatorRolePolicyPrefixFromCluster(cluster, p.awsClient)
+	if err != nil {
+		return fmt.Errorf("Error getting operator role policy prefix: %v", err)
+	}
+	trustPolicyDetails := aws.GetPolicyDetails(p.input.OperatorRolePolicies, operatorRoleTrustPolicy)
+	for _, key := range sortedKeys(p.input.MissingOperators) {
+		operator := p.input.MissingOperators[key]
+		roleName := roles.GetOperatorRoleName(cluster, operator)
+		exists, _, err := p.awsClient.CheckRoleExists(roleName)
+		if err != nil {
+			return fmt.Errorf("Failed to check if operator role '%s' exists: %v", roleName, err)
+		}
+		if exists {
+			continue
+		}
+		roleTags := map[string]string{
+			tags.ClusterID:         cluster.ID(),
+			tags.OperatorNamespace: operator.Namespace(),
+			tags.OperatorName:      operator.Name(),
+			tags.RedHatManaged:     tags.True,
+		}
+		var policyARN string
+		if p.managedPolicies() {
+			policyARN, err = aws.GetManagedPolicyARN(p.input.OperatorRolePolicies,
+				fmt.Sprintf("openshift_%s_policy", key))
+			if err != nil {
+				return err
+			}
+			roleTags[common.ManagedPolicies] = tags.True
+		} else {
+			policyARN = aws.GetOperatorPolicyARN(p.creator.Partition, p.creator.AccountID, policyPrefix,
+				operator.Namespace(), operator.Name(), p.operatorPath)
+		}
+		trustPolicy, err := aws.GenerateOperatorRolePolicyDoc(p.creator.Partition, cluster,
+			p.creator.AccountID, operator, trustPolicyDetails)
+		if err != nil {
+			return err
+		}
+		p.add(&Change{
+			Type:      ChangeOperatorRole,
+			RoleName:  roleName,
+			PolicyARN: policyARN,
+			Path:      p.operatorPath,
+			Desired:   normalize(trustPolicy),
+			Tags:      roleTags,
+		})
+	}
+	return nil
+}
+
+func (p *planner) trustPolicy(role iamtypes.Role, desired string) error {
+	if desired == "" {
+		return nil
+	}
+	current, err := url.QueryUnescape(awssdk.ToString(role.AssumeRolePolicyDocument))
+	if err != nil {
+		return err
+	}
+	if normalize(current) == normalize(desired) {
+		return nil
+	}
+	p.add(&Change{
+		Type:     ChangeTrustPolicy,
+		RoleName: awssdk.ToString(role.RoleName),
+		Current:  normalize(current),
+		Desired:  normalize(desired),
+	})
+	return nil
+}
+
+// permissionPolicy plans the new version of the policy when its document or version differ
+func (p *planner) permissionPolicy(roleName string, policyARN string, desired string,
+	policyTags map[string]string) error {
+	if desired == "" {
+		return nil
+	}
+	path, err := aws.GetPathFromARN(policyARN)
+	if err != nil {
+		return err
+	}
+	change := &Change{
+		Type:      ChangePermissionPolicy,
+		RoleName:  roleName,
+		PolicyARN: policyARN,
+		Path:      path,
+		Desired:   normalize(desired),
+		Tags:      policyTags,
+	}
+	output, err := p.awsClient.IsPolicyExists(policyARN)
+	if err != nil {
+		if !awserr.IsNoSuchEntityException(err) {
+			return fmt.Errorf("Failed to get policy '%s': %v", policyARN, err)
+		}
+		p.add(change)
+		return nil
+	}
+	current, err := p.awsClient.GetDefaultPolicyDocument(policyARN)
+	if err != nil {
+		return fmt.Errorf("Failed to get the document of policy '%s': %v", policyARN, err)
+	}
+	change.Current = normalize(current)
+	change.CurrentVersion = tagValue(output.Policy.Tags, common.OpenShiftVersion)
+	if change.Current == change.Desired && change.CurrentVersion == p.input.PolicyVersion {
+		return nil
+	}
+	p.add(change)
+	return nil
+}
+
+// AccountRolePolicyARN returns the ARN of the permission policy of the account role: the default
+// policy attached to the role, or the one ROSA creates for it when none is attached.
+func AccountRolePolicyARN(awsClient aws.Client, roleName string, prefix string, rolePath string,
+	partition string, accountID string, policiesDetails []aws.PolicyDetail) (string, error) {
+	var err error
+	if policiesDetails == nil {
+		policiesDetails, err = awsClient.GetAttachedPolicy(&roleName)
+		if err != nil {
+			return "", err
+		}
+	}
+	generatedPolicyARN := aws.GetPolicyARN(partition, accountID, roleName, rolePath)
+	if len(aws.FindAllAttachedPolicyDetails(policiesDetails)) == 0 {
+		return generatedPolicyARN, nil
+	}
+	policyArn, err := awsClient.GetAccountRoleDefaultPolicy(roleName, prefix)
+	if err != nil {
+		return "", err
+	}
+	if policyArn == "" {
+		return generatedPolicyARN, nil
+	}
+	return policyArn, nil
+}
+
+// OperatorRolePolicyARN returns the ARN of the permission policy of the operator role: the default
+// policy attached to the role, or the one ROSA creates for it when none is attached.
+func OperatorRolePolicyARN(awsClient aws.Client, operatorRoleName string, operatorRolePolicyPrefix string,
+	operatorPolicyPath string, operator *cmv1.STSOperator, partition string, accountID string,
+	policiesDetails []aws.PolicyDetail) (string, error) {
+	var err error
+	if policiesDetails == nil {
+		policiesDetails, err = awsClient.GetAttachedPolicy(&operatorRoleName)
+		if err != nil {
+			return "", err
+		}
+	}
+	generatedPolicyARN := aws.GetOperatorPolicyARN(partition, accountID, operatorRolePolicyPrefix,
+		operator.Namespace(), operator.Name(), operatorPolicyPath)
+	if len(aws.FindAllAttachedPolicyDetails(policiesDetails)) == 0 {
+		return generatedPolicyARN, nil
+	}
+	policyArn, err := awsClient.GetOperatorRoleDefaultPolicy(operatorRoleName)
+	if err != nil {
+		return "", err
+	}
+	if policyArn == "" {
+		return generatedPolicyARN, nil
+	}
+	return policyArn, nil
+}
+
+// Save writes the plan to the file, readable only by the current user
+func (p *Plan) Save(filename string) error {
+	body, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(body, '\n'), 0600)
+}
+
+// Load reads a plan saved to a file
+func Load(filename string) (*Plan, error) {
+	body, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read plan file '%s': %v", filename, err)
+	}
+	plan := &Plan{}
+	err = json.Unmarshal(body, plan)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse plan file '%s': %v", filename, err)
+	}
+	for i, change := range plan.Changes {
+		switch change.Type {
+		case ChangePermissionPolicy, ChangeTrustPolicy, ChangeRoleTag, ChangeOperatorRole:
+		default:
+			return nil, fmt.Errorf("Invalid plan file '%s': unknown type '%s' of change %d",
+				filename, change.Type, i+1)
+		}
+	}
+	return plan, nil
+}
+
+// Validate checks that the plan was made for the upgrade of the cluster in the AWS account
+func (p *Plan) Validate(clusterID string, clusterVersion string, accountID string) error {
+	if p.ClusterID != clusterID {
+		return fmt.Errorf("The plan was created for cluster '%s', not '%s'", p.ClusterID, clusterID)
+	}
+	if p.ClusterVersion != clusterVersion {
+		return fmt.Errorf("The plan was created for the upgrade to version '%s', not '%s'",
+			p.ClusterVersion, clusterVersion)
+	}
+	if p.AccountID != accountID {
+		return fmt.Errorf("The plan was created for AWS account '%s', not '%s'", p.AccountID, accountID)
+	}
+	return nil
+}
+
+// Describe lists the changes of the plan, with the differences between the current and desired
+// policy documents
+func (p *Plan) Describe() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Plan to upgrade the roles of cluster '%s' to version '%s' (policy version '%s'), created at %s\n",
+		p.ClusterID, p.ClusterVersion, p.PolicyVersion, p.CreatedAt.Format(time.RFC3339))
+	for i, change := range p.Changes {
+		fmt.Fprintf(&b, "\n%d. %s\n", i+1, change.summary(p.PolicyVersion))
+		var lines []string
+		switch change.Type {
+		case ChangePermissionPolicy, ChangeTrustPolicy:
+			lines = Diff(change.Current, change.Desired)
+		case ChangeOperatorRole:
+			lines = Diff("", change.Desired)
+		}
+		for _, line := range lines {
+			fmt.Fprintf(&b, "   %s\n", line)
+		}
+	}
+	return b.String()
+}
+
+func (c *Change) summary(policyVersion string) string {
+	switch c.Type {
+	case ChangePermissionPolicy:
+		summary := fmt.Sprintf("Create permission policy '%s'", c.PolicyARN)
+		if c.Current != "" {
+			summary = fmt.Sprintf("Update permission policy '%s' from version '%s' to '%s'",
+				c.PolicyARN, c.CurrentVersion, policyVersion)
+			if c.Current == c.Desired {
+				summary += ", the document doesn't change"
+			}
+		}
+		if c.RoleName != "" {
+			summary += fmt.Sprintf(" and attach it to role '%s'", c.RoleName)
+		}
+		return summary
+	case ChangeTrustPolicy:
+		return fmt.Sprintf("Update trust policy of role '%s'", c.RoleName)
+	case ChangeRoleTag:
+		return fmt.Sprintf("Set tag '%s' of role '%s' from '%s' to '%s'", c.TagKey, c.RoleName, c.Current, c.Desired)
+	case ChangeOperatorRole:
+		return fmt.Sprintf("Create operator role '%s' with permission policy '%s' and trust policy",
+			c.RoleName, c.PolicyARN)
+	}
+	return c.Type
+}
+
+// normalize indents the JSON document with sorted keys so that documents differing only in
+// formatting compare equal
+func normalize(document string) string {
+	var value interface{}
+	err := json.Unmarshal([]byte(document), &value)
+	if err != nil {
+		return document
+	}
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(value)
+	if err != nil {
+		return document
+	}
+	return strings.TrimSuffix(body.String(), "\n")
+}
+
+func tagValue(resourceTags []iamtypes.Tag, key string) string {
+	for _, tag := range resourceTags {
+		if awssdk.ToString(tag.Key) == key {
+			return awssdk.ToString(tag.Value)
+		}
+	}
+	return ""
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,247 @@
+package upgradeplan
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/reporter"
+)
+
+var _ = Describe("Plan", func() {
+	const (
+		accountID        = "123456789012"
+		installerRole    = "acme-Installer-Role"
+		ingressRole      = "acme-openshift-ingress-operator-cloud-credentials"
+		installerPolicy  = "arn:aws:iam::123456789012:policy/acme-Installer-Role-Policy"
+		ingressPolicy    = "arn:aws:iam::123456789012:policy/acme-openshift-ingress-operator-cloud-credentials"
+		newTrustPolicy   = `{"Statement":[{"Action":"sts:AssumeRole","Principal":{"AWS":"arn:%{partition}:iam::%{aws_account_id}:root"}}]}`
+		oldPermissions   = `{"Statement":[{"Action":["ec2:DescribeInstances"],"Effect":"Allow"}]}`
+		newPermissions   = `{"Statement":[{"Action":["ec2:DescribeInstances","ec2:DescribeSubnets"],"Effect":"Allow"}]}`
+		ingressPolicyDoc = `{"Statement":[{"Action":["elasticloadbalancing:*"],"Effect":"Allow"}]}`
+	)
+
+	var (
+		mockCtrl  *gomock.Controller
+		awsClient *aws.MockClient
+		creator   *aws.Creator
+		input     *Input
+	)
+
+	policy := func(id string, details string) *cmv1.AWSSTSPolicy {
+		p, err := cmv1.NewAWSSTSPolicy().ID(id).Details(details).Build()
+		Expect(err).NotTo(HaveOccurred())
+		return p
+	}
+	operator := func(namespace string, name string) *cmv1.STSOperator {
+		o, err := cmv1.NewSTSOperator().Namespace(namespace).Name(name).
+			ServiceAccounts("operator").Build()
+		Expect(err).NotTo(HaveOccurred())
+		return o
+	}
+	trustPolicy := func(jumpAccount string) string {
+		return fmt.Sprintf(`{ "Statement": [ { "Principal": { "AWS": "arn:aws:iam::%s:root" },
+			"Action": "sts:AssumeRole" } ] }`, jumpAccount)
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		awsClient = aws.NewMockClient(mockCtrl)
+		creator = &aws.Creator{AccountID: accountID, Partition: "aws"}
+		cluster, err := cmv1.NewCluster().ID("cluster-id").AWS(cmv1.NewAWS().STS(cmv1.NewSTS().
+			RoleARN("arn:aws:iam::123456789012:role/" + installerRole).
+			OIDCEndpointURL("https://oidc.example.com/abc").
+			OperatorRolePrefix("acme").
+			OperatorIAMRoles(cmv1.NewOperatorIAMRole().Namespace("openshift-ingress-operator").
+				Name("cloud-credentials").RoleARN("arn:aws:iam::123456789012:role/" + ingressRole)))).Build()
+		Expect(err).NotTo(HaveOccurred())
+		input = &Input{
+			Cluster:        cluster,
+			ClusterVersion: "4.15.3",
+			PolicyVersion:  "4.15",
+			Env:            "production",
+			AccountRolePolicies: map[string]*cmv1.AWSSTSPolicy{
+				"sts_installer_trust_policy":      policy("sts_installer_trust_policy", newTrustPolicy),
+				"sts_installer_permission_policy": policy("sts_installer_permission_policy", newPermissions),
+			},
+			OperatorRolePolicies: map[string]*cmv1.AWSSTSPolicy{
+				"openshift_ingress_policy": policy("openshift_ingress_policy", ingressPolicyDoc),
+				"operator_iam_role_policy": policy("operator_iam_role_policy",
+					`{"Statement":[{"Principal":{"Federated":"%{oidc_provider_arn}"}}]}`),
+			},
+			CredRequests: map[string]*cmv1.STSOperator{
+				"ingress": operator("openshift-ingress-operator", "cloud-credentials"),
+			},
+			MissingOperators: map[string]*cmv1.STSOperator{
+				"new_operator": operator("openshift-new-operator", "credentials"),
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("lists the changes needed for the upgrade", func() {
+		// Trust policies of account roles are not upgraded
+		awsClient.EXPECT().GetRoleByName(installerRole).Return(iamtypes.Role{
+			RoleName:                 awssdk.String(installerRole),
+			AssumeRolePolicyDocument: awssdk.String(url.QueryEscape(trustPolicy("111111111111"))),
+			Tags:                     []iamtypes.Tag{{Key: awssdk.String("rosa_openshift_version"), Value: awssdk.String("4.14")}},
+		}, nil)
+		awsClient.EXPECT().GetAttachedPolicy(gomock.Any()).Return([]aws.PolicyDetail{}, nil).Times(2)
+		awsClient.EXPECT().IsPolicyExists(installerPolicy).Return(&iam.GetPolicyOutput{
+			Policy: &iamtypes.Policy{
+				Tags: []iamtypes.Tag{{Key: awssdk.String("rosa_openshift_version"), Value: awssdk.String("4.14")}},
+			},
+		}, nil)
+		awsClient.EXPECT().GetDefaultPolicyDocument(installerPolicy).Return(oldPermissions, nil)
+		awsClient.EXPECT().GetRoleByName(ingressRole).Return(iamtypes.Role{
+			RoleName:                 awssdk.String(ingressRole),
+			AssumeRolePolicyDocument: awssdk.String("{}"),
+		}, nil)
+		awsClient.EXPECT().IsPolicyExists(ingressPolicy).Return(nil, &iamtypes.NoSuchEntityException{})
+		awsClient.EXPECT().CheckRoleExists("acme-openshift-new-operator-credentials").Return(false, "", nil)
+
+		plan, err := Build(awsClient, creator, input)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.ClusterID).To(Equal("cluster-id"))
+		Expect(plan.AccountID).To(Equal(accountID))
+		Expect(plan.Changes).To(HaveLen(5))
+
+		Expect(plan.Changes[0].Type).To(Equal(ChangePermissionPolicy))
+		Expect(plan.Changes[0].RoleName).To(Equal(installerRole))
+		Expect(plan.Changes[0].PolicyARN).To(Equal(installerPolicy))
+		Expect(plan.Changes[0].CurrentVersion).To(Equal("4.14"))
+		Expect(plan.Changes[0].Desired).To(Equal(normalize(newPermissions)))
+
+		Expect(plan.Changes[1].Type).To(Equal(ChangeRoleTag))
+		Expect(plan.Changes[1].Current).To(Equal("4.14"))
+		Expect(plan.Changes[1].Desired).To(Equal("4.15"))
+
+		Expect(plan.Changes[2].Type).To(Equal(ChangeTrustPolicy))
+		Expect(plan.Changes[2].RoleName).To(Equal(ingressRole))
+		Expect(plan.Changes[2].Desired).To(ContainSubstring("oidc.example.com/abc"))
+
+		Expect(plan.Changes[3].Type).To(Equal(ChangePermissionPolicy))
+		Expect(plan.Changes[3].PolicyARN).To(Equal(ingressPolicy))
+		Expect(plan.Changes[3].Current).To(BeEmpty())
+
+		Expect(plan.Changes[4].Type).To(Equal(ChangeOperatorRole))
+		Expect(plan.Changes[4].RoleName).To(Equal("acme-openshift-new-operator-credentials"))
+		Expect(plan.Changes[4].PolicyARN).To(Equal(
+			"arn:aws:iam::123456789012:policy/acme-openshift-new-operator-credentials"))
+		Expect(plan.Changes[4].Path).To(BeEmpty())
+
+		description := plan.Describe()
+		Expect(description).To(ContainSubstring(
+			"1. Update permission policy '" + installerPolicy + "' from version '4.14' to '4.15'"))
+		Expect(description).To(ContainSubstring(`+         "ec2:DescribeSubnets"`))
+		Expect(description).To(ContainSubstring("2. Set tag 'rosa_openshift_version' of role '" + installerRole +
+			"' from '4.14' to '4.15'"))
+	})
+
+	It("uses the path of the operator roles for the policies of missing operator roles", func() {
+		cluster, err := cmv1.NewCluster().ID("cluster-id").AWS(cmv1.NewAWS().STS(cmv1.NewSTS().
+			RoleARN("arn:aws:iam::123456789012:role/" + installerRole).
+			OIDCEndpointURL("https://oidc.example.com/abc").
+			OperatorRolePrefix("acme").
+			OperatorIAMRoles(cmv1.NewOperatorIAMRole().Namespace("openshift-ingress-operator").
+				Name("cloud-credentials").
+				RoleARN("arn:aws:iam::123456789012:role/operators/" + ingressRole)))).Build()
+		Expect(err).NotTo(HaveOccurred())
+		input.Cluster = cluster
+		input.AccountRolePolicies = map[string]*cmv1.AWSSTSPolicy{}
+		input.CredRequests = map[string]*cmv1.STSOperator{}
+		awsClient.EXPECT().GetRoleByName(installerRole).Return(iamtypes.Role{
+			Tags: []iamtypes.Tag{{Key: awssdk.String("rosa_openshift_version"), Value: awssdk.String("4.15")}},
+		}, nil)
+		awsClient.EXPECT().GetAttachedPolicy(gomock.Any()).Return([]aws.PolicyDetail{}, nil)
+		awsClient.EXPECT().CheckRoleExists("acme-openshift-new-operator-credentials").Return(false, "", nil)
+
+		plan, err := Build(awsClient, creator, input)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Changes).To(HaveLen(1))
+		Expect(plan.Changes[0].Type).To(Equal(ChangeOperatorRole))
+		Expect(plan.Changes[0].Path).To(Equal("/operators/"))
+		Expect(plan.Changes[0].PolicyARN).To(Equal(
+			"arn:aws:iam::123456789012:policy/operators/acme-openshift-new-operator-credentials"))
+	})
+
+	Context("Save and Load", func() {
+		It("reads back the saved plan", func() {
+			plan := &Plan{ClusterID: "cluster-id", ClusterVersion: "4.15.3", AccountID: accountID,
+				Changes: []*Change{{Type: ChangeRoleTag, RoleName: installerRole, Desired: "4.15"}}}
+			filename := filepath.Join(GinkgoT().TempDir(), "plan.json")
+			Expect(plan.Save(filename)).To(Succeed())
+			loaded, err := Load(filename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded).To(Equal(plan))
+			Expect(loaded.Validate("cluster-id", "4.15.3", accountID)).To(Succeed())
+			Expect(loaded.Validate("cluster-id", "4.16.0", accountID)).To(MatchError(
+				"The plan was created for the upgrade to version '4.15.3', not '4.16.0'"))
+		})
+
+		It("fails for unknown changes", func() {
+			filename := filepath.Join(GinkgoT().TempDir(), "plan.json")
+			Expect(os.WriteFile(filename, []byte(`{"changes":[{"type":"delete-role"}]}`), 0600)).To(Succeed())
+			_, err := Load(filename)
+			Expect(err).To(MatchError(ContainSubstring("unknown type 'delete-role' of change 1")))
+		})
+	})
+
+	Context("Apply", func() {
+		var plan *Plan
+
+		BeforeEach(func() {
+			plan = &Plan{
+				PolicyVersion: "4.15",
+				Changes: []*Change{
+					{
+						Type:      ChangePermissionPolicy,
+						RoleName:  installerRole,
+						PolicyARN: installerPolicy,
+						Path:      "/",
+						Current:   normalize(oldPermissions),
+						Desired:   normalize(newPermissions),
+					},
+					{Type: ChangeRoleTag, RoleName: installerRole, TagKey: "rosa_openshift_version",
+						Current: "4.14", Desired: "4.15"},
+				},
+			}
+		})
+
+		It("makes the changes of the plan", func() {
+			awsClient.EXPECT().IsPolicyExists(installerPolicy).Return(&iam.GetPolicyOutput{}, nil)
+			awsClient.EXPECT().GetDefaultPolicyDocument(installerPolicy).Return(oldPermissions, nil)
+			awsClient.EXPECT().GetRoleByName(installerRole).Return(iamtypes.Role{
+				Tags: []iamtypes.Tag{{Key: awssdk.String("rosa_openshift_version"), Value: awssdk.String("4.14")}},
+			}, nil)
+			awsClient.EXPECT().ForceEnsurePolicy(installerPolicy, normalize(newPermissions), "4.15", gomock.Any(), "/").
+				Return(installerPolicy, nil)
+			awsClient.EXPECT().AttachRolePolicy(gomock.Any(), installerRole, installerPolicy).Return(nil)
+			awsClient.EXPECT().AddRoleTag(installerRole, "rosa_openshift_version", "4.15").Return(nil)
+
+			Expect(Apply(awsClient, reporter.CreateReporter(), plan)).To(Succeed())
+		})
+
+		It("changes nothing when a resource changed since the plan was created", func() {
+			awsClient.EXPECT().IsPolicyExists(installerPolicy).Return(&iam.GetPolicyOutput{}, nil)
+			awsClient.EXPECT().GetDefaultPolicyDocument(installerPolicy).Return(newPermissions, nil)
+
+			err := Apply(awsClient, reporter.CreateReporter(), plan)
+			Expect(err).To(MatchError(ContainSubstring(
+				"Change 1 of the plan can't be applied: policy '" + installerPolicy + "' has changed")))
+		})
+	})
+})
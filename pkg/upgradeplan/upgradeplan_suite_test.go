@@ -0,0 +1,13 @@
+package upgradeplan
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestUpgradePlan(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Upgrade plan suite")
+}
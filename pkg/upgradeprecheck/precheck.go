@@ -0,0 +1,479 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradeprecheck
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	ver "github.com/hashicorp/go-version"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Severity is how a finding affects the upgrade
+type Severity string
+
+const (
+	SeverityOK      Severity = "ok"
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	// SeverityBlocker findings must be resolved before the upgrade can be scheduled
+	SeverityBlocker Severity = "blocker"
+)
+
+// Names of the checks of the report
+const (
+	CheckClusterState     = "cluster state"
+	CheckScheduledUpgrade = "scheduled upgrade"
+	CheckUpgradePath      = "upgrade path"
+	CheckGates            = "version gates"
+	CheckAccountRoles     = "account roles"
+	CheckOperatorRoles    = "operator roles"
+	CheckMissingRoles     = "missing operator roles"
+	CheckNodePools        = "node pool version skew"
+	CheckEndOfLife        = "end of life"
+	CheckLimitedSupport   = "limited support"
+	CheckAddOns           = "add-ons"
+)
+
+// maxNodePoolMinorSkew is the number of minor versions node pools may run behind the control plane
+const maxNodePoolMinorSkew = 2
+
+// OCMClient is the part of the OCM client the checks are run with
+type OCMClient interface {
+	GetAvailableUpgrades(versionID string) ([]string, error)
+	GetScheduledUpgrade(clusterID string) (*cmv1.UpgradePolicy, *cmv1.UpgradePolicyState, error)
+	GetControlPlaneScheduledUpgrade(clusterID string) (*cmv1.ControlPlaneUpgradePolicy, error)
+	GetMissingGateAgreementsClassic(clusterID string, upgradePolicy *cmv1.UpgradePolicy) ([]*cmv1.VersionGate, error)
+	GetMissingGateAgreementsHypershift(clusterID string,
+		upgradePolicy *cmv1.ControlPlaneUpgradePolicy) ([]*cmv1.VersionGate, error)
+	GetPolicyVersion(userRequestedVersion string, channelGroup string) (string, error)
+	GetCredRequests(isHypershift bool) (map[string]*cmv1.STSOperator, error)
+	FindMissingOperatorRolesForUpgrade(cluster *cmv1.Cluster, newMinorVersion string,
+		credRequests map[string]*cmv1.STSOperator) (map[string]*cmv1.STSOperator, error)
+	GetNodePools(clusterID string) ([]*cmv1.NodePool, error)
+	IsVersionCloseToEol(daysAwayToCheck int, version string, channelGroup string) error
+	GetLimitedSupportReasons(clusterID string) ([]*cmv1.LimitedSupportReason, error)
+	GetAddOnInstallations(clusterID string) ([]*cmv1.AddOnInstallation, error)
+}
+
+// Finding is the outcome of a check
+type Finding struct {
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Details  []string `json:"details,omitempty"`
+}
+
+// Report is the outcome of the checks of the upgrade of a cluster
+type Report struct {
+	ClusterID         string    `json:"clusterId"`
+	ClusterName       string    `json:"clusterName"`
+	CurrentVersion    string    `json:"currentVersion"`
+	TargetVersion     string    `json:"targetVersion,omitempty"`
+	AvailableUpgrades []string  `json:"availableUpgrades"`
+	Findings          []Finding `json:"findings"`
+}
+
+// Blockers returns the findings that prevent the upgrade
+func (r *Report) Blockers() []Finding {
+	blockers := []Finding{}
+	for _, finding := range r.Findings {
+		if finding.Severity == SeverityBlocker {
+			blockers = append(blockers, finding)
+		}
+	}
+	return blockers
+}
+
+// Input is the cluster to check and the version it would be upgraded to. The latest available version
+// is checked when the target version is empty.
+type Input struct {
+	Cluster       *cmv1.Cluster
+	TargetVersion string
+	Creator       *aws.Creator
+}
+
+// Run checks the upgrade of the cluster without changing anything. Checks that can't be run are
+// reported as warnings.
+func Run(ocmClient OCMClient, awsClient aws.Client, input *Input) (*Report, error) {
+	cluster := input.Cluster
+	report := &Report{
+		ClusterID:      cluster.ID(),
+		ClusterName:    cluster.Name(),
+		CurrentVersion: cluster.Version().RawID(),
+		Findings:       []Finding{},
+	}
+	var err error
+	if cluster.Hypershift().Enabled() {
+		report.AvailableUpgrades = ocm.GetAvailableUpgradesByCluster(cluster)
+	} else {
+		report.AvailableUpgrades, err = ocmClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to find available upgrades: %v", err)
+		}
+	}
+	if report.AvailableUpgrades == nil {
+		report.AvailableUpgrades = []string{}
+	}
+
+	report.add(checkClusterState(cluster))
+	report.add(checkScheduledUpgrade(ocmClient, cluster))
+	report.add(checkUpgradePath(report, input.TargetVersion))
+	report.add(checkEndOfLife(ocmClient, cluster, report.CurrentVersion, "Current version"))
+	if report.TargetVersion != "" {
+		report.add(checkGates(ocmClient, cluster, report.TargetVersion))
+		report.add(checkEndOfLife(ocmClient, cluster, report.TargetVersion, "Target version"))
+		if _, isSTS := cluster.AWS().STS().GetRoleARN(); isSTS {
+			report.add(checkRoles(ocmClient, awsClient, input.Creator, cluster, report.TargetVersion)...)
+		}
+		if cluster.Hypershift().Enabled() {
+			report.add(checkNodePools(ocmClient, cluster, report.TargetVersion))
+		}
+	}
+	report.add(checkLimitedSupport(ocmClient, cluster))
+	report.add(checkAddOns(ocmClient, cluster))
+	return report, nil
+}
+
+func (r *Report) add(findings ...Finding) {
+	r.Findings = append(r.Findings, findings...)
+}
+
+func failedFinding(check string, what string, err error) Finding {
+	return Finding{
+		Check:    check,
+		Severity: SeverityWarning,
+		Message:  fmt.Sprintf("Failed to check %s: %v", what, err),
+	}
+}
+
+func checkClusterState(cluster *cmv1.Cluster) Finding {
+	if cluster.State() != cmv1.ClusterStateReady {
+		return Finding{
+			Check:    CheckClusterState,
+			Severity: SeverityBlocker,
+			Message:  fmt.Sprintf("Cluster is %s, it must be ready to be upgraded", cluster.State()),
+		}
+	}
+	return Finding{Check: CheckClusterState, Severity: SeverityOK, Message: "Cluster is ready"}
+}
+
+func checkScheduledUpgrade(ocmClient OCMClient, cluster *cmv1.Cluster) Finding {
+	var version string
+	var nextRun time.Time
+	var state cmv1.UpgradePolicyStateValue
+	if cluster.Hypershift().Enabled() {
+		upgradePolicy, err := ocmClient.GetControlPlaneScheduledUpgrade(cluster.ID())
+		if err != nil {
+			return failedFinding(CheckScheduledUpgrade, "scheduled upgrades", err)
+		}
+		if upgradePolicy != nil {
+			version, nextRun, state = upgradePolicy.Version(), upgradePolicy.NextRun(), upgradePolicy.State().Value()
+		}
+	} else {
+		upgradePolicy, upgradeState, err := ocmClient.GetScheduledUpgrade(cluster.ID())
+		if err != nil {
+			return failedFinding(CheckScheduledUpgrade, "scheduled upgrades", err)
+		}
+		if upgradePolicy != nil {
+			version, nextRun, state = upgradePolicy.Version(), upgradePolicy.NextRun(), upgradeState.Value()
+		}
+	}
+	if version == "" {
+		return Finding{Check: CheckScheduledUpgrade, Severity: SeverityOK, Message: "No upgrade is scheduled"}
+	}
+	return Finding{
+		Check:    CheckScheduledUpgrade,
+		Severity: SeverityBlocker,
+		Message: fmt.Sprintf("There is already a %s upgrade to version %s on %s", state, version,
+			nextRun.Format("2006-01-02 15:04 MST")),
+	}
+}
+
+// checkUpgradePath sets the target version of the report, the latest available one unless it is given
+func checkUpgradePath(report *Report, targetVersion string) Finding {
+	if len(report.AvailableUpgrades) == 0 {
+		// A requested version can't be reached without an upgrade path
+		if targetVersion != "" {
+			return Finding{
+				Check:    CheckUpgradePath,
+				Severity: SeverityBlocker,
+				Message: fmt.Sprintf("Version %s isn't an available upgrade of version %s, there are no "+
+					"available upgrades", targetVersion, report.CurrentVersion),
+			}
+		}
+		return Finding{Check: CheckUpgradePath, Severity: SeverityInfo, Message: "There are no available upgrades"}
+	}
+	if targetVersion == "" {
+		targetVersion = report.AvailableUpgrades[0]
+	}
+	if !helper.Contains(report.AvailableUpgrades, targetVersion) {
+		return Finding{
+			Check:    CheckUpgradePath,
+			Severity: SeverityBlocker,
+			Message: fmt.Sprintf("Version %s isn't an available upgrade of version %s", targetVersion,
+				report.CurrentVersion),
+			Details: []string{fmt.Sprintf("Available upgrades: %s", strings.Join(report.AvailableUpgrades, ", "))},
+		}
+	}
+	report.TargetVersion = targetVersion
+	return Finding{
+		Check:    CheckUpgradePath,
+		Severity: SeverityOK,
+		Message:  fmt.Sprintf("Version %s can be upgraded to %s", report.CurrentVersion, targetVersion),
+	}
+}
+
+func checkGates(ocmClient OCMClient, cluster *cmv1.Cluster, targetVersion string) Finding {
+	var gates []*cmv1.VersionGate
+	if cluster.Hypershift().Enabled() {
+		upgradePolicy, err := cmv1.NewControlPlaneUpgradePolicy().UpgradeType(cmv1.UpgradeTypeControlPlane).
+			ScheduleType(cmv1.ScheduleTypeManual).Version(targetVersion).
+			NextRun(time.Now().UTC().Add(10 * time.Minute)).Build()
+		if err == nil {
+			gates, err = ocmClient.GetMissingGateAgreementsHypershift(cluster.ID(), upgradePolicy)
+		}
+		if err != nil {
+			return failedFinding(CheckGates, "version gates", err)
+		}
+	} else {
+		upgradePolicy, err := cmv1.NewUpgradePolicy().ScheduleType(cmv1.ScheduleTypeManual).
+			Version(targetVersion).Build()
+		if err == nil {
+			gates, err = ocmClient.GetMissingGateAgreementsClassic(cluster.ID(), upgradePolicy)
+		}
+		if err != nil {
+			return failedFinding(CheckGates, "version gates", err)
+		}
+	}
+	finding := Finding{Check: CheckGates, Severity: SeverityOK, Message: "No version gate to acknowledge"}
+	for _, gate := range gates {
+		// STS only gates are acknowledged along with the role upgrades
+		if gate.STSOnly() {
+			continue
+		}
+		finding.Details = append(finding.Details, fmt.Sprintf("%s (%s)", gate.Description(),
+			gate.DocumentationURL()))
+	}
+	if len(finding.Details) > 0 {
+		finding.Severity = SeverityBlocker
+		finding.Message = fmt.Sprintf("%d version gate(s) must be acknowledged", len(finding.Details))
+	}
+	return finding
+}
+
+func checkRoles(ocmClient OCMClient, awsClient aws.Client, creator *aws.Creator, cluster *cmv1.Cluster,
+	targetVersion string) []Finding {
+	findings := []Finding{}
+	credRequests, err := ocmClient.GetCredRequests(cluster.Hypershift().Enabled())
+	if err != nil {
+		return append(findings, failedFinding(CheckOperatorRoles, "operator credential requests", err))
+	}
+
+	if cluster.AWS().STS().ManagedPolicies() {
+		findings = append(findings,
+			Finding{Check: CheckAccountRoles, Severity: SeverityOK,
+				Message: "Account roles use managed policies, no upgrade is needed"},
+			Finding{Check: CheckOperatorRoles, Severity: SeverityOK,
+				Message: "Operator roles use managed policies, no upgrade is needed"})
+	} else {
+		findings = append(findings, checkRolePolicies(ocmClient, awsClient, creator, cluster, targetVersion,
+			credRequests)...)
+	}
+
+	missingRoles, err := ocmClient.FindMissingOperatorRolesForUpgrade(cluster, targetVersion, credRequests)
+	if err != nil {
+		return append(findings, failedFinding(CheckMissingRoles, "missing operator roles", err))
+	}
+	if len(missingRoles) == 0 {
+		return append(findings, Finding{Check: CheckMissingRoles, Severity: SeverityOK,
+			Message: "No operator role is missing"})
+	}
+	finding := Finding{
+		Check:    CheckMissingRoles,
+		Severity: SeverityBlocker,
+		Message: fmt.Sprintf("%d operator role(s) must be created, run 'rosa upgrade roles -c %s "+
+			"--cluster-version %s'", len(missingRoles), cluster.ID(), targetVersion),
+	}
+	for _, operator := range missingRoles {
+		finding.Details = append(finding.Details, fmt.Sprintf("%s/%s", operator.Namespace(), operator.Name()))
+	}
+	sort.Strings(finding.Details)
+	return append(findings, finding)
+}
+
+// checkRolePolicies checks the policies of the roles against the policy version of the target version
+func checkRolePolicies(ocmClient OCMClient, awsClient aws.Client, creator *aws.Creator, cluster *cmv1.Cluster,
+	targetVersion string, credRequests map[string]*cmv1.STSOperator) []Finding {
+	targetPolicyVersion, err := ocm.ParseVersion(targetVersion)
+	if err != nil {
+		return []Finding{failedFinding(CheckAccountRoles, "the policy version", err)}
+	}
+	policyVersion, err := ocmClient.GetPolicyVersion(targetPolicyVersion, cluster.Version().ChannelGroup())
+	if err != nil {
+		return []Finding{failedFinding(CheckAccountRoles, "the policy version", err)}
+	}
+	findings := []Finding{}
+
+	upgradeNeeded, err := awsClient.IsUpgradedNeededForAccountRolePoliciesUsingCluster(cluster, policyVersion)
+	if err != nil {
+		findings = append(findings, failedFinding(CheckAccountRoles, "account role policies", err))
+	} else {
+		findings = append(findings, rolePoliciesFinding(CheckAccountRoles, "Account", upgradeNeeded,
+			policyVersion, cluster))
+	}
+
+	prefix, err := aws.GetOperatorRolePolicyPrefixFromCluster(cluster, awsClient)
+	if err == nil {
+		upgradeNeeded, err = awsClient.IsUpgradedNeededForOperatorRolePoliciesUsingCluster(cluster,
+			creator.Partition, creator.AccountID, policyVersion, credRequests, prefix)
+	}
+	if err != nil {
+		findings = append(findings, failedFinding(CheckOperatorRoles, "operator role policies", err))
+	} else {
+		findings = append(findings, rolePoliciesFinding(CheckOperatorRoles, "Operator", upgradeNeeded,
+			policyVersion, cluster))
+	}
+	return findings
+}
+
+func rolePoliciesFinding(check string, kind string, upgradeNeeded bool, policyVersion string,
+	cluster *cmv1.Cluster) Finding {
+	if !upgradeNeeded {
+		return Finding{
+			Check:    check,
+			Severity: SeverityOK,
+			Message:  fmt.Sprintf("%s role policies are compatible with version %s", kind, policyVersion),
+		}
+	}
+	return Finding{
+		Check:    check,
+		Severity: SeverityBlocker,
+		Message: fmt.Sprintf("%s role policies must be upgraded to version %s, run 'rosa upgrade roles -c %s'",
+			kind, policyVersion, cluster.ID()),
+	}
+}
+
+// checkNodePools reports the node pools that would fall too far behind the upgraded control plane
+func checkNodePools(ocmClient OCMClient, cluster *cmv1.Cluster, targetVersion string) Finding {
+	target, err := ver.NewVersion(targetVersion)
+	if err != nil {
+		return failedFinding(CheckNodePools, "node pool versions", err)
+	}
+	nodePools, err := ocmClient.GetNodePools(cluster.ID())
+	if err != nil {
+		return failedFinding(CheckNodePools, "node pool versions", err)
+	}
+	finding := Finding{
+		Check:    CheckNodePools,
+		Severity: SeverityOK,
+		Message: fmt.Sprintf("Node pools stay within %d minor versions of the control plane",
+			maxNodePoolMinorSkew),
+	}
+	for _, nodePool := range nodePools {
+		nodePoolVersion, err := ver.NewVersion(nodePool.Version().RawID())
+		if err != nil {
+			continue
+		}
+		skew := target.Segments()[1] - nodePoolVersion.Segments()[1]
+		if target.Segments()[0] != nodePoolVersion.Segments()[0] || skew > maxNodePoolMinorSkew {
+			finding.Severity = SeverityBlocker
+			finding.Details = append(finding.Details, fmt.Sprintf("Node pool '%s' runs version %s, "+
+				"upgrade it before the control plane", nodePool.ID(), nodePoolVersion.Original()))
+		}
+	}
+	if finding.Severity == SeverityBlocker {
+		finding.Message = fmt.Sprintf("%d node pool(s) would be more than %d minor versions behind version %s",
+			len(finding.Details), maxNodePoolMinorSkew, targetVersion)
+	}
+	return finding
+}
+
+func checkEndOfLife(ocmClient OCMClient, cluster *cmv1.Cluster, version string, what string) Finding {
+	err := ocmClient.IsVersionCloseToEol(ocm.CloseToEolDays, version, cluster.Version().ChannelGroup())
+	if err != nil {
+		return Finding{Check: CheckEndOfLife, Severity: SeverityWarning, Message: fmt.Sprintf("%s %s: %v",
+			what, version, err)}
+	}
+	return Finding{
+		Check:    CheckEndOfLife,
+		Severity: SeverityOK,
+		Message: fmt.Sprintf("%s %s is supported for more than %d days", what, version,
+			ocm.CloseToEolDays),
+	}
+}
+
+func checkLimitedSupport(ocmClient OCMClient, cluster *cmv1.Cluster) Finding {
+	reasons, err := ocmClient.GetLimitedSupportReasons(cluster.ID())
+	if err != nil {
+		return failedFinding(CheckLimitedSupport, "limited support reasons", err)
+	}
+	if len(reasons) == 0 {
+		return Finding{Check: CheckLimitedSupport, Severity: SeverityOK, Message: "Cluster is fully supported"}
+	}
+	finding := Finding{
+		Check:    CheckLimitedSupport,
+		Severity: SeverityWarning,
+		Message:  fmt.Sprintf("Cluster is in limited support for %d reason(s)", len(reasons)),
+	}
+	for _, reason := range reasons {
+		finding.Details = append(finding.Details, reason.Summary())
+	}
+	return finding
+}
+
+// checkAddOns reports the add-ons that failed or whose requirements aren't fulfilled
+func checkAddOns(ocmClient OCMClient, cluster *cmv1.Cluster) Finding {
+	addOns, err := ocmClient.GetAddOnInstallations(cluster.ID())
+	if err != nil {
+		return failedFinding(CheckAddOns, "add-on installations", err)
+	}
+	finding := Finding{
+		Check:    CheckAddOns,
+		Severity: SeverityOK,
+		Message:  fmt.Sprintf("%d add-on(s) are compatible", len(addOns)),
+	}
+	for _, addOn := range addOns {
+		if addOn.State() == cmv1.AddOnInstallationStateFailed {
+			finding.Details = append(finding.Details, fmt.Sprintf("Add-on '%s' is failed: %s", addOn.ID(),
+				addOn.StateDescription()))
+		}
+		requirements := append(addOn.Addon().Requirements(), addOn.AddonVersion().Requirements()...)
+		for _, requirement := range requirements {
+			if !requirement.Enabled() || requirement.Status().Fulfilled() {
+				continue
+			}
+			detail := fmt.Sprintf("Add-on '%s' requirement '%s' isn't fulfilled", addOn.ID(), requirement.ID())
+			if messages := requirement.Status().ErrorMsgs(); len(messages) > 0 {
+				detail += ": " + strings.Join(messages, "; ")
+			}
+			finding.Details = append(finding.Details, detail)
+		}
+	}
+	if len(finding.Details) > 0 {
+		finding.Severity = SeverityWarning
+		finding.Message = fmt.Sprintf("%d add-on issue(s) found", len(finding.Details))
+	}
+	return finding
+}
@@ -0,0 +1,309 @@
+package upgradeprecheck
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+// fakeOCMClient returns the same resources for every cluster
+type fakeOCMClient struct {
+	availableUpgrades     []string
+	upgradePolicy         *cmv1.UpgradePolicy
+	upgradeState          *cmv1.UpgradePolicyState
+	gates                 []*cmv1.VersionGate
+	missingRoles          map[string]*cmv1.STSOperator
+	nodePools             []*cmv1.NodePool
+	closeToEol            map[string]bool
+	limitedSupportReasons []*cmv1.LimitedSupportReason
+	addOns                []*cmv1.AddOnInstallation
+	err                   error
+}
+
+func (c *fakeOCMClient) GetAvailableUpgrades(_ string) ([]string, error) {
+	return c.availableUpgrades, nil
+}
+
+func (c *fakeOCMClient) GetScheduledUpgrade(_ string) (*cmv1.UpgradePolicy, *cmv1.UpgradePolicyState, error) {
+	return c.upgradePolicy, c.upgradeState, nil
+}
+
+func (c *fakeOCMClient) GetControlPlaneScheduledUpgrade(_ string) (*cmv1.ControlPlaneUpgradePolicy, error) {
+	return nil, nil
+}
+
+func (c *fakeOCMClient) GetMissingGateAgreementsClassic(_ string,
+	_ *cmv1.UpgradePolicy) ([]*cmv1.VersionGate, error) {
+	return c.gates, c.err
+}
+
+func (c *fakeOCMClient) GetMissingGateAgreementsHypershift(_ string,
+	_ *cmv1.ControlPlaneUpgradePolicy) ([]*cmv1.VersionGate, error) {
+	return c.gates, c.err
+}
+
+func (c *fakeOCMClient) GetPolicyVersion(userRequestedVersion string, _ string) (string, error) {
+	if userRequestedVersion == "" {
+		return "4.16", nil
+	}
+	return userRequestedVersion, nil
+}
+
+func (c *fakeOCMClient) GetCredRequests(_ bool) (map[string]*cmv1.STSOperator, error) {
+	return map[string]*cmv1.STSOperator{}, nil
+}
+
+func (c *fakeOCMClient) FindMissingOperatorRolesForUpgrade(_ *cmv1.Cluster, _ string,
+	_ map[string]*cmv1.STSOperator) (map[string]*cmv1.STSOperator, error) {
+	return c.missingRoles, nil
+}
+
+func (c *fakeOCMClient) GetNodePools(_ string) ([]*cmv1.NodePool, error) {
+	return c.nodePools, nil
+}
+
+func (c *fakeOCMClient) IsVersionCloseToEol(_ int, version string, _ string) error {
+	if c.closeToEol[version] {
+		return fmt.Errorf("Version %s reaches end of life soon", version)
+	}
+	return nil
+}
+
+func (c *fakeOCMClient) GetLimitedSupportReasons(_ string) ([]*cmv1.LimitedSupportReason, error) {
+	return c.limitedSupportReasons, nil
+}
+
+func (c *fakeOCMClient) GetAddOnInstallations(_ string) ([]*cmv1.AddOnInstallation, error) {
+	return c.addOns, nil
+}
+
+func buildCluster(builder *cmv1.ClusterBuilder) *cmv1.Cluster {
+	cluster, err := builder.Build()
+	Expect(err).ToNot(HaveOccurred())
+	return cluster
+}
+
+func readyCluster() *cmv1.ClusterBuilder {
+	return cmv1.NewCluster().ID("foo-id").Name("foo").State(cmv1.ClusterStateReady).
+		Version(cmv1.NewVersion().ID("openshift-v4.15.10").RawID("4.15.10").ChannelGroup("stable"))
+}
+
+func findFinding(report *Report, check string) Finding {
+	for _, finding := range report.Findings {
+		if finding.Check == check {
+			return finding
+		}
+	}
+	Fail(fmt.Sprintf("Finding '%s' not found", check))
+	return Finding{}
+}
+
+var _ = Describe("Upgrade precheck", func() {
+	var (
+		ctrl      *gomock.Controller
+		awsClient *aws.MockClient
+		ocmClient *fakeOCMClient
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		awsClient = aws.NewMockClient(ctrl)
+		ocmClient = &fakeOCMClient{availableUpgrades: []string{"4.16.2", "4.15.12"}}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("has no blockers for a ready cluster", func() {
+		report, err := Run(ocmClient, awsClient, &Input{Cluster: buildCluster(readyCluster())})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.TargetVersion).To(Equal("4.16.2"))
+		Expect(report.Blockers()).To(BeEmpty())
+		for _, finding := range report.Findings {
+			Expect(finding.Severity).To(Equal(SeverityOK), finding.Message)
+		}
+	})
+
+	It("blocks versions that aren't available upgrades", func() {
+		report, err := Run(ocmClient, awsClient, &Input{
+			Cluster:       buildCluster(readyCluster()),
+			TargetVersion: "4.17.0",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.TargetVersion).To(BeEmpty())
+		finding := findFinding(report, CheckUpgradePath)
+		Expect(finding.Severity).To(Equal(SeverityBlocker))
+		Expect(finding.Details).To(Equal([]string{"Available upgrades: 4.16.2, 4.15.12"}))
+	})
+
+	It("blocks requested versions when there are no available upgrades", func() {
+		ocmClient.availableUpgrades = nil
+		report, err := Run(ocmClient, awsClient, &Input{
+			Cluster:       buildCluster(readyCluster()),
+			TargetVersion: "4.17.0",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		finding := findFinding(report, CheckUpgradePath)
+		Expect(finding.Severity).To(Equal(SeverityBlocker))
+		Expect(report.Blockers()).ToNot(BeEmpty())
+	})
+
+	It("blocks clusters that aren't ready or have an upgrade scheduled", func() {
+		ocmClient.upgradePolicy = buildUpgradePolicy()
+		ocmClient.upgradeState = buildUpgradeState()
+		report, err := Run(ocmClient, awsClient, &Input{
+			Cluster: buildCluster(readyCluster().State(cmv1.ClusterStateInstalling)),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Blockers()).To(HaveLen(2))
+		Expect(findFinding(report, CheckScheduledUpgrade).Message).To(
+			ContainSubstring("There is already a scheduled upgrade to version 4.15.12"))
+	})
+
+	It("only blocks gates that aren't STS only", func() {
+		ocmClient.gates = []*cmv1.VersionGate{
+			buildGate(cmv1.NewVersionGate().ID("gate-1").Description("API removals").
+				DocumentationURL("https://docs/removals")),
+			buildGate(cmv1.NewVersionGate().ID("gate-2").STSOnly(true).Description("Policies")),
+		}
+		report, err := Run(ocmClient, awsClient, &Input{Cluster: buildCluster(readyCluster())})
+		Expect(err).ToNot(HaveOccurred())
+		finding := findFinding(report, CheckGates)
+		Expect(finding.Severity).To(Equal(SeverityBlocker))
+		Expect(finding.Details).To(Equal([]string{"API removals (https://docs/removals)"}))
+	})
+
+	It("reports gates it fails to get as warnings", func() {
+		ocmClient.err = fmt.Errorf("boom")
+		report, err := Run(ocmClient, awsClient, &Input{Cluster: buildCluster(readyCluster())})
+		Expect(err).ToNot(HaveOccurred())
+		finding := findFinding(report, CheckGates)
+		Expect(finding.Severity).To(Equal(SeverityWarning))
+		Expect(finding.Message).To(Equal("Failed to check version gates: boom"))
+	})
+
+	It("blocks role policy upgrades and missing operator roles of STS clusters", func() {
+		cluster := buildCluster(readyCluster().AWS(cmv1.NewAWS().STS(cmv1.NewSTS().
+			RoleARN("arn:aws:iam::123456789012:role/foo-Installer-Role"))))
+		ocmClient.missingRoles = map[string]*cmv1.STSOperator{
+			"ingress": buildOperator(cmv1.NewSTSOperator().Namespace("openshift-ingress-operator").
+				Name("cloud-credentials")),
+		}
+		awsClient.EXPECT().IsUpgradedNeededForAccountRolePoliciesUsingCluster(cluster, "4.16").Return(true, nil)
+		awsClient.EXPECT().IsUpgradedNeededForOperatorRolePoliciesUsingCluster(cluster, "aws", "123456789012",
+			"4.16", gomock.Any(), "foo").Return(false, nil)
+
+		report, err := Run(ocmClient, awsClient, &Input{
+			Cluster: cluster,
+			Creator: &aws.Creator{Partition: "aws", AccountID: "123456789012"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(findFinding(report, CheckAccountRoles).Severity).To(Equal(SeverityBlocker))
+		Expect(findFinding(report, CheckOperatorRoles).Severity).To(Equal(SeverityOK))
+		finding := findFinding(report, CheckMissingRoles)
+		Expect(finding.Severity).To(Equal(SeverityBlocker))
+		Expect(finding.Details).To(Equal([]string{"openshift-ingress-operator/cloud-credentials"}))
+	})
+
+	It("checks the role policies against the target version rather than the latest one", func() {
+		cluster := buildCluster(readyCluster().AWS(cmv1.NewAWS().STS(cmv1.NewSTS().
+			RoleARN("arn:aws:iam::123456789012:role/foo-Installer-Role"))))
+		awsClient.EXPECT().IsUpgradedNeededForAccountRolePoliciesUsingCluster(cluster, "4.15").Return(false, nil)
+		awsClient.EXPECT().IsUpgradedNeededForOperatorRolePoliciesUsingCluster(cluster, "aws", "123456789012",
+			"4.15", gomock.Any(), "foo").Return(false, nil)
+
+		report, err := Run(ocmClient, awsClient, &Input{
+			Cluster:       cluster,
+			Creator:       &aws.Creator{Partition: "aws", AccountID: "123456789012"},
+			TargetVersion: "4.15.12",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.TargetVersion).To(Equal("4.15.12"))
+		Expect(findFinding(report, CheckAccountRoles).Severity).To(Equal(SeverityOK))
+		Expect(findFinding(report, CheckOperatorRoles).Severity).To(Equal(SeverityOK))
+	})
+
+	It("blocks node pools that would fall too far behind the control plane", func() {
+		ocmClient.nodePools = []*cmv1.NodePool{
+			buildNodePool(cmv1.NewNodePool().ID("workers").Version(cmv1.NewVersion().RawID("4.13.5"))),
+			buildNodePool(cmv1.NewNodePool().ID("infra").Version(cmv1.NewVersion().RawID("4.15.10"))),
+		}
+		cluster := buildCluster(readyCluster().Hypershift(cmv1.NewHypershift().Enabled(true)).
+			Version(cmv1.NewVersion().RawID("4.15.10").AvailableUpgrades("4.16.2")))
+
+		report, err := Run(ocmClient, awsClient, &Input{Cluster: cluster})
+		Expect(err).ToNot(HaveOccurred())
+		finding := findFinding(report, CheckNodePools)
+		Expect(finding.Severity).To(Equal(SeverityBlocker))
+		Expect(finding.Details).To(HaveLen(1))
+		Expect(finding.Details[0]).To(ContainSubstring("'workers' runs version 4.13.5"))
+	})
+
+	It("warns about end of life, limited support and add-on issues", func() {
+		ocmClient.closeToEol = map[string]bool{"4.15.10": true}
+		ocmClient.limitedSupportReasons = []*cmv1.LimitedSupportReason{
+			buildReason(cmv1.NewLimitedSupportReason().Summary("Cluster is out of support")),
+		}
+		addOn, err := cmv1.NewAddOnInstallation().ID("logging").State(cmv1.AddOnInstallationStateReady).
+			Addon(cmv1.NewAddOn().Requirements(cmv1.NewAddOnRequirement().ID("nodes").Enabled(true).
+				Status(cmv1.NewAddOnRequirementStatus().Fulfilled(false).ErrorMsgs("Not enough nodes")))).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		ocmClient.addOns = []*cmv1.AddOnInstallation{addOn}
+
+		report, err := Run(ocmClient, awsClient, &Input{Cluster: buildCluster(readyCluster())})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Blockers()).To(BeEmpty())
+		Expect(report.Findings).To(ContainElement(Finding{
+			Check:    CheckEndOfLife,
+			Severity: SeverityWarning,
+			Message:  "Current version 4.15.10: Version 4.15.10 reaches end of life soon",
+		}))
+		Expect(findFinding(report, CheckLimitedSupport).Details).To(Equal([]string{"Cluster is out of support"}))
+		Expect(findFinding(report, CheckAddOns).Details).To(Equal([]string{
+			"Add-on 'logging' requirement 'nodes' isn't fulfilled: Not enough nodes",
+		}))
+	})
+})
+
+func buildUpgradePolicy() *cmv1.UpgradePolicy {
+	upgradePolicy, err := cmv1.NewUpgradePolicy().Version("4.15.12").Build()
+	Expect(err).ToNot(HaveOccurred())
+	return upgradePolicy
+}
+
+func buildUpgradeState() *cmv1.UpgradePolicyState {
+	upgradeState, err := cmv1.NewUpgradePolicyState().Value(cmv1.UpgradePolicyStateValueScheduled).Build()
+	Expect(err).ToNot(HaveOccurred())
+	return upgradeState
+}
+
+func buildGate(builder *cmv1.VersionGateBuilder) *cmv1.VersionGate {
+	gate, err := builder.Build()
+	Expect(err).ToNot(HaveOccurred())
+	return gate
+}
+
+func buildOperator(builder *cmv1.STSOperatorBuilder) *cmv1.STSOperator {
+	operator, err := builder.Build()
+	Expect(err).ToNot(HaveOccurred())
+	return operator
+}
+
+func buildNodePool(builder *cmv1.NodePoolBuilder) *cmv1.NodePool {
+	nodePool, err := builder.Build()
+	Expect(err).ToNot(HaveOccurred())
+	return nodePool
+}
+
+func buildReason(builder *cmv1.LimitedSupportReasonBuilder) *cmv1.LimitedSupportReason {
+	reason, err := builder.Build()
+	Expect(err).ToNot(HaveOccurred())
+	return reason
+}
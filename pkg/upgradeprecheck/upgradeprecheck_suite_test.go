@@ -0,0 +1,13 @@
+package upgradeprecheck
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+func TestUpgradePrecheck(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Upgrade precheck suite")
+}